@@ -0,0 +1,177 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// 和 mp.RateLimiter, corp.RateLimiter 相同, mp.TokenBucketLimiter 等实现都可以直接使用.
+type RateLimiter interface {
+	Wait(endpoint string) error
+}
+
+// 默认的并发数
+const DefaultConcurrency = 8
+
+type Options struct {
+	Concurrency int // 同时执行的任务数, <= 0 时为 DefaultConcurrency
+
+	// 可以为 nil; 每个任务执行前调用 RateLimiter.Wait(Endpoint), 返回错误时该任务不执行, 错误记录到 Result.Errors.
+	//  NOTE: 如果 Client 也设置了同一个 RateLimiter, 这里就不需要再设置了.
+	RateLimiter RateLimiter
+	Endpoint    string
+
+	// 有任务出错时取消还没有执行的任务, 默认继续执行所有任务.
+	StopOnError bool
+
+	// 可以为 nil; 每个任务完成后调用, done 为已经完成的任务数. 会在多个 goroutine 里调用, 但不会并发调用.
+	Progress func(done, total int)
+}
+
+// 一个任务的错误
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %s", e.Index, e.Err.Error())
+}
+
+// 批量执行的结果
+type Result struct {
+	Total     int         // 任务总数
+	Succeeded int         // 成功的任务数
+	Failed    int         // 失败的任务数, 等于 len(Errors)
+	Skipped   int         // 因为 ctx 取消或者 StopOnError 没有执行的任务数
+	Errors    []ItemError // 按 Index 排序
+}
+
+// 所有任务都成功时返回 nil, 否则返回 *Error.
+func (r *Result) Err() error {
+	if r.Failed == 0 && r.Skipped == 0 {
+		return nil
+	}
+	return &Error{Result: r}
+}
+
+type Error struct {
+	Result *Result
+}
+
+func (e *Error) Error() string {
+	r := e.Result
+	s := fmt.Sprintf("batch: %d of %d items failed, %d skipped", r.Failed, r.Total, r.Skipped)
+	if len(r.Errors) > 0 {
+		s += ", first error: " + r.Errors[0].Error()
+	}
+	return s
+}
+
+// 并发执行 fn(ctx, 0) ... fn(ctx, n-1), 等所有已经开始的任务结束后返回.
+//  ctx 取消后不再开始新的任务, 已经开始的任务通过 fn 的 ctx 参数感知取消.
+//  opts 可以为 nil.
+func Run(ctx context.Context, n int, opts *Options, fn func(ctx context.Context, i int) error) (result *Result) {
+	if fn == nil {
+		panic("nil fn")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result = &Result{Total: n}
+	errs := make([]error, n)
+	ran := make([]bool, n)
+
+	var (
+		mutex sync.Mutex // 保护 result 和调用 Progress
+		done  int
+	)
+	finish := func(i int, err error) {
+		mutex.Lock()
+		errs[i] = err
+		ran[i] = true
+		done++
+		if err != nil && opts.StopOnError {
+			cancel()
+		}
+		if opts.Progress != nil {
+			opts.Progress(done, n)
+		}
+		mutex.Unlock()
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if ctx.Err() != nil {
+					continue // 取消后队列里剩下的任务不执行
+				}
+				if opts.RateLimiter != nil {
+					if err := opts.RateLimiter.Wait(opts.Endpoint); err != nil {
+						finish(i, err)
+						continue
+					}
+				}
+				finish(i, callFn(ctx, i, fn))
+			}
+		}()
+	}
+
+Feed:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break Feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		switch {
+		case !ran[i]:
+			result.Skipped++
+		case errs[i] != nil:
+			result.Failed++
+			result.Errors = append(result.Errors, ItemError{Index: i, Err: errs[i]})
+		default:
+			result.Succeeded++
+		}
+	}
+	return
+}
+
+// 调用 fn, 把 panic 转换为错误, 避免一个任务 panic 导致整个进程退出.
+func callFn(ctx context.Context, i int, fn func(ctx context.Context, i int) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, i)
+}
@@ -0,0 +1,155 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+var errTest = errors.New("test error")
+
+type countingLimiter struct {
+	n   int32
+	err error
+}
+
+func (l *countingLimiter) Wait(endpoint string) error {
+	atomic.AddInt32(&l.n, 1)
+	return l.err
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		opts      *Options
+		fail      map[int]bool // 返回错误的任务
+		cancelled bool         // ctx 已经取消
+		want      Result
+	}{
+		{
+			name: "all succeed",
+			n:    20,
+			want: Result{Total: 20, Succeeded: 20},
+		},
+		{
+			name: "empty",
+			n:    0,
+			want: Result{},
+		},
+		{
+			name: "some fail",
+			n:    5,
+			opts: &Options{Concurrency: 2},
+			fail: map[int]bool{1: true, 3: true},
+			want: Result{Total: 5, Succeeded: 3, Failed: 2, Errors: []ItemError{
+				{Index: 1, Err: errTest},
+				{Index: 3, Err: errTest},
+			}},
+		},
+		{
+			name: "stop on error",
+			n:    5,
+			opts: &Options{Concurrency: 1, StopOnError: true},
+			fail: map[int]bool{1: true},
+			want: Result{Total: 5, Succeeded: 1, Failed: 1, Skipped: 3, Errors: []ItemError{{Index: 1, Err: errTest}}},
+		},
+		{
+			name:      "cancelled",
+			n:         5,
+			cancelled: true,
+			want:      Result{Total: 5, Skipped: 5},
+		},
+		{
+			name: "rate limiter error",
+			n:    3,
+			opts: &Options{RateLimiter: &countingLimiter{err: errTest}},
+			want: Result{Total: 3, Failed: 3, Errors: []ItemError{
+				{Index: 0, Err: errTest},
+				{Index: 1, Err: errTest},
+				{Index: 2, Err: errTest},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		if tt.cancelled {
+			cancel()
+		}
+		result := Run(ctx, tt.n, tt.opts, func(ctx context.Context, i int) error {
+			if tt.fail[i] {
+				return errTest
+			}
+			return nil
+		})
+		cancel()
+
+		have := *result
+		if have.Total != tt.want.Total || have.Succeeded != tt.want.Succeeded || have.Failed != tt.want.Failed ||
+			have.Skipped != tt.want.Skipped || len(have.Errors) != len(tt.want.Errors) {
+			t.Errorf("%s: have %+v, want %+v", tt.name, have, tt.want)
+			continue
+		}
+		for i := range have.Errors {
+			if have.Errors[i].Index != tt.want.Errors[i].Index || have.Errors[i].Err != tt.want.Errors[i].Err {
+				t.Errorf("%s: have error %v, want %v", tt.name, &have.Errors[i], &tt.want.Errors[i])
+			}
+		}
+		if (result.Err() == nil) != (have.Failed == 0 && have.Skipped == 0) {
+			t.Errorf("%s: have Err() %v", tt.name, result.Err())
+		}
+	}
+}
+
+func TestRunPanic(t *testing.T) {
+	result := Run(nil, 3, nil, func(ctx context.Context, i int) error {
+		if i == 1 {
+			panic("boom")
+		}
+		return nil
+	})
+	if result.Succeeded != 2 || result.Failed != 1 || result.Errors[0].Index != 1 {
+		t.Errorf("have %+v", result)
+	}
+}
+
+func TestRunConcurrencyAndProgress(t *testing.T) {
+	var running, maxRunning int32
+	var progress []int
+	result := Run(context.Background(), 50, &Options{
+		Concurrency: 4,
+		Progress: func(done, total int) {
+			progress = append(progress, done)
+		},
+	}, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	if result.Succeeded != 50 {
+		t.Errorf("have %+v", result)
+	}
+	if maxRunning > 4 {
+		t.Errorf("have %d tasks running at the same time, want at most 4", maxRunning)
+	}
+	for i, done := range progress {
+		if done != i+1 {
+			t.Fatalf("have progress %v, want 1..50", progress)
+		}
+	}
+	if len(progress) != 50 {
+		t.Errorf("have %d progress calls, want 50", len(progress))
+	}
+}
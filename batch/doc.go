@@ -0,0 +1,19 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 批量执行大量互相独立的接口调用(比如给一万个用户发模板消息), 控制并发数和调用频率, 汇总每一项的错误.
+//
+//  result := batch.Run(ctx, len(openIds), &batch.Options{
+//      Concurrency: 16,
+//      RateLimiter: limiter,
+//      Endpoint:    "/cgi-bin/message/template/send",
+//  }, func(ctx context.Context, i int) error {
+//      _, err := template.Send(clt, &msgs[i])
+//      return err
+//  })
+//  for _, e := range result.Errors {
+//      log.Println(openIds[e.Index], e.Err)
+//  }
+package batch
@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package cipher
+
+import (
+	"crypto/aes"
+	cryptocipher "crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// 微信 PKCS#7 补位用的 block size, 不是 AES 的 block size(16)
+const BlockSize = 32
+
+var ErrAppIdMismatch = errors.New("appid mismatch")
+
+// 把长度为 43 的 EncodingAESKey base64 decode 为 32 字节的 AES key.
+//  encodedAESKey 由 a-z,A-Z,0-9 组成, 一般在微信管理后台随机生成
+func DecodeAESKey(encodedAESKey string) (key [32]byte, err error) {
+	if len(encodedAESKey) != 43 {
+		err = errors.New("the length of encodedAESKey must be equal to 43")
+		return
+	}
+	b, err := base64.StdEncoding.DecodeString(encodedAESKey + "=")
+	if err != nil {
+		return
+	}
+	if len(b) != 32 {
+		err = errors.New("the length of decoded AESKey must be equal to 32")
+		return
+	}
+	copy(key[:], b)
+	return
+}
+
+// PKCS#7 补位, 返回的 slice 可能和 b 共用底层数组.
+func PKCS7Pad(b []byte, blockSize int) []byte {
+	amountToPad := blockSize - len(b)%blockSize
+	for i := 0; i < amountToPad; i++ {
+		b = append(b, byte(amountToPad))
+	}
+	return b
+}
+
+// PKCS#7 去除补位
+func PKCS7Unpad(b []byte, blockSize int) ([]byte, error) {
+	if len(b) == 0 || len(b)%blockSize != 0 {
+		return nil, fmt.Errorf("invalid padded length: %d", len(b))
+	}
+	amountToPad := int(b[len(b)-1])
+	if amountToPad < 1 || amountToPad > blockSize {
+		return nil, fmt.Errorf("the amount to pad is invalid: %d", amountToPad)
+	}
+	return b[:len(b)-amountToPad], nil
+}
+
+// 生成加密用的 16 字节 random.
+func NewRandom() (random [16]byte) {
+	if _, err := rand.Read(random[:]); err != nil {
+		panic(err)
+	}
+	return
+}
+
+const randomStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// 生成长度为 n 的随机字符串(a-z,A-Z,0-9), 一般用作 nonce.
+func RandomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = randomStringChars[int(b[i])%len(randomStringChars)]
+	}
+	return string(b)
+}
+
+// 加密 msg, ciphertext = AES_Encrypt[random(16B) + msg_len(4B) + msg + appId]
+func Encrypt(random []byte, msg []byte, appId string, key [32]byte) (ciphertext []byte) {
+	plain := make([]byte, 20, 20+len(msg)+len(appId)+BlockSize)
+	copy(plain, random)
+	n := len(msg)
+	plain[16] = byte(n >> 24)
+	plain[17] = byte(n >> 16)
+	plain[18] = byte(n >> 8)
+	plain[19] = byte(n)
+	plain = append(plain, msg...)
+	plain = append(plain, appId...)
+	plain = PKCS7Pad(plain, BlockSize)
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err)
+	}
+	cryptocipher.NewCBCEncrypter(block, key[:16]).CryptBlocks(plain, plain)
+	return plain
+}
+
+// 解密 ciphertext, 并检查 appId, appId 不一致(包括 appId 为空)时返回 ErrAppIdMismatch.
+func Decrypt(ciphertext []byte, appId string, key [32]byte) (random, msg []byte, err error) {
+	random, msg, haveAppId, err := DecryptWithoutAppIdCheck(ciphertext, key)
+	if err != nil {
+		return
+	}
+	// 两个空的 []byte 比较结果也是相等, 需要单独拒绝空的 appId
+	if len(appId) == 0 || subtle.ConstantTimeCompare(haveAppId, []byte(appId)) != 1 {
+		err = fmt.Errorf("%w, have: %s, want: %s", ErrAppIdMismatch, haveAppId, appId)
+		random, msg = nil, nil
+		return
+	}
+	return
+}
+
+// 解密 ciphertext, 不检查 appId, 返回消息里的 appId, 由调用者自己检查.
+//  NOTE: 只用于事先不知道 appId 的场景(比如第三方平台按 appId 分发消息), 一般请使用 Decrypt.
+func DecryptWithoutAppIdCheck(ciphertext []byte, key [32]byte) (random, msg, appId []byte, err error) {
+	if len(ciphertext) < BlockSize {
+		err = fmt.Errorf("the length of ciphertext too short: %d", len(ciphertext))
+		return
+	}
+	if len(ciphertext)%aes.BlockSize != 0 {
+		err = fmt.Errorf("ciphertext is not a multiple of the block size, the length is %d", len(ciphertext))
+		return
+	}
+
+	plain := make([]byte, len(ciphertext))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err)
+	}
+	cryptocipher.NewCBCDecrypter(block, key[:16]).CryptBlocks(plain, ciphertext)
+
+	if plain, err = PKCS7Unpad(plain, BlockSize); err != nil {
+		return
+	}
+
+	// len(plain) == 16+4+len(msg)+len(appId)
+	if len(plain) < 20 {
+		err = fmt.Errorf("plain msg too short, the length is %d", len(plain))
+		return
+	}
+	msgLen := int(plain[16])<<24 | int(plain[17])<<16 | int(plain[18])<<8 | int(plain[19])
+	msgEnd := 20 + msgLen
+	if msgLen < 0 || msgEnd > len(plain) {
+		err = fmt.Errorf("invalid msg length: %d", msgLen)
+		return
+	}
+
+	random = plain[:16:16]
+	msg = plain[20:msgEnd:msgEnd]
+	appId = plain[msgEnd:]
+	return
+}
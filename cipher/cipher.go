@@ -0,0 +1,56 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package cipher
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+var ErrSignatureMismatch = errors.New("msg_signature mismatch")
+
+// 一个公众号(企业号, 第三方应用)回调消息的加解密参数.
+type Cipher struct {
+	Token  string
+	AppId  string // 公众号为 AppId, 企业号为 CorpId, 第三方应用为 SuiteId
+	AESKey [32]byte
+}
+
+// encodedAESKey 为管理后台的 EncodingAESKey(43 个字符).
+func New(token, appId, encodedAESKey string) (c *Cipher, err error) {
+	key, err := DecodeAESKey(encodedAESKey)
+	if err != nil {
+		return
+	}
+	c = &Cipher{
+		Token:  token,
+		AppId:  appId,
+		AESKey: key,
+	}
+	return
+}
+
+// 验证 msgSignature 后解密 base64 编码的 encryptedMsg(回调消息的 Encrypt 字段).
+func (c *Cipher) DecryptMsg(msgSignature, timestamp, nonce, encryptedMsg string) (msg []byte, err error) {
+	if !VerifyMsgSignature(msgSignature, c.Token, timestamp, nonce, encryptedMsg) {
+		err = ErrSignatureMismatch
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedMsg)
+	if err != nil {
+		return
+	}
+	_, msg, err = Decrypt(ciphertext, c.AppId, c.AESKey)
+	return
+}
+
+// 加密 msg, 返回 base64 编码的 encryptedMsg 和它的 msgSignature, 用于被动回复消息.
+func (c *Cipher) EncryptMsg(msg []byte, timestamp, nonce string) (encryptedMsg, msgSignature string) {
+	random := NewRandom()
+	encryptedMsg = base64.StdEncoding.EncodeToString(Encrypt(random[:], msg, c.AppId, c.AESKey))
+	msgSignature = MsgSign(c.Token, timestamp, nonce, encryptedMsg)
+	return
+}
@@ -0,0 +1,115 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package cipher
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	c, err := New("token", "wx5823bf96d3bd56c7", "jWmYm7qr5nMoAUwZRjGtBxmz3KA1tkAj3ykkR6q2B2C")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	msg := []byte("<xml><Content><![CDATA[hello]]></Content></xml>")
+	encryptedMsg, msgSignature := c.EncryptMsg(msg, "1409304348", "xxxxxx")
+	have, err := c.DecryptMsg(msgSignature, "1409304348", "xxxxxx", encryptedMsg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(have, msg) {
+		t.Errorf("have %q, want %q", have, msg)
+		return
+	}
+
+	if _, err = c.DecryptMsg(msgSignature, "1409304349", "xxxxxx", encryptedMsg); err != ErrSignatureMismatch {
+		t.Errorf("want ErrSignatureMismatch, have %v", err)
+		return
+	}
+
+	c2 := *c
+	c2.AppId = "wx0000000000000000"
+	if _, err = c2.DecryptMsg(msgSignature, "1409304348", "xxxxxx", encryptedMsg); err == nil {
+		t.Error("want appid mismatch error")
+		return
+	}
+}
+
+func TestDecryptAppId(t *testing.T) {
+	key, err := DecodeAESKey("jWmYm7qr5nMoAUwZRjGtBxmz3KA1tkAj3ykkR6q2B2C")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	random := NewRandom()
+	msg := []byte("<xml></xml>")
+	ciphertext := Encrypt(random[:], msg, "wx5823bf96d3bd56c7", key)
+
+	for _, appId := range []string{"", "wx0000000000000000"} {
+		if _, _, err = Decrypt(ciphertext, appId, key); !errors.Is(err, ErrAppIdMismatch) {
+			t.Errorf("appId %q: want ErrAppIdMismatch, have %v", appId, err)
+			return
+		}
+	}
+
+	// 消息里的 appId 和传入的 appId 都为空
+	if _, _, err = Decrypt(Encrypt(random[:], msg, "", key), "", key); !errors.Is(err, ErrAppIdMismatch) {
+		t.Errorf("empty appId: want ErrAppIdMismatch, have %v", err)
+		return
+	}
+
+	_, have, appId, err := DecryptWithoutAppIdCheck(ciphertext, key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(have, msg) || string(appId) != "wx5823bf96d3bd56c7" {
+		t.Errorf("have %q %q", have, appId)
+		return
+	}
+}
+
+func TestPKCS7(t *testing.T) {
+	for n := 0; n <= 2*BlockSize; n++ {
+		b := bytes.Repeat([]byte{'a'}, n)
+		padded := PKCS7Pad(append([]byte(nil), b...), BlockSize)
+		if len(padded)%BlockSize != 0 || len(padded) <= n {
+			t.Errorf("invalid padded length %d for %d", len(padded), n)
+			return
+		}
+		have, err := PKCS7Unpad(padded, BlockSize)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !bytes.Equal(have, b) {
+			t.Errorf("have %q, want %q", have, b)
+			return
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// sha1("123" + "abc" + "token")
+	if signature := Sign("token", "123", "abc"); signature != "84501b652458690801074dc0b44e9822dfb63245" {
+		t.Errorf("have %s", signature)
+		return
+	}
+	// sha1("123" + "abc" + "encrypt" + "token")
+	if signature := MsgSign("token", "123", "abc", "encrypt"); signature != "5624d5c0d83e57902dd907929748228abd7097c2" {
+		t.Errorf("have %s", signature)
+		return
+	}
+	if !VerifyMsgSignature("5624d5c0d83e57902dd907929748228abd7097c2", "token", "123", "abc", "encrypt") {
+		t.Error("VerifyMsgSignature failed")
+		return
+	}
+}
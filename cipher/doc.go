@@ -0,0 +1,10 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 公众号, 企业号和第三方平台回调消息的加解密.
+//  签名: sha1(sort(token, timestamp, nonce, encrypt)), 见 MsgSign;
+//  加密: AES-256-CBC(random(16B) + msg_len(4B) + msg + appid), key 为 EncodingAESKey 解码后的 32 字节, iv 为 key 的前 16 字节,
+//  补位为 block size 为 32 的 PKCS#7, 密文再 base64 编码.
+package cipher
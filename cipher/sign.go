@@ -0,0 +1,54 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package cipher
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"sort"
+)
+
+// 明文模式/URL认证 签名
+func Sign(token, timestamp, nonce string) (signature string) {
+	return sha1Sign(token, timestamp, nonce)
+}
+
+// 密文模式消息签名
+func MsgSign(token, timestamp, nonce, encryptedMsg string) (signature string) {
+	return sha1Sign(token, timestamp, nonce, encryptedMsg)
+}
+
+// 字典序排序后拼接, 再计算 sha1
+func sha1Sign(strs ...string) string {
+	sort.Strings(strs)
+
+	n := 0
+	for _, str := range strs {
+		n += len(str)
+	}
+	buf := make([]byte, 0, n)
+	for _, str := range strs {
+		buf = append(buf, str...)
+	}
+
+	hashsum := sha1.Sum(buf)
+	return hex.EncodeToString(hashsum[:])
+}
+
+// 验证签名, 用常量时间比较.
+func VerifySignature(signature, token, timestamp, nonce string) bool {
+	return secureCompare(signature, Sign(token, timestamp, nonce))
+}
+
+// 验证消息签名 msg_signature, 用常量时间比较.
+func VerifyMsgSignature(msgSignature, token, timestamp, nonce, encryptedMsg string) bool {
+	return secureCompare(msgSignature, MsgSign(token, timestamp, nonce, encryptedMsg))
+}
+
+func secureCompare(given, actual string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(actual)) == 1
+}
@@ -16,7 +16,10 @@ import (
 	"net/url"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/chanxuehong/wechat/util"
 )
 
 // access_token 中控服务器接口, see access_token_server.png
@@ -47,7 +50,10 @@ var _ AccessTokenServer = (*DefaultAccessTokenServer)(nil)
 type DefaultAccessTokenServer struct {
 	corpId     string
 	corpSecret string
+	secret     util.SecretProvider // 不为 nil 时替代 corpSecret, 见 NewDefaultAccessTokenServerWithSecretProvider
 	httpClient *http.Client
+	baseURL    string
+	metrics    atomic.Value // metricsValue, 见 SetMetrics
 
 	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
 
@@ -64,16 +70,44 @@ type DefaultAccessTokenServer struct {
 }
 
 // 创建一个新的 DefaultAccessTokenServer.
-//  如果 clt == nil 则默认使用 http.DefaultClient.
+//  如果 clt == nil 则默认使用 TextHttpClient.
 func NewDefaultAccessTokenServer(corpId, corpSecret string, clt *http.Client) (srv *DefaultAccessTokenServer) {
+	return NewDefaultAccessTokenServerWithBaseURL(corpId, corpSecret, "", clt)
+}
+
+// 创建一个新的 DefaultAccessTokenServer, 从 baseURL 获取 access_token, 见 Client.BaseURL.
+//  baseURL 为空时等同于 NewDefaultAccessTokenServer.
+func NewDefaultAccessTokenServerWithBaseURL(corpId, corpSecret, baseURL string, clt *http.Client) (srv *DefaultAccessTokenServer) {
 	if clt == nil {
-		clt = http.DefaultClient
+		clt = TextHttpClient
 	}
 
 	srv = &DefaultAccessTokenServer{
 		corpId:          corpId,
 		corpSecret:      corpSecret,
 		httpClient:      clt,
+		baseURL:         baseURL,
+		resetTickerChan: make(chan time.Duration),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+// 创建一个新的 DefaultAccessTokenServer, 每次从微信服务器获取 access_token 时从 secret 获取 CorpSecret.
+//  如果 clt == nil 则默认使用 TextHttpClient.
+func NewDefaultAccessTokenServerWithSecretProvider(corpId string, secret util.SecretProvider, clt *http.Client) (srv *DefaultAccessTokenServer) {
+	if secret == nil {
+		panic("nil SecretProvider")
+	}
+	if clt == nil {
+		clt = TextHttpClient
+	}
+
+	srv = &DefaultAccessTokenServer{
+		corpId:          corpId,
+		secret:          secret,
+		httpClient:      clt,
 		resetTickerChan: make(chan time.Duration),
 	}
 
@@ -96,6 +130,9 @@ func (srv *DefaultAccessTokenServer) Token() (token string, err error) {
 
 func (srv *DefaultAccessTokenServer) TokenRefresh() (token string, err error) {
 	accessTokenInfo, cached, err := srv.getToken()
+	if !cached {
+		srv.incRefresh("client", err)
+	}
 	if err != nil {
 		return
 	}
@@ -118,6 +155,9 @@ NEW_TICK_DURATION:
 
 		case <-ticker.C:
 			accessTokenInfo, cached, err := srv.getToken()
+			if !cached {
+				srv.incRefresh("daemon", err)
+			}
 			if err != nil {
 				break
 			}
@@ -158,10 +198,21 @@ func (srv *DefaultAccessTokenServer) getToken() (token accessTokenInfo, cached b
 		return
 	}
 
-	_url := "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=" + url.QueryEscape(srv.corpId) +
-		"&corpsecret=" + url.QueryEscape(srv.corpSecret)
+	corpSecret := srv.corpSecret
+	if srv.secret != nil {
+		if corpSecret, err = srv.secret.Secret(); err != nil {
+			srv.tokenCache.Lock()
+			srv.tokenCache.Token = ""
+			srv.tokenCache.Unlock()
+			return
+		}
+	}
+
+	_url := ReplaceBaseURL("https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=", srv.baseURL) + url.QueryEscape(srv.corpId) +
+		"&corpsecret=" + url.QueryEscape(corpSecret)
 	httpResp, err := srv.httpClient.Get(_url)
 	if err != nil {
+		err = util.RedactError(err)
 		srv.tokenCache.Lock()
 		srv.tokenCache.Token = ""
 		srv.tokenCache.Unlock()
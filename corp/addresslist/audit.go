@@ -0,0 +1,391 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 通讯录变更的事件类型
+const EventTypeChangeContact = "change_contact"
+
+// 通讯录变更事件的 ChangeType
+const (
+	ChangeTypeCreateUser  = "create_user"
+	ChangeTypeUpdateUser  = "update_user"
+	ChangeTypeDeleteUser  = "delete_user"
+	ChangeTypeCreateParty = "create_party"
+	ChangeTypeUpdateParty = "update_party"
+	ChangeTypeDeleteParty = "delete_party"
+	ChangeTypeUpdateTag   = "update_tag"
+)
+
+// 审计记录的对象类型
+const (
+	AuditObjectUser  = "user"
+	AuditObjectParty = "party"
+	AuditObjectTag   = "tag"
+)
+
+// 一个字段的变更, Before 或 After 为 nil 表示该字段不存在.
+type AuditFieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// 一条通讯录变更的审计记录.
+//  Before 为 ContactAuditor 记录的该对象最后的状态, 没有记录时为 nil;
+//  After 为收到事件后通过通讯录接口获取的当前状态, 对象被删除时为 nil.
+type AuditRecord struct {
+	EventTime  int64  `json:"event_time"`  // 事件的 CreateTime
+	RecordTime int64  `json:"record_time"` // 生成审计记录的时间
+	CorpId     string `json:"corp_id,omitempty"`
+	AgentId    int64  `json:"agent_id,omitempty"`
+	ChangeType string `json:"change_type"` // ChangeTypeCreateUser...
+	Object     string `json:"object"`      // AuditObjectUser, AuditObjectParty, AuditObjectTag
+	ObjectId   string `json:"object_id"`   // userid, 部门 id 或者标签 id
+	NewUserId  string `json:"new_user_id,omitempty"`
+
+	Before     json.RawMessage    `json:"before,omitempty"`
+	After      json.RawMessage    `json:"after,omitempty"`
+	Changes    []AuditFieldChange `json:"changes,omitempty"`
+	FetchError string             `json:"fetch_error,omitempty"` // 获取当前状态失败时的错误信息, 此时 After 为 nil
+}
+
+// 审计记录的存储, 只追加不修改.
+type AuditSink interface {
+	Append(record *AuditRecord) error
+}
+
+var _ AuditSink = (*JSONLinesAuditSink)(nil)
+
+// 把审计记录按 JSON Lines 格式(每行一条记录)写入 io.Writer, 可以并发调用.
+type JSONLinesAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJSONLinesAuditSink(w io.Writer) *JSONLinesAuditSink {
+	if w == nil {
+		panic("nil io.Writer")
+	}
+	return &JSONLinesAuditSink{w: w}
+}
+
+// 以追加的方式打开(不存在则创建) path 文件作为审计记录的存储, 调用者负责关闭 file.
+func OpenAuditFile(path string) (sink *JSONLinesAuditSink, file *os.File, err error) {
+	file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return
+	}
+	sink = NewJSONLinesAuditSink(file)
+	return
+}
+
+func (sink *JSONLinesAuditSink) Append(record *AuditRecord) (err error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	_, err = sink.w.Write(line) // 一次 Write 写入整行, 避免多进程追加同一个文件时交错
+	return
+}
+
+// 通讯录变更审计, 收到 change_contact 事件后通过通讯录接口获取对象的当前状态,
+// 和之前记录的状态对比后生成 AuditRecord 写入 AuditSink. 一般这样注册:
+//  auditor := addresslist.NewContactAuditor(clt, sink)
+//  if err := auditor.LoadSnapshot(1); err != nil {
+//      ...
+//  }
+//  mux.EventHandle(addresslist.EventTypeChangeContact, auditor)
+//
+//  NOTE:
+//  1. 对象的状态只保存在内存里, 进程重启后第一次变更的 Before 为 nil, 可以调用 LoadSnapshot 预先加载.
+//  2. 获取当前状态需要 clt 有通讯录的读取权限.
+type ContactAuditor struct {
+	clt  Client
+	sink AuditSink
+
+	mu      sync.Mutex
+	users   map[string]json.RawMessage
+	parties map[int64]json.RawMessage
+	tags    map[int64]json.RawMessage
+}
+
+func NewContactAuditor(clt Client, sink AuditSink) *ContactAuditor {
+	if clt.Client == nil {
+		panic("nil corp.Client")
+	}
+	if sink == nil {
+		panic("nil AuditSink")
+	}
+	return &ContactAuditor{
+		clt:     clt,
+		sink:    sink,
+		users:   make(map[string]json.RawMessage),
+		parties: make(map[int64]json.RawMessage),
+		tags:    make(map[int64]json.RawMessage),
+	}
+}
+
+// 加载 rootId 部门下的组织架构作为对象的初始状态, 见 Client.CurrentOrg.
+func (auditor *ContactAuditor) LoadSnapshot(rootId int64) (err error) {
+	org, err := auditor.clt.CurrentOrg(rootId)
+	if err != nil {
+		return
+	}
+
+	users := make(map[string]json.RawMessage, len(org.Users))
+	for i := range org.Users {
+		if users[org.Users[i].Id], err = json.Marshal(&org.Users[i]); err != nil {
+			return
+		}
+	}
+	parties := make(map[int64]json.RawMessage, len(org.Departments))
+	for i := range org.Departments {
+		if parties[org.Departments[i].Id], err = json.Marshal(&org.Departments[i]); err != nil {
+			return
+		}
+	}
+
+	auditor.mu.Lock()
+	for k, v := range users {
+		auditor.users[k] = v
+	}
+	for k, v := range parties {
+		auditor.parties[k] = v
+	}
+	auditor.mu.Unlock()
+	return
+}
+
+// 实现 corp.MessageHandler, 出错时只记录日志.
+func (auditor *ContactAuditor) ServeMessage(w http.ResponseWriter, r *corp.Request) {
+	record, err := auditor.Record(r.MixedMsg)
+	if err != nil {
+		keyvals := []interface{}{"change_type", r.MixedMsg.ChangeType, "error", err.Error()}
+		if record != nil {
+			keyvals = append(keyvals, "object", record.Object, "object_id", record.ObjectId)
+		}
+		auditor.clt.GetLogger().Error("wechat: contact audit failed", keyvals...)
+	}
+}
+
+// 根据 change_contact 事件生成一条审计记录并写入 AuditSink.
+//  获取当前状态失败时仍然写入审计记录(见 AuditRecord.FetchError), 同时返回该错误;
+//  写入 AuditSink 失败时返回写入的错误, 此时不更新记录的状态.
+func (auditor *ContactAuditor) Record(msg *corp.MixedMessage) (record *AuditRecord, err error) {
+	if msg == nil {
+		err = errors.New("nil MixedMessage")
+		return
+	}
+
+	record = &AuditRecord{
+		EventTime:  msg.CreateTime,
+		RecordTime: time.Now().Unix(),
+		CorpId:     msg.ToUserName,
+		AgentId:    msg.AgentId,
+		ChangeType: msg.ChangeType,
+	}
+
+	var (
+		after    json.RawMessage
+		fetchErr error
+		commit   func() // 写入 AuditSink 成功后更新对象的状态
+	)
+
+	switch msg.ChangeType {
+	case ChangeTypeCreateUser, ChangeTypeUpdateUser, ChangeTypeDeleteUser:
+		record.Object = AuditObjectUser
+		record.ObjectId = msg.UserId
+		record.NewUserId = msg.NewUserId
+
+		currentId := msg.UserId
+		if msg.NewUserId != "" {
+			currentId = msg.NewUserId
+		}
+		if msg.ChangeType != ChangeTypeDeleteUser {
+			var info *UserInfo
+			if info, fetchErr = auditor.clt.UserInfo(currentId); fetchErr == nil {
+				after, fetchErr = json.Marshal(info)
+			}
+		}
+
+		auditor.mu.Lock()
+		record.Before = auditor.users[msg.UserId]
+		auditor.mu.Unlock()
+
+		commit = func() {
+			auditor.mu.Lock()
+			delete(auditor.users, msg.UserId)
+			if after != nil {
+				auditor.users[currentId] = after
+			}
+			auditor.mu.Unlock()
+		}
+
+	case ChangeTypeCreateParty, ChangeTypeUpdateParty, ChangeTypeDeleteParty:
+		record.Object = AuditObjectParty
+		record.ObjectId = msg.Id
+
+		var id int64
+		if id, err = strconv.ParseInt(msg.Id, 10, 64); err != nil {
+			return
+		}
+		if msg.ChangeType != ChangeTypeDeleteParty {
+			after, fetchErr = auditor.fetchParty(id)
+		}
+
+		auditor.mu.Lock()
+		record.Before = auditor.parties[id]
+		auditor.mu.Unlock()
+
+		commit = func() {
+			auditor.mu.Lock()
+			delete(auditor.parties, id)
+			if after != nil {
+				auditor.parties[id] = after
+			}
+			auditor.mu.Unlock()
+		}
+
+	case ChangeTypeUpdateTag:
+		record.Object = AuditObjectTag
+		record.ObjectId = strconv.FormatInt(msg.TagId, 10)
+
+		after, fetchErr = auditor.fetchTag(msg.TagId)
+
+		auditor.mu.Lock()
+		record.Before = auditor.tags[msg.TagId]
+		auditor.mu.Unlock()
+
+		commit = func() {
+			if after == nil {
+				return
+			}
+			auditor.mu.Lock()
+			auditor.tags[msg.TagId] = after
+			auditor.mu.Unlock()
+		}
+
+	default:
+		err = errors.New("unknown change_contact ChangeType: " + msg.ChangeType)
+		return
+	}
+
+	if fetchErr != nil {
+		after = nil
+		record.FetchError = fetchErr.Error()
+	}
+	record.After = after
+	if fetchErr == nil {
+		if record.Changes, err = auditDiff(record.Before, record.After); err != nil {
+			return
+		}
+	}
+
+	if err = auditor.sink.Append(record); err != nil {
+		return
+	}
+	if fetchErr != nil {
+		err = fetchErr // 保留之前的状态, 下次变更时仍然和它对比
+		return
+	}
+	commit()
+	return
+}
+
+func (auditor *ContactAuditor) fetchParty(id int64) (data json.RawMessage, err error) {
+	departments, err := auditor.clt.DepartmentList(id)
+	if err != nil {
+		return
+	}
+	for i := range departments {
+		if departments[i].Id == id {
+			return json.Marshal(&departments[i])
+		}
+	}
+	err = errors.New("department not found: " + strconv.FormatInt(id, 10))
+	return
+}
+
+// 标签的状态, 成员和部门都已排序.
+type auditTag struct {
+	Id        int64    `json:"tagid"`
+	UserList  []string `json:"userlist"`
+	PartyList []int64  `json:"partylist"`
+}
+
+func (auditor *ContactAuditor) fetchTag(id int64) (data json.RawMessage, err error) {
+	userList, partyList, err := auditor.clt.TagInfo(id)
+	if err != nil {
+		return
+	}
+
+	tag := auditTag{
+		Id:        id,
+		UserList:  make([]string, 0, len(userList)),
+		PartyList: append(make([]int64, 0, len(partyList)), partyList...),
+	}
+	for i := range userList {
+		tag.UserList = append(tag.UserList, userList[i].Id)
+	}
+	sort.Strings(tag.UserList)
+	sort.Slice(tag.PartyList, func(i, j int) bool { return tag.PartyList[i] < tag.PartyList[j] })
+	return json.Marshal(&tag)
+}
+
+// 按 JSON 的顶层字段对比 before 和 after, 返回的变更按字段名排序.
+func auditDiff(before, after json.RawMessage) (changes []AuditFieldChange, err error) {
+	var beforeFields, afterFields map[string]interface{}
+	if len(before) > 0 && !bytes.Equal(before, []byte("null")) {
+		if err = json.Unmarshal(before, &beforeFields); err != nil {
+			return
+		}
+	}
+	if len(after) > 0 && !bytes.Equal(after, []byte("null")) {
+		if err = json.Unmarshal(after, &afterFields); err != nil {
+			return
+		}
+	}
+
+	fields := make([]string, 0, len(beforeFields)+len(afterFields))
+	for k := range beforeFields {
+		fields = append(fields, k)
+	}
+	for k := range afterFields {
+		if _, ok := beforeFields[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		b, a := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, AuditFieldChange{Field: field, Before: b, After: a})
+	}
+	return
+}
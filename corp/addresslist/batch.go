@@ -0,0 +1,195 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	BatchJobTypeSyncUser     = "sync_user"
+	BatchJobTypeReplaceUser  = "replace_user"
+	BatchJobTypeInviteUser   = "invite_user"
+	BatchJobTypeReplaceParty = "replace_party"
+)
+
+const (
+	BatchJobStatusPending    = 1 // 任务开始
+	BatchJobStatusProcessing = 2 // 任务处理中
+	BatchJobStatusFinished   = 3 // 任务已经完成
+)
+
+// 异步任务完成后的回调设置, 为 nil 时不回调
+type BatchCallback struct {
+	URL            string `json:"url,omitempty"`            // 企业应用接收企业号推送请求的访问协议和地址，支持http或https协议
+	Token          string `json:"token,omitempty"`          // 用于生成签名
+	EncodingAESKey string `json:"encodingaeskey,omitempty"` // 用于消息体的加密，是AES密钥的Base64编码
+}
+
+// 增量更新成员.
+//  mediaId: 上传的 csv 文件的 media_id, 文件格式见企业号管理端的模板
+func (clt Client) BatchSyncUser(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchSubmit("https://qyapi.weixin.qq.com/cgi-bin/batch/syncuser?access_token=", mediaId, callback)
+}
+
+// 全量覆盖成员.
+//  mediaId: 上传的 csv 文件的 media_id, 文件格式见企业号管理端的模板
+func (clt Client) BatchReplaceUser(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchSubmit("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceuser?access_token=", mediaId, callback)
+}
+
+// 全量覆盖部门.
+//  mediaId: 上传的 csv 文件的 media_id, 文件格式见企业号管理端的模板
+func (clt Client) BatchReplaceParty(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchSubmit("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceparty?access_token=", mediaId, callback)
+}
+
+func (clt Client) batchSubmit(incompleteURL, mediaId string, callback *BatchCallback) (jobId string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId  string         `json:"media_id"`
+		Callback *BatchCallback `json:"callback,omitempty"`
+	}{
+		MediaId:  mediaId,
+		Callback: callback,
+	}
+
+	var result struct {
+		corp.Error
+		JobId string `json:"jobid"`
+	}
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	jobId = result.JobId
+	return
+}
+
+// 异步任务的结果
+type BatchJobResult struct {
+	Status     int    `json:"status"`     // 任务状态，整型，1表示任务开始，2表示任务进行中，3表示任务已完成
+	Type       string `json:"type"`       // 操作类型，字节串，目前分别有：sync_user, replace_user, invite_user, replace_party
+	Total      int    `json:"total"`      // 任务运行总条数
+	Percentage int    `json:"percentage"` // 目前运行百分比，当任务完成时为100
+	RemainTime int    `json:"remaintime"` // 预估剩余时间（单位：分钟），当任务完成时为0
+
+	// 详细的处理结果, 不同的 Type 格式不一样, 可以用 UserResults, PartyResults 解析.
+	Result []json.RawMessage `json:"result"`
+}
+
+// 成员相关任务(sync_user, replace_user, invite_user)每个成员的处理结果
+type BatchUserResult struct {
+	UserId     string `json:"userid"`
+	Action     int    `json:"action,omitempty"` // 操作类型（按位或）：1 表示修改，2 表示生成，4表示邀请
+	InviteType int    `json:"invitetype,omitempty"`
+	ErrCode    int    `json:"errcode"`
+	ErrMsg     string `json:"errmsg"`
+}
+
+// 部门相关任务(replace_party)每个部门的处理结果
+type BatchPartyResult struct {
+	Action  int    `json:"action"` // 操作类型（按位或）：1 新建部门 ，2 更改部门名称， 4 移动部门， 8 修改部门排序
+	PartyId int64  `json:"partyid"`
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+func (result *BatchJobResult) Finished() bool {
+	return result.Status == BatchJobStatusFinished
+}
+
+// 解析成员相关任务的处理结果.
+func (result *BatchJobResult) UserResults() (list []BatchUserResult, err error) {
+	if result.Type == BatchJobTypeReplaceParty {
+		err = fmt.Errorf("job type %s has no user results", result.Type)
+		return
+	}
+	list = make([]BatchUserResult, len(result.Result))
+	for i, raw := range result.Result {
+		if err = json.Unmarshal(raw, &list[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 解析部门相关任务的处理结果.
+func (result *BatchJobResult) PartyResults() (list []BatchPartyResult, err error) {
+	if result.Type != BatchJobTypeReplaceParty {
+		err = fmt.Errorf("job type %s has no party results", result.Type)
+		return
+	}
+	list = make([]BatchPartyResult, len(result.Result))
+	for i, raw := range result.Result {
+		if err = json.Unmarshal(raw, &list[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 获取异步任务结果
+func (clt Client) BatchGetResult(jobId string) (jobResult *BatchJobResult, err error) {
+	if jobId == "" {
+		err = errors.New("empty jobId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		BatchJobResult
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/batch/getresult?jobid=" +
+		url.QueryEscape(jobId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	jobResult = &result.BatchJobResult
+	return
+}
+
+// 每隔 interval 查询一次异步任务的结果, 直到任务完成或者超过 timeout.
+//  interval <= 0 时默认为 5 秒.
+func (clt Client) BatchWaitResult(jobId string, interval, timeout time.Duration) (jobResult *BatchJobResult, err error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		if jobResult, err = clt.BatchGetResult(jobId); err != nil {
+			return
+		}
+		if jobResult.Finished() {
+			return
+		}
+		if time.Now().Add(interval).After(deadline) {
+			err = fmt.Errorf("batch job %s not finished after %s, percentage: %d", jobId, timeout, jobResult.Percentage)
+			return
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// userid 转换成 openid.
+//  agentId: 需要发送红包的应用ID，若只是使用微信支付和企业转账，则无需该参数, 传 0 即可.
+//  appId 只有在传入 agentId 时才返回, 为应用对应的公众号 appid.
+func (clt Client) ConvertToOpenId(userId string, agentId int64) (openId, appId string, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		UserId  string `json:"userid"`
+		AgentId int64  `json:"agentid,omitempty"`
+	}{
+		UserId:  userId,
+		AgentId: agentId,
+	}
+
+	var result struct {
+		corp.Error
+		OpenId string `json:"openid"`
+		AppId  string `json:"appid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_openid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openId = result.OpenId
+	appId = result.AppId
+	return
+}
+
+// openid 转换成 userid.
+func (clt Client) ConvertToUserId(openId string) (userId string, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: openId,
+	}
+
+	var result struct {
+		corp.Error
+		UserId string `json:"userid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_userid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	userId = result.UserId
+	return
+}
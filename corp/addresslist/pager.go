@@ -0,0 +1,46 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"context"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 逐个返回成员ID列表的遍历器, 需要时自动拉取下一页, 用法见 util.Iterator.
+type DeptUserPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一项, 遍历结束时返回 util.Done.
+func (pager *DeptUserPager) Next(ctx context.Context) (deptUser *DeptUser, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*DeptUser), nil
+}
+
+// 获取企业所有成员ID的 DeptUserPager, cursor 为开始遍历的分页游标, 为空表示从头遍历.
+//  NOTE: 第一次调用 Next 时才拉取数据, 每页拉取 UserListIdLimit 个.
+func (clt Client) DeptUserPager(cursor string) *DeptUserPager {
+	return &DeptUserPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			list, nextCursor, err := Client{Client: clt.WithContext(ctx)}.UserListId(cursor, UserListIdLimit)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			cursor = nextCursor
+			hasNext = nextCursor != ""
+			return
+		}),
+	}
+}
@@ -0,0 +1,359 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	SyncOpDepartmentCreate = "department_create"
+	SyncOpDepartmentUpdate = "department_update"
+	SyncOpDepartmentDelete = "department_delete"
+	SyncOpUserCreate       = "user_create"
+	SyncOpUserUpdate       = "user_update"
+	SyncOpUserDelete       = "user_delete"
+)
+
+// 组织架构, 部门和成员的集合.
+// 部门通过 Department.Id 匹配, 成员通过 UserInfo.Id 匹配.
+type OrgStructure struct {
+	Departments []Department
+	Users       []UserInfo
+}
+
+// 同步组织架构需要执行的一个操作.
+type SyncAction struct {
+	Op string // SyncOpDepartmentCreate, SyncOpUserUpdate...
+
+	DepartmentCreate *DepartmentCreateParameters `json:",omitempty"`
+	DepartmentUpdate *DepartmentUpdateParameters `json:",omitempty"`
+	DepartmentId     int64                       `json:",omitempty"` // SyncOpDepartmentDelete
+	UserCreate       *UserCreateParameters       `json:",omitempty"`
+	UserUpdate       *UserUpdateParameters       `json:",omitempty"`
+	UserId           string                      `json:",omitempty"` // SyncOpUserDelete
+}
+
+// 用于 dry-run 输出.
+func (action *SyncAction) String() string {
+	switch action.Op {
+	case SyncOpDepartmentCreate:
+		para := action.DepartmentCreate
+		return fmt.Sprintf("%s id=%d name=%q parentid=%d", action.Op, *para.DepartmentId, para.DepartmentName, para.ParentId)
+	case SyncOpDepartmentUpdate:
+		para := action.DepartmentUpdate
+		s := fmt.Sprintf("%s id=%d", action.Op, para.DepartmentId)
+		if para.DepartmentName != "" {
+			s += fmt.Sprintf(" name=%q", para.DepartmentName)
+		}
+		if para.ParentId != nil {
+			s += fmt.Sprintf(" parentid=%d", *para.ParentId)
+		}
+		return s
+	case SyncOpDepartmentDelete:
+		return fmt.Sprintf("%s id=%d", action.Op, action.DepartmentId)
+	case SyncOpUserCreate:
+		para := action.UserCreate
+		return fmt.Sprintf("%s userid=%q name=%q department=%v", action.Op, para.UserId, para.Name, para.Department)
+	case SyncOpUserUpdate:
+		para := action.UserUpdate
+		s := fmt.Sprintf("%s userid=%q", action.Op, para.UserId)
+		if para.Name != "" {
+			s += fmt.Sprintf(" name=%q", para.Name)
+		}
+		if para.Department != nil {
+			s += fmt.Sprintf(" department=%v", para.Department)
+		}
+		if para.Position != "" {
+			s += fmt.Sprintf(" position=%q", para.Position)
+		}
+		if para.Mobile != "" {
+			s += fmt.Sprintf(" mobile=%q", para.Mobile)
+		}
+		if para.Email != "" {
+			s += fmt.Sprintf(" email=%q", para.Email)
+		}
+		return s
+	case SyncOpUserDelete:
+		return fmt.Sprintf("%s userid=%q", action.Op, action.UserId)
+	default:
+		return action.Op
+	}
+}
+
+// 比较 rootId 部门下当前的组织架构 current 和期望的组织架构 desired, 返回需要执行的最少的操作.
+// rootId 部门本身不会被创建, 修改或者删除; 根部门的 id 为 1.
+// 返回的操作已经按照执行顺序排好:
+//  1. 创建部门(父部门在前)
+//  2. 更新部门
+//  3. 创建成员, 更新成员
+//  4. 删除成员
+//  5. 删除部门(子部门在前)
+//  NOTE: desired 中的部门必须指定 Id; 成员只比较 Name, Department, Position, Mobile, Email,
+//  因为更新接口的参数都是 omitempty 的, desired 里为空的字段不会被清空.
+func DiffOrg(rootId int64, current, desired *OrgStructure) (actions []SyncAction, err error) {
+	if current == nil {
+		err = errors.New("nil current")
+		return
+	}
+	if desired == nil {
+		err = errors.New("nil desired")
+		return
+	}
+
+	currentDepts := make(map[int64]*Department, len(current.Departments))
+	for i := range current.Departments {
+		currentDepts[current.Departments[i].Id] = &current.Departments[i]
+	}
+	desiredDepts := make(map[int64]*Department, len(desired.Departments))
+	for i := range desired.Departments {
+		dept := &desired.Departments[i]
+		if dept.Id <= 0 {
+			err = fmt.Errorf("invalid department id %d for department %q", dept.Id, dept.Name)
+			return
+		}
+		if _, ok := desiredDepts[dept.Id]; ok {
+			err = fmt.Errorf("duplicate department id %d", dept.Id)
+			return
+		}
+		desiredDepts[dept.Id] = dept
+	}
+
+	// 部门: 创建和更新
+	var creates, deletes []*Department
+	var updates []SyncAction
+	for i := range desired.Departments {
+		want := &desired.Departments[i]
+		if want.Id == rootId {
+			continue
+		}
+		have, ok := currentDepts[want.Id]
+		if !ok {
+			creates = append(creates, want)
+			continue
+		}
+		var para *DepartmentUpdateParameters
+		if want.Name != have.Name {
+			para = &DepartmentUpdateParameters{DepartmentId: want.Id, DepartmentName: want.Name}
+		}
+		if want.ParentId != have.ParentId {
+			if para == nil {
+				para = &DepartmentUpdateParameters{DepartmentId: want.Id}
+			}
+			parentId := want.ParentId
+			para.ParentId = &parentId
+		}
+		if para != nil {
+			updates = append(updates, SyncAction{Op: SyncOpDepartmentUpdate, DepartmentUpdate: para})
+		}
+	}
+	for i := range current.Departments {
+		have := &current.Departments[i]
+		if have.Id == rootId {
+			continue
+		}
+		if _, ok := desiredDepts[have.Id]; !ok {
+			deletes = append(deletes, have)
+		}
+	}
+
+	sortDepartmentsByDepth(creates, desiredDepts, false)
+	for _, dept := range creates {
+		id := dept.Id
+		actions = append(actions, SyncAction{
+			Op: SyncOpDepartmentCreate,
+			DepartmentCreate: &DepartmentCreateParameters{
+				DepartmentName: dept.Name,
+				ParentId:       dept.ParentId,
+				DepartmentId:   &id,
+			},
+		})
+	}
+	actions = append(actions, updates...)
+
+	// 成员
+	currentUsers := make(map[string]*UserInfo, len(current.Users))
+	for i := range current.Users {
+		currentUsers[current.Users[i].Id] = &current.Users[i]
+	}
+	desiredUsers := make(map[string]bool, len(desired.Users))
+	for i := range desired.Users {
+		want := &desired.Users[i]
+		if want.Id == "" {
+			err = fmt.Errorf("empty userid for user %q", want.Name)
+			return
+		}
+		if desiredUsers[want.Id] {
+			err = fmt.Errorf("duplicate userid %q", want.Id)
+			return
+		}
+		desiredUsers[want.Id] = true
+
+		have, ok := currentUsers[want.Id]
+		if !ok {
+			actions = append(actions, SyncAction{
+				Op: SyncOpUserCreate,
+				UserCreate: &UserCreateParameters{
+					UserId:     want.Id,
+					Name:       want.Name,
+					Department: want.Department,
+					Position:   want.Position,
+					Mobile:     want.Mobile,
+					Email:      want.Email,
+					WeixinId:   want.WeixinId,
+				},
+			})
+			continue
+		}
+		if para := diffUser(have, want); para != nil {
+			actions = append(actions, SyncAction{Op: SyncOpUserUpdate, UserUpdate: para})
+		}
+	}
+	for i := range current.Users {
+		if !desiredUsers[current.Users[i].Id] {
+			actions = append(actions, SyncAction{Op: SyncOpUserDelete, UserId: current.Users[i].Id})
+		}
+	}
+
+	sortDepartmentsByDepth(deletes, currentDepts, true)
+	for _, dept := range deletes {
+		actions = append(actions, SyncAction{Op: SyncOpDepartmentDelete, DepartmentId: dept.Id})
+	}
+	return
+}
+
+// 比较成员, 没有变化返回 nil, 否则返回只包含变化字段的更新参数.
+func diffUser(have, want *UserInfo) (para *UserUpdateParameters) {
+	para = &UserUpdateParameters{UserId: want.Id}
+	changed := false
+	if want.Name != "" && want.Name != have.Name {
+		para.Name = want.Name
+		changed = true
+	}
+	if want.Position != "" && want.Position != have.Position {
+		para.Position = want.Position
+		changed = true
+	}
+	if want.Mobile != "" && want.Mobile != have.Mobile {
+		para.Mobile = want.Mobile
+		changed = true
+	}
+	if want.Email != "" && want.Email != have.Email {
+		para.Email = want.Email
+		changed = true
+	}
+	if len(want.Department) > 0 && !sameDepartmentIds(have.Department, want.Department) {
+		para.Department = want.Department
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return
+}
+
+func sameDepartmentIds(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[int64]int, len(a))
+	for _, id := range a {
+		set[id]++
+	}
+	for _, id := range b {
+		if set[id] <= 0 {
+			return false
+		}
+		set[id]--
+	}
+	return true
+}
+
+// 按照部门在 tree 中的深度排序, deepestFirst 为 false 时父部门在前, 为 true 时子部门在前.
+func sortDepartmentsByDepth(depts []*Department, tree map[int64]*Department, deepestFirst bool) {
+	depth := make(map[int64]int, len(depts))
+	for _, dept := range depts {
+		n := 0
+		visited := make(map[int64]bool)
+		for d := dept; d != nil && !visited[d.Id]; d = tree[d.ParentId] {
+			visited[d.Id] = true
+			n++
+		}
+		depth[dept.Id] = n
+	}
+	sort.SliceStable(depts, func(i, j int) bool {
+		if deepestFirst {
+			return depth[depts[i].Id] > depth[depts[j].Id]
+		}
+		return depth[depts[i].Id] < depth[depts[j].Id]
+	})
+}
+
+// 获取 rootId 部门下当前的组织架构(包括 rootId 部门本身和所有子部门的成员).
+func (clt Client) CurrentOrg(rootId int64) (org *OrgStructure, err error) {
+	departments, err := clt.DepartmentList(rootId)
+	if err != nil {
+		return
+	}
+	users, err := clt.UserList(rootId, true, 0)
+	if err != nil {
+		return
+	}
+	org = &OrgStructure{
+		Departments: departments,
+		Users:       users,
+	}
+	return
+}
+
+// 按顺序执行 actions, 遇到错误立即返回, done 为已经成功执行的操作个数.
+//  返回的错误包装了原始错误, 可以用 errors.As 获取微信服务器返回的 *corp.Error.
+func (clt Client) ApplySyncActions(actions []SyncAction) (done int, err error) {
+	for i := range actions {
+		action := &actions[i]
+		switch action.Op {
+		case SyncOpDepartmentCreate:
+			_, err = clt.DepartmentCreate(action.DepartmentCreate)
+		case SyncOpDepartmentUpdate:
+			err = clt.DepartmentUpdate(action.DepartmentUpdate)
+		case SyncOpDepartmentDelete:
+			err = clt.DepartmentDelete(action.DepartmentId)
+		case SyncOpUserCreate:
+			err = clt.UserCreate(action.UserCreate)
+		case SyncOpUserUpdate:
+			err = clt.UserUpdate(action.UserUpdate)
+		case SyncOpUserDelete:
+			err = clt.UserDelete(action.UserId)
+		default:
+			err = fmt.Errorf("unknown sync op: %s", action.Op)
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %w", action, err)
+			return
+		}
+		done++
+	}
+	return
+}
+
+// 同步 rootId 部门下的组织架构到 desired.
+// dryRun 为 true 时只返回需要执行的操作, 不做任何修改, 可以逐个打印 actions 检查.
+func (clt Client) SyncOrg(rootId int64, desired *OrgStructure, dryRun bool) (actions []SyncAction, err error) {
+	current, err := clt.CurrentOrg(rootId)
+	if err != nil {
+		return
+	}
+	if actions, err = DiffOrg(rootId, current, desired); err != nil {
+		return
+	}
+	if dryRun {
+		return
+	}
+	_, err = clt.ApplySyncActions(actions)
+	return
+}
@@ -0,0 +1,150 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestDiffOrg(t *testing.T) {
+	tests := []struct {
+		name    string
+		rootId  int64
+		current OrgStructure
+		desired OrgStructure
+		want    []string
+	}{
+		{
+			name:   "no change",
+			rootId: 1,
+			current: OrgStructure{
+				Departments: []Department{{Id: 1, Name: "root"}, {Id: 2, Name: "a", ParentId: 1}},
+				Users:       []UserInfo{{Id: "u1", Name: "n1", Department: []int64{2}}},
+			},
+			desired: OrgStructure{
+				Departments: []Department{{Id: 1, Name: "root"}, {Id: 2, Name: "a", ParentId: 1}},
+				Users:       []UserInfo{{Id: "u1", Name: "n1", Department: []int64{2}}},
+			},
+			want: nil,
+		},
+		{
+			name:   "create parents first, delete children first",
+			rootId: 1,
+			current: OrgStructure{
+				Departments: []Department{{Id: 1}, {Id: 2, ParentId: 1}, {Id: 3, ParentId: 2}},
+				Users:       []UserInfo{{Id: "u1", Name: "n1", Department: []int64{3}}},
+			},
+			desired: OrgStructure{
+				Departments: []Department{{Id: 1}, {Id: 5, Name: "c", ParentId: 4}, {Id: 4, Name: "b", ParentId: 1}},
+				Users:       []UserInfo{{Id: "u2", Name: "n2", Department: []int64{5}}},
+			},
+			want: []string{
+				`department_create id=4 name="b" parentid=1`,
+				`department_create id=5 name="c" parentid=4`,
+				`user_create userid="u2" name="n2" department=[5]`,
+				`user_delete userid="u1"`,
+				`department_delete id=3`,
+				`department_delete id=2`,
+			},
+		},
+		{
+			name:   "update department and user",
+			rootId: 1,
+			current: OrgStructure{
+				Departments: []Department{{Id: 1}, {Id: 2, Name: "a", ParentId: 1}, {Id: 3, Name: "b", ParentId: 1}},
+				Users:       []UserInfo{{Id: "u1", Name: "n1", Department: []int64{2, 3}, Mobile: "1"}},
+			},
+			desired: OrgStructure{
+				Departments: []Department{{Id: 1}, {Id: 2, Name: "a2", ParentId: 1}, {Id: 3, Name: "b", ParentId: 2}},
+				Users:       []UserInfo{{Id: "u1", Department: []int64{3, 2}, Mobile: "2"}},
+			},
+			want: []string{
+				`department_update id=2 name="a2"`,
+				`department_update id=3 parentid=2`,
+				`user_update userid="u1" mobile="2"`,
+			},
+		},
+		{
+			name:   "sub-tree root is left alone",
+			rootId: 10,
+			current: OrgStructure{
+				Departments: []Department{{Id: 10, Name: "sub", ParentId: 1}, {Id: 11, Name: "a", ParentId: 10}},
+			},
+			desired: OrgStructure{
+				Departments: []Department{{Id: 12, Name: "b", ParentId: 10}},
+			},
+			want: []string{
+				`department_create id=12 name="b" parentid=10`,
+				`department_delete id=11`,
+			},
+		},
+		{
+			name:   "sub-tree root in desired is not updated",
+			rootId: 10,
+			current: OrgStructure{
+				Departments: []Department{{Id: 10, Name: "sub", ParentId: 1}},
+			},
+			desired: OrgStructure{
+				Departments: []Department{{Id: 10, Name: "renamed", ParentId: 2}, {Id: 11, Name: "a", ParentId: 10}},
+			},
+			want: []string{
+				`department_create id=11 name="a" parentid=10`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		actions, err := DiffOrg(tt.rootId, &tt.current, &tt.desired)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var have []string
+		for i := range actions {
+			have = append(have, actions[i].String())
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s:\nhave: %q\nwant: %q", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestDiffOrgInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired OrgStructure
+	}{
+		{"zero department id", OrgStructure{Departments: []Department{{Id: 0}}}},
+		{"duplicate department id", OrgStructure{Departments: []Department{{Id: 2}, {Id: 2}}}},
+		{"empty userid", OrgStructure{Users: []UserInfo{{Name: "n"}}}},
+		{"duplicate userid", OrgStructure{Users: []UserInfo{{Id: "u"}, {Id: "u"}}}},
+	}
+	for _, tt := range tests {
+		if _, err := DiffOrg(1, &OrgStructure{}, &tt.desired); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+}
+
+func TestApplySyncActionsWrapsError(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/user/delete", 60111, "userid not found")
+
+	clt := NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	done, err := clt.ApplySyncActions([]SyncAction{{Op: SyncOpUserDelete, UserId: "zhangsan"}})
+	if done != 0 {
+		t.Errorf("done: have %d, want 0", done)
+	}
+	var apiErr *corp.Error
+	if !errors.As(err, &apiErr) || apiErr.ErrCode != 60111 {
+		t.Errorf("want *corp.Error with errcode 60111, have %v", err)
+	}
+}
@@ -252,3 +252,49 @@ func (clt Client) UserList(departmentId int64,
 	UserList = result.UserList
 	return
 }
+
+const UserListIdLimit = 10000 // 获取成员ID列表, 每次最多返回 10000 个
+
+// 成员ID列表的一项, 第三方应用返回 OpenUserId, 自建应用返回 UserId.
+type DeptUser struct {
+	UserId     string `json:"userid,omitempty"`
+	OpenUserId string `json:"open_userid,omitempty"`
+	Department int64  `json:"department"` // 成员所属的部门; 成员属于多个部门时返回多项
+}
+
+// 获取成员ID列表
+//  cursor: 分页游标, 第一页为空
+//  limit:  每页的个数, 取值在 1 到 UserListIdLimit 之间
+//  nextCursor 为空表示没有下一页.
+func (clt Client) UserListId(cursor string, limit int) (deptUsers []DeptUser, nextCursor string, err error) {
+	if limit <= 0 || limit > UserListIdLimit {
+		err = errors.New("invalid limit")
+		return
+	}
+
+	var request = struct {
+		Cursor string `json:"cursor,omitempty"`
+		Limit  int    `json:"limit"`
+	}{
+		Cursor: cursor,
+		Limit:  limit,
+	}
+	var result struct {
+		corp.Error
+		NextCursor string     `json:"next_cursor"`
+		DeptUser   []DeptUser `json:"dept_user"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/list_id?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	deptUsers = result.DeptUser
+	nextCursor = result.NextCursor
+	return
+}
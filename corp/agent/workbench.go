@@ -0,0 +1,210 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 工作台展示的类型
+const (
+	WorkbenchTypeKeyData = "keydata" // 关键数据型
+	WorkbenchTypeImage   = "image"   // 图片型
+	WorkbenchTypeList    = "list"    // 列表型
+	WorkbenchTypeWebView = "webview" // webview 型
+	WorkbenchTypeNormal  = "normal"  // 取消自定义展示, 恢复为应用的默认展示, 仅用于 SetWorkbenchTemplate
+)
+
+const (
+	WorkbenchKeyDataItemLimit = 4 // 关键数据型最多 4 项
+	WorkbenchListItemLimit    = 3 // 列表型最多 3 项
+)
+
+// webview 的高度
+const (
+	WorkbenchWebViewHeightSingleRow = "single_row"
+	WorkbenchWebViewHeightDoubleRow = "double_row"
+)
+
+// 关键数据型的一项
+type WorkbenchKeyDataItem struct {
+	Key      string `json:"key,omitempty"`      // 关键数据名称
+	Data     string `json:"data"`               // 关键数据
+	JumpURL  string `json:"jump_url,omitempty"` // 点击跳转的 url
+	PagePath string `json:"pagepath,omitempty"` // 点击跳转的小程序页面, 需要应用关联了小程序
+}
+
+type WorkbenchKeyData struct {
+	Items []WorkbenchKeyDataItem `json:"items"`
+}
+
+type WorkbenchImage struct {
+	URL      string `json:"url"` // 图片 url, 建议尺寸 1050*270px
+	JumpURL  string `json:"jump_url,omitempty"`
+	PagePath string `json:"pagepath,omitempty"`
+}
+
+// 列表型的一项
+type WorkbenchListItem struct {
+	Title    string `json:"title"`
+	JumpURL  string `json:"jump_url,omitempty"`
+	PagePath string `json:"pagepath,omitempty"`
+}
+
+type WorkbenchList struct {
+	Items []WorkbenchListItem `json:"items"`
+}
+
+type WorkbenchWebView struct {
+	URL                string `json:"url"`
+	JumpURL            string `json:"jump_url,omitempty"`
+	PagePath           string `json:"pagepath,omitempty"`
+	Height             string `json:"height,omitempty"`               // WorkbenchWebViewHeightSingleRow 或 WorkbenchWebViewHeightDoubleRow
+	HideTitle          bool   `json:"hide_title,omitempty"`           // 是否隐藏应用名称
+	EnableWebviewClick bool   `json:"enable_webview_click,omitempty"` // 是否开启 webview 内的链接跳转能力, 仅用于模版
+}
+
+// 工作台展示的数据, Type 对应的字段必须设置, 其他的字段为 nil.
+type WorkbenchData struct {
+	Type    string            `json:"type"`
+	KeyData *WorkbenchKeyData `json:"keydata,omitempty"`
+	Image   *WorkbenchImage   `json:"image,omitempty"`
+	List    *WorkbenchList    `json:"list,omitempty"`
+	WebView *WorkbenchWebView `json:"webview,omitempty"`
+}
+
+func (data *WorkbenchData) check() error {
+	switch data.Type {
+	case WorkbenchTypeKeyData:
+		if data.KeyData == nil {
+			return errors.New("nil KeyData")
+		}
+		if n := len(data.KeyData.Items); n > WorkbenchKeyDataItemLimit {
+			return fmt.Errorf("the number of keydata items must be less than or equal to %d", WorkbenchKeyDataItemLimit)
+		}
+	case WorkbenchTypeImage:
+		if data.Image == nil {
+			return errors.New("nil Image")
+		}
+	case WorkbenchTypeList:
+		if data.List == nil {
+			return errors.New("nil List")
+		}
+		if n := len(data.List.Items); n > WorkbenchListItemLimit {
+			return fmt.Errorf("the number of list items must be less than or equal to %d", WorkbenchListItemLimit)
+		}
+	case WorkbenchTypeWebView:
+		if data.WebView == nil {
+			return errors.New("nil WebView")
+		}
+	default:
+		return fmt.Errorf("invalid workbench type: %q", data.Type)
+	}
+	return nil
+}
+
+// 应用在工作台展示的模版
+type WorkbenchTemplate struct {
+	WorkbenchData
+
+	// 是否覆盖用户工作台的数据, 为 true 时用模版的数据替换 SetWorkbenchData 设置的数据.
+	ReplaceUserData bool `json:"replace_user_data"`
+}
+
+// 设置应用在工作台展示的模版, tpl.Type 为 WorkbenchTypeNormal 时取消自定义展示.
+func (clt Client) SetWorkbenchTemplate(agentId int64, tpl *WorkbenchTemplate) (err error) {
+	if tpl == nil {
+		return errors.New("nil WorkbenchTemplate")
+	}
+	if tpl.Type != WorkbenchTypeNormal {
+		if err = tpl.check(); err != nil {
+			return
+		}
+	}
+
+	var request = struct {
+		AgentId int64 `json:"agentid"`
+		*WorkbenchTemplate
+	}{
+		AgentId:           agentId,
+		WorkbenchTemplate: tpl,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/set_workbench_template?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取应用在工作台展示的模版.
+func (clt Client) GetWorkbenchTemplate(agentId int64) (tpl *WorkbenchTemplate, err error) {
+	var request = struct {
+		AgentId int64 `json:"agentid"`
+	}{
+		AgentId: agentId,
+	}
+
+	var result struct {
+		corp.Error
+		WorkbenchTemplate
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/get_workbench_template?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tpl = &result.WorkbenchTemplate
+	return
+}
+
+// 设置成员 userId 在工作台展示的数据, 类型必须和 SetWorkbenchTemplate 设置的模版相同.
+func (clt Client) SetWorkbenchData(agentId int64, userId string, data *WorkbenchData) (err error) {
+	if data == nil {
+		return errors.New("nil WorkbenchData")
+	}
+	if err = data.check(); err != nil {
+		return
+	}
+
+	var request = struct {
+		AgentId int64  `json:"agentid"`
+		UserId  string `json:"userid"`
+		*WorkbenchData
+	}{
+		AgentId:       agentId,
+		UserId:        userId,
+		WorkbenchData: data,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/set_workbench_data?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	CheckinDataUserIdCountLimit = 100                 // 获取打卡记录, 一次最多 100 个成员
+	CheckinDataMaxDuration      = 30 * 24 * time.Hour // 获取打卡记录, 时间跨度不超过30天
+	AddCheckinRecordCountLimit  = 200                 // 添加打卡记录, 一次最多 200 条
+)
+
+// 打卡类型
+const (
+	CheckinDataTypeOnOffDuty = 1 // 上下班打卡
+	CheckinDataTypeOutside   = 2 // 外出打卡
+	CheckinDataTypeAll       = 3 // 全部打卡
+)
+
+// 打卡记录
+type CheckinData struct {
+	UserId         string        `json:"userid"`
+	GroupName      string        `json:"groupname"`        // 打卡规则名称
+	CheckinType    string        `json:"checkin_type"`     // 打卡类型, 比如 "上班打卡", "下班打卡", "外出打卡"
+	ExceptionType  string        `json:"exception_type"`   // 异常类型, 比如 "时间异常", "地点异常", "未打卡", 为空表示正常
+	CheckinTime    util.UnixTime `json:"checkin_time"`     // 打卡时间
+	LocationTitle  string        `json:"location_title"`   // 打卡地点title
+	LocationDetail string        `json:"location_detail"`  // 打卡地点详情
+	WifiName       string        `json:"wifiname"`         // 打卡wifi名称
+	WifiMac        string        `json:"wifimac"`          // 打卡的MAC地址/bssid
+	Notes          string        `json:"notes"`            // 打卡备注
+	MediaIds       []string      `json:"mediaids"`         // 打卡的附件media_id
+	Lat            int64         `json:"lat"`              // 位置打卡地点纬度，是实际纬度的1000000倍
+	Lng            int64         `json:"lng"`              // 位置打卡地点经度，是实际经度的1000000倍
+	DeviceId       string        `json:"deviceid"`         // 打卡设备id
+	SchCheckinTime util.UnixTime `json:"sch_checkin_time"` // 标准打卡时间，指此次打卡时间对应的标准上班时间或标准下班时间
+	GroupId        int64         `json:"groupid"`          // 规则id
+	ScheduleId     int64         `json:"schedule_id"`      // 班次id
+	TimelineId     int64         `json:"timeline_id"`      // 时段id
+}
+
+// 获取打卡记录.
+//  dataType 为 CheckinDataTypeOnOffDuty, CheckinDataTypeOutside 或者 CheckinDataTypeAll;
+//  时间跨度不超过 CheckinDataMaxDuration, userIdList 的个数不超过 CheckinDataUserIdCountLimit.
+func (clt Client) GetCheckinData(dataType int, startTime, endTime time.Time, userIdList []string) (list []CheckinData, err error) {
+	if len(userIdList) <= 0 {
+		err = errors.New("empty userIdList")
+		return
+	}
+	if len(userIdList) > CheckinDataUserIdCountLimit {
+		err = fmt.Errorf("the length of userIdList must be less than or equal to %d", CheckinDataUserIdCountLimit)
+		return
+	}
+	if endTime.Before(startTime) {
+		err = errors.New("endTime is before startTime")
+		return
+	}
+	if endTime.Sub(startTime) > CheckinDataMaxDuration {
+		err = errors.New("the duration between startTime and endTime must be less than or equal to 30 days")
+		return
+	}
+
+	var request = struct {
+		DataType   int      `json:"opencheckindatatype"`
+		StartTime  int64    `json:"starttime"`
+		EndTime    int64    `json:"endtime"`
+		UserIdList []string `json:"useridlist"`
+	}{
+		DataType:   dataType,
+		StartTime:  startTime.Unix(),
+		EndTime:    endTime.Unix(),
+		UserIdList: userIdList,
+	}
+
+	var result struct {
+		corp.Error
+		CheckinData []CheckinData `json:"checkindata"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckindata?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.CheckinData
+	return
+}
+
+// 打卡设备类型
+const (
+	DeviceTypeFace        = 1 // 门禁/人脸识别
+	DeviceTypeFingerprint = 2 // 指纹
+	DeviceTypeMobile      = 3 // 手机
+)
+
+// 添加的打卡记录, 比如补卡或者第三方考勤机的打卡记录
+type CheckinRecord struct {
+	UserId         string        `json:"userid"`
+	CheckinTime    util.UnixTime `json:"checkin_time"`              // 打卡时间
+	LocationTitle  string        `json:"location_title,omitempty"`  // 打卡地点title
+	LocationDetail string        `json:"location_detail,omitempty"` // 打卡地点详情
+	MediaIds       []string      `json:"mediaids,omitempty"`        // 打卡的附件media_id
+	Notes          string        `json:"notes,omitempty"`           // 打卡备注
+	DeviceType     int           `json:"device_type"`               // 打卡设备类型, DeviceTypeFace...
+	Lat            int64         `json:"lat,omitempty"`             // 打卡地点纬度，是实际纬度的1000000倍
+	Lng            int64         `json:"lng,omitempty"`             // 打卡地点经度，是实际经度的1000000倍
+	DeviceDetail   string        `json:"device_detail,omitempty"`   // 打卡设备品牌型号
+	WifiName       string        `json:"wifiname,omitempty"`        // 打卡wifi名称
+	WifiMac        string        `json:"wifimac,omitempty"`         // 打卡的MAC地址/bssid
+}
+
+// 添加打卡记录, 可以用于把补卡记录或者考勤机的打卡记录写入企业微信.
+//  records 的个数不超过 AddCheckinRecordCountLimit.
+func (clt Client) AddCheckinRecord(records []CheckinRecord) (err error) {
+	if len(records) <= 0 {
+		return
+	}
+	if len(records) > AddCheckinRecordCountLimit {
+		err = fmt.Errorf("the length of records must be less than or equal to %d", AddCheckinRecordCountLimit)
+		return
+	}
+
+	var request = struct {
+		Records []CheckinRecord `json:"records"`
+	}{
+		Records: records,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/add_checkin_record?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 录入打卡人员人脸信息, face 为人脸图片的原始数据(不用 base64 编码), 图片大小不超过 1MB.
+//  NOTE: 同一个人员多次录入会覆盖之前的人脸信息.
+func (clt Client) AddCheckinUserFace(userId string, face []byte) (err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+	if len(face) <= 0 {
+		err = errors.New("empty face")
+		return
+	}
+
+	var request = struct {
+		UserId   string `json:"userid"`
+		UserFace string `json:"userface"`
+	}{
+		UserId:   userId,
+		UserFace: base64.StdEncoding.EncodeToString(face),
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/addcheckinuserface?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 打卡, 包括打卡记录, 人脸录入和排班.
+//  NOTE: 需要使用"打卡"应用的 secret 获取的 access_token.
+package checkin
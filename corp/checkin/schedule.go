@@ -0,0 +1,143 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	ScheduleUserIdCountLimit  = 100 // 获取排班信息, 一次最多 100 个成员
+	SetScheduleItemCountLimit = 200 // 设置排班信息, 一次最多 200 条
+)
+
+// 班次的一个时段, 时间为距离当天0点的秒数
+type TimeSection struct {
+	Id               int64 `json:"id"`
+	WorkSec          int   `json:"work_sec"`            // 上班时间
+	OffWorkSec       int   `json:"off_work_sec"`        // 下班时间
+	RemindWorkSec    int   `json:"remind_work_sec"`     // 上班提醒时间
+	RemindOffWorkSec int   `json:"remind_off_work_sec"` // 下班提醒时间
+}
+
+// 班次
+type ScheduleInfo struct {
+	ScheduleId   int64         `json:"schedule_id"` // 班次id, 为 0 表示休息
+	ScheduleName string        `json:"schedule_name"`
+	TimeSection  []TimeSection `json:"time_section"`
+}
+
+// 一天的排班
+type DaySchedule struct {
+	Day          int          `json:"day"` // 排班日期, 为当月第几天
+	ScheduleInfo ScheduleInfo `json:"schedule_info"`
+}
+
+// 一个成员一个月的排班
+type UserSchedule struct {
+	UserId    string `json:"userid"`
+	YearMonth int    `json:"yearmonth"` // 排班表月份, 格式为年月, 比如 202011
+	GroupId   int64  `json:"groupid"`   // 打卡规则id
+	GroupName string `json:"groupname"` // 打卡规则名称
+	Schedule  struct {
+		ScheduleList []DaySchedule `json:"scheduleList"`
+	} `json:"schedule"`
+}
+
+// 获取打卡人员排班信息.
+//  时间跨度不超过一个月, userIdList 的个数不超过 ScheduleUserIdCountLimit.
+func (clt Client) GetScheduleList(startTime, endTime time.Time, userIdList []string) (list []UserSchedule, err error) {
+	if len(userIdList) <= 0 {
+		err = errors.New("empty userIdList")
+		return
+	}
+	if len(userIdList) > ScheduleUserIdCountLimit {
+		err = fmt.Errorf("the length of userIdList must be less than or equal to %d", ScheduleUserIdCountLimit)
+		return
+	}
+	if endTime.Before(startTime) {
+		err = errors.New("endTime is before startTime")
+		return
+	}
+
+	var request = struct {
+		StartTime  int64    `json:"starttime"`
+		EndTime    int64    `json:"endtime"`
+		UserIdList []string `json:"useridlist"`
+	}{
+		StartTime:  startTime.Unix(),
+		EndTime:    endTime.Unix(),
+		UserIdList: userIdList,
+	}
+
+	var result struct {
+		corp.Error
+		ScheduleList []UserSchedule `json:"schedule_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckinschedulist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.ScheduleList
+	return
+}
+
+// 设置排班的一条记录
+type ScheduleItem struct {
+	UserId     string `json:"userid"`
+	Day        int    `json:"day"`         // 要设置的天日期, 取值在1-31之间
+	ScheduleId int64  `json:"schedule_id"` // 对应 groupid 规则下的班次id, 为 0 表示休息
+}
+
+// 为打卡人员排班.
+//  groupId 为打卡规则id, 必须是按班次上下班的规则; yearMonth 为排班表月份, 比如 202011;
+//  items 的个数不超过 SetScheduleItemCountLimit.
+func (clt Client) SetScheduleList(groupId int64, yearMonth int, items []ScheduleItem) (err error) {
+	if len(items) <= 0 {
+		return
+	}
+	if len(items) > SetScheduleItemCountLimit {
+		err = fmt.Errorf("the length of items must be less than or equal to %d", SetScheduleItemCountLimit)
+		return
+	}
+	if yearMonth < 100001 || yearMonth%100 < 1 || yearMonth%100 > 12 {
+		err = fmt.Errorf("invalid yearMonth %d", yearMonth)
+		return
+	}
+
+	var request = struct {
+		GroupId   int64          `json:"groupid"`
+		Items     []ScheduleItem `json:"items"`
+		YearMonth int            `json:"yearmonth"`
+	}{
+		GroupId:   groupId,
+		Items:     items,
+		YearMonth: yearMonth,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/setcheckinschedulist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -9,12 +9,14 @@ package corp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	wechatjson "github.com/chanxuehong/wechat/json"
 )
@@ -23,16 +25,45 @@ import (
 type Client struct {
 	AccessTokenServer
 	HttpClient *http.Client
+
+	RetryPolicy *RetryPolicy // 可以为 nil, 表示除了 access_token 失效以外不重试
+	RateLimiter RateLimiter  // 可以为 nil, 表示不限流
+	Logger      Logger       // 可以为 nil, 表示使用 DefaultLogger
+	Metrics     Metrics      // 可以为 nil; 不为 nil 时统计每个接口的调用次数, 耗时, errcode 和 access_token 的刷新次数
+	Tracer      Tracer       // 可以为 nil
+
+	// 可以为空; 不为空时替换所有接口 url 开头的 DefaultBaseURL, 用于沙箱环境, 内部网关或者代理, 见 ReplaceBaseURL.
+	BaseURL string
+
+	// 可以为 nil; 不为 nil 时所有请求(包括重试)都绑定到 Context, Context 取消后请求立即返回, 见 WithContext.
+	Context context.Context
+
+	// 每次接口调用(包括重试, 重试前的退避和限流的等待)的总超时, 0 表示只受 HttpClient 的超时限制; 和 Context 同时设置时先到期的生效.
+	//  NOTE: 不包括 AccessTokenServer 获取 access_token 的时间, 它由 AccessTokenServer 自己的 http.Client 控制.
+	Timeout time.Duration
+
+	// RequestId 不为空时所有请求都使用它(见 WithRequestId), 否则调用 RequestIdGenerator 生成,
+	// RequestIdGenerator 为 nil 时使用 NewRequestId. request id 会带在 Logger 的每条日志, Tracer 的 span 和 RequestError 里.
+	RequestId          string
+	RequestIdGenerator func() string
+
+	// 为 true 时通过 Logger.Debug 打印每个请求和应答(access_token 等敏感参数被隐藏), 见 util.DumpTransport.
+	// 运行时可以随时打开, 不需要 wechatdebug 编译标签.
+	DebugMode bool
+
+	// 为 true 时日志(Logger)和错误里不隐藏 access_token, secret 等敏感参数, 只用于本地调试!
+	//  NOTE: 只影响这个 Client 自己的日志和错误, util.DumpTransport 和 AccessTokenServer 总是隐藏敏感参数.
+	DisableRedaction bool
 }
 
 // 创建一个新的 Client.
-//  如果 clt == nil 则默认用 http.DefaultClient
+//  如果 clt == nil 则默认用 TextHttpClient
 func NewClient(srv AccessTokenServer, clt *http.Client) *Client {
 	if srv == nil {
 		panic("nil AccessTokenServer")
 	}
 	if clt == nil {
-		clt = http.DefaultClient
+		clt = TextHttpClient
 	}
 
 	return &Client{
@@ -53,6 +84,18 @@ func NewClient(srv AccessTokenServer, clt *http.Client) *Client {
 //          ...
 //      }
 func (clt *Client) PostJSON(incompleteURL string, request interface{}, response interface{}) (err error) {
+	ctx, cancel := clt.callContext()
+	defer cancel()
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+	logger := clt.requestLogger(requestId)
+	ctx, done := clt.startCall(ctx, incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+
 	buf := textBufferPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer textBufferPool.Put(buf)
@@ -68,19 +111,32 @@ func (clt *Client) PostJSON(incompleteURL string, request interface{}, response
 	}
 
 	hasRetried := false
+	attempt := 1 // 用于 RetryPolicy
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	if err = clt.waitRateLimiter(ctx, incompleteURL); err != nil {
+		return
+	}
+	finalURL := ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
-	LogInfoln("[WECHAT_DEBUG] request json:", string(requestBytes))
+	logger.Debug("wechat: request", "url", finalURL)
+	logger.Debug("wechat: request", "json", string(requestBytes))
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	httpResp, err := clt.httpPost(ctx, finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		if clt.RetryPolicy.shouldRetry(attempt, httpResp.StatusCode, ErrCodeOK) {
+			logger.Warn("wechat: retry", "http_status", httpResp.Status, "attempt", attempt)
+			httpResp.Body.Close()
+			if err = clt.RetryPolicy.wait(ctx, attempt); err != nil {
+				return
+			}
+			attempt++
+			goto RETRY
+		}
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
@@ -88,7 +144,7 @@ RETRY:
 	if err != nil {
 		return
 	}
-	LogInfoln("[WECHAT_DEBUG] response json:", string(respBody))
+	logger.Debug("wechat: response", "json", string(respBody))
 
 	if err = json.Unmarshal(respBody, response); err != nil {
 		return
@@ -109,8 +165,8 @@ RETRY:
 		return
 	case ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		logger.Warn("wechat: access_token expired", "err_code", ErrCode, "err_msg", ErrMsg)
+		logger.Debug("wechat: current access_token", "token", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -118,14 +174,25 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			logger.Info("wechat: access_token refreshed, retry", "token", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		logger.Warn("wechat: access_token still invalid after refresh", "token", clt.maskToken(token))
 		fallthrough
 	default:
+		if clt.RetryPolicy.shouldRetry(attempt, http.StatusOK, int(ErrCode)) {
+			logger.Warn("wechat: retry", "err_code", ErrCode, "attempt", attempt)
+			if err = clt.RetryPolicy.wait(ctx, attempt); err != nil {
+				return
+			}
+			attempt++
+
+			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
+			goto RETRY
+		}
 		return
 	}
 }
@@ -141,22 +208,47 @@ RETRY:
 //          ...
 //      }
 func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err error) {
+	ctx, cancel := clt.callContext()
+	defer cancel()
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+	logger := clt.requestLogger(requestId)
+	ctx, done := clt.startCall(ctx, incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	attempt := 1 // 用于 RetryPolicy
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	if err = clt.waitRateLimiter(ctx, incompleteURL); err != nil {
+		return
+	}
+	finalURL := ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.httpGet(ctx, finalURL)
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		if clt.RetryPolicy.shouldRetry(attempt, httpResp.StatusCode, ErrCodeOK) {
+			logger.Warn("wechat: retry", "http_status", httpResp.Status, "attempt", attempt)
+			httpResp.Body.Close()
+			if err = clt.RetryPolicy.wait(ctx, attempt); err != nil {
+				return
+			}
+			attempt++
+			goto RETRY
+		}
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
@@ -164,8 +256,8 @@ RETRY:
 	if err != nil {
 		return
 	}
-	LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
-	LogInfoln("[WECHAT_DEBUG] response json:", string(respBody))
+	logger.Debug("wechat: request", "url", finalURL)
+	logger.Debug("wechat: response", "json", string(respBody))
 
 	if err = json.Unmarshal(respBody, response); err != nil {
 		return
@@ -186,8 +278,8 @@ RETRY:
 		return
 	case ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		logger.Warn("wechat: access_token expired", "err_code", ErrCode, "err_msg", ErrMsg)
+		logger.Debug("wechat: current access_token", "token", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -195,14 +287,25 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			logger.Info("wechat: access_token refreshed, retry", "token", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		logger.Warn("wechat: access_token still invalid after refresh", "token", clt.maskToken(token))
 		fallthrough
 	default:
+		if clt.RetryPolicy.shouldRetry(attempt, http.StatusOK, int(ErrCode)) {
+			logger.Warn("wechat: retry", "err_code", ErrCode, "attempt", attempt)
+			if err = clt.RetryPolicy.wait(ctx, attempt); err != nil {
+				return
+			}
+			attempt++
+
+			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
+			goto RETRY
+		}
 		return
 	}
 }
@@ -0,0 +1,96 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// 保留原始 json 的应答, 可以作为 PostJSON, GetJSON 的 response, 用于调用本包没有封装的接口.
+type RawResponse struct {
+	Error
+	Body json.RawMessage `json:"-"` // 完整的应答 json, 包括 errcode 和 errmsg
+}
+
+func (resp *RawResponse) UnmarshalJSON(b []byte) error {
+	resp.Body = append(resp.Body[:0], b...)
+	return json.Unmarshal(b, &resp.Error)
+}
+
+// 用 Body 解码到 v, v 为 nil 时什么都不做.
+func (resp *RawResponse) Unmarshal(v interface{}) error {
+	if v == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Body, v)
+}
+
+// 构造 Call 用的 incompleteURL, tokenName 为 access_token 或者 suite_access_token 等.
+//  path 为接口的路径, 比如 "/cgi-bin/user/list_id", query 为 access_token 以外的查询参数, 可以为 nil.
+func CallURL(path string, query url.Values, tokenName string) (incompleteURL string, err error) {
+	if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, "?#") {
+		err = errors.New("path must be an absolute path without query")
+		return
+	}
+	query2 := make(url.Values, len(query))
+	for k, v := range query {
+		if k == tokenName {
+			continue // 由 Client 填入
+		}
+		query2[k] = v
+	}
+	incompleteURL = DefaultBaseURL + path + "?"
+	if len(query2) > 0 {
+		incompleteURL += query2.Encode() + "&"
+	}
+	incompleteURL += tokenName + "="
+	return
+}
+
+// 调用本包没有封装的接口, 和封装好的接口一样处理 access_token 的填入和刷新, 限流, 重试和错误码.
+//  method 为 "GET" 或者 "POST", POST 时把 request 编码为 json 作为 http body(request 为 nil 时为 {}), GET 时 request 必须为 nil.
+//  path 为接口的路径, 比如 "/cgi-bin/user/list_id"; query 为 access_token 以外的查询参数, 可以为 nil.
+//  errcode 不为 0 时返回 *Error, 否则把应答的 json 解码到 response, response 为 nil 时忽略应答.
+//
+//  var result struct {
+//      Name string `json:"name"`
+//  }
+//  err := clt.Call("GET", "/cgi-bin/user/get", url.Values{"userid": {userId}}, nil, &result)
+func (clt *Client) Call(method, path string, query url.Values, request interface{}, response interface{}) (err error) {
+	incompleteURL, err := CallURL(path, query, "access_token")
+	if err != nil {
+		return
+	}
+
+	var result RawResponse
+	switch strings.ToUpper(method) {
+	case "GET":
+		if request != nil {
+			return errors.New("GET request must not have a body")
+		}
+		err = clt.GetJSON(incompleteURL, &result)
+	case "POST":
+		if request == nil {
+			request = struct{}{}
+		}
+		err = clt.PostJSON(incompleteURL, request, &result)
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+	if err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	return result.Unmarshal(response)
+}
@@ -0,0 +1,125 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+type recordedCounter struct {
+	name   string
+	labels map[string]string
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	counters []recordedCounter
+}
+
+func (m *recordingMetrics) IncCounter(name string, labels map[string]string) {
+	m.mu.Lock()
+	m.counters = append(m.counters, recordedCounter{name, labels})
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {}
+
+func (m *recordingMetrics) count(name, label, value string) (n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.counters {
+		if c.name == name && c.labels[label] == value {
+			n++
+		}
+	}
+	return
+}
+
+func TestClientMetrics(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("/cgi-bin/ok", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") == "token" {
+			w.Write(wechattest.ErrorJSON(corp.ErrCodeAccessTokenExpired, "access_token expired"))
+			return
+		}
+		w.Write(wechattest.ErrorJSON(corp.ErrCodeOK, "ok"))
+	})
+	srv.HandleError("/cgi-bin/fail", 60011, "no privilege")
+
+	metrics := &recordingMetrics{}
+	clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.Metrics = metrics
+
+	var result corp.Error
+	if err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/ok?access_token=", &result); err != nil {
+		t.Fatal(err)
+	}
+	if err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/fail?access_token=", &result); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, label, value string
+		want               int
+	}{
+		{corp.MetricClientRequestsTotal, "outcome", corp.MetricOutcomeSuccess, 1},
+		{corp.MetricClientRequestsTotal, "outcome", corp.MetricOutcomeErrCode, 1},
+		{corp.MetricClientRequestsTotal, "errcode", "60011", 1},
+		{corp.MetricClientTokenRefreshTotal, "endpoint", "/cgi-bin/ok", 1},
+	}
+	for _, tt := range tests {
+		if n := metrics.count(tt.name, tt.label, tt.value); n != tt.want {
+			t.Errorf("%s{%s=%q}: have %d, want %d", tt.name, tt.label, tt.value, n, tt.want)
+		}
+	}
+}
+
+type spanContextKey struct{}
+
+// 在 context 里放入 span 名字的 ContextTracer
+type contextTracer struct{}
+
+func (contextTracer) StartSpan(name string, attrs map[string]string) corp.Span {
+	return nopSpan{}
+}
+
+func (contextTracer) StartSpanContext(ctx context.Context, name string, attrs map[string]string) (context.Context, corp.Span) {
+	return context.WithValue(ctx, spanContextKey{}, name), nopSpan{}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClientSpanContext(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/test", corp.ErrCodeOK, "ok")
+
+	var spans []interface{}
+	transport := srv.Client().Transport
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		spans = append(spans, r.Context().Value(spanContextKey{}))
+		return transport.RoundTrip(r)
+	})}
+	clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), httpClient)
+	clt.Tracer = contextTracer{}
+
+	var result corp.Error
+	if err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/test?access_token=", &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 1 || spans[0] != "/cgi-bin/test" {
+		t.Errorf("http request context: have spans %v, want [/cgi-bin/test]", spans)
+	}
+}
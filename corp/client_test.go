@@ -0,0 +1,112 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestClientTimeoutIncludesRetryBackoff(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/test", corp.ErrCodeSystemBusy, "system error")
+
+	clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.RetryPolicy = &corp.RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+	}
+	clt.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	var result corp.Error
+	err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/test?access_token=", &result)
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("GetJSON returned after %s, want about %s", elapsed, clt.Timeout)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("have error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := len(srv.RequestsTo("/cgi-bin/test")); n != 1 {
+		t.Errorf("have %d requests, want 1", n)
+	}
+}
+
+func TestTokenBucketLimiterWaitContext(t *testing.T) {
+	limiter := corp.NewTokenBucketLimiter(corp.RateLimit{Rate: 0.1, Burst: 1})
+	if err := limiter.Wait("/cgi-bin/test"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.WaitContext(ctx, "/cgi-bin/test"); err != context.DeadlineExceeded {
+		t.Errorf("have error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitContext returned after %s", elapsed)
+	}
+}
+
+func TestClientCall(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleJSON("/cgi-bin/test", `{"errcode":0,"errmsg":"ok","name":"n1"}`)
+
+	clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+
+	tests := []struct {
+		method     string
+		request    interface{}
+		wantMethod string
+		wantBody   string
+		wantErr    bool
+	}{
+		{method: "GET", wantMethod: "GET"},
+		{method: "post", wantMethod: "POST", wantBody: "{}\n"},
+		{method: "POST", request: map[string]string{"userid": "u1"}, wantMethod: "POST", wantBody: "{\"userid\":\"u1\"}\n"},
+		{method: "GET", request: map[string]string{}, wantErr: true},
+		{method: "PUT", wantErr: true},
+	}
+	for _, tt := range tests {
+		srv.Reset()
+		var result struct {
+			Name string `json:"name"`
+		}
+		err := clt.Call(tt.method, "/cgi-bin/test", url.Values{"userid": {"u1"}}, tt.request, &result)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s %v: want error", tt.method, tt.request)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %v: %v", tt.method, tt.request, err)
+			continue
+		}
+		if result.Name != "n1" {
+			t.Errorf("%s %v: have name %q, want %q", tt.method, tt.request, result.Name, "n1")
+		}
+		requests := srv.RequestsTo("/cgi-bin/test")
+		if len(requests) != 1 {
+			t.Errorf("%s %v: have %d requests, want 1", tt.method, tt.request, len(requests))
+			continue
+		}
+		req := requests[0]
+		if req.Method != tt.wantMethod || string(req.Body) != tt.wantBody || req.Query.Get("userid") != "u1" {
+			t.Errorf("%s %v: have request %s %q %v", tt.method, tt.request, req.Method, req.Body, req.Query)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// 返回一个 Client 的浅拷贝, 之后通过它发起的请求都绑定到 ctx, ctx 取消后请求(包括重试)立即返回.
+func (clt *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	clt2 := *clt
+	clt2.Context = ctx
+	return &clt2
+}
+
+// 一次接口调用(包括重试)的 context, 以 Context 为父 context, Timeout 为 0 时没有超时; 用完后必须调用 cancel.
+func (clt *Client) callContext() (ctx context.Context, cancel context.CancelFunc) {
+	parent := clt.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if clt.Timeout > 0 {
+		return context.WithTimeout(parent, clt.Timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+func (clt *Client) httpPost(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := clt.httpClient().Do(req.WithContext(ctx))
+	return resp, clt.redactError(err)
+}
+
+func (clt *Client) httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := clt.httpClient().Do(req.WithContext(ctx))
+	return resp, clt.redactError(err)
+}
+
+// 同 HttpClient.Get, 但是请求绑定到 Context(如果不为 nil), DebugMode 为 true 时打印请求和应答.
+func (clt *Client) HttpGet(url string) (*http.Response, error) {
+	ctx := clt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return clt.httpGet(ctx, url)
+}
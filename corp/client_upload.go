@@ -48,6 +48,18 @@ type MultipartFormField struct {
 //          ...
 //      }
 func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFormField, response interface{}) (err error) {
+	ctx, cancel := clt.callContext()
+	defer cancel()
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+	logger := clt.requestLogger(requestId)
+	ctx, done := clt.startCall(ctx, incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+
 	bodyBuf := mediaBufferPool.Get().(*bytes.Buffer)
 	bodyBuf.Reset()
 	defer mediaBufferPool.Put(bodyBuf)
@@ -88,9 +100,12 @@ func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFor
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	if err = clt.waitRateLimiter(ctx, incompleteURL); err != nil {
+		return
+	}
+	finalURL := ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
+	httpResp, err := clt.httpPost(ctx, finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return
 	}
@@ -120,8 +135,8 @@ RETRY:
 		return
 	case ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		logger.Warn("wechat: access_token expired", "err_code", ErrCode, "err_msg", ErrMsg)
+		logger.Debug("wechat: current access_token", "token", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -129,12 +144,13 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			logger.Info("wechat: access_token refreshed, retry", "token", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		logger.Warn("wechat: access_token still invalid after refresh", "token", clt.maskToken(token))
 		fallthrough
 	default:
 		return
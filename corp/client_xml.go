@@ -0,0 +1,115 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 用 util.EncodeXMLFromMap 把 request 编码为 xml, POST 到微信服务器, 然后用 util.DecodeXMLToMap 解析微信服务器返回的 xml,
+// 用于少数使用 xml 的接口.
+//
+//  NOTE:
+//  1. 一般不用调用这个方法, 请直接调用高层次的封装方法;
+//  2. 最终的 URL == incompleteURL + access_token;
+//  3. 微信服务器返回 json 的错误时(比如 access_token 过期), errcode 不为 0 返回 *Error;
+//  4. 除了 access_token 过期以外不重试, 也不使用 RetryPolicy.
+func (clt *Client) PostXML(incompleteURL string, request map[string]string) (response map[string]string, err error) {
+	buf := textBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer textBufferPool.Put(buf)
+
+	if err = util.EncodeXMLFromMap(buf, request); err != nil {
+		return
+	}
+	requestBytes := buf.Bytes()
+
+	ctx, cancel := clt.callContext()
+	defer cancel()
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+	logger := clt.requestLogger(requestId)
+	var result Error
+	ctx, done := clt.startCall(ctx, incompleteURL, requestId)
+	defer func() {
+		done(&result, err)
+	}()
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+RETRY:
+	if err = clt.waitRateLimiter(ctx, incompleteURL); err != nil {
+		return
+	}
+	finalURL := ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
+
+	httpResp, err := clt.httpPost(ctx, finalURL, "text/xml; charset=utf-8", bytes.NewReader(requestBytes))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if response, err = decodeXMLResponse(httpResp, &result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case ErrCodeOK:
+		return
+	case ErrCodeAccessTokenExpired:
+		logger.Warn("wechat: access_token expired", "err_code", result.ErrCode, "err_msg", result.ErrMsg)
+		logger.Debug("wechat: current access_token", "token", clt.maskToken(token))
+
+		if !hasRetried {
+			hasRetried = true
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			clt.incTokenRefresh(incompleteURL)
+			logger.Info("wechat: access_token refreshed, retry", "token", clt.maskToken(token))
+
+			result = Error{}
+			goto RETRY
+		}
+		logger.Warn("wechat: access_token still invalid after refresh", "token", clt.maskToken(token))
+		fallthrough
+	default:
+		err = &result
+		return
+	}
+}
+
+// 解析 xml 接口的应答; 应答为 json 时(微信服务器的错误一般是 json)解析到 result, 此时 response 为 nil.
+func decodeXMLResponse(httpResp *http.Response, result *Error) (response map[string]string, err error) {
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, util.MaxXMLSize+1))
+	if err != nil {
+		return
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		err = json.Unmarshal(body, result)
+		return
+	}
+	return util.DecodeXMLToMap(bytes.NewReader(body))
+}
@@ -0,0 +1,48 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestClientPostXML(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("/cgi-bin/xml", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") == "token" {
+			w.Write(wechattest.ErrorJSON(corp.ErrCodeAccessTokenExpired, "access_token expired"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.Write([]byte(`<xml><result><![CDATA[ok]]></result></xml>`))
+	})
+	srv.HandleError("/cgi-bin/xml_error", 60011, "no privilege")
+
+	atSrv := wechattest.NewAccessTokenServer("token")
+	clt := corp.NewClient(atSrv, srv.Client())
+
+	resp, err := clt.PostXML("https://qyapi.weixin.qq.com/cgi-bin/xml?access_token=", map[string]string{"userid": "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"result": "ok"}; !reflect.DeepEqual(resp, want) {
+		t.Errorf("have response %v, want %v", resp, want)
+	}
+	if n := atSrv.RefreshCount(); n != 1 {
+		t.Errorf("have %d token refreshes, want 1", n)
+	}
+
+	_, err = clt.PostXML("https://qyapi.weixin.qq.com/cgi-bin/xml_error?access_token=", nil)
+	if e, ok := err.(*corp.Error); !ok || e.ErrCode != 60011 {
+		t.Errorf("have error %v, want errcode 60011", err)
+	}
+}
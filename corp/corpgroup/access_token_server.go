@@ -0,0 +1,195 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corpgroup
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+var _ corp.AccessTokenServer = (*DownstreamAccessTokenServer)(nil)
+
+// 下级企业的 AccessTokenServer, 用上级企业共享应用的 access_token 获取下级企业的 access_token.
+//  NOTE:
+//  1. 用于单进程环境.
+//  2. 因为 DownstreamAccessTokenServer 同时也是一个简单的中控服务器, 而不是仅仅实现 AccessTokenServer 接口,
+//     所以整个系统对同一个下级企业的同一个应用只能存在一个 DownstreamAccessTokenServer 实例!
+//  3. 不再使用时调用 Stop 结束后台刷新 access_token 的 goroutine.
+type DownstreamAccessTokenServer struct {
+	client       Client
+	corpId       string
+	agentId      int64
+	businessType int
+
+	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
+	quitChan        chan struct{}      // 用于结束 tokenDaemon
+
+	tokenGet struct {
+		sync.Mutex
+		LastTokenInfo AccessTokenInfo // 最后一次成功从微信服务器获取的 access_token 信息
+		LastTimestamp int64           // 最后一次成功从微信服务器获取 access_token 的时间戳
+	}
+
+	tokenCache struct {
+		sync.RWMutex
+		Token string
+	}
+}
+
+// 创建一个新的 DownstreamAccessTokenServer.
+//  clt 为上级企业的 corp.Client, 其 access_token 必须是用共享应用的 secret 获取的;
+//  corpId 为下级企业的 corpid, agentId 为应用在下级企业的 agentid.
+func NewDownstreamAccessTokenServer(clt *corp.Client, corpId string, agentId int64, businessType int) (srv *DownstreamAccessTokenServer) {
+	if clt == nil {
+		panic("nil corp.Client")
+	}
+
+	srv = &DownstreamAccessTokenServer{
+		client:          Client{Client: clt},
+		corpId:          corpId,
+		agentId:         agentId,
+		businessType:    businessType,
+		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+func (srv *DownstreamAccessTokenServer) Tag6D89F2E2FE9811E49EAAA4DB30FED8E1() {}
+
+// 结束后台刷新 access_token 的 goroutine, 只能调用一次.
+func (srv *DownstreamAccessTokenServer) Stop() {
+	close(srv.quitChan)
+}
+
+func (srv *DownstreamAccessTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	srv.tokenCache.RUnlock()
+
+	if token != "" {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *DownstreamAccessTokenServer) TokenRefresh() (token string, err error) {
+	tokenInfo, cached, err := srv.getToken()
+	if err != nil {
+		return
+	}
+	if !cached {
+		select {
+		case srv.resetTickerChan <- time.Duration(tokenInfo.ExpiresIn) * time.Second:
+		case <-srv.quitChan:
+		}
+	}
+	token = tokenInfo.Token
+	return
+}
+
+func (srv *DownstreamAccessTokenServer) tokenDaemon(tickDuration time.Duration) {
+NEW_TICK_DURATION:
+	ticker := time.NewTicker(tickDuration)
+
+	for {
+		select {
+		case <-srv.quitChan:
+			ticker.Stop()
+			return
+
+		case tickDuration = <-srv.resetTickerChan:
+			ticker.Stop()
+			goto NEW_TICK_DURATION
+
+		case <-ticker.C:
+			AccessTokenInfo, cached, err := srv.getToken()
+			if err != nil {
+				break
+			}
+			if !cached {
+				newTickDuration := time.Duration(AccessTokenInfo.ExpiresIn) * time.Second
+				if tickDuration != newTickDuration {
+					tickDuration = newTickDuration
+					ticker.Stop()
+					goto NEW_TICK_DURATION
+				}
+			}
+		}
+	}
+}
+
+// 从微信服务器获取下级企业的 access_token.
+//  同一时刻只能一个 goroutine 进入, 防止没必要的重复获取.
+func (srv *DownstreamAccessTokenServer) getToken() (token AccessTokenInfo, cached bool, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 access_token, 这里的收敛时间设定为4秒.
+	if n := srv.tokenGet.LastTimestamp; n <= timeNowUnix && timeNowUnix < n+4 {
+		// 因为只有成功获取后才会更新 srv.tokenGet.LastTimestamp, 所以这些都是有效数据
+		token = AccessTokenInfo{
+			Token:     srv.tokenGet.LastTokenInfo.Token,
+			ExpiresIn: srv.tokenGet.LastTokenInfo.ExpiresIn - timeNowUnix + n,
+		}
+		cached = true
+		return
+	}
+
+	result, err := srv.client.GetCorpToken(srv.corpId, srv.agentId, srv.businessType)
+	if err != nil {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+		return
+	}
+
+	// 由于网络的延时, access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 31556952: // 60*60*24*365.2425
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+
+		err = errors.New("expires_in too large: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	default:
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+
+		err = errors.New("expires_in too small: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	// 更新 tokenGet 信息
+	srv.tokenGet.LastTokenInfo = *result
+	srv.tokenGet.LastTimestamp = timeNowUnix
+
+	// 更新缓存
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = result.Token
+	srv.tokenCache.Unlock()
+
+	token = *result
+	return
+}
@@ -0,0 +1,10 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 企业互联和上下游, 上级企业管理共享给下级企业的应用.
+//  上级企业用共享应用的 access_token 获取下级企业的 access_token(DownstreamAccessTokenServer),
+//  然后就可以用普通的 corp.Client 以下级企业的身份调用接口, 包括获取下级企业的 jsapi_ticket.
+//  NOTE: 应用共享(安装到下级企业)只能在管理后台设置, 没有对应的接口, ListAllAppShareInfo 可以列出所有已经共享的下级企业.
+package corpgroup
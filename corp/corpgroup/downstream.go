@@ -0,0 +1,51 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corpgroup
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/corp/jssdk"
+)
+
+// 下级企业, 包括以下级企业身份调用接口的 corp.Client 和 jsapi_ticket 中控服务器.
+type Downstream struct {
+	AppShareCorp
+
+	AccessTokenServer *DownstreamAccessTokenServer
+	Client            *corp.Client
+
+	TicketServer      *jssdk.DefaultTicketServer // 下级企业的 jsapi_ticket, 用于 wx.config
+	AgentTicketServer *jssdk.DefaultTicketServer // 应用在下级企业的 jsapi_ticket, 用于 wx.agentConfig
+}
+
+// 创建下级企业的 Downstream, httpClient 为 nil 时使用 corp.NewClient 的默认值.
+func NewDownstream(clt *corp.Client, shareCorp *AppShareCorp, businessType int, httpClient *http.Client) *Downstream {
+	srv := NewDownstreamAccessTokenServer(clt, shareCorp.CorpId, shareCorp.AgentId, businessType)
+	downstreamClient := corp.NewClient(srv, httpClient)
+	return &Downstream{
+		AppShareCorp:      *shareCorp,
+		AccessTokenServer: srv,
+		Client:            downstreamClient,
+		TicketServer:      jssdk.NewDefaultTicketServer(downstreamClient),
+		AgentTicketServer: jssdk.NewDefaultAgentTicketServer(downstreamClient),
+	}
+}
+
+// 为共享了应用 agentId 的所有下级企业批量创建 Downstream, 返回 corpid => *Downstream.
+//  NOTE: 每个 Downstream 都有后台刷新 access_token 和 jsapi_ticket 的 goroutine, 整个系统对同一个下级企业只能调用一次.
+func (clt Client) NewDownstreams(agentId int64, businessType int, httpClient *http.Client) (downstreams map[string]*Downstream, err error) {
+	list, err := clt.ListAllAppShareInfo(agentId, businessType)
+	if err != nil {
+		return
+	}
+	downstreams = make(map[string]*Downstream, len(list))
+	for i := range list {
+		downstreams[list[i].CorpId] = NewDownstream(clt.Client, &list[i], businessType, httpClient)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corpgroup
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// business_type
+const (
+	BusinessTypeCorpGroup = 0 // 企业互联/局校互联
+	BusinessTypeChain     = 1 // 上下游
+)
+
+// 应用共享的下级企业
+type AppShareCorp struct {
+	CorpId   string `json:"corpid"`    // 下级企业的 corpid
+	CorpName string `json:"corp_name"` // 下级企业的名称
+	AgentId  int64  `json:"agentid"`   // 应用在下级企业的 agentid
+}
+
+type ListAppShareInfoRequest struct {
+	AgentId      int64  `json:"agentid"`                 // 必须; 上级企业的应用 agentid
+	BusinessType int    `json:"business_type,omitempty"` // BusinessTypeCorpGroup, BusinessTypeChain
+	CorpId       string `json:"corpid,omitempty"`        // 下级企业的 corpid, 上下游时用于查询指定下级企业
+	Limit        int    `json:"limit,omitempty"`         // 每次返回的最大数量, 上下游时有效, 最大 100
+	Cursor       string `json:"cursor,omitempty"`        // 上一次调用返回的 NextCursor, 第一次调用为空
+}
+
+// 获取应用共享信息, 即共享了该应用的下级企业列表.
+//  ending 为 true 表示已经是最后一页.
+func (clt Client) ListAppShareInfo(req *ListAppShareInfoRequest) (list []AppShareCorp, ending bool, nextCursor string, err error) {
+	var result struct {
+		corp.Error
+		Ending     int            `json:"ending"`
+		CorpList   []AppShareCorp `json:"corp_list"`
+		NextCursor string         `json:"next_cursor"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/corpgroup/corp/list_app_share_info?access_token="
+	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.CorpList
+	ending = result.Ending == 1 || result.NextCursor == ""
+	nextCursor = result.NextCursor
+	return
+}
+
+// 获取共享了应用 agentId 的所有下级企业, 内部按 cursor 翻页.
+func (clt Client) ListAllAppShareInfo(agentId int64, businessType int) (list []AppShareCorp, err error) {
+	req := ListAppShareInfoRequest{
+		AgentId:      agentId,
+		BusinessType: businessType,
+	}
+	if businessType == BusinessTypeChain {
+		req.Limit = 100
+	}
+	for {
+		page, ending, nextCursor, err := clt.ListAppShareInfo(&req)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, page...)
+		if ending || nextCursor == req.Cursor {
+			return list, nil
+		}
+		req.Cursor = nextCursor
+	}
+}
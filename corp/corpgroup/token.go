@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corpgroup
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+type AccessTokenInfo struct {
+	Token     string `json:"access_token"`
+	ExpiresIn int64  `json:"expires_in"` // 有效时间, seconds
+}
+
+// 获取下级企业的 access_token.
+//  corpId 为下级企业的 corpid, agentId 为应用在下级企业的 agentid(见 AppShareCorp).
+//  NOTE: 一般不用直接调用, DownstreamAccessTokenServer 会缓存并定时刷新 access_token.
+func (clt Client) GetCorpToken(corpId string, agentId int64, businessType int) (info *AccessTokenInfo, err error) {
+	request := struct {
+		CorpId       string `json:"corpid"`
+		BusinessType int    `json:"business_type"`
+		AgentId      int64  `json:"agentid"`
+	}{
+		CorpId:       corpId,
+		BusinessType: businessType,
+		AgentId:      agentId,
+	}
+
+	var result struct {
+		corp.Error
+		AccessTokenInfo
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/corpgroup/corp/gettoken?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.AccessTokenInfo
+	return
+}
+
+// 获取下级企业的小程序 session, 用上级企业小程序 wx.qy.login 得到的 userid 和 session_key 换取下级企业的.
+func (clt Client) TransferSession(userId, sessionKey string) (downstreamUserId, downstreamSessionKey string, err error) {
+	request := struct {
+		UserId     string `json:"userid"`
+		SessionKey string `json:"session_key"`
+	}{
+		UserId:     userId,
+		SessionKey: sessionKey,
+	}
+
+	var result struct {
+		corp.Error
+		UserId     string `json:"userid"`
+		SessionKey string `json:"session_key"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/miniprogram/transfer_session?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	downstreamUserId = result.UserId
+	downstreamSessionKey = result.SessionKey
+	return
+}
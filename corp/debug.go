@@ -0,0 +1,34 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 返回一个通过 DefaultLogger 打印每个请求和应答的 clt 的浅拷贝, 用于 NewDefaultAccessTokenServer 等不经过 Client 的请求,
+// 见 Client.DebugMode. clt 为 nil 时使用 TextHttpClient.
+func NewDebugHttpClient(clt *http.Client) *http.Client {
+	if clt == nil {
+		clt = TextHttpClient
+	}
+	return util.NewDumpHttpClient(clt, func(line string) {
+		DefaultLogger.Debug(line)
+	})
+}
+
+// DebugMode 为 true 时返回包装了 util.DumpTransport 的 HttpClient.
+func (clt *Client) httpClient() *http.Client {
+	if !clt.DebugMode {
+		return clt.HttpClient
+	}
+	logger := clt.GetLogger()
+	return util.NewDumpHttpClient(clt.HttpClient, func(line string) {
+		logger.Debug(line)
+	})
+}
@@ -0,0 +1,212 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"io"
+
+	"github.com/chanxuehong/wechat/corp/media"
+)
+
+const (
+	AttachmentTypeImage       = "image"
+	AttachmentTypeLink        = "link"
+	AttachmentTypeMiniProgram = "miniprogram"
+	AttachmentTypeVideo       = "video"
+	AttachmentTypeFile        = "file"
+)
+
+// 附件的使用场景, 决定了上传素材的接口
+const (
+	AttachmentSceneMessage = 0 // 欢迎语, 客户群发, 客户群群发: 使用 media/upload 上传的临时素材
+	AttachmentSceneMoment  = 1 // 客户朋友圈: 使用 media/upload_attachment 上传的素材
+)
+
+// 欢迎语, 群发消息, 朋友圈的附件, 一个附件只有 MsgType 对应的字段有效.
+type Attachment struct {
+	MsgType     string                 `json:"msgtype"`
+	Image       *AttachmentImage       `json:"image,omitempty"`
+	Link        *AttachmentLink        `json:"link,omitempty"`
+	MiniProgram *AttachmentMiniProgram `json:"miniprogram,omitempty"`
+	Video       *AttachmentVideo       `json:"video,omitempty"`
+	File        *AttachmentFile        `json:"file,omitempty"`
+}
+
+type AttachmentImage struct {
+	MediaId string `json:"media_id,omitempty"` // 图片的media_id
+	PicURL  string `json:"pic_url,omitempty"`  // 图片的链接，仅可使用上传图片接口得到的链接; 朋友圈不支持
+}
+
+type AttachmentLink struct {
+	Title   string `json:"title"`              // 图文消息标题，最长为128字节
+	URL     string `json:"url"`                // 图文消息的链接
+	PicURL  string `json:"picurl,omitempty"`   // 图文消息封面的url; 朋友圈不支持
+	Desc    string `json:"desc,omitempty"`     // 图文消息的描述，最长为512字节; 朋友圈不支持
+	MediaId string `json:"media_id,omitempty"` // 图文消息封面的media_id, 仅朋友圈使用
+}
+
+type AttachmentMiniProgram struct {
+	Title      string `json:"title"`        // 小程序消息标题，最长为64字节
+	PicMediaId string `json:"pic_media_id"` // 小程序消息封面的mediaid，封面图建议尺寸为520*416
+	AppId      string `json:"appid"`        // 小程序appid，必须是关联到企业的小程序应用
+	Page       string `json:"page"`         // 小程序page路径
+}
+
+type AttachmentVideo struct {
+	MediaId string `json:"media_id"`
+}
+
+type AttachmentFile struct {
+	MediaId string `json:"media_id"`
+}
+
+func NewImageAttachment(mediaId string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeImage,
+		Image:   &AttachmentImage{MediaId: mediaId},
+	}
+}
+
+// picURL 必须是上传图片接口得到的链接, 朋友圈不支持.
+func NewImageURLAttachment(picURL string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeImage,
+		Image:   &AttachmentImage{PicURL: picURL},
+	}
+}
+
+// 欢迎语和群发消息的图文链接.
+func NewLinkAttachment(title, url, picURL, desc string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeLink,
+		Link: &AttachmentLink{
+			Title:  title,
+			URL:    url,
+			PicURL: picURL,
+			Desc:   desc,
+		},
+	}
+}
+
+// 朋友圈的图文链接, mediaId 为 AttachmentSceneMoment 上传的封面图片.
+func NewMomentLinkAttachment(title, url, mediaId string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeLink,
+		Link: &AttachmentLink{
+			Title:   title,
+			URL:     url,
+			MediaId: mediaId,
+		},
+	}
+}
+
+func NewMiniProgramAttachment(title, picMediaId, appId, page string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeMiniProgram,
+		MiniProgram: &AttachmentMiniProgram{
+			Title:      title,
+			PicMediaId: picMediaId,
+			AppId:      appId,
+			Page:       page,
+		},
+	}
+}
+
+func NewVideoAttachment(mediaId string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeVideo,
+		Video:   &AttachmentVideo{MediaId: mediaId},
+	}
+}
+
+// 朋友圈不支持.
+func NewFileAttachment(mediaId string) Attachment {
+	return Attachment{
+		MsgType: AttachmentTypeFile,
+		File:    &AttachmentFile{MediaId: mediaId},
+	}
+}
+
+// =============================================================================
+
+// 上传图片并创建图片附件.
+//  scene: AttachmentSceneMessage, AttachmentSceneMoment
+func (clt Client) UploadImageAttachment(scene int, filename string, reader io.Reader) (attachment Attachment, err error) {
+	mediaId, err := clt.uploadAttachment(scene, media.MediaTypeImage, filename, reader)
+	if err != nil {
+		return
+	}
+	attachment = NewImageAttachment(mediaId)
+	return
+}
+
+// 上传视频并创建视频附件.
+//  scene: AttachmentSceneMessage, AttachmentSceneMoment
+func (clt Client) UploadVideoAttachment(scene int, filename string, reader io.Reader) (attachment Attachment, err error) {
+	mediaId, err := clt.uploadAttachment(scene, media.MediaTypeVideo, filename, reader)
+	if err != nil {
+		return
+	}
+	attachment = NewVideoAttachment(mediaId)
+	return
+}
+
+// 上传文件并创建文件附件, 朋友圈不支持文件附件.
+func (clt Client) UploadFileAttachment(filename string, reader io.Reader) (attachment Attachment, err error) {
+	mediaId, err := clt.uploadAttachment(AttachmentSceneMessage, media.MediaTypeFile, filename, reader)
+	if err != nil {
+		return
+	}
+	attachment = NewFileAttachment(mediaId)
+	return
+}
+
+// 上传封面图片并创建小程序附件, 小程序附件只能用于欢迎语和群发消息.
+func (clt Client) UploadMiniProgramAttachment(title, appId, page, picFilename string, picReader io.Reader) (attachment Attachment, err error) {
+	picMediaId, err := clt.uploadAttachment(AttachmentSceneMessage, media.MediaTypeImage, picFilename, picReader)
+	if err != nil {
+		return
+	}
+	attachment = NewMiniProgramAttachment(title, picMediaId, appId, page)
+	return
+}
+
+// 上传封面图片并创建朋友圈的图文链接附件.
+func (clt Client) UploadMomentLinkAttachment(title, url, picFilename string, picReader io.Reader) (attachment Attachment, err error) {
+	mediaId, err := clt.uploadAttachment(AttachmentSceneMoment, media.MediaTypeImage, picFilename, picReader)
+	if err != nil {
+		return
+	}
+	attachment = NewMomentLinkAttachment(title, url, mediaId)
+	return
+}
+
+func (clt Client) uploadAttachment(scene int, mediaType, filename string, reader io.Reader) (mediaId string, err error) {
+	mediaClient := media.Client{Client: clt.Client}
+
+	var info *media.MediaInfo
+	switch scene {
+	case AttachmentSceneMessage:
+		switch mediaType {
+		case media.MediaTypeImage:
+			info, err = mediaClient.UploadImageFromReader(filename, reader)
+		case media.MediaTypeVideo:
+			info, err = mediaClient.UploadVideoFromReader(filename, reader)
+		default:
+			info, err = mediaClient.UploadFileFromReader(filename, reader)
+		}
+	case AttachmentSceneMoment:
+		info, err = mediaClient.UploadAttachmentFromReader(mediaType, media.AttachmentTypeMoment, filename, reader)
+	default:
+		err = errors.New("invalid attachment scene")
+	}
+	if err != nil {
+		return
+	}
+	mediaId = info.MediaId
+	return
+}
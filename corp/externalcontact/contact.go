@@ -0,0 +1,187 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 外部联系人的类型
+const (
+	ContactTypeWechat = 1 // 微信用户
+	ContactTypeCorp   = 2 // 企业微信用户
+)
+
+// 外部联系人
+type ExternalContact struct {
+	ExternalUserId  string          `json:"external_userid"`
+	Name            string          `json:"name"`
+	Position        string          `json:"position,omitempty"`
+	Avatar          string          `json:"avatar,omitempty"`
+	CorpName        string          `json:"corp_name,omitempty"`
+	CorpFullName    string          `json:"corp_full_name,omitempty"`
+	Type            int             `json:"type"`
+	Gender          int             `json:"gender"` // 0-未知 1-男性 2-女性
+	UnionId         string          `json:"unionid,omitempty"`
+	ExternalProfile json.RawMessage `json:"external_profile,omitempty"` // 对外属性, 仅企业微信用户有
+}
+
+// 添加了外部联系人的成员在外部联系人上的信息
+type FollowUser struct {
+	UserId         string          `json:"userid"`
+	Remark         string          `json:"remark"`
+	Description    string          `json:"description"`
+	CreateTime     int64           `json:"createtime"`
+	Tags           []FollowUserTag `json:"tags,omitempty"`
+	RemarkCorpName string          `json:"remark_corp_name,omitempty"`
+	RemarkMobiles  []string        `json:"remark_mobiles,omitempty"`
+	OperUserId     string          `json:"oper_userid"`
+	AddWay         int             `json:"add_way"`
+	State          string          `json:"state,omitempty"`
+}
+
+type FollowUserTag struct {
+	GroupName string `json:"group_name"`
+	TagName   string `json:"tag_name"`
+	TagId     string `json:"tag_id,omitempty"`
+	Type      int    `json:"type"` // 1-企业设置 2-用户自定义 3-规则组标签
+}
+
+// 获取配置了客户联系功能的成员列表
+func (clt Client) GetFollowUserList() (userIdList []string, err error) {
+	var result struct {
+		corp.Error
+		FollowUser []string `json:"follow_user"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_follow_user_list?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	userIdList = result.FollowUser
+	return
+}
+
+// 获取成员 userId 添加的外部联系人的 external_userid 列表
+func (clt Client) List(userId string) (externalUserIdList []string, err error) {
+	var result struct {
+		corp.Error
+		ExternalUserId []string `json:"external_userid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/list?userid=" + url.QueryEscape(userId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	externalUserIdList = result.ExternalUserId
+	return
+}
+
+// 获取外部联系人详情.
+//  外部联系人的 follow_user 超过 500 个时分页返回, cursor 为上一次返回的 nextCursor, 第一次为空;
+//  nextCursor 为空表示没有更多了.
+func (clt Client) Get(externalUserId, cursor string) (contact *ExternalContact, followUsers []FollowUser, nextCursor string, err error) {
+	var result struct {
+		corp.Error
+		ExternalContact ExternalContact `json:"external_contact"`
+		FollowUser      []FollowUser    `json:"follow_user"`
+		NextCursor      string          `json:"next_cursor"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get?external_userid=" + url.QueryEscape(externalUserId) +
+		"&cursor=" + url.QueryEscape(cursor) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	contact = &result.ExternalContact
+	followUsers = result.FollowUser
+	nextCursor = result.NextCursor
+	return
+}
+
+// BatchGetByUser 每次返回的最大数量
+const BatchGetByUserLimit = 100
+
+// 批量获取客户详情时的跟进人信息, 和 FollowUser 相比只有标签的 id
+type FollowInfo struct {
+	UserId         string   `json:"userid"`
+	Remark         string   `json:"remark"`
+	Description    string   `json:"description"`
+	CreateTime     int64    `json:"createtime"`
+	TagId          []string `json:"tag_id,omitempty"`
+	RemarkCorpName string   `json:"remark_corp_name,omitempty"`
+	RemarkMobiles  []string `json:"remark_mobiles,omitempty"`
+	OperUserId     string   `json:"oper_userid"`
+	AddWay         int      `json:"add_way"`
+	State          string   `json:"state,omitempty"`
+}
+
+// 批量获取客户详情的一项
+type ContactWithFollowInfo struct {
+	ExternalContact ExternalContact `json:"external_contact"`
+	FollowInfo      FollowInfo      `json:"follow_info"`
+}
+
+// 批量获取成员 userIdList(最多100个)添加的客户详情.
+//  cursor 为上一次返回的 nextCursor, 第一次为空; nextCursor 为空表示没有更多了.
+//  limit 为返回的最大数量, <= 0 或者超过 BatchGetByUserLimit 时为 BatchGetByUserLimit.
+func (clt Client) BatchGetByUser(userIdList []string, cursor string, limit int) (list []ContactWithFollowInfo, nextCursor string, err error) {
+	if len(userIdList) == 0 {
+		err = errors.New("empty userIdList")
+		return
+	}
+	if limit <= 0 || limit > BatchGetByUserLimit {
+		limit = BatchGetByUserLimit
+	}
+
+	var request = struct {
+		UserIdList []string `json:"userid_list"`
+		Cursor     string   `json:"cursor,omitempty"`
+		Limit      int      `json:"limit"`
+	}{
+		UserIdList: userIdList,
+		Cursor:     cursor,
+		Limit:      limit,
+	}
+
+	var result struct {
+		corp.Error
+		ExternalContactList []ContactWithFollowInfo `json:"external_contact_list"`
+		NextCursor          string                  `json:"next_cursor"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/batch/get_by_user?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.ExternalContactList
+	nextCursor = result.NextCursor
+	return
+}
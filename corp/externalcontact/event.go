@@ -0,0 +1,143 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 客户联系相关的事件类型
+const (
+	EventTypeChangeExternalContact = "change_external_contact" // 企业客户变更事件
+	EventTypeChangeExternalChat    = "change_external_chat"    // 客户群变更事件
+	EventTypeChangeExternalTag     = "change_external_tag"     // 企业客户标签变更事件
+)
+
+// 企业客户变更事件的 ChangeType
+const (
+	ChangeTypeAddExternalContact     = "add_external_contact"      // 添加企业客户
+	ChangeTypeEditExternalContact    = "edit_external_contact"     // 编辑企业客户
+	ChangeTypeAddHalfExternalContact = "add_half_external_contact" // 外部联系人免验证添加成员
+	ChangeTypeDelExternalContact     = "del_external_contact"      // 成员删除企业客户
+	ChangeTypeDelFollowUser          = "del_follow_user"           // 企业客户删除跟进成员
+	ChangeTypeTransferFail           = "transfer_fail"             // 客户接替失败
+)
+
+// 客户群变更事件的 ChangeType
+const (
+	ChangeTypeCreateChat  = "create"
+	ChangeTypeUpdateChat  = "update"
+	ChangeTypeDismissChat = "dismiss"
+)
+
+// 客户群变更事件 update 的 UpdateDetail
+const (
+	UpdateDetailAddMember    = "add_member"    // 成员入群
+	UpdateDetailDelMember    = "del_member"    // 成员退群
+	UpdateDetailChangeOwner  = "change_owner"  // 群主变更
+	UpdateDetailChangeName   = "change_name"   // 群名变更
+	UpdateDetailChangeNotice = "change_notice" // 群公告变更
+)
+
+// 企业客户标签变更事件的 ChangeType
+const (
+	ChangeTypeCreateTag  = "create"
+	ChangeTypeUpdateTag  = "update"
+	ChangeTypeDeleteTag  = "delete"
+	ChangeTypeShuffleTag = "shuffle" // 标签重排
+)
+
+// 企业客户标签变更事件的 TagType
+const (
+	TagTypeTag      = "tag"
+	TagTypeTagGroup = "tag_group"
+)
+
+// 企业客户变更事件, 一般这样注册:
+//  mux.EventHandleFunc(externalcontact.EventTypeChangeExternalContact, func(w http.ResponseWriter, r *corp.Request) {
+//      event := externalcontact.GetChangeExternalContactEvent(r.MixedMsg)
+//      switch event.ChangeType {
+//      case externalcontact.ChangeTypeAddExternalContact:
+//          ...
+//      }
+//  })
+type ChangeExternalContactEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event          string `xml:"Event"          json:"Event"`          // 事件类型, change_external_contact
+	ChangeType     string `xml:"ChangeType"     json:"ChangeType"`     // ChangeTypeAddExternalContact...
+	UserId         string `xml:"UserID"         json:"UserID"`         // 企业服务人员的 userid
+	ExternalUserId string `xml:"ExternalUserID" json:"ExternalUserID"` // 外部联系人的 userid
+	State          string `xml:"State"          json:"State"`          // 添加此用户的「联系我」方式配置的 state 参数
+	WelcomeCode    string `xml:"WelcomeCode"    json:"WelcomeCode"`    // 欢迎语 code, 见 Client.SendWelcomeMsg
+	Source         string `xml:"Source"         json:"Source"`         // 删除客户的操作来源, DELETE_BY_TRANSFER 表示由于在职转接而被删除
+	FailReason     string `xml:"FailReason"     json:"FailReason"`     // 接替失败的原因, customer_refused-客户拒绝, customer_limit_exceed-接替成员的客户数达到上限
+}
+
+func GetChangeExternalContactEvent(msg *corp.MixedMessage) *ChangeExternalContactEvent {
+	return &ChangeExternalContactEvent{
+		MessageHeader:  msg.MessageHeader,
+		Event:          msg.Event,
+		ChangeType:     msg.ChangeType,
+		UserId:         msg.UserId,
+		ExternalUserId: msg.ExternalUserId,
+		State:          msg.State,
+		WelcomeCode:    msg.WelcomeCode,
+		Source:         msg.Source,
+		FailReason:     msg.FailReason,
+	}
+}
+
+// 客户群变更事件
+type ChangeExternalChatEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, change_external_chat
+	ChangeType   string `xml:"ChangeType"   json:"ChangeType"`   // ChangeTypeCreateChat, ChangeTypeUpdateChat, ChangeTypeDismissChat
+	ChatId       string `xml:"ChatId"       json:"ChatId"`       // 群 id
+	UpdateDetail string `xml:"UpdateDetail" json:"UpdateDetail"` // UpdateDetailAddMember...
+	JoinScene    int    `xml:"JoinScene"    json:"JoinScene"`    // 成员入群方式, 0-由成员邀请入群 3-通过扫描群二维码入群
+	QuitScene    int    `xml:"QuitScene"    json:"QuitScene"`    // 成员退群方式, 0-自己退群 1-群主/群管理员移出
+	MemChangeCnt int    `xml:"MemChangeCnt" json:"MemChangeCnt"` // 成员变更数量
+}
+
+func GetChangeExternalChatEvent(msg *corp.MixedMessage) *ChangeExternalChatEvent {
+	return &ChangeExternalChatEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		ChangeType:    msg.ChangeType,
+		ChatId:        msg.ChatId,
+		UpdateDetail:  msg.UpdateDetail,
+		JoinScene:     msg.JoinScene,
+		QuitScene:     msg.QuitScene,
+		MemChangeCnt:  msg.MemChangeCnt,
+	}
+}
+
+// 企业客户标签变更事件
+type ChangeExternalTagEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event      string `xml:"Event"      json:"Event"`      // 事件类型, change_external_tag
+	ChangeType string `xml:"ChangeType" json:"ChangeType"` // ChangeTypeCreateTag...
+	Id         string `xml:"Id"         json:"Id"`         // 标签或者标签组的 id
+	TagType    string `xml:"TagType"    json:"TagType"`    // TagTypeTag, TagTypeTagGroup
+	StrategyId int64  `xml:"StrategyId" json:"StrategyId"` // 规则组 id, 非规则组标签时为 0
+}
+
+func GetChangeExternalTagEvent(msg *corp.MixedMessage) *ChangeExternalTagEvent {
+	return &ChangeExternalTagEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		ChangeType:    msg.ChangeType,
+		Id:            msg.Id,
+		TagType:       msg.TagType,
+		StrategyId:    msg.StrategyId,
+	}
+}
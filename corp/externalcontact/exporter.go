@@ -0,0 +1,194 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/batch"
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const ErrCodeAPIConcurrentOutOfLimit = 45033 // 接口并发调用超过限制
+
+// 导出的一条记录: 一个成员和他添加的一个外部联系人.
+//  同一个外部联系人被多个成员添加时, 每个成员各有一条记录.
+type ExportRecord struct {
+	UserId          string          `json:"userid"` // 成员的 userid, 等于 FollowInfo.UserId
+	ExternalContact ExternalContact `json:"external_contact"`
+	FollowInfo      FollowInfo      `json:"follow_info"`
+}
+
+// 全量导出所有配置了客户联系功能的成员添加的外部联系人, 一般用于导入 CRM.
+//
+//  NOTE:
+//  1. 按成员并发导出, 每个成员用 BatchGetByUser 分页获取客户详情;
+//  2. 接口返回 45033(并发调用超过限制)时所有的 goroutine 一起暂停, 暂停时间按指数退避增加,
+//     请求成功后恢复, 同一个请求最多重试 MaxThrottleRetries 次.
+type Exporter struct {
+	Client Client
+
+	Concurrency int              // 同时导出的成员数, <= 0 时为 batch.DefaultConcurrency
+	RateLimiter corp.RateLimiter // 可以为 nil; 每个请求前调用 Wait, 如果 Client 已经设置了 RateLimiter 这里就不需要再设置
+	PageSize    int              // 每次获取的客户数, <= 0 或者超过 BatchGetByUserLimit 时为 BatchGetByUserLimit
+
+	MaxThrottleRetries int           // 45033 的最大重试次数, <= 0 时为 10
+	InitialBackoff     time.Duration // 第一次收到 45033 后暂停的时间, <= 0 时为 500ms
+	MaxBackoff         time.Duration // 最长的暂停时间, <= 0 时为 30s
+
+	// 可以为 nil; 每个成员导出完成后调用, done 为已经完成的成员数.
+	Progress func(done, total int)
+
+	throttle struct {
+		sync.Mutex
+		until   time.Time     // 在这个时间之前暂停请求
+		backoff time.Duration // 下一次收到 45033 后暂停的时间
+	}
+}
+
+func NewExporter(clt *corp.Client) *Exporter {
+	return &Exporter{
+		Client: Client{Client: clt},
+	}
+}
+
+// 导出所有的记录, 每条记录调用一次 emit; emit 不会被并发调用, 返回错误时停止导出并返回该错误.
+//  同一个成员的记录按接口返回的顺序 emit, 不同成员的记录交替 emit.
+func (e *Exporter) Export(ctx context.Context, emit func(record *ExportRecord) error) (err error) {
+	var userIdList []string
+	if err = e.call(ctx, "/cgi-bin/externalcontact/get_follow_user_list", func(clt Client) (err error) {
+		userIdList, err = clt.GetFollowUserList()
+		return
+	}); err != nil {
+		return
+	}
+
+	var (
+		emitMutex sync.Mutex
+		emitErr   error
+	)
+	opts := &batch.Options{
+		Concurrency: e.Concurrency,
+		StopOnError: true,
+		Progress:    e.Progress,
+	}
+	result := batch.Run(ctx, len(userIdList), opts, func(ctx context.Context, i int) (err error) {
+		userId := userIdList[i]
+		var cursor string
+		for {
+			var list []ContactWithFollowInfo
+			if err = e.call(ctx, "/cgi-bin/externalcontact/batch/get_by_user", func(clt Client) (err error) {
+				list, cursor, err = clt.BatchGetByUser([]string{userId}, cursor, e.PageSize)
+				return
+			}); err != nil {
+				return
+			}
+
+			emitMutex.Lock()
+			for j := 0; j < len(list) && emitErr == nil; j++ {
+				emitErr = emit(&ExportRecord{
+					UserId:          userId,
+					ExternalContact: list[j].ExternalContact,
+					FollowInfo:      list[j].FollowInfo,
+				})
+			}
+			err = emitErr
+			emitMutex.Unlock()
+			if err != nil || cursor == "" {
+				return
+			}
+		}
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	return result.Err()
+}
+
+// 调用 fn, 收到 45033 时暂停后重试; endpoint 为 RateLimiter.Wait 的参数.
+func (e *Exporter) call(ctx context.Context, endpoint string, fn func(clt Client) error) (err error) {
+	clt := Client{Client: e.Client.WithContext(ctx)}
+	maxRetries := e.MaxThrottleRetries
+	if maxRetries <= 0 {
+		maxRetries = 10
+	}
+
+	for retries := 0; ; retries++ {
+		if err = e.waitThrottle(ctx); err != nil {
+			return
+		}
+		if e.RateLimiter != nil {
+			if err = e.RateLimiter.Wait(endpoint); err != nil {
+				return
+			}
+		}
+
+		err = fn(clt)
+		if wxErr, ok := err.(*corp.Error); ok && wxErr.ErrCode == ErrCodeAPIConcurrentOutOfLimit && retries < maxRetries {
+			e.hitThrottle()
+			continue
+		}
+		if err == nil {
+			e.resetThrottle()
+		}
+		return
+	}
+}
+
+func (e *Exporter) waitThrottle(ctx context.Context) error {
+	e.throttle.Lock()
+	until := e.throttle.until
+	e.throttle.Unlock()
+
+	d := time.Until(until)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (e *Exporter) hitThrottle() {
+	initialBackoff := e.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = 500 * time.Millisecond
+	}
+	maxBackoff := e.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	e.throttle.Lock()
+	defer e.throttle.Unlock()
+
+	now := time.Now()
+	if now.Before(e.throttle.until) {
+		return // 其他 goroutine 已经设置了暂停
+	}
+	backoff := e.throttle.backoff
+	if backoff < initialBackoff {
+		backoff = initialBackoff
+	}
+	e.throttle.until = now.Add(backoff)
+	if backoff *= 2; backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	e.throttle.backoff = backoff
+}
+
+func (e *Exporter) resetThrottle() {
+	e.throttle.Lock()
+	e.throttle.backoff = 0
+	e.throttle.Unlock()
+}
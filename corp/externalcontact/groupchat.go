@@ -0,0 +1,132 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 客户群的跟进状态
+const (
+	GroupChatStatusNormal       = 0 // 跟进人正常
+	GroupChatStatusResigned     = 1 // 跟进人离职
+	GroupChatStatusTransferring = 2 // 离职继承中
+	GroupChatStatusTransferred  = 3 // 离职继承完成
+)
+
+// 客户群成员的类型
+const (
+	GroupChatMemberTypeUser     = 1 // 企业成员
+	GroupChatMemberTypeExternal = 2 // 外部联系人
+)
+
+// ListGroupChat 每次返回的最大数量
+const ListGroupChatLimit = 1000
+
+type ListGroupChatRequest struct {
+	StatusFilter int `json:"status_filter,omitempty"` // GroupChatStatusNormal...; 0 表示所有列表(即不过滤)
+	OwnerFilter  *struct {
+		UserIdList []string `json:"userid_list"` // 群主的 userid 列表, 最多 100 个
+	} `json:"owner_filter,omitempty"`
+	Cursor string `json:"cursor,omitempty"` // 上一次调用返回的 nextCursor, 第一次为空
+	Limit  int    `json:"limit"`            // 1 到 ListGroupChatLimit
+}
+
+type GroupChatStatus struct {
+	ChatId string `json:"chat_id"`
+	Status int    `json:"status"`
+}
+
+// 获取客户群列表, nextCursor 为空表示没有更多了.
+func (clt Client) ListGroupChat(req *ListGroupChatRequest) (list []GroupChatStatus, nextCursor string, err error) {
+	if req == nil {
+		err = errors.New("nil ListGroupChatRequest")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > ListGroupChatLimit {
+		req.Limit = ListGroupChatLimit
+	}
+
+	var result struct {
+		corp.Error
+		GroupChatList []GroupChatStatus `json:"group_chat_list"`
+		NextCursor    string            `json:"next_cursor"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/list?access_token="
+	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.GroupChatList
+	nextCursor = result.NextCursor
+	return
+}
+
+// 客户群成员
+type GroupChatMember struct {
+	UserId    string `json:"userid"` // 企业成员的 userid 或者外部联系人的 external_userid
+	Type      int    `json:"type"`   // GroupChatMemberTypeUser, GroupChatMemberTypeExternal
+	UnionId   string `json:"unionid,omitempty"`
+	JoinTime  int64  `json:"join_time"`
+	JoinScene int    `json:"join_scene"` // 1-由群成员邀请入群(直接邀请) 2-由群成员邀请入群(通过邀请链接) 3-通过扫描群二维码入群
+	Invitor   *struct {
+		UserId string `json:"userid"`
+	} `json:"invitor,omitempty"`
+	GroupNickname string `json:"group_nickname,omitempty"`
+	Name          string `json:"name,omitempty"`
+}
+
+// 客户群详情
+type GroupChat struct {
+	ChatId     string            `json:"chat_id"`
+	Name       string            `json:"name"`
+	Owner      string            `json:"owner"`
+	CreateTime int64             `json:"create_time"`
+	Notice     string            `json:"notice,omitempty"`
+	MemberList []GroupChatMember `json:"member_list"`
+	AdminList  []struct {
+		UserId string `json:"userid"`
+	} `json:"admin_list,omitempty"`
+	MemberVersion string `json:"member_version,omitempty"` // 成员版本号, 和客户群变更事件的 LastMemVer, CurMemVer 对应
+}
+
+// 获取客户群详情.
+//  needName 为 true 时返回成员的名字(外部联系人的名字需要有相应的权限).
+func (clt Client) GetGroupChat(chatId string, needName bool) (chat *GroupChat, err error) {
+	var request = struct {
+		ChatId   string `json:"chat_id"`
+		NeedName int    `json:"need_name"`
+	}{
+		ChatId: chatId,
+	}
+	if needName {
+		request.NeedName = 1
+	}
+
+	var result struct {
+		corp.Error
+		GroupChat GroupChat `json:"group_chat"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	chat = &result.GroupChat
+	return
+}
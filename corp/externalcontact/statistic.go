@@ -0,0 +1,247 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	StatisticMaxDays        = 30 // 统计数据的时间跨度不超过30天
+	GroupChatStatisticLimit = 1000
+)
+
+// 群聊数据统计的排序方式
+const (
+	GroupChatOrderByNewChat   = 1 // 新增群个数
+	GroupChatOrderByTotal     = 2 // 群总数
+	GroupChatOrderByHasMsg    = 3 // 有发过消息的群个数
+	GroupChatOrderByNewMember = 4 // 新增群人数
+)
+
+// 统计的时间范围, 以天为粒度, 包括 Begin 和 End 当天.
+type DateRange struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// 最近 days 天(不包括今天)的时间范围.
+func LastDays(days int) DateRange {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return DateRange{
+		Begin: today.AddDate(0, 0, -days),
+		End:   today.AddDate(0, 0, -1),
+	}
+}
+
+func (r DateRange) check(maxDays int) error {
+	if r.Begin.IsZero() || r.End.IsZero() {
+		return errors.New("empty date range")
+	}
+	if r.End.Before(r.Begin) {
+		return errors.New("date range end is before begin")
+	}
+	if maxDays > 0 && r.End.Sub(r.Begin) >= time.Duration(maxDays)*24*time.Hour {
+		return errors.New("date range is too long")
+	}
+	return nil
+}
+
+// ==============================================================================
+
+// 联系客户统计数据, 每天一行
+type BehaviorData struct {
+	StatTime            util.UnixTime `json:"stat_time"`             // 数据日期，为当日0点的时间戳
+	ChatCnt             int           `json:"chat_cnt"`              // 聊天总数， 成员有主动发送过消息的单聊总数
+	MessageCnt          int           `json:"message_cnt"`           // 发送消息数，成员在单聊中发送的消息总数
+	ReplyPercentage     float64       `json:"reply_percentage"`      // 已回复聊天占比，百分比
+	AvgReplyTime        int           `json:"avg_reply_time"`        // 平均首次回复时长，单位为分钟
+	NegativeFeedbackCnt int           `json:"negative_feedback_cnt"` // 删除/拉黑成员的客户数
+	NewApplyCnt         int           `json:"new_apply_cnt"`         // 发起申请数，成员通过「搜索手机号」、「扫一扫」等主动向客户发起的好友申请数量
+	NewContactCnt       int           `json:"new_contact_cnt"`       // 新增客户数，成员新添加的客户数量
+}
+
+func (data *BehaviorData) Date() time.Time {
+	return data.StatTime.Time()
+}
+
+// 获取联系客户统计数据.
+//  userIdList 和 partyIdList 不能同时为空; 时间跨度不超过30天.
+func (clt Client) GetUserBehaviorData(userIdList []string, partyIdList []int64, dateRange DateRange) (list []BehaviorData, err error) {
+	if len(userIdList) == 0 && len(partyIdList) == 0 {
+		err = errors.New("userIdList and partyIdList are both empty")
+		return
+	}
+	if err = dateRange.check(StatisticMaxDays); err != nil {
+		return
+	}
+
+	var request = struct {
+		UserId    []string `json:"userid,omitempty"`
+		PartyId   []int64  `json:"partyid,omitempty"`
+		StartTime int64    `json:"start_time"`
+		EndTime   int64    `json:"end_time"`
+	}{
+		UserId:    userIdList,
+		PartyId:   partyIdList,
+		StartTime: dateRange.Begin.Unix(),
+		EndTime:   dateRange.End.Unix(),
+	}
+
+	var result struct {
+		corp.Error
+		BehaviorData []BehaviorData `json:"behavior_data"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_user_behavior_data?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.BehaviorData
+	return
+}
+
+// ==============================================================================
+
+// 客户群统计数据
+type GroupChatData struct {
+	NewChatCnt            int `json:"new_chat_cnt"`             // 新增客户群数量
+	ChatTotal             int `json:"chat_total"`               // 截至当天客户群总数量
+	ChatHasMsg            int `json:"chat_has_msg"`             // 截至当天有发过消息的客户群数量
+	NewMemberCnt          int `json:"new_member_cnt"`           // 客户群新增群人数
+	MemberTotal           int `json:"member_total"`             // 截至当天客户群总人数
+	MemberHasMsg          int `json:"member_has_msg"`           // 截至当天有发过消息的群成员数
+	MsgTotal              int `json:"msg_total"`                // 截至当天客户群消息总数
+	MigrateTraineeChatCnt int `json:"migrate_trainee_chat_cnt"` // 截至当天新增迁移群数
+}
+
+// 按群主聚合的统计数据
+type GroupChatOwnerStatistic struct {
+	Owner string        `json:"owner"` // 群主ID
+	Data  GroupChatData `json:"data"`
+}
+
+// 按自然日聚合的统计数据
+type GroupChatDayStatistic struct {
+	StatTime util.UnixTime `json:"stat_time"` // 数据日期，为当日0点的时间戳
+	Data     GroupChatData `json:"data"`
+}
+
+type GroupChatStatisticParameters struct {
+	DateRange   DateRange // 时间跨度不超过30天
+	OwnerIdList []string  // 群主过滤, 为空则不过滤, 最多100个
+	OrderBy     int       // 排序方式, GroupChatOrderByXXX, 默认为 GroupChatOrderByNewChat
+	OrderAsc    bool      // 是否升序
+	Offset      int
+	Limit       int // 默认为500，取值范围1 ~ 1000
+}
+
+type groupChatOwnerFilter struct {
+	UserIdList []string `json:"userid_list"`
+}
+
+// 获取「群聊数据统计」数据, 按群主聚合.
+func (clt Client) GroupChatStatistic(para *GroupChatStatisticParameters) (list []GroupChatOwnerStatistic, total, nextOffset int, err error) {
+	if para == nil {
+		err = errors.New("nil GroupChatStatisticParameters")
+		return
+	}
+	if err = para.DateRange.check(StatisticMaxDays); err != nil {
+		return
+	}
+	if para.Limit < 0 || para.Limit > GroupChatStatisticLimit {
+		err = errors.New("invalid limit")
+		return
+	}
+
+	var request struct {
+		DayBeginTime int64                 `json:"day_begin_time"`
+		DayEndTime   int64                 `json:"day_end_time"`
+		OwnerFilter  *groupChatOwnerFilter `json:"owner_filter,omitempty"`
+		OrderBy      int                   `json:"order_by,omitempty"`
+		OrderAsc     int                   `json:"order_asc,omitempty"`
+		Offset       int                   `json:"offset,omitempty"`
+		Limit        int                   `json:"limit,omitempty"`
+	}
+	request.DayBeginTime = para.DateRange.Begin.Unix()
+	request.DayEndTime = para.DateRange.End.Unix()
+	if len(para.OwnerIdList) > 0 {
+		request.OwnerFilter = &groupChatOwnerFilter{UserIdList: para.OwnerIdList}
+	}
+	request.OrderBy = para.OrderBy
+	if para.OrderAsc {
+		request.OrderAsc = 1
+	}
+	request.Offset = para.Offset
+	request.Limit = para.Limit
+
+	var result struct {
+		corp.Error
+		Total      int                       `json:"total"`
+		NextOffset int                       `json:"next_offset"`
+		Items      []GroupChatOwnerStatistic `json:"items"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/statistic?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.Items
+	total = result.Total
+	nextOffset = result.NextOffset
+	return
+}
+
+// 获取「群聊数据统计」数据, 按自然日聚合.
+//  ownerIdList 为群主过滤, 为空则不过滤.
+func (clt Client) GroupChatStatisticByDay(dateRange DateRange, ownerIdList []string) (list []GroupChatDayStatistic, err error) {
+	if err = dateRange.check(StatisticMaxDays); err != nil {
+		return
+	}
+
+	var request struct {
+		DayBeginTime int64                 `json:"day_begin_time"`
+		DayEndTime   int64                 `json:"day_end_time"`
+		OwnerFilter  *groupChatOwnerFilter `json:"owner_filter,omitempty"`
+	}
+	request.DayBeginTime = dateRange.Begin.Unix()
+	request.DayEndTime = dateRange.End.Unix()
+	if len(ownerIdList) > 0 {
+		request.OwnerFilter = &groupChatOwnerFilter{UserIdList: ownerIdList}
+	}
+
+	var result struct {
+		corp.Error
+		Items []GroupChatDayStatistic `json:"items"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/statistic_group_by_day?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.Items
+	return
+}
@@ -0,0 +1,193 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 企业客户标签
+type CorpTag struct {
+	Id         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	CreateTime int64  `json:"create_time,omitempty"`
+	Order      int    `json:"order,omitempty"` // 排序值, 值越大越靠前
+	Deleted    bool   `json:"deleted,omitempty"`
+}
+
+// 企业客户标签组
+type CorpTagGroup struct {
+	GroupId    string    `json:"group_id,omitempty"`
+	GroupName  string    `json:"group_name,omitempty"`
+	CreateTime int64     `json:"create_time,omitempty"`
+	Order      int       `json:"order,omitempty"` // 排序值, 值越大越靠前
+	Deleted    bool      `json:"deleted,omitempty"`
+	Tag        []CorpTag `json:"tag"`
+}
+
+// 获取企业标签库.
+//  tagIdList 和 groupIdList 都为空时返回所有的标签; 同时不为空时忽略 groupIdList.
+func (clt Client) GetCorpTagList(tagIdList, groupIdList []string) (list []CorpTagGroup, err error) {
+	var request = struct {
+		TagId   []string `json:"tag_id,omitempty"`
+		GroupId []string `json:"group_id,omitempty"`
+	}{
+		TagId:   tagIdList,
+		GroupId: groupIdList,
+	}
+
+	var result struct {
+		corp.Error
+		TagGroup []CorpTagGroup `json:"tag_group"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_corp_tag_list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.TagGroup
+	return
+}
+
+// 添加企业客户标签.
+//  group.GroupId 不为空时把 group.Tag 添加到已有的标签组, 否则用 group.GroupName 创建新的标签组(已经存在同名的标签组时添加到该组);
+//  agentId 为 0 时表示企业自己调用, 否则为有该接口权限的自建应用的 agentid.
+//  返回添加后的标签组, 包括新标签的 id.
+func (clt Client) AddCorpTag(group *CorpTagGroup, agentId int64) (added *CorpTagGroup, err error) {
+	if group == nil || len(group.Tag) == 0 {
+		err = errors.New("empty tag")
+		return
+	}
+
+	var request = struct {
+		*CorpTagGroup
+		AgentId int64 `json:"agentid,omitempty"`
+	}{
+		CorpTagGroup: group,
+		AgentId:      agentId,
+	}
+
+	var result struct {
+		corp.Error
+		TagGroup CorpTagGroup `json:"tag_group"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/add_corp_tag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	added = &result.TagGroup
+	return
+}
+
+// 编辑企业客户标签或者标签组的名称和排序值.
+//  id 为标签或标签组的 id; name 为空时不修改名称, order 为 0 时不修改排序值.
+func (clt Client) EditCorpTag(id, name string, order int, agentId int64) (err error) {
+	if id == "" {
+		return errors.New("empty id")
+	}
+
+	var request = struct {
+		Id      string `json:"id"`
+		Name    string `json:"name,omitempty"`
+		Order   int    `json:"order,omitempty"`
+		AgentId int64  `json:"agentid,omitempty"`
+	}{
+		Id:      id,
+		Name:    name,
+		Order:   order,
+		AgentId: agentId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/edit_corp_tag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除企业客户标签或者标签组.
+//  tagIdList 和 groupIdList 不能同时为空; 标签组的所有标签都被删除后, 标签组也会被删除.
+func (clt Client) DelCorpTag(tagIdList, groupIdList []string, agentId int64) (err error) {
+	if len(tagIdList) == 0 && len(groupIdList) == 0 {
+		return errors.New("empty tagIdList and groupIdList")
+	}
+
+	var request = struct {
+		TagId   []string `json:"tag_id,omitempty"`
+		GroupId []string `json:"group_id,omitempty"`
+		AgentId int64    `json:"agentid,omitempty"`
+	}{
+		TagId:   tagIdList,
+		GroupId: groupIdList,
+		AgentId: agentId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/del_corp_tag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 编辑成员 userId 添加的客户 externalUserId 的企业标签.
+//  addTagIdList 和 removeTagIdList 不能同时为空, 同一个标签不能同时在两个列表里.
+func (clt Client) MarkTag(userId, externalUserId string, addTagIdList, removeTagIdList []string) (err error) {
+	if len(addTagIdList) == 0 && len(removeTagIdList) == 0 {
+		return errors.New("empty addTagIdList and removeTagIdList")
+	}
+
+	var request = struct {
+		UserId         string   `json:"userid"`
+		ExternalUserId string   `json:"external_userid"`
+		AddTag         []string `json:"add_tag,omitempty"`
+		RemoveTag      []string `json:"remove_tag,omitempty"`
+	}{
+		UserId:         userId,
+		ExternalUserId: externalUserId,
+		AddTag:         addTagIdList,
+		RemoveTag:      removeTagIdList,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/mark_tag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,192 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 欢迎语最多的附件数量
+const WelcomeMsgAttachmentsLimit = 9
+
+// 发送新客户欢迎语.
+//  welcomeCode 为添加外部联系人事件(ChangeExternalContactEvent)中的 WelcomeCode, 有效期为 20 秒;
+//  text 和 attachments 不能同时为空.
+func (clt Client) SendWelcomeMsg(welcomeCode, text string, attachments []Attachment) (err error) {
+	if welcomeCode == "" {
+		return errors.New("empty welcomeCode")
+	}
+	if text == "" && len(attachments) == 0 {
+		return errors.New("empty text and attachments")
+	}
+	if len(attachments) > WelcomeMsgAttachmentsLimit {
+		return errors.New("too many attachments")
+	}
+
+	var request struct {
+		WelcomeCode string `json:"welcome_code"`
+		Text        *struct {
+			Content string `json:"content"`
+		} `json:"text,omitempty"`
+		Attachments []Attachment `json:"attachments,omitempty"`
+	}
+	request.WelcomeCode = welcomeCode
+	if text != "" {
+		request.Text = &struct {
+			Content string `json:"content"`
+		}{Content: text}
+	}
+	request.Attachments = attachments
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/send_welcome_msg?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 入群欢迎语素材, Image, Link, MiniProgram, File, Video 只能有一个有效, 同时有多个时按这个顺序选择.
+type GroupWelcomeTemplate struct {
+	Text *struct {
+		Content string `json:"content"`
+	} `json:"text,omitempty"`
+	Image       *AttachmentImage       `json:"image,omitempty"`
+	Link        *AttachmentLink        `json:"link,omitempty"`
+	MiniProgram *AttachmentMiniProgram `json:"miniprogram,omitempty"`
+	File        *AttachmentFile        `json:"file,omitempty"`
+	Video       *AttachmentVideo       `json:"video,omitempty"`
+}
+
+// 添加入群欢迎语素材, 返回 templateId.
+//  notify 为 true 时通知成员将该素材设置为入群欢迎语;
+//  agentId 为 0 时表示企业自己调用, 否则为有该接口权限的自建应用的 agentid.
+func (clt Client) AddGroupWelcomeTemplate(tpl *GroupWelcomeTemplate, agentId int64, notify bool) (templateId string, err error) {
+	if tpl == nil {
+		err = errors.New("nil GroupWelcomeTemplate")
+		return
+	}
+
+	var request = struct {
+		*GroupWelcomeTemplate
+		AgentId int64 `json:"agentid,omitempty"`
+		Notify  int   `json:"notify"`
+	}{
+		GroupWelcomeTemplate: tpl,
+		AgentId:              agentId,
+	}
+	if notify {
+		request.Notify = 1
+	}
+
+	var result struct {
+		corp.Error
+		TemplateId string `json:"template_id"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/group_welcome_template/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templateId = result.TemplateId
+	return
+}
+
+// 编辑入群欢迎语素材.
+func (clt Client) EditGroupWelcomeTemplate(templateId string, tpl *GroupWelcomeTemplate, agentId int64) (err error) {
+	if templateId == "" {
+		return errors.New("empty templateId")
+	}
+	if tpl == nil {
+		return errors.New("nil GroupWelcomeTemplate")
+	}
+
+	var request = struct {
+		TemplateId string `json:"template_id"`
+		*GroupWelcomeTemplate
+		AgentId int64 `json:"agentid,omitempty"`
+	}{
+		TemplateId:           templateId,
+		GroupWelcomeTemplate: tpl,
+		AgentId:              agentId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/group_welcome_template/edit?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取入群欢迎语素材.
+func (clt Client) GetGroupWelcomeTemplate(templateId string) (tpl *GroupWelcomeTemplate, err error) {
+	var request = struct {
+		TemplateId string `json:"template_id"`
+	}{
+		TemplateId: templateId,
+	}
+
+	var result struct {
+		corp.Error
+		GroupWelcomeTemplate
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/group_welcome_template/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tpl = &result.GroupWelcomeTemplate
+	return
+}
+
+// 删除入群欢迎语素材.
+func (clt Client) DelGroupWelcomeTemplate(templateId string, agentId int64) (err error) {
+	var request = struct {
+		TemplateId string `json:"template_id"`
+		AgentId    int64  `json:"agentid,omitempty"`
+	}{
+		TemplateId: templateId,
+		AgentId:    agentId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/group_welcome_template/del?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
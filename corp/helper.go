@@ -12,7 +12,7 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/chanxuehong/wechat/util"
+	"github.com/chanxuehong/wechat/cipher"
 )
 
 // 回复消息的 http body
@@ -44,7 +44,7 @@ func WriteResponse(w http.ResponseWriter, r *Request, msg interface{}) (err erro
 		return
 	}
 
-	encryptedMsg := util.AESEncryptMsg(r.Random, rawMsgXML, r.CorpId, r.AESKey)
+	encryptedMsg := cipher.Encrypt(r.Random, rawMsgXML, r.CorpId, r.AESKey)
 	base64EncryptedMsg := base64.StdEncoding.EncodeToString(encryptedMsg)
 
 	responseHttpBody := ResponseHttpBody{
@@ -54,7 +54,7 @@ func WriteResponse(w http.ResponseWriter, r *Request, msg interface{}) (err erro
 	}
 
 	TimestampStr := strconv.FormatInt(responseHttpBody.Timestamp, 10)
-	responseHttpBody.MsgSignature = util.MsgSign(r.AgentToken, TimestampStr,
+	responseHttpBody.MsgSignature = cipher.MsgSign(r.AgentToken, TimestampStr,
 		responseHttpBody.Nonce, responseHttpBody.EncryptedMsg)
 
 	return xml.NewEncoder(w).Encode(&responseHttpBody)
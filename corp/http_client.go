@@ -6,16 +6,103 @@
 package corp
 
 import (
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
-// 一般请求的 http.Client
-var TextHttpClient = &http.Client{
-	Timeout: 60 * time.Second,
+// 创建 http.Client 的参数, 零值的字段使用 DefaultHttpClientOptions 里对应的值.
+type HttpClientOptions struct {
+	DialTimeout           time.Duration // 建立 tcp 连接的超时
+	KeepAlive             time.Duration // tcp keep-alive 探测的间隔
+	TLSHandshakeTimeout   time.Duration // tls 握手的超时
+	ResponseHeaderTimeout time.Duration // 发送完请求后等待应答 header 的超时
+	IdleConnTimeout       time.Duration // 空闲连接在连接池里保留的时间
+	MaxIdleConns          int           // 连接池里空闲连接的总数
+	MaxIdleConnsPerHost   int           // 每个 host 的空闲连接数, 微信的接口集中在少数几个域名, 标准库默认的 2 太小
+	MaxConnsPerHost       int           // 每个 host 的最大连接数, 0 表示不限制
+	Timeout               time.Duration // 整个请求(包括读取 body)的超时
+
+	DisableHTTP2 bool                                  // 默认尝试 HTTP/2
+	Proxy        func(*http.Request) (*url.URL, error) // 为 nil 时使用 http.ProxyFromEnvironment
+}
+
+// 微信接口一般在几百毫秒内返回, 连接和握手超过几秒基本上是网络问题, 尽快失败交给 RetryPolicy 重试.
+var DefaultHttpClientOptions = HttpClientOptions{
+	DialTimeout:           5 * time.Second,
+	KeepAlive:             30 * time.Second,
+	TLSHandshakeTimeout:   5 * time.Second,
+	ResponseHeaderTimeout: 30 * time.Second,
+	IdleConnTimeout:       90 * time.Second,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   32,
+	Timeout:               60 * time.Second,
 }
 
-// 多媒体上传下载请求的 http.Client
+// 根据 opts 创建一个新的 http.Client, opts 为 nil 时使用 DefaultHttpClientOptions.
+func NewHttpClient(opts *HttpClientOptions) *http.Client {
+	o := DefaultHttpClientOptions
+	if opts != nil {
+		if opts.DialTimeout > 0 {
+			o.DialTimeout = opts.DialTimeout
+		}
+		if opts.KeepAlive > 0 {
+			o.KeepAlive = opts.KeepAlive
+		}
+		if opts.TLSHandshakeTimeout > 0 {
+			o.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+		}
+		if opts.ResponseHeaderTimeout > 0 {
+			o.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+		}
+		if opts.IdleConnTimeout > 0 {
+			o.IdleConnTimeout = opts.IdleConnTimeout
+		}
+		if opts.MaxIdleConns > 0 {
+			o.MaxIdleConns = opts.MaxIdleConns
+		}
+		if opts.MaxIdleConnsPerHost > 0 {
+			o.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		}
+		if opts.MaxConnsPerHost > 0 {
+			o.MaxConnsPerHost = opts.MaxConnsPerHost
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		o.DisableHTTP2 = opts.DisableHTTP2
+		o.Proxy = opts.Proxy
+	}
+	if o.Proxy == nil {
+		o.Proxy = http.ProxyFromEnvironment
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: o.Proxy,
+			DialContext: (&net.Dialer{
+				Timeout:   o.DialTimeout,
+				KeepAlive: o.KeepAlive,
+			}).DialContext,
+			ForceAttemptHTTP2:     !o.DisableHTTP2,
+			TLSHandshakeTimeout:   o.TLSHandshakeTimeout,
+			ResponseHeaderTimeout: o.ResponseHeaderTimeout,
+			IdleConnTimeout:       o.IdleConnTimeout,
+			MaxIdleConns:          o.MaxIdleConns,
+			MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+			MaxConnsPerHost:       o.MaxConnsPerHost,
+			ExpectContinueTimeout: time.Second,
+		},
+		Timeout: o.Timeout,
+	}
+}
+
+// 一般请求的 http.Client, 也是 NewClient 和 NewDefaultAccessTokenServer 的参数 clt 为 nil 时默认使用的 http.Client
+var TextHttpClient = NewHttpClient(nil)
+
+// 多媒体上传下载请求的 http.Client, 和 TextHttpClient 共用连接池
 var MediaHttpClient = &http.Client{
-	Timeout: 300 * time.Second, // 因为目前微信支持最大的文件是 10MB, 请求超时时间保守设置为 300 秒
+	Transport: TextHttpClient.Transport,
+	Timeout:   300 * time.Second, // 因为目前微信支持最大的文件是 10MB, 请求超时时间保守设置为 300 秒
 }
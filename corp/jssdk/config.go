@@ -0,0 +1,153 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wx.config 需要的参数, 可以直接 json 序列化后给前端使用.
+type WxConfig struct {
+	AppId     string `json:"appId"` // 企业的 corpid
+	Timestamp string `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// wx.agentConfig 需要的参数, 可以直接 json 序列化后给前端使用.
+type WxAgentConfig struct {
+	CorpId    string `json:"corpid"`
+	AgentId   string `json:"agentid"`
+	Timestamp string `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// ConfigSigner 通过 TicketServer 获取 jsapi_ticket, 生成 wx.config 和 wx.agentConfig 需要的参数.
+type ConfigSigner struct {
+	corpId            string
+	agentId           int64
+	ticketServer      TicketServer
+	agentTicketServer TicketServer
+}
+
+// 创建 ConfigSigner.
+//  corpId:            企业的 corpid
+//  agentId:           应用的 agentid, 不使用 wx.agentConfig 时可以为 0
+//  ticketServer:      企业的 jsapi_ticket, 一般是 NewDefaultTicketServer 的返回值
+//  agentTicketServer: 应用的 jsapi_ticket, 一般是 NewDefaultAgentTicketServer 的返回值, 不使用 wx.agentConfig 时可以为 nil
+func NewConfigSigner(corpId string, agentId int64, ticketServer, agentTicketServer TicketServer) *ConfigSigner {
+	if corpId == "" {
+		panic("empty corpId")
+	}
+	if ticketServer == nil {
+		panic("nil TicketServer")
+	}
+	return &ConfigSigner{
+		corpId:            corpId,
+		agentId:           agentId,
+		ticketServer:      ticketServer,
+		agentTicketServer: agentTicketServer,
+	}
+}
+
+// 获取 url 对应页面的 wx.config 参数.
+//  url 为调用 wx.config 的页面的完整地址, '#' 及其后面部分会被去掉.
+func (signer *ConfigSigner) WxConfig(url string) (config *WxConfig, err error) {
+	ticket, err := signer.ticketServer.Ticket()
+	if err != nil {
+		return
+	}
+	return signer.wxConfig(ticket, url)
+}
+
+// 前端 wx.config 提示签名错误(jsapi_ticket 已经失效)时, 刷新 jsapi_ticket 后重新获取 wx.config 参数.
+func (signer *ConfigSigner) WxConfigRefresh(url string) (config *WxConfig, err error) {
+	ticket, err := signer.ticketServer.TicketRefresh()
+	if err != nil {
+		return
+	}
+	return signer.wxConfig(ticket, url)
+}
+
+// 获取 url 对应页面的 wx.agentConfig 参数, 签名算法和 wx.config 一样, 只是使用应用的 jsapi_ticket.
+func (signer *ConfigSigner) WxAgentConfig(url string) (config *WxAgentConfig, err error) {
+	if signer.agentTicketServer == nil {
+		err = errors.New("nil agent TicketServer")
+		return
+	}
+	ticket, err := signer.agentTicketServer.Ticket()
+	if err != nil {
+		return
+	}
+	return signer.wxAgentConfig(ticket, url)
+}
+
+// 前端 wx.agentConfig 提示签名错误时, 刷新应用的 jsapi_ticket 后重新获取 wx.agentConfig 参数.
+func (signer *ConfigSigner) WxAgentConfigRefresh(url string) (config *WxAgentConfig, err error) {
+	if signer.agentTicketServer == nil {
+		err = errors.New("nil agent TicketServer")
+		return
+	}
+	ticket, err := signer.agentTicketServer.TicketRefresh()
+	if err != nil {
+		return
+	}
+	return signer.wxAgentConfig(ticket, url)
+}
+
+func (signer *ConfigSigner) wxConfig(ticket, url string) (config *WxConfig, err error) {
+	nonceStr, timestamp, signature, err := sign(ticket, url)
+	if err != nil {
+		return
+	}
+	config = &WxConfig{
+		AppId:     signer.corpId,
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: signature,
+	}
+	return
+}
+
+func (signer *ConfigSigner) wxAgentConfig(ticket, url string) (config *WxAgentConfig, err error) {
+	nonceStr, timestamp, signature, err := sign(ticket, url)
+	if err != nil {
+		return
+	}
+	config = &WxAgentConfig{
+		CorpId:    signer.corpId,
+		AgentId:   strconv.FormatInt(signer.agentId, 10),
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: signature,
+	}
+	return
+}
+
+func sign(ticket, url string) (nonceStr, timestamp, signature string, err error) {
+	if ticket == "" {
+		err = errors.New("empty jsapi_ticket")
+		return
+	}
+	if i := strings.IndexByte(url, '#'); i >= 0 {
+		url = url[:i]
+	}
+
+	var b [16]byte
+	if _, err = rand.Read(b[:]); err != nil {
+		return
+	}
+	nonceStr = hex.EncodeToString(b[:])
+	timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	signature = WXConfigSign(ticket, nonceStr, timestamp, url)
+	return
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/chanxuehong/wechat/corp"
 )
 
-// jsapi_ticket 中控服务器接口.
+// jsapi_ticket 中控服务器接口, 企业的 jsapi_ticket(wx.config) 和应用的 jsapi_ticket(wx.agentConfig) 都用这个接口.
 type TicketServer interface {
 	// 从中控服务器获取被缓存的 jsapi_ticket.
 	Ticket() (string, error)
@@ -38,9 +38,10 @@ var _ TicketServer = (*DefaultTicketServer)(nil)
 //  NOTE:
 //  1. 用于单进程环境.
 //  2. 因为 DefaultTicketServer 同时也是一个简单的中控服务器, 而不是仅仅实现 TicketServer 接口,
-//     所以整个系统只能存在一个 DefaultTicketServer 实例!
+//     所以整个系统对同一个 jsapi_ticket 只能存在一个 DefaultTicketServer 实例!
 type DefaultTicketServer struct {
 	corpClient *corp.Client
+	ticketURL  string // 获取 jsapi_ticket 的 url, 不包括 access_token 的值
 
 	resetTickerChan chan time.Duration // 用于重置 ticketDaemon 里的 ticker
 
@@ -56,14 +57,25 @@ type DefaultTicketServer struct {
 	}
 }
 
-// 创建一个新的 DefaultTicketServer.
+// 创建一个新的 DefaultTicketServer, 管理企业的 jsapi_ticket, 用于 wx.config.
 func NewDefaultTicketServer(clt *corp.Client) (srv *DefaultTicketServer) {
+	return newDefaultTicketServer(clt, "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token=")
+}
+
+// 创建一个新的 DefaultTicketServer, 管理应用的 jsapi_ticket, 用于 wx.agentConfig.
+//  NOTE: clt 的 access_token 必须是用该应用的 secret 获取的, 应用的 jsapi_ticket 属于 access_token 对应的应用.
+func NewDefaultAgentTicketServer(clt *corp.Client) (srv *DefaultTicketServer) {
+	return newDefaultTicketServer(clt, "https://qyapi.weixin.qq.com/cgi-bin/ticket/get?type=agent_config&access_token=")
+}
+
+func newDefaultTicketServer(clt *corp.Client, ticketURL string) (srv *DefaultTicketServer) {
 	if clt == nil {
 		panic("nil corp.Client")
 	}
 
 	srv = &DefaultTicketServer{
 		corpClient:      clt,
+		ticketURL:       ticketURL,
 		resetTickerChan: make(chan time.Duration),
 	}
 
@@ -152,8 +164,7 @@ func (srv *DefaultTicketServer) getTicket() (ticket ticketInfo, cached bool, err
 		ticketInfo
 	}
 
-	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token="
-	if err = srv.corpClient.GetJSON(incompleteURL, &result); err != nil {
+	if err = srv.corpClient.GetJSON(srv.ticketURL, &result); err != nil {
 		srv.ticketCache.Lock()
 		srv.ticketCache.Ticket = ""
 		srv.ticketCache.Unlock()
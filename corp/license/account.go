@@ -0,0 +1,240 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package license
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	BatchActiveAccountCountLimit   = 1000 // 批量激活帐号, 一次最多 1000 个
+	BatchTransferLicenseCountLimit = 1000 // 批量继承帐号, 一次最多 1000 个
+)
+
+// 帐号状态
+const (
+	AccountStatusUnbound = 1 // 未绑定
+	AccountStatusBound   = 2 // 已绑定且有效
+	AccountStatusExpired = 3 // 已过期
+	AccountStatusWaiting = 4 // 待转移
+	AccountStatusMerged  = 5 // 已合并
+	AccountStatusShared  = 6 // 已分配给下游
+)
+
+// 激活帐号, 把激活码绑定到企业成员.
+func (clt Client) ActiveAccount(corpId, activeCode, userId string) (err error) {
+	var request = struct {
+		ActiveCode string `json:"active_code"`
+		CorpId     string `json:"corpid"`
+		UserId     string `json:"userid"`
+	}{
+		ActiveCode: activeCode,
+		CorpId:     corpId,
+		UserId:     userId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/active_account?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 激活码和成员
+type ActiveItem struct {
+	ActiveCode string `json:"active_code"`
+	UserId     string `json:"userid"`
+}
+
+// 批量激活的结果, ErrCode 不为 0 表示该成员激活失败
+type ActiveResult struct {
+	ActiveCode string `json:"active_code"`
+	UserId     string `json:"userid"`
+	ErrCode    int64  `json:"errcode"`
+}
+
+// 批量激活帐号, 部分失败时 err 为 nil, 失败的成员见 results 的 ErrCode.
+func (clt Client) BatchActiveAccount(corpId string, items []ActiveItem) (results []ActiveResult, err error) {
+	if len(items) <= 0 {
+		return
+	}
+	if len(items) > BatchActiveAccountCountLimit {
+		err = fmt.Errorf("the length of items must be less than or equal to %d", BatchActiveAccountCountLimit)
+		return
+	}
+
+	var request = struct {
+		CorpId     string       `json:"corpid"`
+		ActiveList []ActiveItem `json:"active_list"`
+	}{
+		CorpId:     corpId,
+		ActiveList: items,
+	}
+
+	var result struct {
+		corp.Error
+		ActiveResult []ActiveResult `json:"active_result"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/batch_active_account?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.ActiveResult
+	return
+}
+
+// 激活码详情
+type ActiveInfo struct {
+	ActiveCode string        `json:"active_code"`
+	Type       int           `json:"type"`   // AccountTypeBase, AccountTypeExternalContact
+	Status     int           `json:"status"` // AccountStatusUnbound...
+	UserId     string        `json:"userid,omitempty"`
+	CreateTime util.UnixTime `json:"create_time"`
+	ActiveTime util.UnixTime `json:"active_time,omitempty"`
+	ExpireTime util.UnixTime `json:"expire_time,omitempty"`
+	MergeInfo  *MergeInfo    `json:"merge_info,omitempty"` // 合并信息
+}
+
+// 激活码的合并信息
+type MergeInfo struct {
+	ToActiveCode   string `json:"to_active_code,omitempty"`   // 合并到的激活码
+	FromActiveCode string `json:"from_active_code,omitempty"` // 被合并的激活码
+}
+
+// 获取激活码详情.
+func (clt Client) GetActiveInfoByCode(corpId, activeCode string) (info *ActiveInfo, err error) {
+	if activeCode == "" {
+		err = errors.New("empty activeCode")
+		return
+	}
+
+	var request = struct {
+		CorpId     string `json:"corpid"`
+		ActiveCode string `json:"active_code"`
+	}{
+		CorpId:     corpId,
+		ActiveCode: activeCode,
+	}
+
+	var result struct {
+		corp.Error
+		ActiveInfo ActiveInfo `json:"active_info"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/get_active_info_by_code?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.ActiveInfo
+	return
+}
+
+// 获取成员的激活详情, active 表示成员是否已经激活.
+func (clt Client) GetActiveInfoByUser(corpId, userId string) (active bool, infos []ActiveInfo, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		CorpId string `json:"corpid"`
+		UserId string `json:"userid"`
+	}{
+		CorpId: corpId,
+		UserId: userId,
+	}
+
+	var result struct {
+		corp.Error
+		ActiveStatus   int          `json:"active_status"`
+		ActiveInfoList []ActiveInfo `json:"active_info_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/get_active_info_by_user?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	active = result.ActiveStatus == 1
+	infos = result.ActiveInfoList
+	return
+}
+
+// 帐号继承, 把离职成员的帐号转移给在职成员
+type TransferItem struct {
+	HandoverUserId string `json:"handover_userid"` // 转移成员
+	TakeoverUserId string `json:"takeover_userid"` // 接收成员
+}
+
+// 帐号继承的结果, ErrCode 不为 0 表示该成员转移失败
+type TransferResult struct {
+	HandoverUserId string `json:"handover_userid"`
+	TakeoverUserId string `json:"takeover_userid"`
+	ErrCode        int64  `json:"errcode"`
+}
+
+// 帐号继承, 部分失败时 err 为 nil, 失败的成员见 results 的 ErrCode.
+//  NOTE: 转移成员必须已经离职, 接收成员必须未激活或者帐号已过期.
+func (clt Client) BatchTransferLicense(corpId string, items []TransferItem) (results []TransferResult, err error) {
+	if len(items) <= 0 {
+		return
+	}
+	if len(items) > BatchTransferLicenseCountLimit {
+		err = fmt.Errorf("the length of items must be less than or equal to %d", BatchTransferLicenseCountLimit)
+		return
+	}
+
+	var request = struct {
+		CorpId       string         `json:"corpid"`
+		TransferList []TransferItem `json:"transfer_list"`
+	}{
+		CorpId:       corpId,
+		TransferList: items,
+	}
+
+	var result struct {
+		corp.Error
+		TransferResult []TransferResult `json:"transfer_result"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/batch_transfer_license?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.TransferResult
+	return
+}
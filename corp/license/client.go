@@ -0,0 +1,23 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package license
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+type Client struct {
+	*corp.Client
+}
+
+// srv 必须是 provider_access_token 的中控服务器, 比如 suite.ProviderAccessTokenServer.
+func NewClient(srv corp.AccessTokenServer, clt *http.Client) Client {
+	return Client{
+		Client: corp.NewClient(srv, clt),
+	}
+}
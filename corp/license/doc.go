@@ -0,0 +1,11 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 接口调用许可, 服务商为授权企业购买和管理帐号.
+//  NOTE: 所有接口都使用服务商的 provider_access_token, 见 suite.ProviderAccessTokenServer:
+//
+//  srv := suite.NewProviderAccessTokenServer(providerCorpId, providerSecret, nil)
+//  clt := license.NewClient(srv, nil)
+package license
@@ -0,0 +1,253 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package license
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 订单类型
+const (
+	OrderTypeNew     = 1 // 购买帐号
+	OrderTypeRenew   = 2 // 续期帐号
+	OrderTypeHistory = 5 // 历史企业迁移订单
+)
+
+// 订单状态
+const (
+	OrderStatusUnpaid         = 0 // 待支付
+	OrderStatusPaid           = 1 // 已支付
+	OrderStatusCanceled       = 2 // 已取消(未支付, 订单已关闭)
+	OrderStatusExpired        = 3 // 未支付, 订单已过期
+	OrderStatusRefunding      = 4 // 申请退款中
+	OrderStatusRefunded       = 5 // 退款成功
+	OrderStatusRefundRejected = 6 // 退款被拒绝
+)
+
+// 帐号类型
+const (
+	AccountTypeBase            = 1 // 基础帐号
+	AccountTypeExternalContact = 2 // 互通帐号
+)
+
+// 帐号个数
+type AccountCount struct {
+	BaseCount            int `json:"base_count,omitempty"`             // 基础帐号个数
+	ExternalContactCount int `json:"external_contact_count,omitempty"` // 互通帐号个数
+}
+
+// 帐号购买时长, 总时长为 Months 个月加 Days 天
+type AccountDuration struct {
+	Months int `json:"months,omitempty"`
+	Days   int `json:"days,omitempty"`
+}
+
+// 下单购买帐号的参数
+type CreateNewOrderParameters struct {
+	CorpId          string          `json:"corpid"`       // 企业id, 只支持加密的corpid
+	BuyerUserId     string          `json:"buyer_userid"` // 下单人, 服务商企业内成员的明文userid
+	AccountCount    AccountCount    `json:"account_count"`
+	AccountDuration AccountDuration `json:"account_duration"`
+}
+
+// 下单购买帐号, 下单后需要到服务商管理后台支付.
+func (clt Client) CreateNewOrder(para *CreateNewOrderParameters) (orderId string, err error) {
+	if para == nil {
+		err = errors.New("nil parameters")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		OrderId string `json:"order_id"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/create_new_order?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	orderId = result.OrderId
+	return
+}
+
+// 续期帐号的成员
+type RenewAccount struct {
+	UserId string `json:"userid"`
+	Type   int    `json:"type"` // AccountTypeBase, AccountTypeExternalContact
+}
+
+// 创建续期任务, 每次最多 1000 个成员; jobId 为空表示新建任务, 否则往任务里追加成员.
+//  invalidAccounts 为不能续期的成员.
+func (clt Client) CreateRenewOrderJob(corpId string, accounts []RenewAccount, jobId string) (newJobId string, invalidAccounts []RenewAccount, err error) {
+	if corpId == "" {
+		err = errors.New("empty corpId")
+		return
+	}
+
+	var request = struct {
+		CorpId      string         `json:"corpid"`
+		AccountList []RenewAccount `json:"account_list"`
+		JobId       string         `json:"jobid,omitempty"`
+	}{
+		CorpId:      corpId,
+		AccountList: accounts,
+		JobId:       jobId,
+	}
+
+	var result struct {
+		corp.Error
+		JobId              string         `json:"jobid"`
+		InvalidAccountList []RenewAccount `json:"invalid_account_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/create_renew_order_job?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	newJobId = result.JobId
+	invalidAccounts = result.InvalidAccountList
+	return
+}
+
+// 提交续期订单, 返回订单号.
+func (clt Client) SubmitOrderJob(jobId, buyerUserId string, duration AccountDuration) (orderId string, err error) {
+	if jobId == "" {
+		err = errors.New("empty jobId")
+		return
+	}
+
+	var request = struct {
+		JobId           string          `json:"jobid"`
+		BuyerUserId     string          `json:"buyer_userid"`
+		AccountDuration AccountDuration `json:"account_duration"`
+	}{
+		JobId:           jobId,
+		BuyerUserId:     buyerUserId,
+		AccountDuration: duration,
+	}
+
+	var result struct {
+		corp.Error
+		OrderId string `json:"order_id"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/submit_order_job?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	orderId = result.OrderId
+	return
+}
+
+// 订单详情
+type Order struct {
+	OrderId         string          `json:"order_id"`
+	OrderType       int             `json:"order_type"`   // OrderTypeNew, OrderTypeRenew...
+	OrderStatus     int             `json:"order_status"` // OrderStatusUnpaid...
+	CorpId          string          `json:"corpid"`
+	Price           int64           `json:"price"` // 订单金额, 单位为分
+	AccountCount    AccountCount    `json:"account_count"`
+	AccountDuration AccountDuration `json:"account_duration"`
+	CreateTime      util.UnixTime   `json:"create_time"`
+	PayTime         util.UnixTime   `json:"pay_time"`
+}
+
+// 获取订单详情.
+func (clt Client) GetOrder(orderId string) (order *Order, err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+
+	var request = struct {
+		OrderId string `json:"order_id"`
+	}{
+		OrderId: orderId,
+	}
+
+	var result struct {
+		corp.Error
+		Order Order `json:"order"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/get_order?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	order = &result.Order
+	return
+}
+
+// 订单中的帐号
+type OrderAccount struct {
+	ActiveCode string `json:"active_code"`
+	UserId     string `json:"userid,omitempty"` // 续期订单才有
+	Type       int    `json:"type"`             // AccountTypeBase, AccountTypeExternalContact
+}
+
+// 获取订单中的帐号列表, 订单支付后才能获取; cursor 为空表示从头获取, 返回的 nextCursor 为空表示没有更多了.
+//  limit 最大为 1000.
+func (clt Client) ListOrderAccount(orderId string, cursor string, limit int) (accounts []OrderAccount, nextCursor string, err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+
+	var request = struct {
+		OrderId string `json:"order_id"`
+		Limit   int    `json:"limit,omitempty"`
+		Cursor  string `json:"cursor,omitempty"`
+	}{
+		OrderId: orderId,
+		Limit:   limit,
+		Cursor:  cursor,
+	}
+
+	var result struct {
+		corp.Error
+		NextCursor  string         `json:"next_cursor"`
+		HasMore     int            `json:"has_more"`
+		AccountList []OrderAccount `json:"account_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/license/list_order_account?provider_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	accounts = result.AccountList
+	if result.HasMore != 0 {
+		nextCursor = result.NextCursor
+	}
+	return
+}
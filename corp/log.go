@@ -6,17 +6,34 @@
 package corp
 
 import (
+	"fmt"
 	"log"
+	"sync/atomic"
+
+	"github.com/chanxuehong/wechat/util"
 )
 
-var LogInfoln = log.Println
+// 实际输出日志的函数, 类型为 func(v ...interface{}), 见 SetLogInfoln.
+var logOutput atomic.Value
+
+// 输出日志, 参数里的 access_token, secret 等敏感参数被隐藏, 见 util.Redact; 默认输出到标准库的 log, 见 SetLogInfoln.
+func LogInfoln(v ...interface{}) {
+	logln(util.RedactArgs(v))
+}
 
-// 沒有加锁, 请确保在初始化阶段调用!
+// 设置 LogInfoln 的输出函数, fn 收到的参数已经隐藏了敏感参数, fn 为 nil 时恢复输出到标准库的 log.
+//  可以在运行时随时调用.
 func SetLogInfoln(fn func(v ...interface{})) {
-	if fn == nil {
+	logOutput.Store(fn)
+}
+
+// LogInfoln 和 Client 的日志都经过这里, 保证标准库 log 的调用栈深度一致.
+func logln(v []interface{}) {
+	if fn, _ := logOutput.Load().(func(v ...interface{})); fn != nil {
+		fn(v...)
 		return
 	}
-	LogInfoln = fn
+	log.Output(3, fmt.Sprintln(v...))
 }
 
 func init() {
@@ -0,0 +1,144 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 结构化的日志接口, keyvals 为交替的 key, value, 比如:
+//  logger.Warn("wechat: retry", "err_code", 42001, "attempt", 1)
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// 默认的 Logger, 输出到 LogInfoln, 格式为:
+//  [WECHAT_WARN] wechat: retry err_code=42001 attempt=1
+var DefaultLogger Logger = logInfolnLogger{}
+
+// 返回 clt.Logger, 为 nil 时返回 DefaultLogger.
+func (clt *Client) GetLogger() Logger {
+	if clt.Logger != nil {
+		return clt.Logger
+	}
+	return DefaultLogger
+}
+
+type logInfolnLogger struct {
+	raw bool // 为 true 时不隐藏敏感参数, 见 Client.DisableRedaction
+}
+
+func (l logInfolnLogger) Debug(msg string, keyvals ...interface{}) {
+	l.log(formatLog("[WECHAT_DEBUG]", msg, keyvals))
+}
+
+func (l logInfolnLogger) Info(msg string, keyvals ...interface{}) {
+	l.log(formatLog("[WECHAT_INFO]", msg, keyvals))
+}
+
+func (l logInfolnLogger) Warn(msg string, keyvals ...interface{}) {
+	l.log(formatLog("[WECHAT_WARN]", msg, keyvals))
+}
+
+func (l logInfolnLogger) Error(msg string, keyvals ...interface{}) {
+	l.log(formatLog("[WECHAT_ERROR]", msg, keyvals))
+}
+
+func (l logInfolnLogger) log(line string) {
+	if l.raw {
+		logln([]interface{}{line})
+		return
+	}
+	LogInfoln(line)
+}
+
+func formatLog(level, msg string, keyvals []interface{}) string {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		b.WriteByte(' ')
+		fmt.Fprint(&b, keyvals[i])
+		b.WriteByte('=')
+		if i+1 < len(keyvals) {
+			fmt.Fprint(&b, keyvals[i+1])
+		} else {
+			b.WriteString("MISSING")
+		}
+	}
+	return b.String()
+}
+
+// zap.SugaredLogger 等 "xxxw" 风格的日志接口, 不需要依赖 zap.
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// 把 SugaredLogger(比如 *zap.SugaredLogger) 适配为 Logger:
+//  clt.Logger = corp.NewSugaredLogger(zapLogger.Sugar())
+func NewSugaredLogger(l SugaredLogger) Logger {
+	if l == nil {
+		panic("nil SugaredLogger")
+	}
+	return sugaredLogger{l}
+}
+
+type sugaredLogger struct {
+	l SugaredLogger
+}
+
+func (s sugaredLogger) Debug(msg string, keyvals ...interface{}) { s.l.Debugw(msg, keyvals...) }
+func (s sugaredLogger) Info(msg string, keyvals ...interface{})  { s.l.Infow(msg, keyvals...) }
+func (s sugaredLogger) Warn(msg string, keyvals ...interface{})  { s.l.Warnw(msg, keyvals...) }
+func (s sugaredLogger) Error(msg string, keyvals ...interface{}) { s.l.Errorw(msg, keyvals...) }
+
+// 返回这次请求使用的 Logger, 每条日志都带上 request_id, 并且隐藏 keyvals 里的敏感参数(DisableRedaction 为 true 时除外).
+func (clt *Client) requestLogger(requestId string) Logger {
+	l := clt.GetLogger()
+	if _, ok := l.(logInfolnLogger); ok && clt.DisableRedaction {
+		l = logInfolnLogger{raw: true}
+	}
+	return requestIdLogger{l: l, requestId: requestId, redact: !clt.DisableRedaction}
+}
+
+type requestIdLogger struct {
+	l         Logger
+	requestId string
+	redact    bool
+}
+
+func (r requestIdLogger) with(keyvals []interface{}) []interface{} {
+	if r.redact {
+		keyvals = util.RedactArgs(keyvals)
+	}
+	return append([]interface{}{"request_id", r.requestId}, keyvals...)
+}
+
+func (r requestIdLogger) Debug(msg string, keyvals ...interface{}) {
+	r.l.Debug(msg, r.with(keyvals)...)
+}
+
+func (r requestIdLogger) Info(msg string, keyvals ...interface{}) {
+	r.l.Info(msg, r.with(keyvals)...)
+}
+
+func (r requestIdLogger) Warn(msg string, keyvals ...interface{}) {
+	r.l.Warn(msg, r.with(keyvals)...)
+}
+
+func (r requestIdLogger) Error(msg string, keyvals ...interface{}) {
+	r.l.Error(msg, r.with(keyvals)...)
+}
@@ -16,6 +16,7 @@ import (
 	"os"
 
 	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 下载多媒体到文件.
@@ -51,10 +52,10 @@ func (clt Client) downloadMediaToWriter(mediaId string, writer io.Writer) (err e
 
 	hasRetried := false
 RETRY:
-	finalURL := "https://qyapi.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) +
+	finalURL := corp.ReplaceBaseURL("https://qyapi.weixin.qq.com/cgi-bin/media/get?media_id=", clt.BaseURL) + url.QueryEscape(mediaId) +
 		"&access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.HttpGet(finalURL)
 	if err != nil {
 		return
 	}
@@ -81,8 +82,8 @@ RETRY:
 	case corp.ErrCodeOK:
 		return // 基本不会出现
 	case corp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
-		corp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
-		corp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		clt.GetLogger().Warn("wechat: access_token expired", "err_code", result.ErrCode, "err_msg", result.ErrMsg)
+		clt.GetLogger().Debug("wechat: current access_token", "token", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -90,12 +91,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			corp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.GetLogger().Info("wechat: access_token refreshed, retry", "token", util.MaskToken(token))
 
 			result = corp.Error{}
 			goto RETRY
 		}
-		corp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		clt.GetLogger().Warn("wechat: access_token still invalid after refresh", "token", util.MaskToken(token))
 		fallthrough
 	default:
 		err = &result
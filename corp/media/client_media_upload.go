@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/chanxuehong/wechat/corp"
 )
@@ -127,3 +128,102 @@ func (clt Client) uploadMediaFromReader(mediaType, filename string, reader io.Re
 	info = &result.MediaInfo
 	return
 }
+
+// =============================================================================
+
+const (
+	AttachmentTypeMoment  = 1 // 朋友圈
+	AttachmentTypeProduct = 2 // 商品图册
+)
+
+// 上传附件资源, 用于朋友圈, 商品图册等.
+//  mediaType:      MediaTypeImage, MediaTypeVideo, MediaTypeFile
+//  attachmentType: AttachmentTypeMoment, AttachmentTypeProduct
+func (clt Client) UploadAttachmentFromReader(mediaType string, attachmentType int, filename string, reader io.Reader) (info *MediaInfo, err error) {
+	if mediaType == "" {
+		err = errors.New("empty mediaType")
+		return
+	}
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		MediaInfo
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/media/upload_attachment?media_type=" +
+		url.QueryEscape(mediaType) + "&attachment_type=" + strconv.Itoa(attachmentType) + "&access_token="
+	fields := []corp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "media",
+		FileName:    filename,
+		Value:       reader,
+	}}
+	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.MediaInfo
+	return
+}
+
+// =============================================================================
+
+// 上传图片, 返回的 url 可以用于图文消息的正文, 永久有效.
+//  图片仅支持jpg/png格式, 大小在2MB以内.
+func (clt Client) UploadImg(_filepath string) (imgURL string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.UploadImgFromReader(filepath.Base(_filepath), file)
+}
+
+// 上传图片, 返回的 url 可以用于图文消息的正文, 永久有效.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt Client) UploadImgFromReader(filename string, reader io.Reader) (imgURL string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		URL string `json:"url"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/media/uploadimg?access_token="
+	fields := []corp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "media",
+		FileName:    filename,
+		Value:       reader,
+	}}
+	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	imgURL = result.URL
+	return
+}
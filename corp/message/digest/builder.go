@@ -0,0 +1,308 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package digest
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/corp/media"
+	"github.com/chanxuehong/wechat/corp/message/send"
+)
+
+// 内容的格式
+const (
+	FormatMarkdown = 0 // markdown
+	FormatHTML     = 1 // 简单的 html
+)
+
+// 一条通知
+type Entry struct {
+	Title   string // 必须; 标题
+	Content string // 必须; 内容
+	Format  int    // FormatMarkdown, FormatHTML
+	Author  string // 可选; 作者
+	Digest  string // 可选; 摘要, 仅用于 mpnews
+	URL     string // 可选; 阅读原文的链接
+	Thumb   string // 可选; 封面图片的 url 或者本地路径, 为空则使用内容中的第一张图片, 仅用于 mpnews
+}
+
+// 把 Entry 转换为 mpnews 或者 markdown 消息.
+//  内容中的图片(url 或者本地路径)会上传到企业微信, 同一个 Builder 不会重复上传同一张图片.
+//  NOTE: Builder 不是并发安全的.
+type Builder struct {
+	Media               media.Client
+	HttpClient          *http.Client // 下载远程图片, 如果 HttpClient == nil 则默认用 http.DefaultClient
+	DefaultThumbMediaId string       // 内容中没有图片时 mpnews 使用的封面, 为空时没有图片的 Entry 返回错误
+
+	imgURLs  map[string]string // 图片 => 图文消息正文可用的 url
+	mediaIds map[string]string // 图片 => 封面的 media_id
+}
+
+func NewBuilder(clt *corp.Client) *Builder {
+	return &Builder{
+		Media: media.Client{Client: clt},
+	}
+}
+
+// 把 entries 转换为 mpnews 消息, 每个 Entry 为一篇图文, 调用者需要设置消息的接收者.
+func (b *Builder) MPNews(agentId int64, entries []Entry) (msg *send.MPNews, err error) {
+	if n := len(entries); n == 0 || n > send.NewsArticleCountLimit {
+		err = fmt.Errorf("the number of entries must be between 1 and %d, now is %d", send.NewsArticleCountLimit, n)
+		return
+	}
+
+	msg = &send.MPNews{}
+	msg.MsgType = send.MsgTypeMPNews
+	msg.AgentId = agentId
+	for i := range entries {
+		var article *send.MPNewsArticle
+		if article, err = b.MPNewsArticle(&entries[i]); err != nil {
+			return nil, fmt.Errorf("entries[%d]: %v", i, err)
+		}
+		msg.MPNews.Articles = append(msg.MPNews.Articles, *article)
+	}
+	return
+}
+
+// 把 entry 转换为一篇图文.
+func (b *Builder) MPNewsArticle(entry *Entry) (article *send.MPNewsArticle, err error) {
+	if entry.Title == "" {
+		err = errors.New("empty Title")
+		return
+	}
+
+	content := entry.Content
+	if entry.Format == FormatMarkdown {
+		content = MarkdownToHTML(content)
+	}
+
+	var firstImage string
+	var replaceErr error
+	content = htmlImgSrcRegexp.ReplaceAllStringFunc(content, func(s string) string {
+		m := htmlImgSrcRegexp.FindStringSubmatch(s)
+		src := html.UnescapeString(m[2])
+		if firstImage == "" {
+			firstImage = src
+		}
+		imgURL, err := b.imgURL(src)
+		if err != nil {
+			if replaceErr == nil {
+				replaceErr = err
+			}
+			return s
+		}
+		return m[1] + html.EscapeString(imgURL) + m[3]
+	})
+	if err = replaceErr; err != nil {
+		return
+	}
+
+	thumb := entry.Thumb
+	if thumb == "" {
+		thumb = firstImage
+	}
+	var thumbMediaId string
+	switch {
+	case thumb != "":
+		if thumbMediaId, err = b.thumbMediaId(thumb); err != nil {
+			return
+		}
+	case b.DefaultThumbMediaId != "":
+		thumbMediaId = b.DefaultThumbMediaId
+	default:
+		err = errors.New("no image for thumb and DefaultThumbMediaId is empty")
+		return
+	}
+
+	article = &send.MPNewsArticle{
+		ThumbMediaId:     thumbMediaId,
+		Title:            entry.Title,
+		Author:           entry.Author,
+		ContentSourceURL: entry.URL,
+		Content:          content,
+		Digest:           entry.Digest,
+	}
+	return
+}
+
+// 把 entries 合并为一条 markdown 消息, 调用者需要设置消息的接收者.
+//  企业微信的 markdown 不支持图片, 图片会上传后转换为链接.
+//  NOTE: 内容可能超过 send.ContentByteLimit, 请用 send.Client.SendMarkdownSplit 发送.
+func (b *Builder) Markdown(agentId int64, entries []Entry) (msg *send.Markdown, err error) {
+	if len(entries) == 0 {
+		err = errors.New("empty entries")
+		return
+	}
+
+	parts := make([]string, 0, len(entries))
+	for i := range entries {
+		var part string
+		if part, err = b.markdown(&entries[i]); err != nil {
+			return nil, fmt.Errorf("entries[%d]: %v", i, err)
+		}
+		parts = append(parts, part)
+	}
+
+	msg = &send.Markdown{}
+	msg.MsgType = send.MsgTypeMarkdown
+	msg.AgentId = agentId
+	msg.Markdown.Content = strings.Join(parts, "\n\n")
+	return
+}
+
+func (b *Builder) markdown(entry *Entry) (content string, err error) {
+	content = entry.Content
+	if entry.Format == FormatHTML {
+		content = HTMLToMarkdown(content)
+	}
+
+	var replaceErr error
+	content = markdownImageRegexp.ReplaceAllStringFunc(content, func(s string) string {
+		m := markdownImageRegexp.FindStringSubmatch(s)
+		imgURL, err := b.imgURL(m[2])
+		if err != nil {
+			if replaceErr == nil {
+				replaceErr = err
+			}
+			return s
+		}
+		alt := m[1]
+		if alt == "" {
+			alt = "图片"
+		}
+		return "[" + alt + "](" + imgURL + ")"
+	})
+	if err = replaceErr; err != nil {
+		return
+	}
+
+	var sb strings.Builder
+	if entry.Title != "" {
+		sb.WriteString("### ")
+		sb.WriteString(entry.Title)
+		sb.WriteString("\n")
+	}
+	if entry.Author != "" {
+		sb.WriteString(`> <font color="comment">`)
+		sb.WriteString(entry.Author)
+		sb.WriteString("</font>\n")
+	}
+	sb.WriteString(strings.TrimSpace(content))
+	if entry.URL != "" {
+		sb.WriteString("\n[阅读原文](")
+		sb.WriteString(entry.URL)
+		sb.WriteString(")")
+	}
+	content = sb.String()
+	return
+}
+
+// 已经在企业微信服务器上的图片不需要上传
+func isUploaded(src string) bool {
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(u.Hostname(), ".qpic.cn")
+}
+
+func (b *Builder) imgURL(src string) (imgURL string, err error) {
+	if isUploaded(src) {
+		return src, nil
+	}
+	if imgURL = b.imgURLs[src]; imgURL != "" {
+		return
+	}
+
+	filename, reader, err := b.openImage(src)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	if imgURL, err = b.Media.UploadImgFromReader(filename, reader); err != nil {
+		return
+	}
+	if b.imgURLs == nil {
+		b.imgURLs = make(map[string]string)
+	}
+	b.imgURLs[src] = imgURL
+	return
+}
+
+func (b *Builder) thumbMediaId(src string) (mediaId string, err error) {
+	if mediaId = b.mediaIds[src]; mediaId != "" {
+		return
+	}
+
+	filename, reader, err := b.openImage(src)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	info, err := b.Media.UploadImageFromReader(filename, reader)
+	if err != nil {
+		return
+	}
+	if b.mediaIds == nil {
+		b.mediaIds = make(map[string]string)
+	}
+	b.mediaIds[src] = info.MediaId
+	mediaId = info.MediaId
+	return
+}
+
+// 打开图片, src 为 http(s) url 或者本地路径.
+func (b *Builder) openImage(src string) (filename string, reader io.ReadCloser, err error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		file, err := os.Open(src)
+		if err != nil {
+			return "", nil, err
+		}
+		return filepath.Base(src), file, nil
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return
+	}
+	httpClient := b.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Get(src)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("get %s: http.Status: %s", src, httpResp.Status)
+		return
+	}
+
+	filename = path.Base(u.Path)
+	if path.Ext(filename) == "" {
+		switch httpResp.Header.Get("Content-Type") {
+		case "image/png":
+			filename += ".png"
+		default:
+			filename += ".jpg"
+		}
+	}
+	reader = httpResp.Body
+	return
+}
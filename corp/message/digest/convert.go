@@ -0,0 +1,180 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package digest
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// <img ... src="SRC" ...>, 分组: 前缀, SRC, 后缀
+	htmlImgSrcRegexp = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*["'])([^"']+)(["'])`)
+
+	// ![ALT](SRC), 分组: ALT, SRC
+	markdownImageRegexp = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	markdownLinkRegexp  = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBoldRegexp  = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	markdownCodeRegexp  = regexp.MustCompile("`([^`]+)`")
+
+	markdownHeadingRegexp     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownOrderedItemRegexp = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+)
+
+// 把 markdown 转换为 html, 仅支持常用的语法:
+//  标题, 段落, 引用, 列表, 代码块, 粗体, 行内代码, 链接和图片.
+func MarkdownToHTML(md string) string {
+	var (
+		sb        strings.Builder
+		paragraph []string
+		listTag   string // "ul", "ol" 或者 ""
+		quote     []string
+	)
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + strings.Join(paragraph, "<br/>") + "</p>\n")
+			paragraph = nil
+		}
+	}
+	flushList := func() {
+		if listTag != "" {
+			sb.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	flushQuote := func() {
+		if len(quote) > 0 {
+			sb.WriteString("<blockquote><p>" + strings.Join(quote, "<br/>") + "</p></blockquote>\n")
+			quote = nil
+		}
+	}
+	flush := func() {
+		flushParagraph()
+		flushList()
+		flushQuote()
+	}
+	startList := func(tag string) {
+		flushParagraph()
+		flushQuote()
+		if listTag != tag {
+			flushList()
+			sb.WriteString("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	lines := strings.Split(strings.Replace(md, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			sb.WriteString("<pre><code>")
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				sb.WriteString(html.EscapeString(lines[i]))
+				sb.WriteString("\n")
+			}
+			sb.WriteString("</code></pre>\n")
+		case trimmed == "":
+			flush()
+		case markdownHeadingRegexp.MatchString(trimmed):
+			flush()
+			m := markdownHeadingRegexp.FindStringSubmatch(trimmed)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			sb.WriteString("<" + tag + ">" + markdownInline(m[2]) + "</" + tag + ">\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			flushList()
+			quote = append(quote, markdownInline(strings.TrimSpace(trimmed[1:])))
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			startList("ul")
+			sb.WriteString("<li>" + markdownInline(strings.TrimSpace(trimmed[2:])) + "</li>\n")
+		case markdownOrderedItemRegexp.MatchString(trimmed):
+			startList("ol")
+			m := markdownOrderedItemRegexp.FindStringSubmatch(trimmed)
+			sb.WriteString("<li>" + markdownInline(m[1]) + "</li>\n")
+		default:
+			flushList()
+			flushQuote()
+			paragraph = append(paragraph, markdownInline(trimmed))
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// 转换行内的语法, 先转义 html 再替换, 所以属性值里的 & 和 " 也是转义过的.
+func markdownInline(s string) string {
+	s = html.EscapeString(s)
+	s = markdownImageRegexp.ReplaceAllString(s, `<img src="$2" alt="$1"/>`)
+	s = markdownLinkRegexp.ReplaceAllString(s, `<a href="$2">$1</a>`)
+	s = markdownBoldRegexp.ReplaceAllString(s, `<strong>$1</strong>`)
+	s = markdownCodeRegexp.ReplaceAllString(s, `<code>$1</code>`)
+	return s
+}
+
+var (
+	htmlSpaceRegexp   = regexp.MustCompile(`>\s+<`)
+	htmlImgRegexp     = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	htmlAttrRegexp    = regexp.MustCompile(`(?is)\b(src|alt|href)\s*=\s*["']([^"']*)["']`)
+	htmlLinkRegexp    = regexp.MustCompile(`(?is)<a\b([^>]*)>(.*?)</a>`)
+	htmlHeadingRegexp = regexp.MustCompile(`(?is)<h[1-6][^>]*>(.*?)</h[1-6]>`)
+	htmlBoldRegexp    = regexp.MustCompile(`(?is)<(?:b|strong)\b[^>]*>(.*?)</(?:b|strong)>`)
+	htmlItemRegexp    = regexp.MustCompile(`(?is)<li\b[^>]*>`)
+	htmlBreakRegexp   = regexp.MustCompile(`(?is)<br\s*/?>|</(?:p|div|ul|ol|blockquote|pre|tr)>`)
+	htmlTagRegexp     = regexp.MustCompile(`(?s)<[^>]+>`)
+	blankLinesRegexp  = regexp.MustCompile(`\n{3,}`)
+)
+
+func htmlAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range htmlAttrRegexp.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2])
+	}
+	return attrs
+}
+
+// 把简单的 html 转换为企业微信支持的 markdown 子集, 不支持的标签直接去掉.
+func HTMLToMarkdown(s string) string {
+	s = htmlSpaceRegexp.ReplaceAllString(s, "><")
+	s = htmlImgRegexp.ReplaceAllStringFunc(s, func(tag string) string {
+		attrs := htmlAttrs(tag)
+		if attrs["src"] == "" {
+			return ""
+		}
+		return "![" + attrs["alt"] + "](" + attrs["src"] + ")"
+	})
+	s = htmlLinkRegexp.ReplaceAllStringFunc(s, func(tag string) string {
+		m := htmlLinkRegexp.FindStringSubmatch(tag)
+		href := htmlAttrs(m[1])["href"]
+		text := strings.TrimSpace(htmlTagRegexp.ReplaceAllString(m[2], ""))
+		if href == "" {
+			return text
+		}
+		if text == "" {
+			text = href
+		}
+		return "[" + text + "](" + href + ")"
+	})
+	s = htmlHeadingRegexp.ReplaceAllString(s, "\n### $1\n")
+	s = htmlBoldRegexp.ReplaceAllString(s, "**$1**")
+	s = htmlItemRegexp.ReplaceAllString(s, "\n- ")
+	s = htmlBreakRegexp.ReplaceAllString(s, "\n")
+	s = htmlTagRegexp.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLinesRegexp.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
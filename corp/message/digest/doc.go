@@ -0,0 +1,13 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 把 markdown 或者简单的 html 内容转换为 mpnews 和 markdown 消息, 内容中的图片自动上传.
+//  一般用于内部通知系统, 把模板渲染的内容直接发送给成员:
+//
+//  b := digest.NewBuilder(clt)
+//  msg, err := b.MPNews(agentId, entries)
+//  msg.ToUser = "@all"
+//  r, err := send.Client{Client: clt}.SendMPNews(msg)
+package digest
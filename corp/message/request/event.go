@@ -14,6 +14,8 @@ const (
 	EventTypeSubscribe   = "subscribe"   // 订阅, 包括点击订阅和扫描二维码
 	EventTypeUnsubscribe = "unsubscribe" // 取消订阅
 	EventTypeLocation    = "LOCATION"    // 上报地理位置事件
+
+	EventTypeTemplateCard = "template_card_event" // 模板卡片事件, 用户点击模板卡片的按钮
 )
 
 // 关注事件
@@ -67,3 +69,44 @@ func GetLocationEvent(msg *corp.MixedMessage) *LocationEvent {
 		Precision:     msg.Precision,
 	}
 }
+
+// 模板卡片选择类型的选项
+type TemplateCardSelectedItem struct {
+	QuestionKey string   `xml:"QuestionKey"        json:"QuestionKey"` // 问题的key值
+	OptionIds   []string `xml:"OptionIds>OptionId" json:"OptionIds"`   // 对应问题的选项列表
+}
+
+// 模板卡片事件
+//  可以在 5 秒内回复更新后的卡片, 或者用 ResponseCode 调用 send.Client.UpdateTemplateCard 更新卡片(72小时内有效, 只能使用一次).
+type TemplateCardEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event         string                     `xml:"Event"        json:"Event"`        // 事件类型，此时固定为：template_card_event
+	EventKey      string                     `xml:"EventKey"     json:"EventKey"`     // 按钮的 key
+	TaskId        string                     `xml:"TaskId"       json:"TaskId"`       // 发送模板卡片时指定的 task_id
+	CardType      string                     `xml:"CardType"     json:"CardType"`     // 模板卡片的类型
+	ResponseCode  string                     `xml:"ResponseCode" json:"ResponseCode"` // 用于调用更新卡片接口
+	SelectedItems []TemplateCardSelectedItem `xml:"SelectedItems>SelectedItem,omitempty" json:"SelectedItems,omitempty"`
+}
+
+func GetTemplateCardEvent(msg *corp.MixedMessage) *TemplateCardEvent {
+	event := &TemplateCardEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		EventKey:      msg.EventKey,
+		TaskId:        msg.TaskId,
+		CardType:      msg.CardType,
+		ResponseCode:  msg.ResponseCode,
+	}
+	if n := len(msg.SelectedItems); n > 0 {
+		event.SelectedItems = make([]TemplateCardSelectedItem, n)
+		for i, item := range msg.SelectedItems {
+			event.SelectedItems[i] = TemplateCardSelectedItem{
+				QuestionKey: item.QuestionKey,
+				OptionIds:   item.OptionIds,
+			}
+		}
+	}
+	return event
+}
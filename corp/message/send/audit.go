@@ -0,0 +1,141 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package send
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 一次发送消息的记录
+type AuditRecord struct {
+	Time time.Time
+
+	MsgType string
+	AgentId int64
+	ToUser  string
+	ToParty string
+	ToTag   string
+
+	MsgId        string
+	InvalidUser  string
+	InvalidParty string
+	InvalidTag   string
+	Err          error // 发送失败的错误, 成功为 nil
+
+	Msg interface{} // 发送的消息, 用于 ResendToInvalidUser, ResendToUsers
+}
+
+// 记录发送消息的结果, 比如写入日志或者数据库.
+//  NOTE: Audit 在发送消息的 goroutine 里同步调用, 实现不要阻塞太久.
+type Auditor interface {
+	Audit(record *AuditRecord)
+}
+
+type AuditorFunc func(record *AuditRecord)
+
+func (fn AuditorFunc) Audit(record *AuditRecord) {
+	fn(record)
+}
+
+// 所有的消息类型都内嵌了 MessageHeader.
+type messageHeaderGetter interface {
+	messageHeader() *MessageHeader
+}
+
+func (hdr *MessageHeader) messageHeader() *MessageHeader {
+	return hdr
+}
+
+func newAuditRecord(msg interface{}, r *Result, err error) *AuditRecord {
+	record := &AuditRecord{
+		Time: time.Now(),
+		Err:  err,
+		Msg:  msg,
+	}
+	if getter, ok := msg.(messageHeaderGetter); ok {
+		hdr := getter.messageHeader()
+		record.MsgType = hdr.MsgType
+		record.AgentId = hdr.AgentId
+		record.ToUser = hdr.ToUser
+		record.ToParty = hdr.ToParty
+		record.ToTag = hdr.ToTag
+	}
+	if r != nil {
+		record.MsgId = r.MsgId
+		record.InvalidUser = r.InvalidUser
+		record.InvalidParty = r.InvalidParty
+		record.InvalidTag = r.InvalidTag
+	}
+	return record
+}
+
+// 把 record 对应的消息重新发送给 record.InvalidUser 里的成员, 一般在修正了成员的联系方式后调用.
+//  NOTE: record.InvalidUser 为空时直接返回.
+func (clt Client) ResendToInvalidUser(record *AuditRecord) (r *Result, err error) {
+	if record == nil {
+		err = errors.New("nil AuditRecord")
+		return
+	}
+	if record.InvalidUser == "" {
+		r = &Result{}
+		return
+	}
+	return clt.ResendToUsers(record, SplitString(record.InvalidUser))
+}
+
+// 把 record 对应的消息重新发送给 userIds, 忽略原来的 ToParty, ToTag.
+func (clt Client) ResendToUsers(record *AuditRecord, userIds []string) (r *Result, err error) {
+	if record == nil {
+		err = errors.New("nil AuditRecord")
+		return
+	}
+	if record.Msg == nil {
+		err = errors.New("nil AuditRecord.Msg")
+		return
+	}
+	if len(userIds) <= 0 {
+		err = errors.New("empty userIds")
+		return
+	}
+
+	// 不修改原来的消息, 在 JSON 层面替换接收者
+	msgBytes, err := json.Marshal(record.Msg)
+	if err != nil {
+		return
+	}
+	msg := &resentMessage{}
+	if err = json.Unmarshal(msgBytes, &msg.fields); err != nil {
+		return
+	}
+	if err = json.Unmarshal(msgBytes, &msg.MessageHeader); err != nil {
+		return
+	}
+	msg.ToUser = JoinString(userIds)
+	msg.ToParty = ""
+	msg.ToTag = ""
+
+	toUser, err := json.Marshal(msg.ToUser)
+	if err != nil {
+		return
+	}
+	msg.fields["touser"] = toUser
+	delete(msg.fields, "toparty")
+	delete(msg.fields, "totag")
+
+	return clt.send(msg)
+}
+
+// 重新发送的消息, 按 fields 编码; 内嵌的 MessageHeader 用于 AuditRecord 记录 MsgType, AgentId 和接收者.
+type resentMessage struct {
+	MessageHeader
+	fields map[string]json.RawMessage
+}
+
+func (msg *resentMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(msg.fields)
+}
@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package send_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp/message/send"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestResendToInvalidUser(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("/cgi-bin/message/send", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","invaliduser":"u2","msgid":"m1"}`))
+	})
+
+	var records []*send.AuditRecord
+	clt := send.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.Auditor = send.AuditorFunc(func(record *send.AuditRecord) { records = append(records, record) })
+
+	msg := &send.Text{}
+	msg.ToUser = "u1|u2"
+	msg.ToParty = "1"
+	msg.MsgType = send.MsgTypeText
+	msg.AgentId = 1000002
+	msg.Text.Content = "hello"
+	if _, err := clt.SendText(msg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clt.ResendToInvalidUser(records[0]); err != nil {
+		t.Fatal(err)
+	}
+	// 重发的记录还可以再重发
+	if _, err := clt.ResendToInvalidUser(records[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, record := range records[1:] {
+		if record.MsgType != send.MsgTypeText || record.AgentId != 1000002 || record.ToUser != "u2" || record.ToParty != "" {
+			t.Errorf("have resend record %+v", record)
+		}
+	}
+	requests := srv.RequestsTo("/cgi-bin/message/send")
+	if len(requests) != 3 {
+		t.Fatalf("have %d requests, want 3", len(requests))
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(requests[2].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["touser"] != "u2" || body["toparty"] != nil || body["msgtype"] != send.MsgTypeText || body["agentid"] != float64(1000002) {
+		t.Errorf("have resend body %s", requests[2].Body)
+	}
+}
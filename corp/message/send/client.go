@@ -14,6 +14,8 @@ import (
 
 type Client struct {
 	*corp.Client
+
+	Auditor Auditor // 可以为 nil; 不为 nil 时记录每一次发送消息的结果
 }
 
 // 兼容保留, 建議實際項目全局維護一個 *corp.Client
@@ -25,11 +27,36 @@ func NewClient(srv corp.AccessTokenServer, clt *http.Client) Client {
 
 // 发送消息返回的数据结构
 type Result struct {
+	MsgId        string `json:"msgid"`
 	InvalidUser  string `json:"invaliduser"`
 	InvalidParty string `json:"invalidparty"`
 	InvalidTag   string `json:"invalidtag"`
 }
 
+// 无效的成员ID列表
+func (r *Result) InvalidUserList() []string {
+	if r.InvalidUser == "" {
+		return nil
+	}
+	return SplitString(r.InvalidUser)
+}
+
+// 无效的部门ID列表
+func (r *Result) InvalidPartyList() ([]int64, error) {
+	if r.InvalidParty == "" {
+		return nil, nil
+	}
+	return SplitInt64(r.InvalidParty)
+}
+
+// 无效的标签ID列表
+func (r *Result) InvalidTagList() ([]int64, error) {
+	if r.InvalidTag == "" {
+		return nil, nil
+	}
+	return SplitInt64(r.InvalidTag)
+}
+
 func (clt Client) SendText(msg *Text) (r *Result, err error) {
 	if msg == nil {
 		err = errors.New("nil msg")
@@ -92,7 +119,29 @@ func (clt Client) SendMPNews(msg *MPNews) (r *Result, err error) {
 	return clt.send(msg)
 }
 
+func (clt Client) SendTextCard(msg *TextCard) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendMarkdown(msg *Markdown) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
 func (clt Client) send(msg interface{}) (r *Result, err error) {
+	if clt.Auditor != nil {
+		defer func() {
+			clt.Auditor.Audit(newAuditRecord(msg, r, err))
+		}()
+	}
+
 	var result struct {
 		corp.Error
 		Result
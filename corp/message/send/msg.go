@@ -11,13 +11,15 @@ import (
 )
 
 const (
-	MsgTypeText   = "text"
-	MsgTypeImage  = "image"
-	MsgTypeVoice  = "voice"
-	MsgTypeVideo  = "video"
-	MsgTypeFile   = "file"
-	MsgTypeNews   = "news"
-	MsgTypeMPNews = "mpnews"
+	MsgTypeText     = "text"
+	MsgTypeImage    = "image"
+	MsgTypeVoice    = "voice"
+	MsgTypeVideo    = "video"
+	MsgTypeFile     = "file"
+	MsgTypeNews     = "news"
+	MsgTypeMPNews   = "mpnews"
+	MsgTypeTextCard = "textcard"
+	MsgTypeMarkdown = "markdown"
 )
 
 type MessageHeader struct {
@@ -72,6 +74,27 @@ type File struct {
 	} `json:"file"`
 }
 
+// 文本卡片消息
+type TextCard struct {
+	MessageHeader
+
+	TextCard struct {
+		Title       string `json:"title"`            // 标题，不超过128个字节
+		Description string `json:"description"`      // 描述，不超过512个字节, 支持 <div class="gray"></div> 等简单的 html 标签
+		URL         string `json:"url"`              // 点击后跳转的链接
+		BtnTxt      string `json:"btntxt,omitempty"` // 按钮文字。 默认为“详情”， 不超过4个文字
+	} `json:"textcard"`
+}
+
+// markdown 消息, 目前仅支持 markdown 语法的子集
+type Markdown struct {
+	MessageHeader
+
+	Markdown struct {
+		Content string `json:"content"` // markdown内容，最长不超过2048个字节，必须是utf8编码
+	} `json:"markdown"`
+}
+
 type NewsArticle struct {
 	Title       string `json:"title,omitempty"`       // 图文消息标题
 	Description string `json:"description,omitempty"` // 图文消息描述
@@ -0,0 +1,85 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package send
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// 文本消息和 markdown 消息内容的长度限制, 单位为字节
+const ContentByteLimit = 2048
+
+// 把 content 切分为不超过 limit 字节的若干段, 不会切断 utf8 字符, 尽量在换行处切分.
+func SplitContent(content string, limit int) []string {
+	if limit <= 0 {
+		limit = ContentByteLimit
+	}
+	if len(content) <= limit {
+		return []string{content}
+	}
+
+	var parts []string
+	for len(content) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(content[n]) {
+			n--
+		}
+		if n == 0 { // limit 比一个字符还小
+			_, n = utf8.DecodeRuneInString(content)
+		}
+		if i := strings.LastIndexByte(content[:n], '\n'); i > 0 {
+			n = i + 1
+		}
+		parts = append(parts, content[:n])
+		content = content[n:]
+	}
+	if content != "" {
+		parts = append(parts, content)
+	}
+	return parts
+}
+
+// 发送文本消息, 内容超过 ContentByteLimit 时自动切分为多条消息按顺序发送.
+//  返回每条消息的结果, 遇到错误时立即返回, rs 为已经发送成功的消息的结果.
+func (clt Client) SendTextSplit(msg *Text) (rs []*Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	for _, content := range SplitContent(msg.Text.Content, ContentByteLimit) {
+		part := *msg
+		part.Text.Content = content
+
+		var r *Result
+		if r, err = clt.send(&part); err != nil {
+			return
+		}
+		rs = append(rs, r)
+	}
+	return
+}
+
+// 发送 markdown 消息, 内容超过 ContentByteLimit 时自动切分为多条消息按顺序发送.
+//  NOTE: 按字节切分可能会切断 markdown 的语法结构(比如代码块), 尽量在段落之间换行.
+func (clt Client) SendMarkdownSplit(msg *Markdown) (rs []*Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	for _, content := range SplitContent(msg.Markdown.Content, ContentByteLimit) {
+		part := *msg
+		part.Markdown.Content = content
+
+		var r *Result
+		if r, err = clt.send(&part); err != nil {
+			return
+		}
+		rs = append(rs, r)
+	}
+	return
+}
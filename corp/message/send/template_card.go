@@ -0,0 +1,470 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package send
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const MsgTypeTemplateCard = "template_card"
+
+// 模板卡片的类型
+const (
+	CardTypeTextNotice          = "text_notice"          // 文本通知型
+	CardTypeNewsNotice          = "news_notice"          // 图文展示型
+	CardTypeButtonInteraction   = "button_interaction"   // 按钮交互型
+	CardTypeVoteInteraction     = "vote_interaction"     // 投票选择型
+	CardTypeMultipleInteraction = "multiple_interaction" // 多项选择型
+)
+
+// 模板卡片里各个列表的长度限制
+const (
+	TemplateCardHorizontalContentLimit = 6
+	TemplateCardJumpLimit              = 3
+	TemplateCardVerticalContentLimit   = 4
+	TemplateCardButtonLimit            = 6
+	TemplateCardActionMenuLimit        = 3
+	TemplateCardCheckboxOptionLimit    = 20
+	TemplateCardSelectLimit            = 3
+	TemplateCardSelectOptionLimit      = 10
+)
+
+// 二级标题+文本列表的类型
+const (
+	HorizontalContentTypeText   = 0 // 普通文本
+	HorizontalContentTypeURL    = 1 // 跳转url
+	HorizontalContentTypeMedia  = 2 // 下载附件
+	HorizontalContentTypeUserId = 3 // 点击跳转成员详情
+)
+
+// 跳转的类型, 用于 card_action, jump_list, quote_area, image_text_area
+const (
+	CardJumpTypeNone        = 0 // 没有跳转(card_action 不支持)
+	CardJumpTypeURL         = 1 // 跳转url
+	CardJumpTypeMiniProgram = 2 // 跳转小程序
+)
+
+// 模板卡片消息
+type TemplateCard struct {
+	MessageHeader
+
+	TemplateCard TemplateCardContent `json:"template_card"`
+
+	EnableIdTrans          int `json:"enable_id_trans,omitempty"`          // 表示是否开启id转译，0表示否，1表示是
+	EnableDuplicateCheck   int `json:"enable_duplicate_check,omitempty"`   // 表示是否开启重复消息检查，0表示否，1表示是
+	DuplicateCheckInterval int `json:"duplicate_check_interval,omitempty"` // 表示是否重复消息检查的时间间隔，默认1800s，最大不超过4小时
+}
+
+// 模板卡片的内容, 各个字段只在对应的 CardType 里有效, 见 CheckValid.
+type TemplateCardContent struct {
+	CardType string `json:"card_type"`
+
+	Source     *CardSource     `json:"source,omitempty"`      // 卡片来源样式信息
+	ActionMenu *CardActionMenu `json:"action_menu,omitempty"` // 卡片右上角更多操作按钮, 需要 TaskId
+	TaskId     string          `json:"task_id,omitempty"`     // 任务id，同一个应用任务id不能重复，只能由数字、字母和“_-@”组成，最长128字节
+
+	MainTitle       *CardMainTitle       `json:"main_title,omitempty"`
+	QuoteArea       *CardQuoteArea       `json:"quote_area,omitempty"`       // 引用文献样式
+	EmphasisContent *CardEmphasisContent `json:"emphasis_content,omitempty"` // 关键数据样式, 仅 text_notice
+	SubTitleText    string               `json:"sub_title_text,omitempty"`   // 二级普通文本，建议不超过112个字
+
+	CardImage           *CardImage              `json:"card_image,omitempty"`            // 图片样式, 仅 news_notice
+	ImageTextArea       *CardImageTextArea      `json:"image_text_area,omitempty"`       // 左图右文样式, 仅 news_notice
+	VerticalContentList []CardVerticalContent   `json:"vertical_content_list,omitempty"` // 卡片二级垂直内容, 仅 news_notice, 最多4个
+	HorizontalContent   []CardHorizontalContent `json:"horizontal_content_list,omitempty"`
+	JumpList            []CardJump              `json:"jump_list,omitempty"`   // 跳转指引样式的列表，最多3个
+	CardAction          *CardAction             `json:"card_action,omitempty"` // 整体卡片的点击跳转事件, text_notice 和 news_notice 必须
+
+	ButtonSelection *CardButtonSelection `json:"button_selection,omitempty"` // 下拉式的选择器, 仅 button_interaction
+	ButtonList      []CardButton         `json:"button_list,omitempty"`      // 按钮列表, 仅 button_interaction, 最多6个
+
+	Checkbox     *CardCheckbox     `json:"checkbox,omitempty"`      // 选择题样式, 仅 vote_interaction
+	SelectList   []CardSelect      `json:"select_list,omitempty"`   // 下拉式的选择器列表, 仅 multiple_interaction, 最多3个
+	SubmitButton *CardSubmitButton `json:"submit_button,omitempty"` // 提交按钮, vote_interaction 和 multiple_interaction 必须
+}
+
+type CardSource struct {
+	IconURL   string `json:"icon_url,omitempty"`
+	Desc      string `json:"desc,omitempty"`
+	DescColor int    `json:"desc_color,omitempty"` // 0(默认) 灰色，1 黑色，2 红色，3 绿色
+}
+
+type CardActionMenu struct {
+	Desc       string           `json:"desc,omitempty"`
+	ActionList []CardActionItem `json:"action_list"` // 操作列表，列表长度取值范围为 [1, 3]
+}
+
+type CardActionItem struct {
+	Text string `json:"text"`
+	Key  string `json:"key"`
+}
+
+type CardMainTitle struct {
+	Title string `json:"title,omitempty"` // 一级标题，建议不超过26个字
+	Desc  string `json:"desc,omitempty"`  // 标题辅助信息，建议不超过30个字
+}
+
+type CardQuoteArea struct {
+	Type      int    `json:"type,omitempty"` // CardJumpTypeXXX
+	URL       string `json:"url,omitempty"`
+	AppId     string `json:"appid,omitempty"`
+	PagePath  string `json:"pagepath,omitempty"`
+	Title     string `json:"title,omitempty"`
+	QuoteText string `json:"quote_text,omitempty"`
+}
+
+type CardEmphasisContent struct {
+	Title string `json:"title,omitempty"` // 关键数据样式的数据内容，建议不超过10个字
+	Desc  string `json:"desc,omitempty"`  // 关键数据样式的数据描述内容，建议不超过15个字
+}
+
+type CardImage struct {
+	URL         string  `json:"url"`
+	AspectRatio float64 `json:"aspect_ratio,omitempty"` // 图片的宽高比，宽高比要小于2.25，大于1.3，不填该参数默认1.3
+}
+
+type CardImageTextArea struct {
+	Type     int    `json:"type,omitempty"` // CardJumpTypeXXX
+	URL      string `json:"url,omitempty"`
+	AppId    string `json:"appid,omitempty"`
+	PagePath string `json:"pagepath,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Desc     string `json:"desc,omitempty"`
+	ImageURL string `json:"image_url"`
+}
+
+type CardVerticalContent struct {
+	Title string `json:"title"`
+	Desc  string `json:"desc,omitempty"`
+}
+
+type CardHorizontalContent struct {
+	Type    int    `json:"type,omitempty"` // HorizontalContentTypeXXX
+	KeyName string `json:"keyname"`        // 二级标题，建议不超过5个字
+	Value   string `json:"value,omitempty"`
+	URL     string `json:"url,omitempty"`      // HorizontalContentTypeURL 时必须
+	MediaId string `json:"media_id,omitempty"` // HorizontalContentTypeMedia 时必须
+	UserId  string `json:"userid,omitempty"`   // HorizontalContentTypeUserId 时必须
+}
+
+type CardJump struct {
+	Type     int    `json:"type,omitempty"` // CardJumpTypeXXX
+	Title    string `json:"title"`
+	URL      string `json:"url,omitempty"`
+	AppId    string `json:"appid,omitempty"`
+	PagePath string `json:"pagepath,omitempty"`
+}
+
+type CardAction struct {
+	Type     int    `json:"type"` // CardJumpTypeURL, CardJumpTypeMiniProgram
+	URL      string `json:"url,omitempty"`
+	AppId    string `json:"appid,omitempty"`
+	PagePath string `json:"pagepath,omitempty"`
+}
+
+type CardOption struct {
+	Id        string `json:"id"`
+	Text      string `json:"text"`
+	IsChecked bool   `json:"is_checked,omitempty"` // 仅 checkbox 使用
+}
+
+type CardButtonSelection struct {
+	QuestionKey string       `json:"question_key"`
+	Title       string       `json:"title,omitempty"`
+	OptionList  []CardOption `json:"option_list"`
+	SelectedId  string       `json:"selected_id,omitempty"`
+}
+
+type CardButton struct {
+	Type  int    `json:"type,omitempty"`  // 0 或不填代表回调点击事件，1 代表跳转url
+	Text  string `json:"text"`            // 按钮文案，建议不超过10个字
+	Style int    `json:"style,omitempty"` // 按钮样式，目前可填1~4
+	Key   string `json:"key,omitempty"`   // Type 为 0 时必须
+	URL   string `json:"url,omitempty"`   // Type 为 1 时必须
+}
+
+type CardCheckbox struct {
+	QuestionKey string       `json:"question_key"`
+	OptionList  []CardOption `json:"option_list"`    // 选项list，选项个数不超过 20 个，最少1个
+	Mode        int          `json:"mode,omitempty"` // 选择题模式，单选：0，多选：1，不填默认0
+}
+
+type CardSelect struct {
+	QuestionKey string       `json:"question_key"`
+	Title       string       `json:"title,omitempty"`
+	SelectedId  string       `json:"selected_id,omitempty"`
+	OptionList  []CardOption `json:"option_list"` // 选项列表，下拉选项不超过 10 个，最少1个
+}
+
+type CardSubmitButton struct {
+	Text string `json:"text"`
+	Key  string `json:"key"`
+}
+
+// 检查模板卡片的必填字段和列表长度限制.
+func (card *TemplateCardContent) CheckValid() (err error) {
+	if card.ActionMenu != nil {
+		if n := len(card.ActionMenu.ActionList); n == 0 || n > TemplateCardActionMenuLimit {
+			return fmt.Errorf("the length of action_menu.action_list must be in [1, %d]", TemplateCardActionMenuLimit)
+		}
+		if card.TaskId == "" {
+			return errors.New("task_id is required when action_menu is set")
+		}
+	}
+	if card.QuoteArea != nil {
+		if err = checkCardJump("quote_area", card.QuoteArea.Type, card.QuoteArea.URL, card.QuoteArea.AppId); err != nil {
+			return
+		}
+	}
+	if len(card.HorizontalContent) > TemplateCardHorizontalContentLimit {
+		return fmt.Errorf("the length of horizontal_content_list must be less than or equal to %d", TemplateCardHorizontalContentLimit)
+	}
+	for i := range card.HorizontalContent {
+		item := &card.HorizontalContent[i]
+		if item.KeyName == "" {
+			return fmt.Errorf("horizontal_content_list[%d]: empty keyname", i)
+		}
+		switch item.Type {
+		case HorizontalContentTypeText:
+		case HorizontalContentTypeURL:
+			if item.URL == "" {
+				return fmt.Errorf("horizontal_content_list[%d]: url is required", i)
+			}
+		case HorizontalContentTypeMedia:
+			if item.MediaId == "" {
+				return fmt.Errorf("horizontal_content_list[%d]: media_id is required", i)
+			}
+		case HorizontalContentTypeUserId:
+			if item.UserId == "" {
+				return fmt.Errorf("horizontal_content_list[%d]: userid is required", i)
+			}
+		default:
+			return fmt.Errorf("horizontal_content_list[%d]: invalid type %d", i, item.Type)
+		}
+	}
+	if len(card.JumpList) > TemplateCardJumpLimit {
+		return fmt.Errorf("the length of jump_list must be less than or equal to %d", TemplateCardJumpLimit)
+	}
+	for i := range card.JumpList {
+		item := &card.JumpList[i]
+		if item.Title == "" {
+			return fmt.Errorf("jump_list[%d]: empty title", i)
+		}
+		if err = checkCardJump(fmt.Sprintf("jump_list[%d]", i), item.Type, item.URL, item.AppId); err != nil {
+			return
+		}
+	}
+	if card.CardAction != nil {
+		if card.CardAction.Type == CardJumpTypeNone {
+			return errors.New("card_action: invalid type 0")
+		}
+		if err = checkCardJump("card_action", card.CardAction.Type, card.CardAction.URL, card.CardAction.AppId); err != nil {
+			return
+		}
+	}
+
+	switch card.CardType {
+	case CardTypeTextNotice:
+		if (card.MainTitle == nil || card.MainTitle.Title == "") && card.SubTitleText == "" {
+			return errors.New("text_notice: one of main_title.title and sub_title_text is required")
+		}
+		if card.CardAction == nil {
+			return errors.New("text_notice: card_action is required")
+		}
+	case CardTypeNewsNotice:
+		if card.MainTitle == nil || card.MainTitle.Title == "" {
+			return errors.New("news_notice: main_title.title is required")
+		}
+		if card.CardImage == nil && card.ImageTextArea == nil {
+			return errors.New("news_notice: one of card_image and image_text_area is required")
+		}
+		if card.ImageTextArea != nil {
+			if card.ImageTextArea.ImageURL == "" {
+				return errors.New("news_notice: image_text_area.image_url is required")
+			}
+			if err = checkCardJump("image_text_area", card.ImageTextArea.Type, card.ImageTextArea.URL, card.ImageTextArea.AppId); err != nil {
+				return
+			}
+		}
+		if len(card.VerticalContentList) > TemplateCardVerticalContentLimit {
+			return fmt.Errorf("news_notice: the length of vertical_content_list must be less than or equal to %d", TemplateCardVerticalContentLimit)
+		}
+		if card.CardAction == nil {
+			return errors.New("news_notice: card_action is required")
+		}
+	case CardTypeButtonInteraction:
+		if card.TaskId == "" {
+			return errors.New("button_interaction: task_id is required")
+		}
+		if card.MainTitle == nil || card.MainTitle.Title == "" {
+			return errors.New("button_interaction: main_title.title is required")
+		}
+		if n := len(card.ButtonList); n == 0 || n > TemplateCardButtonLimit {
+			return fmt.Errorf("button_interaction: the length of button_list must be in [1, %d]", TemplateCardButtonLimit)
+		}
+		for i := range card.ButtonList {
+			button := &card.ButtonList[i]
+			if button.Text == "" {
+				return fmt.Errorf("button_list[%d]: empty text", i)
+			}
+			if button.Type == 1 && button.URL == "" {
+				return fmt.Errorf("button_list[%d]: url is required", i)
+			}
+			if button.Type == 0 && button.Key == "" {
+				return fmt.Errorf("button_list[%d]: key is required", i)
+			}
+		}
+		if card.ButtonSelection != nil {
+			if err = checkCardOptions("button_selection", card.ButtonSelection.QuestionKey, card.ButtonSelection.OptionList, TemplateCardSelectOptionLimit); err != nil {
+				return
+			}
+		}
+	case CardTypeVoteInteraction:
+		if card.TaskId == "" {
+			return errors.New("vote_interaction: task_id is required")
+		}
+		if card.Checkbox == nil {
+			return errors.New("vote_interaction: checkbox is required")
+		}
+		if err = checkCardOptions("checkbox", card.Checkbox.QuestionKey, card.Checkbox.OptionList, TemplateCardCheckboxOptionLimit); err != nil {
+			return
+		}
+		if card.SubmitButton == nil || card.SubmitButton.Key == "" {
+			return errors.New("vote_interaction: submit_button.key is required")
+		}
+	case CardTypeMultipleInteraction:
+		if card.TaskId == "" {
+			return errors.New("multiple_interaction: task_id is required")
+		}
+		if n := len(card.SelectList); n == 0 || n > TemplateCardSelectLimit {
+			return fmt.Errorf("multiple_interaction: the length of select_list must be in [1, %d]", TemplateCardSelectLimit)
+		}
+		for i := range card.SelectList {
+			item := &card.SelectList[i]
+			if err = checkCardOptions(fmt.Sprintf("select_list[%d]", i), item.QuestionKey, item.OptionList, TemplateCardSelectOptionLimit); err != nil {
+				return
+			}
+		}
+		if card.SubmitButton == nil || card.SubmitButton.Key == "" {
+			return errors.New("multiple_interaction: submit_button.key is required")
+		}
+	default:
+		return fmt.Errorf("invalid card_type: %q", card.CardType)
+	}
+	return
+}
+
+func checkCardJump(name string, jumpType int, url, appId string) error {
+	switch jumpType {
+	case CardJumpTypeNone:
+	case CardJumpTypeURL:
+		if url == "" {
+			return fmt.Errorf("%s: url is required", name)
+		}
+	case CardJumpTypeMiniProgram:
+		if appId == "" {
+			return fmt.Errorf("%s: appid is required", name)
+		}
+	default:
+		return fmt.Errorf("%s: invalid type %d", name, jumpType)
+	}
+	return nil
+}
+
+func checkCardOptions(name, questionKey string, options []CardOption, limit int) error {
+	if questionKey == "" {
+		return fmt.Errorf("%s: empty question_key", name)
+	}
+	if n := len(options); n == 0 || n > limit {
+		return fmt.Errorf("%s: the length of option_list must be in [1, %d]", name, limit)
+	}
+	for i := range options {
+		if options[i].Id == "" || options[i].Text == "" {
+			return fmt.Errorf("%s: option_list[%d] requires id and text", name, i)
+		}
+	}
+	return nil
+}
+
+func (clt Client) SendTemplateCard(msg *TemplateCard) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	if err = msg.TemplateCard.CheckValid(); err != nil {
+		return
+	}
+	return clt.send(msg)
+}
+
+// =============================================================================
+
+// 更新模板卡片的参数, 用户点击卡片后通过回调的 ResponseCode 更新, 72小时内有效且只能使用一次.
+//  Button 和 TemplateCard 只能设置一个:
+//  Button:       只把按钮更新为不可点击状态, 并修改按钮文案;
+//  TemplateCard: 更新为新的卡片, 卡片类型必须和原来的一致.
+type UpdateTemplateCardParameters struct {
+	UserIds      []string `json:"userids,omitempty"`  // 企业的成员ID列表（最多支持1000个）
+	PartyIds     []int64  `json:"partyids,omitempty"` // 企业的部门ID列表（最多支持100个）
+	TagIds       []int64  `json:"tagids,omitempty"`   // 企业的标签ID列表（最多支持100个）
+	AtAll        int      `json:"atall,omitempty"`    // 更新整个任务接收人员
+	AgentId      int64    `json:"agentid"`
+	ResponseCode string   `json:"response_code"`
+
+	Button       *UpdateTemplateCardButton `json:"button,omitempty"`
+	TemplateCard *TemplateCardContent      `json:"template_card,omitempty"`
+}
+
+type UpdateTemplateCardButton struct {
+	ReplaceName string `json:"replace_name"` // 需要更新的按钮的文案
+}
+
+// 把按钮更新为不可点击状态, 并修改按钮文案为 replaceName.
+func (para *UpdateTemplateCardParameters) SetReplaceName(replaceName string) {
+	para.Button = &UpdateTemplateCardButton{ReplaceName: replaceName}
+	para.TemplateCard = nil
+}
+
+// 更新模板卡片, 返回无效的成员ID列表.
+func (clt Client) UpdateTemplateCard(para *UpdateTemplateCardParameters) (invalidUser []string, err error) {
+	if para == nil {
+		err = errors.New("nil UpdateTemplateCardParameters")
+		return
+	}
+	if para.ResponseCode == "" {
+		err = errors.New("empty response_code")
+		return
+	}
+	if (para.Button == nil) == (para.TemplateCard == nil) {
+		err = errors.New("exactly one of button and template_card must be set")
+		return
+	}
+	if para.TemplateCard != nil {
+		if err = para.TemplateCard.CheckValid(); err != nil {
+			return
+		}
+	}
+
+	var result struct {
+		corp.Error
+		InvalidUser []string `json:"invaliduser"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/message/update_template_card?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	invalidUser = result.InvalidUser
+	return
+}
@@ -0,0 +1,46 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+// 指标收集接口, 和 mp.Metrics 相同, mp.PrometheusMetrics 等实现都可以直接使用.
+//  NOTE: 实现必须是并发安全的.
+type Metrics interface {
+	// 计数器 name 加 1
+	IncCounter(name string, labels map[string]string)
+	// 记录直方图 name 的一个观测值, 时间类的值单位为秒
+	ObserveHistogram(name string, labels map[string]string, value float64)
+}
+
+// 丢弃所有指标的 Metrics
+type NopMetrics struct{}
+
+func (NopMetrics) IncCounter(name string, labels map[string]string)                      {}
+func (NopMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {}
+
+// 支持 exemplar 的 Metrics, Client 优先调用 IncCounterWithExemplar 和 ObserveHistogramWithExemplar,
+// exemplar 里带上这次请求的 request_id, 方便从指标跳转到对应的日志和 trace.
+//  NOTE: request_id 不作为 label, 否则每个请求都会产生一个新的时间序列.
+type ExemplarMetrics interface {
+	Metrics
+
+	// 同 IncCounter, exemplar 为这次观测关联的 label, 比如 {"request_id": "..."}
+	IncCounterWithExemplar(name string, labels, exemplar map[string]string)
+	// 同 ObserveHistogram, exemplar 为这次观测关联的 label, 比如 {"request_id": "..."}
+	ObserveHistogramWithExemplar(name string, labels map[string]string, value float64, exemplar map[string]string)
+}
+
+// 记录一次接口调用的指标, m 实现了 ExemplarMetrics 时带上 request_id.
+func observeCall(m Metrics, requestId string, counterLabels, histogramLabels map[string]string, seconds float64) {
+	em, ok := m.(ExemplarMetrics)
+	if !ok || requestId == "" {
+		m.IncCounter(MetricClientRequestsTotal, counterLabels)
+		m.ObserveHistogram(MetricClientRequestDuration, histogramLabels, seconds)
+		return
+	}
+	exemplar := map[string]string{"request_id": requestId}
+	em.IncCounterWithExemplar(MetricClientRequestsTotal, counterLabels, exemplar)
+	em.ObserveHistogramWithExemplar(MetricClientRequestDuration, histogramLabels, seconds, exemplar)
+}
@@ -96,4 +96,35 @@ type MixedMessage struct {
 	Latitude  float64 `xml:"Latitude"    json:"Latitude"`
 	Longitude float64 `xml:"Longitude"   json:"Longitude"`
 	Precision float64 `xml:"Precision"   json:"Precision"`
+
+	TaskId        string `xml:"TaskId"       json:"TaskId"`
+	CardType      string `xml:"CardType"     json:"CardType"`
+	ResponseCode  string `xml:"ResponseCode" json:"ResponseCode"`
+	SelectedItems []struct {
+		QuestionKey string   `xml:"QuestionKey"        json:"QuestionKey"`
+		OptionIds   []string `xml:"OptionIds>OptionId" json:"OptionIds"`
+	} `xml:"SelectedItems>SelectedItem,omitempty" json:"SelectedItems,omitempty"`
+
+	ChangeType    string `xml:"ChangeType"    json:"ChangeType"`
+	Id            string `xml:"Id"            json:"Id"`
+	NewUserId     string `xml:"NewUserID"     json:"NewUserID"`
+	TagId         int64  `xml:"TagId"         json:"TagId"`
+	AddUserItems  string `xml:"AddUserItems"  json:"AddUserItems"`
+	DelUserItems  string `xml:"DelUserItems"  json:"DelUserItems"`
+	AddPartyItems string `xml:"AddPartyItems" json:"AddPartyItems"`
+	DelPartyItems string `xml:"DelPartyItems" json:"DelPartyItems"`
+
+	UserId         string `xml:"UserID"         json:"UserID"`
+	ExternalUserId string `xml:"ExternalUserID" json:"ExternalUserID"`
+	State          string `xml:"State"          json:"State"`
+	WelcomeCode    string `xml:"WelcomeCode"    json:"WelcomeCode"`
+	Source         string `xml:"Source"         json:"Source"`
+	FailReason     string `xml:"FailReason"     json:"FailReason"`
+	ChatId         string `xml:"ChatId"         json:"ChatId"`
+	UpdateDetail   string `xml:"UpdateDetail"   json:"UpdateDetail"`
+	JoinScene      int    `xml:"JoinScene"      json:"JoinScene"`
+	QuitScene      int    `xml:"QuitScene"      json:"QuitScene"`
+	MemChangeCnt   int    `xml:"MemChangeCnt"   json:"MemChangeCnt"`
+	TagType        string `xml:"TagType"        json:"TagType"`
+	StrategyId     int64  `xml:"StrategyId"     json:"StrategyId"`
 }
@@ -0,0 +1,174 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrDailyQuotaExceeded = errors.New("daily quota exceeded")
+
+// 客户端限流接口, 每次请求微信服务器之前调用(包括重试).
+type RateLimiter interface {
+	// endpoint 为请求的路径, 比如 "/cgi-bin/message/custom/send".
+	// 返回 nil 表示可以发送请求, 否则不发送请求并返回该错误.
+	Wait(endpoint string) error
+}
+
+// 可以取消等待的 RateLimiter, Client 优先调用 WaitContext, ctx 为请求绑定的 context.
+type ContextRateLimiter interface {
+	RateLimiter
+
+	// 同 Wait, 等待期间 ctx 取消时立即返回 ctx.Err().
+	WaitContext(ctx context.Context, endpoint string) error
+}
+
+// 调用 RateLimiter(如果不为 nil), RateLimiter 实现了 ContextRateLimiter 时等待期间可以被 ctx 取消.
+func (clt *Client) waitRateLimiter(ctx context.Context, incompleteURL string) error {
+	if clt.RateLimiter == nil {
+		return nil
+	}
+	endpoint := endpointOf(incompleteURL)
+	if l, ok := clt.RateLimiter.(ContextRateLimiter); ok && ctx != nil {
+		return l.WaitContext(ctx, endpoint)
+	}
+	return clt.RateLimiter.Wait(endpoint)
+}
+
+// 从 incompleteURL 中获取 endpoint, 比如
+//  "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token=" => "/cgi-bin/message/custom/send"
+func endpointOf(incompleteURL string) string {
+	s := incompleteURL
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[i:]
+	} else {
+		return "/"
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// 一个 endpoint 的限流设置.
+type RateLimit struct {
+	Rate  float64 // 每秒允许的请求数, <= 0 表示不限制
+	Burst int     // 允许的突发请求数, <= 0 时为 1
+	Daily int     // 每天允许的请求数(北京时间0点重置), <= 0 表示不限制; 超过后 Wait 返回 ErrDailyQuotaExceeded
+}
+
+var _ ContextRateLimiter = (*TokenBucketLimiter)(nil)
+
+// 按 endpoint 分别限流的令牌桶, 用于单进程环境.
+type TokenBucketLimiter struct {
+	mutex        sync.Mutex
+	defaultLimit RateLimit
+	limits       map[string]RateLimit
+	buckets      map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+
+	day       int // 当前计数的日期, yyyymmdd
+	dailyUsed int
+}
+
+// 创建 TokenBucketLimiter, 没有通过 SetLimit 设置的 endpoint 使用 defaultLimit.
+func NewTokenBucketLimiter(defaultLimit RateLimit) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		defaultLimit: defaultLimit,
+		limits:       make(map[string]RateLimit),
+		buckets:      make(map[string]*tokenBucket),
+	}
+}
+
+// 设置 endpoint 的限流, endpoint 为请求的路径, 比如 "/cgi-bin/message/custom/send".
+func (l *TokenBucketLimiter) SetLimit(endpoint string, limit RateLimit) {
+	l.mutex.Lock()
+	l.limits[endpoint] = limit
+	delete(l.buckets, endpoint)
+	l.mutex.Unlock()
+}
+
+var beijingLocation = time.FixedZone("Asia/Shanghai", 8*60*60)
+
+func (l *TokenBucketLimiter) Wait(endpoint string) error {
+	return l.WaitContext(nil, endpoint)
+}
+
+func (l *TokenBucketLimiter) WaitContext(ctx context.Context, endpoint string) error {
+	for {
+		d, err := l.reserve(endpoint)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return nil
+		}
+		if err = sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// 获取一个令牌, 成功返回 0, 否则返回需要等待的时间.
+func (l *TokenBucketLimiter) reserve(endpoint string) (time.Duration, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	bucket := l.buckets[endpoint]
+	if bucket == nil {
+		limit, ok := l.limits[endpoint]
+		if !ok {
+			limit = l.defaultLimit
+		}
+		if limit.Burst <= 0 {
+			limit.Burst = 1
+		}
+		bucket = &tokenBucket{
+			limit:  limit,
+			tokens: float64(limit.Burst),
+			last:   time.Now(),
+		}
+		l.buckets[endpoint] = bucket
+	}
+
+	now := time.Now()
+	if bucket.limit.Daily > 0 {
+		y, m, d := now.In(beijingLocation).Date()
+		if day := y*10000 + int(m)*100 + d; day != bucket.day {
+			bucket.day = day
+			bucket.dailyUsed = 0
+		}
+		if bucket.dailyUsed >= bucket.limit.Daily {
+			return 0, ErrDailyQuotaExceeded
+		}
+	}
+
+	if bucket.limit.Rate > 0 {
+		bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.limit.Rate
+		if max := float64(bucket.limit.Burst); bucket.tokens > max {
+			bucket.tokens = max
+		}
+		bucket.last = now
+		if bucket.tokens < 1 {
+			return time.Duration((1 - bucket.tokens) / bucket.limit.Rate * float64(time.Second)), nil
+		}
+		bucket.tokens--
+	}
+	bucket.dailyUsed++
+	return 0, nil
+}
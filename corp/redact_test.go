@@ -0,0 +1,48 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestClientDisableRedaction(t *testing.T) {
+	const errMsg = "invalid access_token=SECRET_TOKEN"
+
+	tests := []struct {
+		name             string
+		disableRedaction bool
+		redacted         bool
+	}{
+		{"redacted", false, true},
+		{"disabled", true, false},
+	}
+	for _, tt := range tests {
+		srv := wechattest.NewServer()
+		srv.HandleError("/cgi-bin/test", corp.ErrCodeAccessTokenExpired, errMsg)
+
+		logger := &recordingLogger{}
+		clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+		clt.Logger = logger
+		clt.DisableRedaction = tt.disableRedaction
+		var result corp.Error
+		clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/test?access_token=", &result)
+		srv.Close()
+
+		log := fmt.Sprint(logger.keyvals)
+		if !strings.Contains(log, "err_msg") {
+			t.Fatalf("%s: no err_msg in log: %s", tt.name, log)
+		}
+		if have := !strings.Contains(log, "SECRET_TOKEN"); have != tt.redacted {
+			t.Errorf("%s: redacted: have %v, want %v, log: %s", tt.name, have, tt.redacted, log)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// 包装 Client 请求过程中出现的错误(网络错误, http 状态码错误, 解析错误等), 带上这次请求的 RequestId.
+//  NOTE: 微信服务器返回的 errcode 错误(*Error)不会被包装.
+type RequestError struct {
+	RequestId string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return "request_id: " + e.RequestId + ", " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// 用 RequestError 包装 err, err 为 nil 或者 *Error 时原样返回.
+func wrapRequestError(requestId string, err error) error {
+	switch err.(type) {
+	case nil, *Error:
+		return err
+	}
+	return &RequestError{RequestId: requestId, Err: err}
+}
+
+var requestIdSeq uint64
+
+// 生成一个新的请求 id, 格式为 16 个十六进制字符的随机数.
+func NewRequestId() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// 几乎不可能发生, 用时间戳和序号保证唯一
+		return strconv.FormatInt(time.Now().UnixNano(), 16) + "-" +
+			strconv.FormatUint(atomic.AddUint64(&requestIdSeq, 1), 16)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// 返回一个 Client 的浅拷贝, 之后通过它发起的请求都使用 requestId, 比如使用上游 http 请求的 X-Request-Id,
+// 这样微信接口的日志可以和业务日志关联起来.
+//  NOTE: 一个 requestId 可能对应多次请求(比如 access_token 过期后重试).
+func (clt *Client) WithRequestId(requestId string) *Client {
+	clt2 := *clt
+	clt2.RequestId = requestId
+	return &clt2
+}
+
+// 获取这次请求的 request id.
+func (clt *Client) requestId() string {
+	if clt.RequestId != "" {
+		return clt.RequestId
+	}
+	if clt.RequestIdGenerator != nil {
+		if id := clt.RequestIdGenerator(); id != "" {
+			return id
+		}
+	}
+	return NewRequestId()
+}
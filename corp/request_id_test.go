@@ -0,0 +1,128 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	attrs []map[string]string
+}
+
+func (t *recordingTracer) StartSpan(name string, attrs map[string]string) corp.Span {
+	t.mu.Lock()
+	t.attrs = append(t.attrs, attrs)
+	t.mu.Unlock()
+	return nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(errCode int, err error) {}
+
+type exemplarMetrics struct {
+	recordingMetrics
+	exemplars []map[string]string
+}
+
+func (m *exemplarMetrics) IncCounterWithExemplar(name string, labels, exemplar map[string]string) {
+	m.IncCounter(name, labels)
+	m.mu.Lock()
+	m.exemplars = append(m.exemplars, exemplar)
+	m.mu.Unlock()
+}
+
+func (m *exemplarMetrics) ObserveHistogramWithExemplar(name string, labels map[string]string, value float64, exemplar map[string]string) {
+	m.mu.Lock()
+	m.exemplars = append(m.exemplars, exemplar)
+	m.mu.Unlock()
+}
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	keyvals [][]interface{}
+}
+
+func (l *recordingLogger) log(keyvals []interface{}) {
+	l.mu.Lock()
+	l.keyvals = append(l.keyvals, keyvals)
+	l.mu.Unlock()
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) { l.log(keyvals) }
+func (l *recordingLogger) Info(msg string, keyvals ...interface{})  { l.log(keyvals) }
+func (l *recordingLogger) Warn(msg string, keyvals ...interface{})  { l.log(keyvals) }
+func (l *recordingLogger) Error(msg string, keyvals ...interface{}) { l.log(keyvals) }
+
+func TestClientRequestId(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("/cgi-bin/ok", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") == "token" {
+			w.Write(wechattest.ErrorJSON(corp.ErrCodeAccessTokenExpired, "access_token expired"))
+			return
+		}
+		w.Write(wechattest.ErrorJSON(corp.ErrCodeOK, "ok"))
+	})
+	srv.HandleFunc("/cgi-bin/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	tracer := &recordingTracer{}
+	metrics := &exemplarMetrics{}
+	logger := &recordingLogger{}
+	clt := corp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.Tracer = tracer
+	clt.Metrics = metrics
+	clt.Logger = logger
+	clt = clt.WithRequestId("req-1")
+
+	var result corp.Error
+	if err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/ok?access_token=", &result); err != nil {
+		t.Fatal(err)
+	}
+	err := clt.GetJSON("https://qyapi.weixin.qq.com/cgi-bin/broken?access_token=", &result)
+	var reqErr *corp.RequestError
+	if !errors.As(err, &reqErr) || reqErr.RequestId != "req-1" {
+		t.Errorf("err: have %#v, want *corp.RequestError with RequestId req-1", err)
+	}
+
+	if len(tracer.attrs) != 2 {
+		t.Fatalf("spans: have %d, want 2", len(tracer.attrs))
+	}
+	for _, attrs := range tracer.attrs {
+		if attrs["wechat.request_id"] != "req-1" {
+			t.Errorf("span attrs: have %v, want wechat.request_id=req-1", attrs)
+		}
+	}
+	if len(metrics.exemplars) == 0 {
+		t.Error("no exemplars recorded")
+	}
+	for _, exemplar := range metrics.exemplars {
+		if exemplar["request_id"] != "req-1" {
+			t.Errorf("exemplar: have %v, want request_id=req-1", exemplar)
+		}
+	}
+	if n := metrics.count(corp.MetricClientRequestsTotal, "request_id", "req-1"); n != 0 {
+		t.Errorf("request_id must not be a metric label, found %d", n)
+	}
+	if len(logger.keyvals) == 0 {
+		t.Fatal("no logs recorded")
+	}
+	for _, keyvals := range logger.keyvals {
+		if len(keyvals) < 2 || keyvals[0] != "request_id" || keyvals[1] != "req-1" {
+			t.Errorf("log keyvals: have %v, want request_id=req-1 first", keyvals)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 家校沟通, 包括给家长和学生发送学校通知, 以及家校通讯录变更的回调事件.
+//  NOTE: 学校通知的接收者是家长和学生, 不是企业成员, 企业成员请使用 message/send 包.
+package school
@@ -0,0 +1,51 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package school
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 家校通讯录变更的事件类型
+const EventTypeChangeSchoolContact = "change_school_contact"
+
+// 家校通讯录变更的 ChangeType
+const (
+	ChangeTypeCreateStudent    = "create_student"
+	ChangeTypeUpdateStudent    = "update_student"
+	ChangeTypeDeleteStudent    = "delete_student"
+	ChangeTypeCreateParent     = "create_parent"
+	ChangeTypeUpdateParent     = "update_parent"
+	ChangeTypeDeleteParent     = "delete_parent"
+	ChangeTypeSubscribe        = "subscribe"   // 家长关注家校沟通应用
+	ChangeTypeUnsubscribe      = "unsubscribe" // 家长取消关注家校沟通应用
+	ChangeTypeCreateDepartment = "create_department"
+	ChangeTypeUpdateDepartment = "update_department"
+	ChangeTypeDeleteDepartment = "delete_department"
+)
+
+// 家校通讯录变更事件, 一般这样注册:
+//  mux.EventHandleFunc(school.EventTypeChangeSchoolContact, func(w http.ResponseWriter, r *corp.Request) {
+//      event := school.GetChangeSchoolContactEvent(r.MixedMsg)
+//      ...
+//  })
+type ChangeSchoolContactEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event      string `xml:"Event"      json:"Event"`      // 事件类型, change_school_contact
+	ChangeType string `xml:"ChangeType" json:"ChangeType"` // ChangeTypeCreateStudent...
+	Id         string `xml:"Id"         json:"Id"`         // 学生或者家长的 userid, 或者部门 id
+}
+
+func GetChangeSchoolContactEvent(msg *corp.MixedMessage) *ChangeSchoolContactEvent {
+	return &ChangeSchoolContactEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		ChangeType:    msg.ChangeType,
+		Id:            msg.Id,
+	}
+}
@@ -0,0 +1,245 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package school
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	MsgTypeText        = "text"
+	MsgTypeImage       = "image"
+	MsgTypeVoice       = "voice"
+	MsgTypeVideo       = "video"
+	MsgTypeFile        = "file"
+	MsgTypeNews        = "news"
+	MsgTypeMPNews      = "mpnews"
+	MsgTypeMiniProgram = "miniprogram"
+)
+
+// 学校通知的接收范围
+const (
+	RecvScopeParent           = 0 // 家长
+	RecvScopeStudent          = 1 // 学生
+	RecvScopeParentAndStudent = 2 // 家长和学生
+)
+
+type MessageHeader struct {
+	RecvScope       int      `json:"recv_scope"`                  // RecvScopeParent...
+	ToParentUserId  []string `json:"to_parent_userid,omitempty"`  // 家长的 userid 列表, 最多 1000 个
+	ToStudentUserId []string `json:"to_student_userid,omitempty"` // 学生的 userid 列表, 最多 1000 个
+	ToParty         []string `json:"to_party,omitempty"`          // 家校通讯录的部门 id 列表, 最多 100 个
+	ToAll           int      `json:"toall,omitempty"`             // 1 表示发送给所有的家长(或学生), 这时忽略上面三个参数
+
+	// 家长的 external_userid 列表, 最多 1000 个; 用于家长是企业的外部联系人(旧版家校沟通)的场景.
+	ToExternalUser []string `json:"to_external_user,omitempty"`
+
+	// 是否允许家长选择接收通知的孩子(一个家长关联多个学生时).
+	AllowSelect bool `json:"allow_select,omitempty"`
+
+	MsgType string `json:"msgtype"` // 必须; 消息类型
+	AgentId int64  `json:"agentid"` // 必须; 家校沟通应用的 agentid
+
+	EnableIdTrans          int `json:"enable_id_trans,omitempty"`          // 是否开启 id 转译, 0 表示否, 1 表示是
+	EnableDuplicateCheck   int `json:"enable_duplicate_check,omitempty"`   // 是否开启重复消息检查, 0 表示否, 1 表示是
+	DuplicateCheckInterval int `json:"duplicate_check_interval,omitempty"` // 重复消息检查的时间间隔, 单位为秒, 默认 1800, 最大不超过 4 小时
+}
+
+type Text struct {
+	MessageHeader
+
+	Text struct {
+		Content string `json:"content"` // 消息内容, 最长不超过 2048 个字节
+	} `json:"text"`
+}
+
+type Image struct {
+	MessageHeader
+
+	Image struct {
+		MediaId string `json:"media_id"`
+	} `json:"image"`
+}
+
+type Voice struct {
+	MessageHeader
+
+	Voice struct {
+		MediaId string `json:"media_id"`
+	} `json:"voice"`
+}
+
+type Video struct {
+	MessageHeader
+
+	Video struct {
+		MediaId     string `json:"media_id"`
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+	} `json:"video"`
+}
+
+type File struct {
+	MessageHeader
+
+	File struct {
+		MediaId string `json:"media_id"`
+	} `json:"file"`
+}
+
+type NewsArticle struct {
+	Title       string `json:"title"`                 // 标题, 不超过 128 个字节
+	Description string `json:"description,omitempty"` // 描述, 不超过 512 个字节
+	URL         string `json:"url,omitempty"`         // 点击后跳转的链接
+	PicURL      string `json:"picurl,omitempty"`      // 图片链接, 较好的效果为大图 1068*455, 小图 150*150
+}
+
+type News struct {
+	MessageHeader
+
+	News struct {
+		Articles []NewsArticle `json:"articles"` // 1 到 8 条图文
+	} `json:"news"`
+}
+
+type MPNewsArticle struct {
+	Title            string `json:"title"`
+	ThumbMediaId     string `json:"thumb_media_id"`
+	Author           string `json:"author,omitempty"`
+	ContentSourceURL string `json:"content_source_url,omitempty"`
+	Content          string `json:"content"`
+	Digest           string `json:"digest,omitempty"`
+}
+
+type MPNews struct {
+	MessageHeader
+
+	MPNews struct {
+		Articles []MPNewsArticle `json:"articles"` // 1 到 8 条图文
+	} `json:"mpnews"`
+}
+
+// 小程序通知消息
+type MiniProgram struct {
+	MessageHeader
+
+	MiniProgram struct {
+		AppId        string `json:"appid"`          // 小程序 appid, 必须是关联到企业的小程序应用
+		Title        string `json:"title"`          // 小程序消息的标题, 不超过 64 个字节
+		ThumbMediaId string `json:"thumb_media_id"` // 小程序消息的封面图片, 建议 520*416
+		PagePath     string `json:"pagepath"`       // 点击消息卡片后进入的小程序页面路径
+	} `json:"miniprogram"`
+}
+
+const ArticleCountLimit = 8
+
+// 发送学校通知返回的无效的接收者.
+//  NOTE: 部分接收者无效时 errcode 仍然为 0, 需要检查这里的列表.
+type Result struct {
+	InvalidParentUserId  []string `json:"invalid_parent_userid"`
+	InvalidStudentUserId []string `json:"invalid_student_userid"`
+	InvalidParty         []string `json:"invalid_party"`
+	InvalidExternalUser  []string `json:"invalid_external_user"`
+}
+
+// 是否所有的接收者都有效.
+func (r *Result) AllValid() bool {
+	return len(r.InvalidParentUserId) == 0 && len(r.InvalidStudentUserId) == 0 &&
+		len(r.InvalidParty) == 0 && len(r.InvalidExternalUser) == 0
+}
+
+func (clt Client) SendText(msg *Text) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendImage(msg *Image) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendVoice(msg *Voice) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendVideo(msg *Video) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendFile(msg *File) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendNews(msg *News) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	if n := len(msg.News.Articles); n == 0 || n > ArticleCountLimit {
+		err = errors.New("the number of articles must be between 1 and 8")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendMPNews(msg *MPNews) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	if n := len(msg.MPNews.Articles); n == 0 || n > ArticleCountLimit {
+		err = errors.New("the number of articles must be between 1 and 8")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) SendMiniProgram(msg *MiniProgram) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt Client) send(msg interface{}) (r *Result, err error) {
+	var result struct {
+		corp.Error
+		Result
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/message/send?access_token="
+	if err = clt.PostJSON(incompleteURL, msg, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	r = &result.Result
+	return
+}
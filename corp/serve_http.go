@@ -17,7 +17,7 @@ import (
 	"net/url"
 	"strconv"
 
-	"github.com/chanxuehong/wechat/util"
+	"github.com/chanxuehong/wechat/cipher"
 )
 
 // 微信服务器请求 http body
@@ -101,7 +101,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 		agentToken := srv.Token()
 
 		// 验证签名
-		msgSignature2 := util.MsgSign(agentToken, timestampStr, nonce, requestHttpBody.EncryptedMsg)
+		msgSignature2 := cipher.MsgSign(agentToken, timestampStr, nonce, requestHttpBody.EncryptedMsg)
 		if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 			err = fmt.Errorf("check msg_signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 			irh.ServeInvalidRequest(w, r, err)
@@ -116,7 +116,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 		}
 
 		aesKey := srv.CurrentAESKey()
-		random, rawMsgXML, err := util.AESDecryptMsg(encryptedMsgBytes, corpId, aesKey)
+		random, rawMsgXML, err := cipher.Decrypt(encryptedMsgBytes, corpId, aesKey)
 		if err != nil {
 			// 尝试用上一次的 AESKey 来解密
 			lastAESKey, isLastAESKeyValid := srv.LastAESKey()
@@ -127,7 +127,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 			aesKey = lastAESKey // NOTE
 
-			random, rawMsgXML, err = util.AESDecryptMsg(encryptedMsgBytes, corpId, aesKey)
+			random, rawMsgXML, err = cipher.Decrypt(encryptedMsgBytes, corpId, aesKey)
 			if err != nil {
 				irh.ServeInvalidRequest(w, r, err)
 				return
@@ -216,7 +216,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			return
 		}
 
-		msgSignature2 := util.MsgSign(srv.Token(), timestamp, nonce, encryptedMsg)
+		msgSignature2 := cipher.MsgSign(srv.Token(), timestamp, nonce, encryptedMsg)
 		if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 			err := fmt.Errorf("check msg_signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 			irh.ServeInvalidRequest(w, r, err)
@@ -232,7 +232,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 		corpId := srv.CorpId()
 		aesKey := srv.CurrentAESKey()
-		_, echostr, err := util.AESDecryptMsg(encryptedMsgBytes, corpId, aesKey)
+		_, echostr, err := cipher.Decrypt(encryptedMsgBytes, corpId, aesKey)
 		if err != nil {
 			irh.ServeInvalidRequest(w, r, err)
 			return
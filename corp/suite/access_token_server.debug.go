@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // suite_access_token 中控服务器接口.
@@ -49,6 +50,7 @@ var _ AccessTokenServer = (*DefaultAccessTokenServer)(nil)
 type DefaultAccessTokenServer struct {
 	suiteId      string
 	suiteSecret  string
+	secret       util.SecretProvider // 不为 nil 时替代 suiteSecret, 见 NewDefaultAccessTokenServerWithSecretProvider
 	ticketGetter TicketGetter
 	httpClient   *http.Client
 
@@ -96,6 +98,36 @@ func NewDefaultAccessTokenServer(suiteId, suiteSecret string, ticketGetter Ticke
 	return
 }
 
+// 创建一个新的 DefaultAccessTokenServer, 每次从微信服务器获取 suite_access_token 时从 secret 获取 SuiteSecret.
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewDefaultAccessTokenServerWithSecretProvider(suiteId string, secret util.SecretProvider, ticketGetter TicketGetter,
+	httpClient *http.Client) (srv *DefaultAccessTokenServer) {
+
+	if suiteId == "" {
+		panic("empty suiteId")
+	}
+	if secret == nil {
+		panic("nil SecretProvider")
+	}
+	if ticketGetter == nil {
+		panic("nil ticketGetter")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	srv = &DefaultAccessTokenServer{
+		suiteId:         suiteId,
+		secret:          secret,
+		ticketGetter:    ticketGetter,
+		httpClient:      httpClient,
+		resetTickerChan: make(chan time.Duration),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
 func (srv *DefaultAccessTokenServer) TagBD6F157DFE9811E48A29A4DB30FED8E1() {}
 
 func (srv *DefaultAccessTokenServer) Token() (token string, err error) {
@@ -180,13 +212,23 @@ func (srv *DefaultAccessTokenServer) getToken() (token suiteAccessTokenInfo, cac
 		return
 	}
 
+	suiteSecret := srv.suiteSecret
+	if srv.secret != nil {
+		if suiteSecret, err = srv.secret.Secret(); err != nil {
+			srv.tokenCache.Lock()
+			srv.tokenCache.Token = ""
+			srv.tokenCache.Unlock()
+			return
+		}
+	}
+
 	request := struct {
 		SuiteId     string `json:"suite_id"`
 		SuiteSecret string `json:"suite_secret"`
 		SuiteTicket string `json:"suite_ticket"`
 	}{
 		SuiteId:     srv.suiteId,
-		SuiteSecret: srv.suiteSecret,
+		SuiteSecret: suiteSecret,
 		SuiteTicket: suiteTicket,
 	}
 
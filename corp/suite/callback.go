@@ -0,0 +1,99 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 创建一个处理 suite_ticket 推送的 MessageHandler, 收到的 SuiteTicket 会保存到 store,
+// 这样 DefaultAccessTokenServer 就能通过同一个 store 获取到最新的 SuiteTicket.
+//  NOTE: 保存失败时记录日志并返回 500 状态码, 微信服务器会稍后重新推送.
+func SuiteTicketHandler(store TicketStore) MessageHandler {
+	if store == nil {
+		panic("nil TicketStore")
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		msg := GetSuiteTicketMessage(r.MixedMsg)
+		writeCallbackResponse(w, r, store.SetSuiteTicket(msg.SuiteId, msg.SuiteTicket))
+	})
+}
+
+// 创建一个处理套件回调(授权事件)的 SuiteMessageServeMux, 已经注册了处理 suite_ticket 的 SuiteTicketHandler,
+// 其他授权事件可以通过 OnCreateAuth, OnChangeAuth, OnCancelAuth 注册.
+func NewCallbackServeMux(store TicketStore) *SuiteMessageServeMux {
+	mux := NewSuiteMessageServeMux()
+	mux.MessageHandle(SuiteMsgTypeSuiteTicket, SuiteTicketHandler(store))
+	return mux
+}
+
+// 注册推送 suite_ticket 的处理函数, handler 返回后会回复 "success".
+//  NOTE: 会覆盖 NewCallbackServeMux 注册的 SuiteTicketHandler.
+func (mux *SuiteMessageServeMux) OnSuiteTicket(handler func(*Request, *SuiteTicketMessage) error) {
+	mux.MessageHandleFunc(SuiteMsgTypeSuiteTicket, func(w http.ResponseWriter, r *Request) {
+		writeCallbackResponse(w, r, handler(r, GetSuiteTicketMessage(r.MixedMsg)))
+	})
+}
+
+// 注册授权成功通知的处理函数, handler 返回后会回复 "success".
+// 一般在 handler 里用 AuthCode 调用 Client.GetPermanentCode 获取并保存企业的永久授权码.
+func (mux *SuiteMessageServeMux) OnCreateAuth(handler func(*Request, *CreateAuthMessage) error) {
+	mux.MessageHandleFunc(SuiteMsgTypeCreateAuth, func(w http.ResponseWriter, r *Request) {
+		writeCallbackResponse(w, r, handler(r, GetCreateAuthMessage(r.MixedMsg)))
+	})
+}
+
+// 注册变更授权通知的处理函数, handler 返回后会回复 "success".
+func (mux *SuiteMessageServeMux) OnChangeAuth(handler func(*Request, *ChangeAuthMessage) error) {
+	mux.MessageHandleFunc(SuiteMsgTypeChangeAuth, func(w http.ResponseWriter, r *Request) {
+		writeCallbackResponse(w, r, handler(r, GetChangeAuthMessage(r.MixedMsg)))
+	})
+}
+
+// 注册取消授权通知的处理函数, handler 返回后会回复 "success".
+func (mux *SuiteMessageServeMux) OnCancelAuth(handler func(*Request, *CancelAuthMessage) error) {
+	mux.MessageHandleFunc(SuiteMsgTypeCancelAuth, func(w http.ResponseWriter, r *Request) {
+		writeCallbackResponse(w, r, handler(r, GetCancelAuthMessage(r.MixedMsg)))
+	})
+}
+
+// 注册通讯录变更通知的处理函数, handler 返回后会回复 "success".
+func (mux *SuiteMessageServeMux) OnChangeContact(handler func(*Request, *ChangeContactMessage) error) {
+	mux.MessageHandleFunc(SuiteMsgTypeChangeContact, func(w http.ResponseWriter, r *Request) {
+		writeCallbackResponse(w, r, handler(r, GetChangeContactMessage(r.MixedMsg)))
+	})
+}
+
+// 和 OnChangeContact 一样, 但是调用 handler 之前先用 UpdateOpenUserIdStore 更新 store 里成员的 UserId 和 OpenUserId 的映射.
+//  handler 可以为 nil, 这时只更新映射.
+func (mux *SuiteMessageServeMux) OnChangeContactWithStore(store OpenUserIdStore, handler func(*Request, *ChangeContactMessage) error) {
+	if store == nil {
+		panic("nil OpenUserIdStore")
+	}
+	mux.OnChangeContact(func(r *Request, msg *ChangeContactMessage) error {
+		if err := UpdateOpenUserIdStore(store, msg); err != nil {
+			return err
+		}
+		if handler == nil {
+			return nil
+		}
+		return handler(r, msg)
+	})
+}
+
+// err == nil 时回复 "success", 否则记录日志并返回 500 状态码让微信服务器重新推送.
+//  NOTE: err 只记录在本地日志里, 不会回复给微信服务器.
+func writeCallbackResponse(w http.ResponseWriter, r *Request, err error) {
+	if err != nil {
+		corp.LogInfoln("[WECHAT_CALLBACK] suite_id:", r.MixedMsg.SuiteId, ", info_type:", r.MixedMsg.InfoType, ", err:", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	io.WriteString(w, "success")
+}
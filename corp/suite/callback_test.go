@@ -0,0 +1,44 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+func TestWriteCallbackResponse(t *testing.T) {
+	var logs []string
+	corp.SetLogInfoln(func(v ...interface{}) { logs = append(logs, fmt.Sprint(v...)) })
+	defer corp.SetLogInfoln(nil)
+
+	r := &Request{MixedMsg: &MixedMessage{SuiteId: "suite", InfoType: SuiteMsgTypeCreateAuth}}
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantBody string
+	}{
+		{"ok", nil, http.StatusOK, "success"},
+		{"failed", errors.New("db password wrong"), http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError) + "\n"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		writeCallbackResponse(w, r, tt.err)
+		if w.Code != tt.wantCode || w.Body.String() != tt.wantBody {
+			t.Errorf("%s: have %d %q, want %d %q", tt.name, w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+		}
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "db password wrong") {
+		t.Errorf("want the error in the log, have %q", logs)
+	}
+}
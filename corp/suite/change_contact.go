@@ -0,0 +1,126 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// 通讯录变更通知的 ChangeType
+	ChangeTypeCreateUser  = "create_user"  // 新增成员
+	ChangeTypeUpdateUser  = "update_user"  // 更新成员
+	ChangeTypeDeleteUser  = "delete_user"  // 删除成员
+	ChangeTypeCreateParty = "create_party" // 新增部门
+	ChangeTypeUpdateParty = "update_party" // 更新部门
+	ChangeTypeDeleteParty = "delete_party" // 删除部门
+	ChangeTypeUpdateTag   = "update_tag"   // 标签成员变更
+)
+
+// 授权企业的通讯录变更通知.
+//  NOTE: 第三方应用收到的 UserId 是加密过的, 和企业自建应用里的 userid 不同;
+//  OpenUserId 是成员在服务商范围内的唯一标识, 同一个服务商的不同应用取到的 OpenUserId 相同.
+type ChangeContactMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	SuiteId   string `xml:"SuiteId"   json:"SuiteId"`
+	InfoType  string `xml:"InfoType"  json:"InfoType"`
+	Timestamp int64  `xml:"TimeStamp" json:"TimeStamp"`
+
+	AuthCorpId string `xml:"AuthCorpId" json:"AuthCorpId"`
+	ChangeType string `xml:"ChangeType" json:"ChangeType"`
+
+	// 成员变更
+	UserId         string `xml:"UserID"         json:"UserID"`
+	OpenUserId     string `xml:"OpenUserID"     json:"OpenUserID"`
+	NewUserId      string `xml:"NewUserID"      json:"NewUserID"` // update_user 时成员的 UserId 有变更才有值
+	Name           string `xml:"Name"           json:"Name"`
+	Department     string `xml:"Department"     json:"Department"` // 成员部门列表, 逗号分隔
+	MainDepartment int64  `xml:"MainDepartment" json:"MainDepartment"`
+	IsLeaderInDept string `xml:"IsLeaderInDept" json:"IsLeaderInDept"` // 和 Department 一一对应, 1 表示是部门负责人
+	Position       string `xml:"Position"       json:"Position"`
+	Mobile         string `xml:"Mobile"         json:"Mobile"`
+	Gender         int    `xml:"Gender"         json:"Gender"`
+	Email          string `xml:"Email"          json:"Email"`
+	Status         int    `xml:"Status"         json:"Status"`
+	Avatar         string `xml:"Avatar"         json:"Avatar"`
+	Alias          string `xml:"Alias"          json:"Alias"`
+	Telephone      string `xml:"Telephone"      json:"Telephone"`
+
+	// 部门变更
+	Id       int64 `xml:"Id"       json:"Id"`
+	ParentId int64 `xml:"ParentId" json:"ParentId"`
+	Order    int64 `xml:"Order"    json:"Order"`
+
+	// 标签成员变更
+	TagId         int64  `xml:"TagId"         json:"TagId"`
+	AddUserItems  string `xml:"AddUserItems"  json:"AddUserItems"` // 逗号分隔
+	DelUserItems  string `xml:"DelUserItems"  json:"DelUserItems"`
+	AddPartyItems string `xml:"AddPartyItems" json:"AddPartyItems"`
+	DelPartyItems string `xml:"DelPartyItems" json:"DelPartyItems"`
+}
+
+func GetChangeContactMessage(msg *MixedMessage) *ChangeContactMessage {
+	return &ChangeContactMessage{
+		SuiteId:        msg.SuiteId,
+		InfoType:       msg.InfoType,
+		Timestamp:      msg.Timestamp,
+		AuthCorpId:     msg.AuthCorpId,
+		ChangeType:     msg.ChangeType,
+		UserId:         msg.UserId,
+		OpenUserId:     msg.OpenUserId,
+		NewUserId:      msg.NewUserId,
+		Name:           msg.Name,
+		Department:     msg.Department,
+		MainDepartment: msg.MainDepartment,
+		IsLeaderInDept: msg.IsLeaderInDept,
+		Position:       msg.Position,
+		Mobile:         msg.Mobile,
+		Gender:         msg.Gender,
+		Email:          msg.Email,
+		Status:         msg.Status,
+		Avatar:         msg.Avatar,
+		Alias:          msg.Alias,
+		Telephone:      msg.Telephone,
+		Id:             msg.Id,
+		ParentId:       msg.ParentId,
+		Order:          msg.Order,
+		TagId:          msg.TagId,
+		AddUserItems:   msg.AddUserItems,
+		DelUserItems:   msg.DelUserItems,
+		AddPartyItems:  msg.AddPartyItems,
+		DelPartyItems:  msg.DelPartyItems,
+	}
+}
+
+// 成员在镜像通讯录里的主键, 有 OpenUserId 时用 OpenUserId, 否则用 UserId.
+//  NOTE: UserId 可能被企业修改(update_user 的 NewUserId), OpenUserId 不会变, 所以优先用 OpenUserId.
+func (msg *ChangeContactMessage) UserKey() string {
+	if msg.OpenUserId != "" {
+		return msg.OpenUserId
+	}
+	return msg.UserId
+}
+
+// 成员所在的部门 id 列表, 解析 Department.
+func (msg *ChangeContactMessage) DepartmentIds() (ids []int64, err error) {
+	return splitIds(msg.Department)
+}
+
+func splitIds(s string) (ids []int64, err error) {
+	if s == "" {
+		return
+	}
+	for _, str := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return
+}
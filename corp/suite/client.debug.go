@@ -17,12 +17,22 @@ import (
 	"reflect"
 
 	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 type Client struct {
 	SuiteId string
 	AccessTokenServer
 	HttpClient *http.Client
+
+	BaseURL string // 可以为空; 不为空时替换所有接口 url 开头的 corp.DefaultBaseURL, 见 corp.ReplaceBaseURL
+
+	// 同 corp.Client.RequestId 和 corp.Client.RequestIdGenerator, request id 会带在日志和 corp.RequestError 里.
+	RequestId          string
+	RequestIdGenerator func() string
+
+	// 为 true 时通过 corp.DefaultLogger 打印每个请求和应答(suite_access_token 等敏感参数被隐藏), 见 corp.Client.DebugMode.
+	DebugMode bool
 }
 
 // 创建一个新的 Client.
@@ -57,6 +67,11 @@ func NewClient(SuiteId string, AccessTokenServer AccessTokenServer, HttpClient *
 //          ...
 //      }
 func (clt *Client) PostJSON(incompleteURL string, request interface{}, response interface{}) (err error) {
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+
 	buf := textBufferPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer textBufferPool.Put(buf)
@@ -73,13 +88,14 @@ func (clt *Client) PostJSON(incompleteURL string, request interface{}, response
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := corp.ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	corp.LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
-	corp.LogInfoln("[WECHAT_DEBUG] request json:", string(requestBytes))
+	corp.LogInfoln("[WECHAT_DEBUG] request_id:", requestId, ", request url:", finalURL)
+	corp.LogInfoln("[WECHAT_DEBUG] request_id:", requestId, ", request json:", string(requestBytes))
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	httpResp, err := clt.httpClient().Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
 	if err != nil {
+		err = util.RedactError(err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -92,7 +108,7 @@ RETRY:
 	if err != nil {
 		return
 	}
-	corp.LogInfoln("[WECHAT_DEBUG] response json:", string(respBody))
+	corp.LogInfoln("[WECHAT_DEBUG] request_id:", requestId, ", response json:", string(respBody))
 
 	if err = json.Unmarshal(respBody, response); err != nil {
 		return
@@ -113,8 +129,8 @@ RETRY:
 		return
 	case corp.ErrCodeSuiteAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		corp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		corp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -122,12 +138,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			corp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", util.MaskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		corp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
 		return
@@ -145,6 +161,11 @@ RETRY:
 //          ...
 //      }
 func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err error) {
+	requestId := clt.requestId()
+	defer func() {
+		err = wrapRequestError(requestId, err)
+	}()
+
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -152,10 +173,11 @@ func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err erro
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := corp.ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.httpClient().Get(finalURL)
 	if err != nil {
+		err = util.RedactError(err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -168,8 +190,8 @@ RETRY:
 	if err != nil {
 		return
 	}
-	corp.LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
-	corp.LogInfoln("[WECHAT_DEBUG] response json:", string(respBody))
+	corp.LogInfoln("[WECHAT_DEBUG] request_id:", requestId, ", request url:", finalURL)
+	corp.LogInfoln("[WECHAT_DEBUG] request_id:", requestId, ", response json:", string(respBody))
 
 	if err = json.Unmarshal(respBody, response); err != nil {
 		return
@@ -190,8 +212,8 @@ RETRY:
 		return
 	case corp.ErrCodeSuiteAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		corp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		corp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -199,12 +221,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			corp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", util.MaskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		corp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		corp.LogInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
 		return
@@ -0,0 +1,53 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 调用本包没有封装的第三方应用接口, 和封装好的接口一样处理 suite_access_token 的填入和刷新.
+//  path 为接口的路径, 比如 "/cgi-bin/service/get_admin_list"; query 为 suite_access_token 以外的查询参数, 可以为 nil.
+//  method 为 "GET" 或者 "POST", POST 时把 request 编码为 json 作为 http body(request 为 nil 时为 {}), GET 时 request 必须为 nil.
+//  errcode 不为 0 时返回 *corp.Error, 否则把应答的 json 解码到 response, response 为 nil 时忽略应答.
+//
+//  NOTE: 需要授权企业 access_token 的接口请用 CorpClientFactory.Client 返回的 *corp.Client 的 Call.
+func (clt *Client) Call(method, path string, query url.Values, request interface{}, response interface{}) (err error) {
+	incompleteURL, err := corp.CallURL(path, query, "suite_access_token")
+	if err != nil {
+		return
+	}
+
+	var result corp.RawResponse
+	switch strings.ToUpper(method) {
+	case "GET":
+		if request != nil {
+			return errors.New("GET request must not have a body")
+		}
+		err = clt.GetJSON(incompleteURL, &result)
+	case "POST":
+		if request == nil {
+			request = struct{}{}
+		}
+		err = clt.PostJSON(incompleteURL, request, &result)
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+	if err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	return result.Unmarshal(response)
+}
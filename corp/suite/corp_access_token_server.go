@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 var _ corp.AccessTokenServer = (*CorpAccessTokenServer)(nil)
@@ -26,8 +27,10 @@ type CorpAccessTokenServer struct {
 	client        Client
 	authCorpId    string
 	permanentCode string
+	codeProvider  util.SecretProvider // 不为 nil 时替代 permanentCode, 见 NewCorpAccessTokenServerWithSecretProvider
 
 	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
+	quitChan        chan struct{}      // 用于结束 tokenDaemon
 
 	tokenGet struct {
 		sync.Mutex
@@ -62,6 +65,38 @@ func NewCorpAccessTokenServer(suiteId string, suiteAccessTokenServer AccessToken
 		authCorpId:      authCorpId,
 		permanentCode:   permanentCode,
 		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+// 创建一个新的 CorpAccessTokenServer, 每次从微信服务器获取 access_token 时从 permanentCode 获取永久授权码.
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewCorpAccessTokenServerWithSecretProvider(suiteId string, suiteAccessTokenServer AccessTokenServer,
+	authCorpId string, permanentCode util.SecretProvider, httpClient *http.Client) (srv *CorpAccessTokenServer) {
+
+	if suiteAccessTokenServer == nil {
+		panic("nil AccessTokenServer")
+	}
+	if permanentCode == nil {
+		panic("nil SecretProvider")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	srv = &CorpAccessTokenServer{
+		client: Client{
+			SuiteId:           suiteId,
+			AccessTokenServer: suiteAccessTokenServer,
+			HttpClient:        httpClient,
+		},
+		authCorpId:      authCorpId,
+		codeProvider:    permanentCode,
+		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
 	}
 
 	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
@@ -70,6 +105,11 @@ func NewCorpAccessTokenServer(suiteId string, suiteAccessTokenServer AccessToken
 
 func (srv *CorpAccessTokenServer) Tag6D89F2E2FE9811E49EAAA4DB30FED8E1() {}
 
+// 结束后台刷新 access_token 的 goroutine, 只能调用一次.
+func (srv *CorpAccessTokenServer) stop() {
+	close(srv.quitChan)
+}
+
 func (srv *CorpAccessTokenServer) Token() (token string, err error) {
 	srv.tokenCache.RLock()
 	token = srv.tokenCache.Token
@@ -87,7 +127,10 @@ func (srv *CorpAccessTokenServer) TokenRefresh() (token string, err error) {
 		return
 	}
 	if !cached {
-		srv.resetTickerChan <- time.Duration(tokenInfo.ExpiresIn) * time.Second
+		select {
+		case srv.resetTickerChan <- time.Duration(tokenInfo.ExpiresIn) * time.Second:
+		case <-srv.quitChan:
+		}
 	}
 	token = tokenInfo.Token
 	return
@@ -99,6 +142,10 @@ NEW_TICK_DURATION:
 
 	for {
 		select {
+		case <-srv.quitChan:
+			ticker.Stop()
+			return
+
 		case tickDuration = <-srv.resetTickerChan:
 			ticker.Stop()
 			goto NEW_TICK_DURATION
@@ -144,35 +191,21 @@ func (srv *CorpAccessTokenServer) getToken() (token AccessTokenInfo, cached bool
 		return
 	}
 
-	request := struct {
-		SuiteId       string `json:"suite_id"`
-		AuthCorpId    string `json:"auth_corpid"`
-		PermanentCode string `json:"permanent_code"`
-	}{
-		SuiteId:       srv.client.SuiteId,
-		AuthCorpId:    srv.authCorpId,
-		PermanentCode: srv.permanentCode,
-	}
-
-	var result struct {
-		corp.Error
-		AccessTokenInfo
-	}
-
-	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/service/get_corp_token?suite_access_token="
-	if err = srv.client.PostJSON(incompleteURL, &request, &result); err != nil {
-		srv.tokenCache.Lock()
-		srv.tokenCache.Token = ""
-		srv.tokenCache.Unlock()
-		return
+	permanentCode := srv.permanentCode
+	if srv.codeProvider != nil {
+		if permanentCode, err = srv.codeProvider.Secret(); err != nil {
+			srv.tokenCache.Lock()
+			srv.tokenCache.Token = ""
+			srv.tokenCache.Unlock()
+			return
+		}
 	}
 
-	if result.ErrCode != corp.ErrCodeOK {
+	result, err := srv.client.GetCorpToken(srv.authCorpId, permanentCode)
+	if err != nil {
 		srv.tokenCache.Lock()
 		srv.tokenCache.Token = ""
 		srv.tokenCache.Unlock()
-
-		err = &result.Error
 		return
 	}
 
@@ -203,14 +236,14 @@ func (srv *CorpAccessTokenServer) getToken() (token AccessTokenInfo, cached bool
 	}
 
 	// 更新 tokenGet 信息
-	srv.tokenGet.LastTokenInfo = result.AccessTokenInfo
+	srv.tokenGet.LastTokenInfo = *result
 	srv.tokenGet.LastTimestamp = timeNowUnix
 
 	// 更新缓存
 	srv.tokenCache.Lock()
-	srv.tokenCache.Token = result.AccessTokenInfo.Token
+	srv.tokenCache.Token = result.Token
 	srv.tokenCache.Unlock()
 
-	token = result.AccessTokenInfo
+	token = *result
 	return
 }
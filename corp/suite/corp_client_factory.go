@@ -0,0 +1,136 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 授权企业的永久授权码存储接口, 一般在收到 create_auth 通知后调用 Client.GetPermanentCode 获取并保存,
+// 见 SuiteManager. 多进程部署时需要用共享的存储(比如 redis, 数据库)实现.
+type PermanentCodeStore interface {
+	// 获取 authCorpId 对应的永久授权码, 没有找到时返回错误.
+	PermanentCode(authCorpId string) (permanentCode string, err error)
+
+	// 保存 authCorpId 的永久授权码
+	SetPermanentCode(authCorpId, permanentCode string) (err error)
+
+	// 删除 authCorpId 的永久授权码, 没有找到时不返回错误.
+	DeletePermanentCode(authCorpId string) (err error)
+}
+
+// CorpClientFactory 根据 authCorpId 创建(并缓存)调用授权企业接口的 *corp.Client.
+//  NOTE: 和 CorpAccessTokenServer 一样, 整个系统对于同一个套件只能存在一个 CorpClientFactory 实例!
+type CorpClientFactory struct {
+	suiteId                string
+	suiteAccessTokenServer AccessTokenServer
+	store                  PermanentCodeStore
+	httpClient             *http.Client
+
+	mutex   sync.Mutex
+	entries map[string]*corpClientEntry
+}
+
+type corpClientEntry struct {
+	tokenServer *CorpAccessTokenServer
+	client      *corp.Client
+}
+
+// 创建一个新的 CorpClientFactory.
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewCorpClientFactory(suiteId string, suiteAccessTokenServer AccessTokenServer,
+	store PermanentCodeStore, httpClient *http.Client) *CorpClientFactory {
+
+	if suiteAccessTokenServer == nil {
+		panic("nil AccessTokenServer")
+	}
+	if store == nil {
+		panic("nil PermanentCodeStore")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &CorpClientFactory{
+		suiteId:                suiteId,
+		suiteAccessTokenServer: suiteAccessTokenServer,
+		store:                  store,
+		httpClient:             httpClient,
+		entries:                make(map[string]*corpClientEntry),
+	}
+}
+
+// 获取 authCorpId 对应的 *corp.Client, 可以直接用于 corp 下各个接口包, 比如:
+//  clt, err := factory.Client(authCorpId)
+//  ...
+//  addresslist.Client{Client: clt}.UserInfo(userId)
+func (factory *CorpClientFactory) Client(authCorpId string) (clt *corp.Client, err error) {
+	if authCorpId == "" {
+		err = errors.New("empty authCorpId")
+		return
+	}
+
+	factory.mutex.Lock()
+	defer factory.mutex.Unlock()
+
+	if entry := factory.entries[authCorpId]; entry != nil {
+		clt = entry.client
+		return
+	}
+
+	permanentCode, err := factory.permanentCode(authCorpId)
+	if err != nil {
+		return
+	}
+
+	// 每次刷新 access_token 都重新从 store 获取永久授权码, 这样 store 里的永久授权码更新后不需要调用 Remove
+	tokenServer := NewCorpAccessTokenServerWithSecretProvider(factory.suiteId, factory.suiteAccessTokenServer,
+		authCorpId, permanentCode, factory.httpClient)
+	clt = corp.NewClient(tokenServer, factory.httpClient)
+
+	factory.entries[authCorpId] = &corpClientEntry{
+		tokenServer: tokenServer,
+		client:      clt,
+	}
+	return
+}
+
+// 返回从 store 获取 authCorpId 的永久授权码的 SecretProvider, 先获取一次确认 store 里有永久授权码.
+func (factory *CorpClientFactory) permanentCode(authCorpId string) (provider util.SecretProvider, err error) {
+	provider = util.SecretProviderFunc(func() (permanentCode string, err error) {
+		if permanentCode, err = factory.store.PermanentCode(authCorpId); err != nil {
+			return
+		}
+		if permanentCode == "" {
+			err = errors.New("empty permanent code for corp " + authCorpId)
+			return
+		}
+		return
+	})
+	if _, err = provider.Secret(); err != nil {
+		provider = nil
+		return
+	}
+	return
+}
+
+// 删除 authCorpId 对应的 *corp.Client, 一般在收到 cancel_auth 通知后调用.
+//  NOTE: 永久授权码变化后不需要调用, 下次刷新 access_token 时会从 PermanentCodeStore 获取新的永久授权码.
+func (factory *CorpClientFactory) Remove(authCorpId string) {
+	factory.mutex.Lock()
+	entry := factory.entries[authCorpId]
+	delete(factory.entries, authCorpId)
+	factory.mutex.Unlock()
+
+	if entry != nil {
+		entry.tokenServer.stop()
+	}
+}
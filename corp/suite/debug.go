@@ -0,0 +1,20 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// DebugMode 为 true 时返回包装了 util.DumpTransport 的 HttpClient.
+func (clt *Client) httpClient() *http.Client {
+	if !clt.DebugMode {
+		return clt.HttpClient
+	}
+	return corp.NewDebugHttpClient(clt.HttpClient)
+}
@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 获取授权企业的 access_token
+//  NOTE: 一般不用直接调用, CorpAccessTokenServer 会缓存并定时刷新 access_token.
+func (clt *Client) GetCorpToken(AuthCorpId, PermanentCode string) (info *AccessTokenInfo, err error) {
+	request := struct {
+		SuiteId       string `json:"suite_id"`
+		AuthCorpId    string `json:"auth_corpid"`
+		PermanentCode string `json:"permanent_code"`
+	}{
+		SuiteId:       clt.SuiteId,
+		AuthCorpId:    AuthCorpId,
+		PermanentCode: PermanentCode,
+	}
+
+	var result struct {
+		corp.Error
+		AccessTokenInfo
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/service/get_corp_token?suite_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.AccessTokenInfo
+	return
+}
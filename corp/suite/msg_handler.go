@@ -53,4 +53,31 @@ type MixedMessage struct {
 
 	SuiteTicket string `xml:"SuiteTicket" json:"SuiteTicket"`
 	AuthCorpId  string `xml:"AuthCorpId"  json:"AuthCorpId"`
+	AuthCode    string `xml:"AuthCode"    json:"AuthCode"`
+
+	// change_contact
+	ChangeType     string `xml:"ChangeType"     json:"ChangeType"`
+	UserId         string `xml:"UserID"         json:"UserID"`
+	OpenUserId     string `xml:"OpenUserID"     json:"OpenUserID"`
+	NewUserId      string `xml:"NewUserID"      json:"NewUserID"`
+	Name           string `xml:"Name"           json:"Name"`
+	Department     string `xml:"Department"     json:"Department"`
+	MainDepartment int64  `xml:"MainDepartment" json:"MainDepartment"`
+	IsLeaderInDept string `xml:"IsLeaderInDept" json:"IsLeaderInDept"`
+	Position       string `xml:"Position"       json:"Position"`
+	Mobile         string `xml:"Mobile"         json:"Mobile"`
+	Gender         int    `xml:"Gender"         json:"Gender"`
+	Email          string `xml:"Email"          json:"Email"`
+	Status         int    `xml:"Status"         json:"Status"`
+	Avatar         string `xml:"Avatar"         json:"Avatar"`
+	Alias          string `xml:"Alias"          json:"Alias"`
+	Telephone      string `xml:"Telephone"      json:"Telephone"`
+	Id             int64  `xml:"Id"             json:"Id"`
+	ParentId       int64  `xml:"ParentId"       json:"ParentId"`
+	Order          int64  `xml:"Order"          json:"Order"`
+	TagId          int64  `xml:"TagId"          json:"TagId"`
+	AddUserItems   string `xml:"AddUserItems"   json:"AddUserItems"`
+	DelUserItems   string `xml:"DelUserItems"   json:"DelUserItems"`
+	AddPartyItems  string `xml:"AddPartyItems"  json:"AddPartyItems"`
+	DelPartyItems  string `xml:"DelPartyItems"  json:"DelPartyItems"`
 }
@@ -7,9 +7,11 @@ package suite
 
 const (
 	// 微信服务器推送过来的消息类型
-	SuiteMsgTypeSuiteTicket = "suite_ticket" // 推送suite_ticket协议
-	SuiteMsgTypeChangeAuth  = "change_auth"  // 变更授权的通知
-	SuiteMsgTypeCancelAuth  = "cancel_auth"  // 取消授权的通知
+	SuiteMsgTypeSuiteTicket   = "suite_ticket"   // 推送suite_ticket协议
+	SuiteMsgTypeCreateAuth    = "create_auth"    // 授权成功的通知
+	SuiteMsgTypeChangeAuth    = "change_auth"    // 变更授权的通知
+	SuiteMsgTypeCancelAuth    = "cancel_auth"    // 取消授权的通知
+	SuiteMsgTypeChangeContact = "change_contact" // 授权企业的通讯录变更通知
 )
 
 type SuiteTicketMessage struct {
@@ -31,6 +33,25 @@ func GetSuiteTicketMessage(msg *MixedMessage) *SuiteTicketMessage {
 	}
 }
 
+type CreateAuthMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	SuiteId   string `xml:"SuiteId"   json:"SuiteId"`
+	InfoType  string `xml:"InfoType"  json:"InfoType"`
+	Timestamp int64  `xml:"TimeStamp" json:"TimeStamp"`
+
+	AuthCode string `xml:"AuthCode" json:"AuthCode"` // 临时授权码, 用于获取企业的永久授权码
+}
+
+func GetCreateAuthMessage(msg *MixedMessage) *CreateAuthMessage {
+	return &CreateAuthMessage{
+		SuiteId:   msg.SuiteId,
+		InfoType:  msg.InfoType,
+		Timestamp: msg.Timestamp,
+		AuthCode:  msg.AuthCode,
+	}
+}
+
 type ChangeAuthMessage struct {
 	XMLName struct{} `xml:"xml" json:"-"`
 
@@ -0,0 +1,147 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+	"sync"
+)
+
+// 保存授权企业成员的 UserId 和 OpenUserId 的映射, 服务商镜像的通讯录用 OpenUserId 做主键时,
+// 可以通过它把回调和接口里的 UserId 转换为 OpenUserId.
+//  NOTE: 多进程部署时需要用共享的存储(比如 redis, 数据库)实现.
+type OpenUserIdStore interface {
+	// 保存 corpId 企业里成员 userId 对应的 openUserId, 同一个 openUserId 的旧 userId 会被替换.
+	SetOpenUserId(corpId, userId, openUserId string) (err error)
+
+	// 没有找到时返回 ErrNotFound
+	GetOpenUserId(corpId, userId string) (openUserId string, err error)
+	GetUserId(corpId, openUserId string) (userId string, err error)
+
+	// 删除 corpId 企业里 openUserId 的映射, 没有找到时不返回错误.
+	DeleteOpenUserId(corpId, openUserId string) (err error)
+
+	// 删除 corpId 企业的所有映射, 一般在企业取消授权时调用.
+	DeleteCorp(corpId string) (err error)
+}
+
+// 根据通讯录变更通知更新 store:
+//  create_user: 保存 UserId 和 OpenUserId 的映射;
+//  update_user: NewUserId 不为空时把 OpenUserId 映射到 NewUserId;
+//  delete_user: 删除 OpenUserId 的映射.
+//  其他 ChangeType 和没有 OpenUserId 的通知直接返回 nil.
+func UpdateOpenUserIdStore(store OpenUserIdStore, msg *ChangeContactMessage) (err error) {
+	if msg.OpenUserId == "" {
+		return
+	}
+	switch msg.ChangeType {
+	case ChangeTypeCreateUser:
+		return store.SetOpenUserId(msg.AuthCorpId, msg.UserId, msg.OpenUserId)
+	case ChangeTypeUpdateUser:
+		userId := msg.UserId
+		if msg.NewUserId != "" {
+			userId = msg.NewUserId
+		}
+		return store.SetOpenUserId(msg.AuthCorpId, userId, msg.OpenUserId)
+	case ChangeTypeDeleteUser:
+		return store.DeleteOpenUserId(msg.AuthCorpId, msg.OpenUserId)
+	}
+	return
+}
+
+var _ OpenUserIdStore = (*OpenUserIdCache)(nil)
+
+// 单进程的 OpenUserIdStore 实现, 进程重启后数据丢失.
+type OpenUserIdCache struct {
+	rwmutex sync.RWMutex
+	corps   map[string]*openUserIdMap // map[corpId]
+}
+
+type openUserIdMap struct {
+	userIds     map[string]string // map[openUserId]userId
+	openUserIds map[string]string // map[userId]openUserId
+}
+
+func NewOpenUserIdCache() *OpenUserIdCache {
+	return &OpenUserIdCache{
+		corps: make(map[string]*openUserIdMap),
+	}
+}
+
+func (cache *OpenUserIdCache) SetOpenUserId(corpId, userId, openUserId string) (err error) {
+	if userId == "" {
+		return errors.New("empty userId")
+	}
+	if openUserId == "" {
+		return errors.New("empty openUserId")
+	}
+
+	cache.rwmutex.Lock()
+	defer cache.rwmutex.Unlock()
+
+	m := cache.corps[corpId]
+	if m == nil {
+		m = &openUserIdMap{
+			userIds:     make(map[string]string),
+			openUserIds: make(map[string]string),
+		}
+		cache.corps[corpId] = m
+	}
+	if oldUserId, ok := m.userIds[openUserId]; ok {
+		delete(m.openUserIds, oldUserId)
+	}
+	m.userIds[openUserId] = userId
+	m.openUserIds[userId] = openUserId
+	return
+}
+
+func (cache *OpenUserIdCache) GetOpenUserId(corpId, userId string) (openUserId string, err error) {
+	cache.rwmutex.RLock()
+	defer cache.rwmutex.RUnlock()
+
+	if m := cache.corps[corpId]; m != nil {
+		if openUserId = m.openUserIds[userId]; openUserId != "" {
+			return
+		}
+	}
+	err = ErrNotFound
+	return
+}
+
+func (cache *OpenUserIdCache) GetUserId(corpId, openUserId string) (userId string, err error) {
+	cache.rwmutex.RLock()
+	defer cache.rwmutex.RUnlock()
+
+	if m := cache.corps[corpId]; m != nil {
+		if userId = m.userIds[openUserId]; userId != "" {
+			return
+		}
+	}
+	err = ErrNotFound
+	return
+}
+
+func (cache *OpenUserIdCache) DeleteOpenUserId(corpId, openUserId string) (err error) {
+	cache.rwmutex.Lock()
+	defer cache.rwmutex.Unlock()
+
+	m := cache.corps[corpId]
+	if m == nil {
+		return
+	}
+	if userId, ok := m.userIds[openUserId]; ok {
+		delete(m.openUserIds, userId)
+		delete(m.userIds, openUserId)
+	}
+	return
+}
+
+func (cache *OpenUserIdCache) DeleteCorp(corpId string) (err error) {
+	cache.rwmutex.Lock()
+	delete(cache.corps, corpId)
+	cache.rwmutex.Unlock()
+	return
+}
@@ -0,0 +1,249 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+var _ corp.AccessTokenServer = (*ProviderAccessTokenServer)(nil)
+
+// 服务商的 provider_access_token 中控服务器, 实现了 corp.AccessTokenServer,
+// 用于调用需要 provider_access_token 的接口(比如接口许可), 见 corp/license.
+//  NOTE:
+//  1. 用于单进程环境.
+//  2. 因为 ProviderAccessTokenServer 同时也是一个简单的中控服务器, 而不是仅仅实现 AccessTokenServer 接口,
+//     所以整个系统只能存在一个 ProviderAccessTokenServer 实例!
+type ProviderAccessTokenServer struct {
+	corpId         string
+	providerSecret string
+	httpClient     *http.Client
+
+	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
+	quitChan        chan struct{}      // 用于结束 tokenDaemon
+
+	tokenGet struct {
+		sync.Mutex
+		LastTokenInfo AccessTokenInfo // 最后一次成功从微信服务器获取的 access_token 信息
+		LastTimestamp int64           // 最后一次成功从微信服务器获取 access_token 的时间戳
+	}
+
+	tokenCache struct {
+		sync.RWMutex
+		Token string
+	}
+}
+
+// 创建一个新的 ProviderAccessTokenServer.
+//  corpId 为服务商的 corpid, providerSecret 为服务商的 secret(在服务商管理后台查看);
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewProviderAccessTokenServer(corpId, providerSecret string, httpClient *http.Client) (srv *ProviderAccessTokenServer) {
+	if corpId == "" {
+		panic("empty corpId")
+	}
+	if providerSecret == "" {
+		panic("empty providerSecret")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	srv = &ProviderAccessTokenServer{
+		corpId:          corpId,
+		providerSecret:  providerSecret,
+		httpClient:      httpClient,
+		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+func (srv *ProviderAccessTokenServer) Tag6D89F2E2FE9811E49EAAA4DB30FED8E1() {}
+
+// 结束后台刷新 provider_access_token 的 goroutine, 只能调用一次.
+func (srv *ProviderAccessTokenServer) Stop() {
+	close(srv.quitChan)
+}
+
+func (srv *ProviderAccessTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	srv.tokenCache.RUnlock()
+
+	if token != "" {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *ProviderAccessTokenServer) TokenRefresh() (token string, err error) {
+	tokenInfo, cached, err := srv.getToken()
+	if err != nil {
+		return
+	}
+	if !cached {
+		select {
+		case srv.resetTickerChan <- time.Duration(tokenInfo.ExpiresIn) * time.Second:
+		case <-srv.quitChan:
+		}
+	}
+	token = tokenInfo.Token
+	return
+}
+
+func (srv *ProviderAccessTokenServer) tokenDaemon(tickDuration time.Duration) {
+NEW_TICK_DURATION:
+	ticker := time.NewTicker(tickDuration)
+
+	for {
+		select {
+		case <-srv.quitChan:
+			ticker.Stop()
+			return
+
+		case tickDuration = <-srv.resetTickerChan:
+			ticker.Stop()
+			goto NEW_TICK_DURATION
+
+		case <-ticker.C:
+			tokenInfo, cached, err := srv.getToken()
+			if err != nil {
+				break
+			}
+			if !cached {
+				newTickDuration := time.Duration(tokenInfo.ExpiresIn) * time.Second
+				if tickDuration != newTickDuration {
+					tickDuration = newTickDuration
+					ticker.Stop()
+					goto NEW_TICK_DURATION
+				}
+			}
+		}
+	}
+}
+
+// 从微信服务器获取 provider_access_token.
+//  同一时刻只能一个 goroutine 进入, 防止没必要的重复获取.
+func (srv *ProviderAccessTokenServer) getToken() (token AccessTokenInfo, cached bool, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 provider_access_token, 这里的收敛时间设定为4秒.
+	if n := srv.tokenGet.LastTimestamp; n <= timeNowUnix && timeNowUnix < n+4 {
+		// 因为只有成功获取后才会更新 srv.tokenGet.LastTimestamp, 所以这些都是有效数据
+		token = AccessTokenInfo{
+			Token:     srv.tokenGet.LastTokenInfo.Token,
+			ExpiresIn: srv.tokenGet.LastTokenInfo.ExpiresIn - timeNowUnix + n,
+		}
+		cached = true
+		return
+	}
+
+	request := struct {
+		CorpId         string `json:"corpid"`
+		ProviderSecret string `json:"provider_secret"`
+	}{
+		CorpId:         srv.corpId,
+		ProviderSecret: srv.providerSecret,
+	}
+
+	var result struct {
+		corp.Error
+		Token     string `json:"provider_access_token"`
+		ExpiresIn int64  `json:"expires_in"`
+	}
+
+	if err = postJSONWithoutToken(srv.httpClient, "https://qyapi.weixin.qq.com/cgi-bin/service/get_provider_token", &request, &result); err != nil {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+
+		err = &result.Error
+		return
+	}
+
+	// 由于网络的延时, provider_access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 31556952: // 60*60*24*365.2425
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+
+		err = errors.New("expires_in too large: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	default:
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+
+		err = errors.New("expires_in too small: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	// 更新 tokenGet 信息
+	token = AccessTokenInfo{
+		Token:     result.Token,
+		ExpiresIn: result.ExpiresIn,
+	}
+	srv.tokenGet.LastTokenInfo = token
+	srv.tokenGet.LastTimestamp = timeNowUnix
+
+	// 更新缓存
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = token.Token
+	srv.tokenCache.Unlock()
+	return
+}
+
+// 不需要 access_token 的 POST 请求, 比如获取 provider_access_token.
+func postJSONWithoutToken(httpClient *http.Client, url string, request interface{}, response interface{}) (err error) {
+	requestBuf := textBufferPool.Get().(*bytes.Buffer)
+	requestBuf.Reset()
+	defer textBufferPool.Put(requestBuf)
+
+	if err = json.NewEncoder(requestBuf).Encode(request); err != nil {
+		return
+	}
+
+	httpResp, err := httpClient.Post(url, "application/json; charset=utf-8", bytes.NewReader(requestBuf.Bytes()))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+	return json.NewDecoder(httpResp.Body).Decode(response)
+}
@@ -0,0 +1,39 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 返回一个 Client 的浅拷贝, 之后通过它发起的请求都使用 requestId, 见 corp.Client.WithRequestId.
+func (clt *Client) WithRequestId(requestId string) *Client {
+	clt2 := *clt
+	clt2.RequestId = requestId
+	return &clt2
+}
+
+// 获取这次请求的 request id.
+func (clt *Client) requestId() string {
+	if clt.RequestId != "" {
+		return clt.RequestId
+	}
+	if clt.RequestIdGenerator != nil {
+		if id := clt.RequestIdGenerator(); id != "" {
+			return id
+		}
+	}
+	return corp.NewRequestId()
+}
+
+// 用 corp.RequestError 包装 err, err 为 nil 或者 *corp.Error 时原样返回.
+func wrapRequestError(requestId string, err error) error {
+	switch err.(type) {
+	case nil, *corp.Error:
+		return err
+	}
+	return &corp.RequestError{RequestId: requestId, Err: err}
+}
@@ -16,8 +16,8 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/chanxuehong/wechat/cipher"
 	"github.com/chanxuehong/wechat/corp"
-	"github.com/chanxuehong/wechat/util"
 )
 
 var zeroAESKey [32]byte
@@ -79,7 +79,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values,
 		suiteToken := server.SuiteToken()
 
 		// 验证签名
-		msgSignature2 := util.MsgSign(suiteToken, timestampStr, nonce, requestHttpBody.EncryptedMsg)
+		msgSignature2 := cipher.MsgSign(suiteToken, timestampStr, nonce, requestHttpBody.EncryptedMsg)
 		if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 			err = fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 			invalidRequestHandler.ServeInvalidRequest(w, r, err)
@@ -94,7 +94,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values,
 		}
 
 		AESKey := server.CurrentAESKey()
-		Random, RawMsgXML, err := util.AESDecryptMsg(EncryptedMsgBytes, wantSuiteId, AESKey)
+		Random, RawMsgXML, err := cipher.Decrypt(EncryptedMsgBytes, wantSuiteId, AESKey)
 		if err != nil {
 			// 尝试用上一次的 AESKey 来解密
 			LastAESKey := server.LastAESKey()
@@ -104,7 +104,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values,
 			}
 
 			AESKey = LastAESKey // NOTE
-			Random, RawMsgXML, err = util.AESDecryptMsg(EncryptedMsgBytes, wantSuiteId, AESKey)
+			Random, RawMsgXML, err = cipher.Decrypt(EncryptedMsgBytes, wantSuiteId, AESKey)
 			if err != nil {
 				invalidRequestHandler.ServeInvalidRequest(w, r, err)
 				return
@@ -159,7 +159,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values,
 			return
 		}
 
-		msgSignature2 := util.MsgSign(server.SuiteToken(), timestamp, nonce, encryptedMsg)
+		msgSignature2 := cipher.MsgSign(server.SuiteToken(), timestamp, nonce, encryptedMsg)
 		if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 			err = fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 			invalidRequestHandler.ServeInvalidRequest(w, r, err)
@@ -175,7 +175,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values,
 
 		SuiteId := server.SuiteId()
 		AESKey := server.CurrentAESKey()
-		_, echostr, err := util.AESDecryptMsg(EncryptedMsgBytes, SuiteId, AESKey)
+		_, echostr, err := cipher.Decrypt(EncryptedMsgBytes, SuiteId, AESKey)
 		if err != nil {
 			invalidRequestHandler.ServeInvalidRequest(w, r, err)
 			return
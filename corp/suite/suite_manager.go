@@ -0,0 +1,149 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+var _ PermanentCodeStore = (*PermanentCodeCache)(nil)
+
+// 单进程的 PermanentCodeStore 实现, 进程重启后数据丢失, 一般用于测试.
+type PermanentCodeCache struct {
+	rwmutex sync.RWMutex
+	m       map[string]string
+}
+
+func NewPermanentCodeCache() *PermanentCodeCache {
+	return &PermanentCodeCache{
+		m: make(map[string]string),
+	}
+}
+
+func (cache *PermanentCodeCache) PermanentCode(authCorpId string) (permanentCode string, err error) {
+	cache.rwmutex.RLock()
+	permanentCode = cache.m[authCorpId]
+	cache.rwmutex.RUnlock()
+
+	if permanentCode == "" {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (cache *PermanentCodeCache) SetPermanentCode(authCorpId, permanentCode string) (err error) {
+	if authCorpId == "" {
+		return errors.New("empty authCorpId")
+	}
+	if permanentCode == "" {
+		return errors.New("empty permanentCode")
+	}
+
+	cache.rwmutex.Lock()
+	cache.m[authCorpId] = permanentCode
+	cache.rwmutex.Unlock()
+	return
+}
+
+func (cache *PermanentCodeCache) DeletePermanentCode(authCorpId string) (err error) {
+	cache.rwmutex.Lock()
+	delete(cache.m, authCorpId)
+	cache.rwmutex.Unlock()
+	return
+}
+
+// SuiteManager 管理一个套件的所有授权企业: 处理授权, 取消授权通知, 保存永久授权码,
+// 并为每个授权企业提供一个缓存了 access_token 的 *corp.Client.
+//
+//  mgr := suite.NewSuiteManager(suiteId, suiteAccessTokenServer, store, nil)
+//  mux := suite.NewCallbackServeMux(ticketStore)
+//  mgr.RegisterCallbacks(mux)
+//  ...
+//  clt, err := mgr.CorpClient(authCorpId)
+//
+//  NOTE: 和 CorpClientFactory 一样, 整个系统对于同一个套件只能存在一个 SuiteManager 实例!
+type SuiteManager struct {
+	client  *Client
+	store   PermanentCodeStore
+	factory *CorpClientFactory
+
+	// 可以为 nil; 授权成功并保存永久授权码之后调用, 比如初始化企业的数据.
+	OnAuthorized func(info *PermanentCodeInfo) error
+
+	// 可以为 nil; 取消授权并删除永久授权码之后调用, 比如清理企业的数据.
+	OnDeauthorized func(authCorpId string) error
+}
+
+// 创建一个新的 SuiteManager.
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewSuiteManager(suiteId string, suiteAccessTokenServer AccessTokenServer,
+	store PermanentCodeStore, httpClient *http.Client) *SuiteManager {
+
+	if store == nil {
+		panic("nil PermanentCodeStore")
+	}
+	return &SuiteManager{
+		client:  NewClient(suiteId, suiteAccessTokenServer, httpClient),
+		store:   store,
+		factory: NewCorpClientFactory(suiteId, suiteAccessTokenServer, store, httpClient),
+	}
+}
+
+// 调用套件接口(suite_access_token)的 Client.
+func (mgr *SuiteManager) Client() *Client {
+	return mgr.client
+}
+
+// 获取授权企业的 *corp.Client, 同一个企业返回同一个 *corp.Client, 它的 access_token 会被缓存并定时刷新.
+func (mgr *SuiteManager) CorpClient(authCorpId string) (clt *corp.Client, err error) {
+	return mgr.factory.Client(authCorpId)
+}
+
+// 用临时授权码获取并保存永久授权码, 一般在收到 create_auth 通知或者授权完成跳转回来时调用.
+func (mgr *SuiteManager) Authorize(authCode string) (info *PermanentCodeInfo, err error) {
+	if info, err = mgr.client.GetPermanentCode(authCode); err != nil {
+		return
+	}
+	authCorpId := info.AuthCorpInfo.CorpId
+	if err = mgr.store.SetPermanentCode(authCorpId, info.PermanentCode); err != nil {
+		return
+	}
+	mgr.factory.Remove(authCorpId) // 重新授权时永久授权码会变化
+
+	if mgr.OnAuthorized != nil {
+		err = mgr.OnAuthorized(info)
+	}
+	return
+}
+
+// 删除授权企业的永久授权码和 *corp.Client, 一般在收到 cancel_auth 通知时调用.
+func (mgr *SuiteManager) Deauthorize(authCorpId string) (err error) {
+	if err = mgr.store.DeletePermanentCode(authCorpId); err != nil {
+		return
+	}
+	mgr.factory.Remove(authCorpId)
+
+	if mgr.OnDeauthorized != nil {
+		err = mgr.OnDeauthorized(authCorpId)
+	}
+	return
+}
+
+// 在 mux 上注册 create_auth 和 cancel_auth 的处理函数, 分别调用 Authorize 和 Deauthorize.
+//  NOTE: 会覆盖之前通过 OnCreateAuth, OnCancelAuth 注册的处理函数.
+func (mgr *SuiteManager) RegisterCallbacks(mux *SuiteMessageServeMux) {
+	mux.OnCreateAuth(func(r *Request, msg *CreateAuthMessage) (err error) {
+		_, err = mgr.Authorize(msg.AuthCode)
+		return
+	})
+	mux.OnCancelAuth(func(r *Request, msg *CancelAuthMessage) error {
+		return mgr.Deauthorize(msg.AuthCorpId)
+	})
+}
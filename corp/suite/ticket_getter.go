@@ -20,7 +20,15 @@ type TicketGetter interface {
 	TagEF8503CCFE9811E4959AA4DB30FED8E1()
 }
 
-var _ TicketGetter = (*TicketCache)(nil)
+// 可以保存 SuiteTicket 的 TicketGetter, 用于接收微信服务器推送过来的 suite_ticket.
+type TicketStore interface {
+	TicketGetter
+
+	// 保存 suiteId 对应的 SuiteTicket
+	SetSuiteTicket(suiteId string, ticket string) (err error)
+}
+
+var _ TicketStore = (*TicketCache)(nil)
 
 type TicketCache struct {
 	rwmutex sync.RWMutex
@@ -53,7 +61,7 @@ func (cache *TicketCache) GetSuiteTicket(suiteId string) (ticket string, err err
 	return
 }
 
-var _ TicketGetter = (*TicketCache2)(nil)
+var _ TicketStore = (*TicketCache2)(nil)
 
 type TicketCache2 struct {
 	rwmutex sync.RWMutex
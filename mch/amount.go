@@ -0,0 +1,158 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mch
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 金额, 单位为分.
+//  微信支付的所有金额参数(total_fee, refund_fee, v3 的 amount.total...)都是以分为单位的整数,
+//  不要用 float64 表示元再乘以 100 转换, 比如 float64(19.99)*100 = 1998.9999999999998.
+//  Amount 在 json 和 xml 里都编码为以分为单位的整数.
+type Amount int64
+
+// 把元为单位的十进制字符串转换为 Amount, 比如 "19.99" => 1999, "-0.5" => -50.
+//  NOTE: 最多两位小数, 不接受科学计数法.
+func ParseYuan(yuan string) (amount Amount, err error) {
+	s := strings.TrimSpace(yuan)
+	if s == "" {
+		err = errors.New("empty amount")
+		return
+	}
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		err = fmt.Errorf("invalid amount: %q", yuan)
+		return
+	}
+	if len(fracPart) > 2 {
+		err = fmt.Errorf("amount has more than 2 decimal places: %q", yuan)
+		return
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		err = fmt.Errorf("invalid amount: %q", yuan)
+		return
+	}
+
+	fen, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid amount: %q", yuan)
+		return
+	}
+	if negative {
+		fen = -fen
+	}
+	amount = Amount(fen)
+	return
+}
+
+// 把分为单位的十进制整数字符串转换为 Amount, 用于解析 total_fee 这样的参数.
+func ParseFen(fen string) (amount Amount, err error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(fen), 10, 64)
+	if err != nil {
+		return
+	}
+	amount = Amount(n)
+	return
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// 以分为单位的金额
+func (amount Amount) Fen() int64 {
+	return int64(amount)
+}
+
+// 以分为单位的字符串, 用于 v2 接口的 map[string]string 参数, 比如 req["total_fee"] = amount.FenString().
+func (amount Amount) FenString() string {
+	return strconv.FormatInt(int64(amount), 10)
+}
+
+// 以元为单位的字符串, 固定两位小数, 比如 1999 => "19.99".
+func (amount Amount) Yuan() string {
+	fen := int64(amount)
+	sign := ""
+	if fen < 0 {
+		sign = "-"
+		fen = -fen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, fen/100, fen%100)
+}
+
+// 同 Yuan()
+func (amount Amount) String() string {
+	return amount.Yuan()
+}
+
+func (amount Amount) MarshalJSON() ([]byte, error) {
+	return []byte(amount.FenString()), nil
+}
+
+// 兼容以分为单位的数字和数字字符串.
+func (amount *Amount) UnmarshalJSON(data []byte) (err error) {
+	s := string(data)
+	if s == "null" {
+		return
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := ParseFen(s)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %s", data)
+	}
+	*amount = v
+	return
+}
+
+func (amount Amount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(amount.FenString(), start)
+}
+
+func (amount *Amount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	var s string
+	if err = d.DecodeElement(&s, &start); err != nil {
+		return
+	}
+	v, err := ParseFen(s)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %q", s)
+	}
+	*amount = v
+	return
+}
@@ -14,12 +14,14 @@ import (
 	"io/ioutil"
 	"net/http"
 
-	"github.com/chanxuehong/util"
+	"github.com/chanxuehong/wechat/mch/sign"
+	wechatutil "github.com/chanxuehong/wechat/util"
 )
 
 type Proxy struct {
-	apiKey     string
-	httpClient *http.Client
+	apiKey         string
+	apiKeyProvider wechatutil.SecretProvider // 不为 nil 时替代 apiKey, 见 NewProxyWithSecretProvider
+	httpClient     *http.Client
 }
 
 // 创建一个新的 Proxy.
@@ -42,7 +44,7 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 	bodyBuf.Reset()
 	defer textBufferPool.Put(bodyBuf)
 
-	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+	if err = wechatutil.EncodeXMLFromMap(bodyBuf, req); err != nil {
 		return
 	}
 
@@ -66,7 +68,7 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 	}
 	LogInfoln("[WECHAT_DEBUG] response xml:", string(respBody))
 
-	if resp, err = util.ParseXMLToMap(bytes.NewReader(respBody)); err != nil {
+	if resp, err = wechatutil.DecodeXMLToMap(bytes.NewReader(respBody)); err != nil {
 		return
 	}
 
@@ -90,8 +92,12 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 		err = errors.New("no sign parameter")
 		return
 	}
-	signature2 := Sign(resp, proxy.apiKey, nil)
-	if signature1 != signature2 {
+	apiKey, err := proxy.APIKey()
+	if err != nil {
+		return
+	}
+	signature2 := Sign(resp, apiKey, nil)
+	if !sign.Equal(signature1, signature2) {
 		err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
 		return
 	}
@@ -13,12 +13,14 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/chanxuehong/util"
+	"github.com/chanxuehong/wechat/mch/sign"
+	wechatutil "github.com/chanxuehong/wechat/util"
 )
 
 type Proxy struct {
-	apiKey     string
-	httpClient *http.Client
+	apiKey         string
+	apiKeyProvider wechatutil.SecretProvider // 不为 nil 时替代 apiKey, 见 NewProxyWithSecretProvider
+	httpClient     *http.Client
 }
 
 // 创建一个新的 Proxy.
@@ -41,7 +43,7 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 	bodyBuf.Reset()
 	defer textBufferPool.Put(bodyBuf)
 
-	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+	if err = wechatutil.EncodeXMLFromMap(bodyBuf, req); err != nil {
 		return
 	}
 
@@ -56,7 +58,7 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 		return
 	}
 
-	if resp, err = util.ParseXMLToMap(httpResp.Body); err != nil {
+	if resp, err = wechatutil.DecodeXMLToMap(httpResp.Body); err != nil {
 		return
 	}
 
@@ -80,8 +82,12 @@ func (proxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]
 		err = errors.New("no sign parameter")
 		return
 	}
-	signature2 := Sign(resp, proxy.apiKey, nil)
-	if signature1 != signature2 {
+	apiKey, err := proxy.APIKey()
+	if err != nil {
+		return
+	}
+	signature2 := Sign(resp, apiKey, nil)
+	if !sign.Equal(signature1, signature2) {
 		err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
 		return
 	}
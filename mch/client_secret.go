@@ -0,0 +1,36 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mch
+
+import (
+	"net/http"
+
+	wechatutil "github.com/chanxuehong/wechat/util"
+)
+
+// 创建一个新的 Proxy, 每次验证应答的签名时从 apiKey 获取 API 密钥, 用于密钥轮换或者从 KMS 获取密钥.
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func NewProxyWithSecretProvider(apiKey wechatutil.SecretProvider, httpClient *http.Client) *Proxy {
+	if apiKey == nil {
+		panic("nil SecretProvider")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Proxy{
+		apiKeyProvider: apiKey,
+		httpClient:     httpClient,
+	}
+}
+
+// 返回 API 密钥, 调用者可以用来给请求签名.
+func (proxy *Proxy) APIKey() (string, error) {
+	if proxy.apiKeyProvider != nil {
+		return proxy.apiKeyProvider.Secret()
+	}
+	return proxy.apiKey, nil
+}
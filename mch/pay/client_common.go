@@ -9,11 +9,12 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 
-	"github.com/chanxuehong/util"
 	"github.com/chanxuehong/wechat/mch"
+	wechatutil "github.com/chanxuehong/wechat/util"
 )
 
 // 统一下单.
@@ -42,6 +43,61 @@ func RefundQuery(proxy *mch.Proxy, req map[string]string) (resp map[string]strin
 	return proxy.PostXML("https://api.mch.weixin.qq.com/pay/refundquery", req)
 }
 
+// 打开对账单的文件流, 数据不会整个读入内存, 调用者负责关闭; 对账单较大时用来代替 DownloadBill.
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+//  微信支付返回错误信息(<xml>...</xml>)时返回 *mch.Error.
+func OpenBill(req map[string]string, httpClient *http.Client) (stream *wechatutil.DownloadStream, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	bodyBuf := bytes.NewBuffer(make([]byte, 0, 1024))
+	if err = wechatutil.EncodeXMLFromMap(bodyBuf, req); err != nil {
+		return
+	}
+
+	httpResp, err := httpClient.Post("https://api.mch.weixin.qq.com/pay/downloadbill", "text/xml; charset=utf-8", bodyBuf)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	stream = wechatutil.NewDownloadStream(httpResp)
+	isXML, err := stream.HasPrefix("<xml>")
+	if err != nil || !isXML {
+		if err != nil {
+			stream.Close()
+			stream = nil
+		}
+		return
+	}
+
+	defer func() {
+		stream.Close()
+		stream = nil
+	}()
+	var result mch.Error
+	if err = xml.NewDecoder(stream).Decode(&result); err != nil {
+		return
+	}
+	err = &result
+	return
+}
+
+// 下载对账单写入 w, 返回写入的字节数, 参数和错误见 OpenBill.
+func DownloadBillToWriter(req map[string]string, httpClient *http.Client, w io.Writer) (written int64, err error) {
+	stream, err := OpenBill(req, httpClient)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+	return stream.WriteTo(w)
+}
+
 // 下载对账单.
 func DownloadBill(req map[string]string, httpClient *http.Client) (data []byte, err error) {
 	if httpClient == nil {
@@ -49,7 +105,7 @@ func DownloadBill(req map[string]string, httpClient *http.Client) (data []byte,
 	}
 
 	bodyBuf := bytes.NewBuffer(make([]byte, 0, 1024))
-	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+	if err = wechatutil.EncodeXMLFromMap(bodyBuf, req); err != nil {
 		return
 	}
 
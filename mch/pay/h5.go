@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mch"
+)
+
+// H5 支付的交易类型
+const TradeTypeMWEB = "MWEB"
+
+// H5 支付场景的类型
+const (
+	H5TypeIOS     = "IOS"
+	H5TypeAndroid = "Android"
+	H5TypeWap     = "Wap"
+)
+
+// H5 支付的场景信息, 对应 scene_info 里的 h5_info.
+//  IOS 移动应用:     Type, AppName, BundleId
+//  安卓移动应用:     Type, AppName, PackageName
+//  WAP 网站应用:     Type, WapURL, WapName
+type H5Info struct {
+	Type        string `json:"type"`
+	AppName     string `json:"app_name,omitempty"`
+	BundleId    string `json:"bundle_id,omitempty"`
+	PackageName string `json:"package_name,omitempty"`
+	WapURL      string `json:"wap_url,omitempty"`
+	WapName     string `json:"wap_name,omitempty"`
+}
+
+func (info *H5Info) check() error {
+	switch info.Type {
+	case H5TypeIOS:
+		if info.AppName == "" || info.BundleId == "" {
+			return errors.New("IOS h5_info requires app_name and bundle_id")
+		}
+	case H5TypeAndroid:
+		if info.AppName == "" || info.PackageName == "" {
+			return errors.New("Android h5_info requires app_name and package_name")
+		}
+	case H5TypeWap:
+		if info.WapURL == "" || info.WapName == "" {
+			return errors.New("Wap h5_info requires wap_url and wap_name")
+		}
+	default:
+		return fmt.Errorf("invalid h5_info type: %q", info.Type)
+	}
+	return nil
+}
+
+// 生成 H5 支付统一下单的 scene_info 参数.
+func H5SceneInfo(info *H5Info) (sceneInfo string, err error) {
+	if info == nil {
+		err = errors.New("nil H5Info")
+		return
+	}
+	if err = info.check(); err != nil {
+		return
+	}
+	b, err := json.Marshal(struct {
+		H5Info *H5Info `json:"h5_info"`
+	}{
+		H5Info: info,
+	})
+	if err != nil {
+		return
+	}
+	sceneInfo = string(b)
+	return
+}
+
+// 设置 H5 支付统一下单的 trade_type 和 scene_info 参数.
+func SetH5SceneInfo(req map[string]string, info *H5Info) (err error) {
+	sceneInfo, err := H5SceneInfo(info)
+	if err != nil {
+		return
+	}
+	req["trade_type"] = TradeTypeMWEB
+	req["scene_info"] = sceneInfo
+	return
+}
+
+// 从 H5 支付统一下单的返回结果里获取 mweb_url.
+//  resp 为 UnifiedOrder 的返回结果; result_code 不为 SUCCESS 时返回 err_code 和 err_code_des.
+func MWebURL(resp map[string]string) (mwebURL string, err error) {
+	if resultCode := resp["result_code"]; resultCode != mch.ResultCodeSuccess {
+		err = fmt.Errorf("result_code: %q, err_code: %q, err_code_des: %q", resultCode, resp["err_code"], resp["err_code_des"])
+		return
+	}
+	if mwebURL = resp["mweb_url"]; mwebURL == "" {
+		err = errors.New("no mweb_url parameter")
+		return
+	}
+	return
+}
+
+// 在 mweb_url 后面添加 redirect_url 参数, 用户支付完成后跳转到 redirectURL.
+//  NOTE: 跳转后并不代表支付成功, 需要商户主动查询订单或者等待支付结果通知.
+func MWebURLWithRedirect(mwebURL, redirectURL string) string {
+	if redirectURL == "" {
+		return mwebURL
+	}
+	sep := "&"
+	if !strings.Contains(mwebURL, "?") {
+		sep = "?"
+	}
+	return mwebURL + sep + "redirect_url=" + url.QueryEscape(redirectURL)
+}
@@ -0,0 +1,13 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 v2(XML) 到 v3(JSON) 的迁移适配层.
+//  业务代码只依赖 Payments 接口, 迁移时只需要把 NewV2 换成 NewV3:
+//
+//  var payments payment.Payments = payment.NewV2(appId, mchId, apiKey, httpClient, tlsHttpClient)
+//  // var payments payment.Payments = payment.NewV3(appId, payv3Client, apiV3Key)
+//
+//  NOTE: v2 和 v3 的回调通知格式不同, 迁移期间如果两种通知都可能收到, 需要为它们配置不同的 notify_url.
+package payment
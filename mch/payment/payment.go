@@ -0,0 +1,148 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mch"
+)
+
+// 处理支付结果通知失败时回复给微信支付的消息
+const NotifyFailMessage = "FAIL"
+
+// v2 和 v3 共同的支付接口.
+type Payments interface {
+	// 下单, 返回调起支付需要的 prepay_id(JSAPI, APP), code_url(NATIVE) 或者 h5_url(MWEB).
+	CreateOrder(req *CreateOrderRequest) (resp *CreateOrderResponse, err error)
+
+	// 用商户订单号查询订单.
+	QueryOrder(outTradeNo string) (order *Order, err error)
+
+	// 申请退款.
+	Refund(req *RefundRequest) (resp *RefundResponse, err error)
+
+	// 解析(并验证)支付结果通知.
+	ParseNotify(r *http.Request) (order *Order, err error)
+
+	// 回复支付结果通知, err 为 nil 表示处理成功, 否则微信支付会重新发送通知.
+	//  NOTE: err 只记录到本地日志(mch.LogInfoln), 回复的是固定的 NotifyFailMessage, 不会把错误细节返回给请求方.
+	WriteNotifyResponse(w http.ResponseWriter, err error)
+}
+
+// 交易类型
+const (
+	TradeTypeJSAPI  = "JSAPI"  // 公众号支付, 小程序支付
+	TradeTypeNative = "NATIVE" // 扫码支付
+	TradeTypeApp    = "APP"    // APP支付
+	TradeTypeMWeb   = "MWEB"   // H5支付
+)
+
+// 交易状态, v2 和 v3 相同
+const (
+	TradeStateSuccess    = "SUCCESS"    // 支付成功
+	TradeStateRefund     = "REFUND"     // 转入退款
+	TradeStateNotPay     = "NOTPAY"     // 未支付
+	TradeStateClosed     = "CLOSED"     // 已关闭
+	TradeStateRevoked    = "REVOKED"    // 已撤销(付款码支付)
+	TradeStateUserPaying = "USERPAYING" // 用户支付中(付款码支付)
+	TradeStatePayError   = "PAYERROR"   // 支付失败
+)
+
+// 退款状态
+const (
+	RefundStatusSuccess    = "SUCCESS"    // 退款成功
+	RefundStatusClosed     = "CLOSED"     // 退款关闭
+	RefundStatusProcessing = "PROCESSING" // 退款处理中
+	RefundStatusAbnormal   = "ABNORMAL"   // 退款异常
+)
+
+type CreateOrderRequest struct {
+	TradeType   string     // 必须; TradeTypeJSAPI, TradeTypeNative...
+	Description string     // 必须; 商品描述
+	OutTradeNo  string     // 必须; 商户订单号
+	Amount      mch.Amount // 必须; 订单金额
+	NotifyURL   string     // 必须; 支付结果通知地址
+	OpenId      string     // TradeTypeJSAPI 时必须; 用户在 appid 下的 openid
+	ClientIP    string     // 必须; 用户的客户端IP
+	TimeExpire  time.Time  // 可选; 订单失效时间
+	Attach      string     // 可选; 附加数据, 在查询和支付通知中原样返回
+}
+
+func (req *CreateOrderRequest) CheckValid() (err error) {
+	switch req.TradeType {
+	case TradeTypeJSAPI:
+		if req.OpenId == "" {
+			return errors.New("empty OpenId")
+		}
+	case TradeTypeNative, TradeTypeApp, TradeTypeMWeb:
+	default:
+		return fmt.Errorf("invalid TradeType %q", req.TradeType)
+	}
+	if req.Description == "" {
+		return errors.New("empty Description")
+	}
+	if req.OutTradeNo == "" {
+		return errors.New("empty OutTradeNo")
+	}
+	if req.Amount <= 0 {
+		return errors.New("Amount must be greater than 0")
+	}
+	if req.NotifyURL == "" {
+		return errors.New("empty NotifyURL")
+	}
+	return
+}
+
+type CreateOrderResponse struct {
+	PrepayId string // TradeTypeJSAPI, TradeTypeApp 时有效
+	CodeURL  string // TradeTypeNative 时有效
+	MWebURL  string // TradeTypeMWeb 时有效
+}
+
+// 订单
+type Order struct {
+	OutTradeNo    string
+	TransactionId string     // 微信支付订单号, 未支付时为空
+	TradeType     string     // TradeTypeJSAPI, TradeTypeNative...
+	TradeState    string     // TradeStateSuccess, TradeStateRefund...
+	Amount        mch.Amount // 订单金额
+	PayerAmount   mch.Amount // 用户实际支付金额
+	OpenId        string
+	SuccessTime   time.Time // 支付完成时间, 未支付时为零值
+	Attach        string
+}
+
+type RefundRequest struct {
+	OutTradeNo   string     // 必须; 商户订单号
+	OutRefundNo  string     // 必须; 商户退款单号
+	TotalAmount  mch.Amount // 必须; 原订单金额
+	RefundAmount mch.Amount // 必须; 退款金额
+	Reason       string     // 可选; 退款原因
+	NotifyURL    string     // 可选; 退款结果通知地址
+}
+
+func (req *RefundRequest) CheckValid() (err error) {
+	if req.OutTradeNo == "" {
+		return errors.New("empty OutTradeNo")
+	}
+	if req.OutRefundNo == "" {
+		return errors.New("empty OutRefundNo")
+	}
+	if req.RefundAmount <= 0 || req.RefundAmount > req.TotalAmount {
+		return errors.New("RefundAmount must be greater than 0 and less than or equal to TotalAmount")
+	}
+	return
+}
+
+type RefundResponse struct {
+	RefundId    string // 微信支付退款单号
+	OutRefundNo string
+	Status      string // RefundStatusProcessing...; v2 受理成功后总是 RefundStatusProcessing
+}
@@ -0,0 +1,283 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payment
+
+import (
+	"crypto/subtle"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mch"
+	"github.com/chanxuehong/wechat/mch/pay"
+	"github.com/chanxuehong/wechat/mch/sign"
+	wechatutil "github.com/chanxuehong/wechat/util"
+)
+
+const v2TimeLayout = "20060102150405"
+
+// v2 接口业务结果(result_code)为 FAIL 时返回的错误.
+type ResultError struct {
+	ErrCode    string
+	ErrCodeDes string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("result_code: %q, err_code: %q, err_code_des: %q", mch.ResultCodeFail, e.ErrCode, e.ErrCodeDes)
+}
+
+// 基于 v2(XML) 接口的 Payments.
+type V2 struct {
+	appId  string
+	mchId  string
+	apiKey wechatutil.SecretProvider
+
+	proxy    *mch.Proxy
+	tlsProxy *mch.Proxy
+}
+
+var _ Payments = (*V2)(nil)
+
+// 创建基于 v2 接口的 Payments.
+//  tlsHttpClient 是加载了商户证书的 http.Client, 用于退款; 如果为 nil 则 Refund 返回错误.
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func NewV2(appId, mchId, apiKey string, httpClient, tlsHttpClient *http.Client) *V2 {
+	return NewV2WithSecretProvider(appId, mchId, wechatutil.StaticSecret(apiKey), httpClient, tlsHttpClient)
+}
+
+// 同 NewV2, 每次签名时从 apiKey 获取 API 密钥, 用于密钥轮换或者从 KMS 获取密钥.
+func NewV2WithSecretProvider(appId, mchId string, apiKey wechatutil.SecretProvider, httpClient, tlsHttpClient *http.Client) *V2 {
+	v2 := &V2{
+		appId:  appId,
+		mchId:  mchId,
+		apiKey: apiKey,
+		proxy:  mch.NewProxyWithSecretProvider(apiKey, httpClient),
+	}
+	if tlsHttpClient != nil {
+		v2.tlsProxy = mch.NewProxyWithSecretProvider(apiKey, tlsHttpClient)
+	}
+	return v2
+}
+
+// 补充公共参数并签名.
+func (v2 *V2) sign(req map[string]string) (map[string]string, error) {
+	apiKey, err := v2.apiKey.Secret()
+	if err != nil {
+		return nil, err
+	}
+	nonceStr, err := sign.NonceStr()
+	if err != nil {
+		return nil, err
+	}
+	req["appid"] = v2.appId
+	req["mch_id"] = v2.mchId
+	req["nonce_str"] = nonceStr
+	req["sign"] = mch.Sign(req, apiKey, nil)
+	return req, nil
+}
+
+func checkResult(resp map[string]string) error {
+	if resp["result_code"] != mch.ResultCodeSuccess {
+		return &ResultError{
+			ErrCode:    resp["err_code"],
+			ErrCodeDes: resp["err_code_des"],
+		}
+	}
+	return nil
+}
+
+func (v2 *V2) CreateOrder(req *CreateOrderRequest) (resp *CreateOrderResponse, err error) {
+	if err = req.CheckValid(); err != nil {
+		return
+	}
+
+	m := map[string]string{
+		"body":             req.Description,
+		"out_trade_no":     req.OutTradeNo,
+		"total_fee":        req.Amount.FenString(),
+		"spbill_create_ip": req.ClientIP,
+		"notify_url":       req.NotifyURL,
+		"trade_type":       req.TradeType,
+	}
+	if req.OpenId != "" {
+		m["openid"] = req.OpenId
+	}
+	if !req.TimeExpire.IsZero() {
+		m["time_expire"] = req.TimeExpire.In(wechatutil.BeijingLocation).Format(v2TimeLayout)
+	}
+	if req.Attach != "" {
+		m["attach"] = req.Attach
+	}
+
+	if m, err = v2.sign(m); err != nil {
+		return
+	}
+	result, err := pay.UnifiedOrder(v2.proxy, m)
+	if err != nil {
+		return
+	}
+	if err = checkResult(result); err != nil {
+		return
+	}
+	resp = &CreateOrderResponse{
+		PrepayId: result["prepay_id"],
+		CodeURL:  result["code_url"],
+		MWebURL:  result["mweb_url"],
+	}
+	return
+}
+
+func (v2 *V2) QueryOrder(outTradeNo string) (order *Order, err error) {
+	if outTradeNo == "" {
+		err = errors.New("empty outTradeNo")
+		return
+	}
+
+	m, err := v2.sign(map[string]string{
+		"out_trade_no": outTradeNo,
+	})
+	if err != nil {
+		return
+	}
+	result, err := pay.OrderQuery(v2.proxy, m)
+	if err != nil {
+		return
+	}
+	if err = checkResult(result); err != nil {
+		return
+	}
+	return orderFromV2(result)
+}
+
+func (v2 *V2) Refund(req *RefundRequest) (resp *RefundResponse, err error) {
+	if err = req.CheckValid(); err != nil {
+		return
+	}
+	if v2.tlsProxy == nil {
+		err = errors.New("refund requires a tlsHttpClient with the merchant certificate")
+		return
+	}
+
+	m := map[string]string{
+		"out_trade_no":  req.OutTradeNo,
+		"out_refund_no": req.OutRefundNo,
+		"total_fee":     req.TotalAmount.FenString(),
+		"refund_fee":    req.RefundAmount.FenString(),
+	}
+	if req.Reason != "" {
+		m["refund_desc"] = req.Reason
+	}
+	if req.NotifyURL != "" {
+		m["notify_url"] = req.NotifyURL
+	}
+
+	if m, err = v2.sign(m); err != nil {
+		return
+	}
+	result, err := pay.Refund(v2.tlsProxy, m)
+	if err != nil {
+		return
+	}
+	if err = checkResult(result); err != nil {
+		return
+	}
+	resp = &RefundResponse{
+		RefundId:    result["refund_id"],
+		OutRefundNo: result["out_refund_no"],
+		Status:      RefundStatusProcessing,
+	}
+	return
+}
+
+func (v2 *V2) ParseNotify(r *http.Request) (order *Order, err error) {
+	msg, err := wechatutil.DecodeXMLToMap(r.Body)
+	if err != nil {
+		return
+	}
+
+	if returnCode := msg["return_code"]; returnCode != mch.ReturnCodeSuccess {
+		err = &mch.Error{
+			ReturnCode: returnCode,
+			ReturnMsg:  msg["return_msg"],
+		}
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(msg["appid"]), []byte(v2.appId)) != 1 {
+		err = fmt.Errorf("the message's appid mismatch, have: %s, want: %s", msg["appid"], v2.appId)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(msg["mch_id"]), []byte(v2.mchId)) != 1 {
+		err = fmt.Errorf("the message's mch_id mismatch, have: %s, want: %s", msg["mch_id"], v2.mchId)
+		return
+	}
+	signature1, ok := msg["sign"]
+	if !ok {
+		err = errors.New("no sign parameter")
+		return
+	}
+	apiKey, err := v2.apiKey.Secret()
+	if err != nil {
+		return
+	}
+	// 下单时指定了 sign_type 的订单, 通知按同样的 sign_type 签名
+	signature2, err := sign.Sum(msg, apiKey, msg["sign_type"])
+	if err != nil {
+		return
+	}
+	if !sign.Equal(signature1, signature2) {
+		err = sign.ErrSignatureMismatch // 不能带上本地计算的签名, 见 WriteNotifyResponse
+		return
+	}
+
+	if err = checkResult(msg); err != nil {
+		return
+	}
+	// 支付通知里没有 trade_state, 能收到 result_code 为 SUCCESS 的通知就表示支付成功.
+	if _, ok := msg["trade_state"]; !ok {
+		msg["trade_state"] = TradeStateSuccess
+	}
+	return orderFromV2(msg)
+}
+
+func (v2 *V2) WriteNotifyResponse(w http.ResponseWriter, err error) {
+	resp := &mch.Error{ReturnCode: mch.ReturnCodeSuccess, ReturnMsg: "OK"}
+	if err != nil {
+		mch.LogInfoln("[WECHAT_NOTIFY] v2 notification failed:", err)
+		resp = &mch.Error{ReturnCode: mch.ReturnCodeFail, ReturnMsg: NotifyFailMessage}
+	}
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	xml.NewEncoder(w).Encode(resp)
+}
+
+func orderFromV2(m map[string]string) (order *Order, err error) {
+	order = &Order{
+		OutTradeNo:    m["out_trade_no"],
+		TransactionId: m["transaction_id"],
+		TradeType:     m["trade_type"],
+		TradeState:    m["trade_state"],
+		OpenId:        m["openid"],
+		Attach:        m["attach"],
+	}
+	if s := m["total_fee"]; s != "" {
+		if order.Amount, err = mch.ParseFen(s); err != nil {
+			return nil, fmt.Errorf("invalid total_fee: %q", s)
+		}
+	}
+	order.PayerAmount = order.Amount
+	if s := m["cash_fee"]; s != "" {
+		if order.PayerAmount, err = mch.ParseFen(s); err != nil {
+			return nil, fmt.Errorf("invalid cash_fee: %q", s)
+		}
+	}
+	if s := m["time_end"]; s != "" {
+		if order.SuccessTime, err = time.ParseInLocation(v2TimeLayout, s, wechatutil.BeijingLocation); err != nil {
+			return nil, fmt.Errorf("invalid time_end: %q", s)
+		}
+	}
+	return
+}
@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payment
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mch/sign"
+	"github.com/chanxuehong/wechat/util"
+)
+
+func TestV2ParseNotifySignType(t *testing.T) {
+	const apiKey = "192006250b4c09247ec02edce69f6a2d"
+
+	tests := []struct {
+		name     string
+		signType string
+		sign     func(map[string]string, string) string
+		wantErr  bool
+	}{
+		{"no sign_type", "", sign.MD5, false},
+		{"MD5", sign.TypeMD5, sign.MD5, false},
+		{"HMAC-SHA256", sign.TypeHMACSHA256, sign.HMACSHA256, false},
+		{"HMAC-SHA256 signed with MD5", sign.TypeHMACSHA256, sign.MD5, true},
+		{"unsupported sign_type", "SHA1", sign.MD5, true},
+	}
+	for _, tt := range tests {
+		msg := map[string]string{
+			"return_code":    "SUCCESS",
+			"result_code":    "SUCCESS",
+			"appid":          "appid",
+			"mch_id":         "mchid",
+			"out_trade_no":   "trade-1",
+			"transaction_id": "tx-1",
+			"total_fee":      "100",
+		}
+		if tt.signType != "" {
+			msg["sign_type"] = tt.signType
+		}
+		msg["sign"] = tt.sign(msg, apiKey)
+
+		var body bytes.Buffer
+		if err := util.EncodeXMLFromMap(&body, msg); err != nil {
+			t.Fatal(err)
+		}
+		order, err := NewV2("appid", "mchid", apiKey, nil, nil).ParseNotify(httptest.NewRequest("POST", "/notify", &body))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if order.OutTradeNo != "trade-1" || order.TradeState != TradeStateSuccess {
+			t.Errorf("%s: have order %+v", tt.name, order)
+		}
+	}
+}
+
+func TestV2WriteNotifyResponse(t *testing.T) {
+	const apiKey = "192006250b4c09247ec02edce69f6a2d"
+
+	msg := map[string]string{
+		"return_code": "SUCCESS",
+		"appid":       "appid",
+		"mch_id":      "mchid",
+		"sign":        "forged",
+	}
+	var body bytes.Buffer
+	if err := util.EncodeXMLFromMap(&body, msg); err != nil {
+		t.Fatal(err)
+	}
+	v2 := NewV2("appid", "mchid", apiKey, nil, nil)
+	_, err := v2.ParseNotify(httptest.NewRequest("POST", "/notify", &body))
+	if err != sign.ErrSignatureMismatch {
+		t.Fatalf("have error %v, want %v", err, sign.ErrSignatureMismatch)
+	}
+
+	w := httptest.NewRecorder()
+	v2.WriteNotifyResponse(w, err)
+	resp, err := util.DecodeXMLToMap(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["return_code"] != "FAIL" || resp["return_msg"] != NotifyFailMessage {
+		t.Errorf("have response %v", resp)
+	}
+	if local := sign.MD5(msg, apiKey); strings.Contains(w.Body.String(), local) {
+		t.Errorf("response leaks the local signature: %s", w.Body.String())
+	}
+}
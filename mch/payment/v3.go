@@ -0,0 +1,235 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/chanxuehong/wechat/mch"
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+// 基于 v3(JSON) 接口的 Payments.
+type V3 struct {
+	appId  string
+	client *payv3.Client
+	notify *payv3.NotifyHandler
+}
+
+var _ Payments = (*V3)(nil)
+
+// 创建基于 v3 接口的 Payments.
+//  apiV3Key 用于解密回调通知, 回调通知的签名用 client.Verifier 验证.
+func NewV3(appId string, client *payv3.Client, apiV3Key string) *V3 {
+	return &V3{
+		appId:  appId,
+		client: client,
+		notify: &payv3.NotifyHandler{
+			APIv3Key: apiV3Key,
+			Verifier: client.Verifier,
+		},
+	}
+}
+
+var v3TradeTypePath = map[string]string{
+	TradeTypeJSAPI:  "jsapi",
+	TradeTypeNative: "native",
+	TradeTypeApp:    "app",
+	TradeTypeMWeb:   "h5",
+}
+
+type v3Amount struct {
+	Total         mch.Amount `json:"total"`
+	PayerTotal    mch.Amount `json:"payer_total,omitempty"`
+	Currency      string     `json:"currency,omitempty"`
+	PayerCurrency string     `json:"payer_currency,omitempty"`
+}
+
+type v3Payer struct {
+	OpenId string `json:"openid"`
+}
+
+type v3Transaction struct {
+	AppId         string   `json:"appid"`
+	MchId         string   `json:"mchid"`
+	OutTradeNo    string   `json:"out_trade_no"`
+	TransactionId string   `json:"transaction_id"`
+	TradeType     string   `json:"trade_type"`
+	TradeState    string   `json:"trade_state"`
+	SuccessTime   string   `json:"success_time"` // rfc3339
+	Attach        string   `json:"attach"`
+	Amount        v3Amount `json:"amount"`
+	Payer         v3Payer  `json:"payer"`
+}
+
+func (v3 *V3) CreateOrder(req *CreateOrderRequest) (resp *CreateOrderResponse, err error) {
+	if err = req.CheckValid(); err != nil {
+		return
+	}
+
+	type sceneInfo struct {
+		PayerClientIP string `json:"payer_client_ip"`
+		H5Info        *struct {
+			Type string `json:"type"`
+		} `json:"h5_info,omitempty"`
+	}
+	var request = struct {
+		AppId       string     `json:"appid"`
+		MchId       string     `json:"mchid"`
+		Description string     `json:"description"`
+		OutTradeNo  string     `json:"out_trade_no"`
+		TimeExpire  string     `json:"time_expire,omitempty"`
+		Attach      string     `json:"attach,omitempty"`
+		NotifyURL   string     `json:"notify_url"`
+		Amount      v3Amount   `json:"amount"`
+		Payer       *v3Payer   `json:"payer,omitempty"`
+		SceneInfo   *sceneInfo `json:"scene_info,omitempty"`
+	}{
+		AppId:       v3.appId,
+		MchId:       v3.client.MchId,
+		Description: req.Description,
+		OutTradeNo:  req.OutTradeNo,
+		Attach:      req.Attach,
+		NotifyURL:   req.NotifyURL,
+		Amount:      v3Amount{Total: req.Amount, Currency: "CNY"},
+	}
+	if !req.TimeExpire.IsZero() {
+		request.TimeExpire = req.TimeExpire.Format(time.RFC3339)
+	}
+	if req.OpenId != "" {
+		request.Payer = &v3Payer{OpenId: req.OpenId}
+	}
+	if req.ClientIP != "" || req.TradeType == TradeTypeMWeb {
+		request.SceneInfo = &sceneInfo{PayerClientIP: req.ClientIP}
+		if req.TradeType == TradeTypeMWeb {
+			request.SceneInfo.H5Info = &struct {
+				Type string `json:"type"`
+			}{Type: "Wap"}
+		}
+	}
+
+	var result struct {
+		PrepayId string `json:"prepay_id"`
+		CodeURL  string `json:"code_url"`
+		H5URL    string `json:"h5_url"`
+	}
+	if err = v3.client.PostJSON("/v3/pay/transactions/"+v3TradeTypePath[req.TradeType], &request, &result); err != nil {
+		return
+	}
+	resp = &CreateOrderResponse{
+		PrepayId: result.PrepayId,
+		CodeURL:  result.CodeURL,
+		MWebURL:  result.H5URL,
+	}
+	return
+}
+
+func (v3 *V3) QueryOrder(outTradeNo string) (order *Order, err error) {
+	if outTradeNo == "" {
+		err = errors.New("empty outTradeNo")
+		return
+	}
+
+	var result v3Transaction
+	urlPath := "/v3/pay/transactions/out-trade-no/" + url.PathEscape(outTradeNo) + "?mchid=" + url.QueryEscape(v3.client.MchId)
+	if err = v3.client.GetJSON(urlPath, &result); err != nil {
+		return
+	}
+	return orderFromV3(&result)
+}
+
+func (v3 *V3) Refund(req *RefundRequest) (resp *RefundResponse, err error) {
+	if err = req.CheckValid(); err != nil {
+		return
+	}
+
+	var request = struct {
+		OutTradeNo  string `json:"out_trade_no"`
+		OutRefundNo string `json:"out_refund_no"`
+		Reason      string `json:"reason,omitempty"`
+		NotifyURL   string `json:"notify_url,omitempty"`
+		Amount      struct {
+			Refund   mch.Amount `json:"refund"`
+			Total    mch.Amount `json:"total"`
+			Currency string     `json:"currency"`
+		} `json:"amount"`
+	}{
+		OutTradeNo:  req.OutTradeNo,
+		OutRefundNo: req.OutRefundNo,
+		Reason:      req.Reason,
+		NotifyURL:   req.NotifyURL,
+	}
+	request.Amount.Refund = req.RefundAmount
+	request.Amount.Total = req.TotalAmount
+	request.Amount.Currency = "CNY"
+
+	var result struct {
+		RefundId    string `json:"refund_id"`
+		OutRefundNo string `json:"out_refund_no"`
+		Status      string `json:"status"`
+	}
+	if err = v3.client.PostJSON("/v3/refund/domestic/refunds", &request, &result); err != nil {
+		return
+	}
+	resp = &RefundResponse{
+		RefundId:    result.RefundId,
+		OutRefundNo: result.OutRefundNo,
+		Status:      result.Status,
+	}
+	return
+}
+
+func (v3 *V3) ParseNotify(r *http.Request) (order *Order, err error) {
+	n, err := v3.notify.ParseRequest(r)
+	if err != nil {
+		return
+	}
+	var transaction v3Transaction
+	if err = n.Unmarshal(&transaction); err != nil {
+		return
+	}
+	if transaction.AppId != v3.appId {
+		err = fmt.Errorf("the notification's appid mismatch, have: %s, want: %s", transaction.AppId, v3.appId)
+		return
+	}
+	if transaction.MchId != v3.client.MchId {
+		err = fmt.Errorf("the notification's mchid mismatch, have: %s, want: %s", transaction.MchId, v3.client.MchId)
+		return
+	}
+	return orderFromV3(&transaction)
+}
+
+func (v3 *V3) WriteNotifyResponse(w http.ResponseWriter, err error) {
+	if err != nil {
+		mch.LogInfoln("[WECHAT_NOTIFY] v3 notification failed:", err)
+		payv3.WriteNotifyFail(w, NotifyFailMessage)
+		return
+	}
+	payv3.WriteNotifySuccess(w)
+}
+
+func orderFromV3(t *v3Transaction) (order *Order, err error) {
+	order = &Order{
+		OutTradeNo:    t.OutTradeNo,
+		TransactionId: t.TransactionId,
+		TradeType:     t.TradeType,
+		TradeState:    t.TradeState,
+		Amount:        t.Amount.Total,
+		PayerAmount:   t.Amount.PayerTotal,
+		OpenId:        t.Payer.OpenId,
+		Attach:        t.Attach,
+	}
+	if t.SuccessTime != "" {
+		if order.SuccessTime, err = time.Parse(time.RFC3339, t.SuccessTime); err != nil {
+			return nil, fmt.Errorf("invalid success_time: %q", t.SuccessTime)
+		}
+	}
+	return
+}
@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+const DefaultBaseURL = "https://api.mch.weixin.qq.com"
+
+// 微信支付 APIv3 的客户端.
+type Client struct {
+	MchId      string          // 商户号
+	SerialNo   string          // 商户 API 证书序列号
+	PrivateKey *rsa.PrivateKey // 商户 API 证书私钥, 见 LoadPrivateKey
+
+	// 可以为 nil; 不为 nil 时替代 SerialNo 和 PrivateKey, 用于证书轮换或者从 KMS 获取私钥.
+	PrivateKeyProvider PrivateKeyProvider
+
+	Verifier   Verifier     // 可以为 nil, 表示不验证应答的签名(不推荐)
+	BaseURL    string       // 为空则为 DefaultBaseURL
+	HttpClient *http.Client // 如果 HttpClient == nil 则默认用 http.DefaultClient
+}
+
+// 创建一个新的 Client.
+//  如果 httpClient == nil 则默认用 http.DefaultClient
+func NewClient(mchId, serialNo string, privateKey *rsa.PrivateKey, verifier Verifier, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		MchId:      mchId,
+		SerialNo:   serialNo,
+		PrivateKey: privateKey,
+		Verifier:   verifier,
+		HttpClient: httpClient,
+	}
+}
+
+func (clt *Client) baseURL() string {
+	if clt.BaseURL != "" {
+		return clt.BaseURL
+	}
+	return DefaultBaseURL
+}
+
+func (clt *Client) httpClient() *http.Client {
+	if clt.HttpClient != nil {
+		return clt.HttpClient
+	}
+	return http.DefaultClient
+}
+
+// 用 POST 方法请求 urlPath(比如 /v3/marketing/paygiftactivity/unique-threshold-activity),
+// request 用 json 编码后作为 http body, 应答的 http body 解码到 response, response 为 nil 时忽略应答.
+func (clt *Client) PostJSON(urlPath string, request interface{}, response interface{}) (err error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+	resp, err := clt.do("POST", urlPath, body, nil)
+	if err != nil {
+		return
+	}
+	return resp.Unmarshal(response)
+}
+
+// 用 GET 方法请求 urlPath, urlPath 可以包含 query, 应答的 http body 解码到 response.
+func (clt *Client) GetJSON(urlPath string, response interface{}) (err error) {
+	resp, err := clt.do("GET", urlPath, nil, nil)
+	if err != nil {
+		return
+	}
+	return resp.Unmarshal(response)
+}
+
+// 验证应答的签名, Verifier 为 nil 时不验证.
+func (clt *Client) verifyResponse(header http.Header, body []byte) error {
+	if clt.Verifier == nil {
+		return nil
+	}
+	signature := header.Get("Wechatpay-Signature")
+	if signature == "" {
+		return errors.New("no Wechatpay-Signature header")
+	}
+	message := buildMessage(header.Get("Wechatpay-Timestamp"), header.Get("Wechatpay-Nonce"), string(body))
+	return clt.Verifier.Verify(header.Get("Wechatpay-Serial"), message, signature)
+}
@@ -0,0 +1,362 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package applyment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+// 主体类型 subject_type
+const (
+	SubjectTypeIndividual   = "SUBJECT_TYPE_INDIVIDUAL"   // 个体户
+	SubjectTypeEnterprise   = "SUBJECT_TYPE_ENTERPRISE"   // 企业
+	SubjectTypeGovernment   = "SUBJECT_TYPE_GOVERNMENT"   // 政府机关
+	SubjectTypeInstitutions = "SUBJECT_TYPE_INSTITUTIONS" // 事业单位
+	SubjectTypeOthers       = "SUBJECT_TYPE_OTHERS"       // 社会组织
+)
+
+// 超级管理员类型 contact_type
+const (
+	ContactTypeLegal = "LEGAL" // 经营者/法人
+	ContactTypeSuper = "SUPER" // 经办人
+)
+
+// 经营场景类型 sales_scenes_type
+const (
+	SalesScenesStore       = "SALES_SCENES_STORE"        // 线下场所
+	SalesScenesMP          = "SALES_SCENES_MP"           // 公众号
+	SalesScenesMiniProgram = "SALES_SCENES_MINI_PROGRAM" // 小程序
+	SalesScenesWeb         = "SALES_SCENES_WEB"          // 互联网网站
+	SalesScenesApp         = "SALES_SCENES_APP"          // APP
+	SalesScenesWework      = "SALES_SCENES_WEWORK"       // 企业微信
+)
+
+// 账户类型 bank_account_type
+const (
+	BankAccountTypeCorporate = "BANK_ACCOUNT_TYPE_CORPORATE" // 对公银行账户
+	BankAccountTypePersonal  = "BANK_ACCOUNT_TYPE_PERSONAL"  // 经营者个人银行卡
+)
+
+// 申请单状态 applyment_state
+const (
+	ApplymentStateEditing       = "APPLYMENT_STATE_EDITTING"        // 编辑中
+	ApplymentStateAuditing      = "APPLYMENT_STATE_AUDITING"        // 审核中
+	ApplymentStateRejected      = "APPLYMENT_STATE_REJECTED"        // 已驳回
+	ApplymentStateToBeConfirmed = "APPLYMENT_STATE_TO_BE_CONFIRMED" // 待账户验证
+	ApplymentStateToBeSigned    = "APPLYMENT_STATE_TO_BE_SIGNED"    // 待签约
+	ApplymentStateSigning       = "APPLYMENT_STATE_SIGNING"         // 开通权限中
+	ApplymentStateFinished      = "APPLYMENT_STATE_FINISHED"        // 已完成
+	ApplymentStateCanceled      = "APPLYMENT_STATE_CANCELED"        // 已作废
+)
+
+// 特约商户进件申请, 标记为"敏感"的字段提交时会被加密.
+type Applyment struct {
+	BusinessCode    string           `json:"business_code"` // 业务申请编号, 服务商自定义, 唯一
+	ContactInfo     ContactInfo      `json:"contact_info"`
+	SubjectInfo     SubjectInfo      `json:"subject_info"`
+	BusinessInfo    BusinessInfo     `json:"business_info"`
+	SettlementInfo  SettlementInfo   `json:"settlement_info"`
+	BankAccountInfo *BankAccountInfo `json:"bank_account_info,omitempty"`
+	AdditionInfo    *AdditionInfo    `json:"addition_info,omitempty"`
+}
+
+// 超级管理员信息
+type ContactInfo struct {
+	ContactType                 string `json:"contact_type,omitempty"` // ContactTypeLegal, ContactTypeSuper
+	ContactName                 string `json:"contact_name"`           // 敏感
+	ContactIdDocType            string `json:"contact_id_doc_type,omitempty"`
+	ContactIdNumber             string `json:"contact_id_number,omitempty"` // 敏感
+	ContactIdDocCopy            string `json:"contact_id_doc_copy,omitempty"`
+	ContactIdDocCopyBack        string `json:"contact_id_doc_copy_back,omitempty"`
+	ContactPeriodBegin          string `json:"contact_period_begin,omitempty"`
+	ContactPeriodEnd            string `json:"contact_period_end,omitempty"`
+	BusinessAuthorizationLetter string `json:"business_authorization_letter,omitempty"`
+	OpenId                      string `json:"openid,omitempty"`        // 敏感
+	MobilePhone                 string `json:"mobile_phone"`            // 敏感
+	ContactEmail                string `json:"contact_email,omitempty"` // 敏感
+}
+
+// 主体资料
+type SubjectInfo struct {
+	SubjectType            string                  `json:"subject_type"` // SubjectTypeIndividual...
+	FinanceInstitution     bool                    `json:"finance_institution,omitempty"`
+	BusinessLicenseInfo    *BusinessLicenseInfo    `json:"business_license_info,omitempty"` // 个体户, 企业必填
+	CertificateInfo        *CertificateInfo        `json:"certificate_info,omitempty"`      // 政府机关, 事业单位, 社会组织必填
+	CertificateLetterCopy  string                  `json:"certificate_letter_copy,omitempty"`
+	FinanceInstitutionInfo *FinanceInstitutionInfo `json:"finance_institution_info,omitempty"`
+	IdentityInfo           IdentityInfo            `json:"identity_info"`
+	UboInfoList            []UboInfo               `json:"ubo_info_list,omitempty"` // 最终受益人, 主体为企业时需要
+}
+
+// 营业执照
+type BusinessLicenseInfo struct {
+	LicenseCopy    string `json:"license_copy"`
+	LicenseNumber  string `json:"license_number"`
+	MerchantName   string `json:"merchant_name"`
+	LegalPerson    string `json:"legal_person"`
+	LicenseAddress string `json:"license_address,omitempty"`
+	PeriodBegin    string `json:"period_begin,omitempty"`
+	PeriodEnd      string `json:"period_end,omitempty"` // 长期有效填 "长期"
+}
+
+// 登记证书
+type CertificateInfo struct {
+	CertCopy       string `json:"cert_copy"`
+	CertType       string `json:"cert_type,omitempty"`
+	CertNumber     string `json:"cert_number"`
+	MerchantName   string `json:"merchant_name"`
+	CompanyAddress string `json:"company_address"`
+	LegalPerson    string `json:"legal_person"`
+	PeriodBegin    string `json:"period_begin"`
+	PeriodEnd      string `json:"period_end"`
+}
+
+// 金融机构许可证信息
+type FinanceInstitutionInfo struct {
+	FinanceType        string   `json:"finance_type"`
+	FinanceLicensePics []string `json:"finance_license_pics"`
+}
+
+// 经营者/法人身份证件
+type IdentityInfo struct {
+	IdHolderType        string      `json:"id_holder_type,omitempty"` // ContactTypeLegal, ContactTypeSuper
+	IdDocType           string      `json:"id_doc_type,omitempty"`    // 默认 IDENTIFICATION_TYPE_IDCARD
+	AuthorizeLetterCopy string      `json:"authorize_letter_copy,omitempty"`
+	IdCardInfo          *IdCardInfo `json:"id_card_info,omitempty"` // 证件类型为身份证时填写
+	IdDocInfo           *IdDocInfo  `json:"id_doc_info,omitempty"`  // 证件类型为其他证件时填写
+	Owner               bool        `json:"owner,omitempty"`        // 经营者/法人是否为受益人
+}
+
+type IdCardInfo struct {
+	IdCardCopy      string `json:"id_card_copy"`
+	IdCardNational  string `json:"id_card_national"`
+	IdCardName      string `json:"id_card_name"`              // 敏感
+	IdCardNumber    string `json:"id_card_number"`            // 敏感
+	IdCardAddress   string `json:"id_card_address,omitempty"` // 敏感
+	CardPeriodBegin string `json:"card_period_begin"`
+	CardPeriodEnd   string `json:"card_period_end"`
+}
+
+type IdDocInfo struct {
+	IdDocCopy      string `json:"id_doc_copy"`
+	IdDocCopyBack  string `json:"id_doc_copy_back,omitempty"`
+	IdDocName      string `json:"id_doc_name"`              // 敏感
+	IdDocNumber    string `json:"id_doc_number"`            // 敏感
+	IdDocAddress   string `json:"id_doc_address,omitempty"` // 敏感
+	DocPeriodBegin string `json:"doc_period_begin"`
+	DocPeriodEnd   string `json:"doc_period_end"`
+}
+
+// 最终受益人
+type UboInfo struct {
+	UboIdDocType     string `json:"ubo_id_doc_type"`
+	UboIdDocCopy     string `json:"ubo_id_doc_copy"`
+	UboIdDocCopyBack string `json:"ubo_id_doc_copy_back,omitempty"`
+	UboIdDocName     string `json:"ubo_id_doc_name"`              // 敏感
+	UboIdDocNumber   string `json:"ubo_id_doc_number"`            // 敏感
+	UboIdDocAddress  string `json:"ubo_id_doc_address,omitempty"` // 敏感
+	UboPeriodBegin   string `json:"ubo_period_begin"`
+	UboPeriodEnd     string `json:"ubo_period_end"`
+}
+
+// 经营资料
+type BusinessInfo struct {
+	MerchantShortname string    `json:"merchant_shortname"` // 商户简称, 在支付完成页向买家展示
+	ServicePhone      string    `json:"service_phone"`
+	SalesInfo         SalesInfo `json:"sales_info"`
+}
+
+// 经营场景, SalesScenesType 里的每个场景需要填写对应的信息.
+type SalesInfo struct {
+	SalesScenesType []string `json:"sales_scenes_type"` // SalesScenesStore...
+	BizStoreInfo    *struct {
+		BizStoreName     string   `json:"biz_store_name"`
+		BizAddressCode   string   `json:"biz_address_code"`
+		BizStoreAddress  string   `json:"biz_store_address"`
+		StoreEntrancePic []string `json:"store_entrance_pic"`
+		IndoorPic        []string `json:"indoor_pic"`
+		BizSubAppid      string   `json:"biz_sub_appid,omitempty"`
+	} `json:"biz_store_info,omitempty"`
+	MpInfo *struct {
+		MpAppid    string   `json:"mp_appid,omitempty"`
+		MpSubAppid string   `json:"mp_sub_appid,omitempty"`
+		MpPics     []string `json:"mp_pics"`
+	} `json:"mp_info,omitempty"`
+	MiniProgramInfo *struct {
+		MiniProgramAppid    string   `json:"mini_program_appid,omitempty"`
+		MiniProgramSubAppid string   `json:"mini_program_sub_appid,omitempty"`
+		MiniProgramPics     []string `json:"mini_program_pics,omitempty"`
+	} `json:"mini_program_info,omitempty"`
+	AppInfo *struct {
+		AppAppid    string   `json:"app_appid,omitempty"`
+		AppSubAppid string   `json:"app_sub_appid,omitempty"`
+		AppPics     []string `json:"app_pics"`
+	} `json:"app_info,omitempty"`
+	WebInfo *struct {
+		Domain           string `json:"domain"`
+		WebAuthorisation string `json:"web_authorisation,omitempty"`
+		WebAppid         string `json:"web_appid,omitempty"`
+	} `json:"web_info,omitempty"`
+	WeworkInfo *struct {
+		SubCorpId  string   `json:"sub_corp_id"`
+		WeworkPics []string `json:"wework_pics"`
+	} `json:"wework_info,omitempty"`
+}
+
+// 结算规则
+type SettlementInfo struct {
+	SettlementId         string   `json:"settlement_id"`      // 入驻结算规则 ID
+	QualificationType    string   `json:"qualification_type"` // 所属行业
+	Qualifications       []string `json:"qualifications,omitempty"`
+	ActivitiesId         string   `json:"activities_id,omitempty"`
+	ActivitiesRate       string   `json:"activities_rate,omitempty"`
+	ActivitiesAdditions  []string `json:"activities_additions,omitempty"`
+	DebitActivitiesRate  string   `json:"debit_activities_rate,omitempty"`
+	CreditActivitiesRate string   `json:"credit_activities_rate,omitempty"`
+}
+
+// 结算银行账户
+type BankAccountInfo struct {
+	BankAccountType string `json:"bank_account_type"` // BankAccountTypeCorporate, BankAccountTypePersonal
+	AccountName     string `json:"account_name"`      // 敏感
+	AccountBank     string `json:"account_bank"`
+	BankAddressCode string `json:"bank_address_code"`
+	BankBranchId    string `json:"bank_branch_id,omitempty"`
+	BankName        string `json:"bank_name,omitempty"`
+	AccountNumber   string `json:"account_number"` // 敏感
+}
+
+// 补充材料
+type AdditionInfo struct {
+	LegalPersonCommitment string   `json:"legal_person_commitment,omitempty"`
+	LegalPersonVideo      string   `json:"legal_person_video,omitempty"`
+	BusinessAdditionPics  []string `json:"business_addition_pics,omitempty"`
+	BusinessAdditionMsg   string   `json:"business_addition_msg,omitempty"`
+}
+
+// 加密申请里的敏感字段, 只能调用一次.
+func (applyment *Applyment) Encrypt(enc *payv3.SensitiveEncryptor) (err error) {
+	contact := &applyment.ContactInfo
+	fields := []*string{
+		&contact.ContactName, &contact.ContactIdNumber, &contact.OpenId, &contact.MobilePhone, &contact.ContactEmail,
+	}
+	if info := applyment.SubjectInfo.IdentityInfo.IdCardInfo; info != nil {
+		fields = append(fields, &info.IdCardName, &info.IdCardNumber, &info.IdCardAddress)
+	}
+	if info := applyment.SubjectInfo.IdentityInfo.IdDocInfo; info != nil {
+		fields = append(fields, &info.IdDocName, &info.IdDocNumber, &info.IdDocAddress)
+	}
+	for i := range applyment.SubjectInfo.UboInfoList {
+		info := &applyment.SubjectInfo.UboInfoList[i]
+		fields = append(fields, &info.UboIdDocName, &info.UboIdDocNumber, &info.UboIdDocAddress)
+	}
+	if info := applyment.BankAccountInfo; info != nil {
+		fields = append(fields, &info.AccountName, &info.AccountNumber)
+	}
+	return enc.EncryptFields(fields...)
+}
+
+// 提交特约商户进件申请, 返回微信支付的申请单号.
+//  applyment 里的敏感字段为明文, 提交时用 enc(微信支付平台证书)加密, applyment 本身不会被修改.
+func (clt Client) Submit(applyment *Applyment, enc *payv3.SensitiveEncryptor) (applymentId int64, err error) {
+	if applyment == nil {
+		err = errors.New("nil Applyment")
+		return
+	}
+	if enc == nil {
+		err = errors.New("nil SensitiveEncryptor")
+		return
+	}
+
+	// 深拷贝后再加密, 不修改调用者的 applyment
+	b, err := json.Marshal(applyment)
+	if err != nil {
+		return
+	}
+	var request Applyment
+	if err = json.Unmarshal(b, &request); err != nil {
+		return
+	}
+	if err = request.Encrypt(enc); err != nil {
+		return
+	}
+
+	resp, err := clt.Do(&payv3.Request{
+		Method:  "POST",
+		URLPath: "/v3/applyment4sub/applyment/",
+		Body:    &request,
+		Header:  enc.Header(),
+	})
+	if err != nil {
+		return
+	}
+
+	var result struct {
+		ApplymentId int64 `json:"applyment_id"`
+	}
+	if err = resp.Unmarshal(&result); err != nil {
+		return
+	}
+	applymentId = result.ApplymentId
+	return
+}
+
+// 驳回原因
+type AuditDetail struct {
+	Field        string `json:"field"`
+	FieldName    string `json:"field_name"`
+	RejectReason string `json:"reject_reason"`
+}
+
+// 申请单状态
+type ApplymentStatus struct {
+	BusinessCode      string        `json:"business_code"`
+	ApplymentId       int64         `json:"applyment_id"`
+	SubMchId          string        `json:"sub_mchid"` // 特约商户号, 申请单完成后才有
+	SignURL           string        `json:"sign_url"`  // 超级管理员签约链接
+	ApplymentState    string        `json:"applyment_state"`
+	ApplymentStateMsg string        `json:"applyment_state_msg"`
+	AuditDetail       []AuditDetail `json:"audit_detail"` // 驳回原因, 已驳回时才有
+}
+
+// 是否已经结束(已完成, 已驳回或者已作废).
+func (status *ApplymentStatus) Finished() bool {
+	switch status.ApplymentState {
+	case ApplymentStateFinished, ApplymentStateRejected, ApplymentStateCanceled:
+		return true
+	}
+	return false
+}
+
+// 通过业务申请编号查询申请单状态.
+func (clt Client) QueryByBusinessCode(businessCode string) (status *ApplymentStatus, err error) {
+	if businessCode == "" {
+		err = errors.New("empty businessCode")
+		return
+	}
+
+	var result ApplymentStatus
+	if err = clt.GetJSON("/v3/applyment4sub/applyment/business_code/"+url.PathEscape(businessCode), &result); err != nil {
+		return
+	}
+	status = &result
+	return
+}
+
+// 通过申请单号查询申请单状态.
+func (clt Client) QueryByApplymentId(applymentId int64) (status *ApplymentStatus, err error) {
+	var result ApplymentStatus
+	if err = clt.GetJSON("/v3/applyment4sub/applyment/applyment_id/"+strconv.FormatInt(applymentId, 10), &result); err != nil {
+		return
+	}
+	status = &result
+	return
+}
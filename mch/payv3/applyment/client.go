@@ -0,0 +1,17 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 APIv3 特约商户进件, 服务商为特约商户(子商户)提交进件申请和查询申请状态.
+//  图片, 视频字段为 payv3.Client.UploadImage, payv3.Client.UploadVideo 返回的 media_id;
+//  敏感信息(姓名, 证件号码, 手机号, 银行账号等)提交时用 payv3.SensitiveEncryptor 加密, 见 Client.Submit.
+package applyment
+
+import (
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+type Client struct {
+	*payv3.Client
+}
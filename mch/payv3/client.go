@@ -0,0 +1,66 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+//go:build !wechatdebug
+// +build !wechatdebug
+
+package payv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// 发送签名后的请求, 2xx 以外的状态码返回 *Error, 否则验证应答的签名后返回应答.
+//  header 为额外的请求 header, 可以为 nil.
+func (clt *Client) do(method, urlPath string, body []byte, header http.Header) (resp *Response, err error) {
+	authorization, err := clt.authorization(method, urlPath, body, time.Now().Unix())
+	if err != nil {
+		return
+	}
+
+	httpReq, err := http.NewRequest(method, clt.baseURL()+urlPath, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	for k, v := range header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Authorization", authorization)
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := clt.httpClient().Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		e := &Error{StatusCode: httpResp.StatusCode}
+		json.Unmarshal(respBody, e)
+		err = e
+		return
+	}
+	if err = clt.verifyResponse(httpResp.Header, respBody); err != nil {
+		return
+	}
+	resp = &Response{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       respBody,
+	}
+	return
+}
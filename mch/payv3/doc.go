@@ -0,0 +1,10 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 APIv3.
+//  请求用商户 API 证书的私钥签名(SHA256-RSA2048), 应答和回调通知用微信支付平台证书验签,
+//  回调通知的 resource 用 APIv3 密钥解密(AEAD_AES_256_GCM).
+//  没有封装的接口可以用 Client.Do 直接调用, 或者用 RegisterEndpoint 注册后用 Client.Call 调用.
+package payv3
@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 下载文件的 hash_type
+const (
+	HashTypeSHA1   = "SHA1"
+	HashTypeSHA256 = "SHA256"
+)
+
+var ErrDownloadHashMismatch = errors.New("hash of the downloaded file mismatch")
+
+// 下载 APIv3 接口返回的 download_url(比如账单, 电子回单)指向的文件, 写入 w.
+//  下载请求同样需要签名, 但是应答没有签名, 所以不验签; hashType 和 hashValue 不为空时用它们校验文件的完整性,
+//  不一致时返回 ErrDownloadHashMismatch, 这时 w 里已经写入了不完整或者被篡改的数据, 调用者需要丢弃.
+func (clt *Client) Download(downloadURL, hashType, hashValue string, w io.Writer) (written int64, err error) {
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return
+	}
+	urlPath := u.EscapedPath()
+	if u.RawQuery != "" {
+		urlPath += "?" + u.RawQuery
+	}
+
+	var h hash.Hash
+	switch strings.ToUpper(hashType) {
+	case "":
+	case HashTypeSHA1:
+		h = sha1.New()
+	case HashTypeSHA256:
+		h = sha256.New()
+	default:
+		err = fmt.Errorf("unsupported hash_type: %s", hashType)
+		return
+	}
+
+	authorization, err := clt.authorization("GET", urlPath, nil, time.Now().Unix())
+	if err != nil {
+		return
+	}
+	httpReq, err := http.NewRequest("GET", downloadURL, nil)
+	if err != nil {
+		return
+	}
+	httpReq.Header.Set("Authorization", authorization)
+
+	httpResp, err := clt.httpClient().Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		e := &Error{StatusCode: httpResp.StatusCode}
+		if respBody, _ := ioutil.ReadAll(io.LimitReader(httpResp.Body, 64<<10)); len(respBody) > 0 {
+			json.Unmarshal(respBody, e)
+		}
+		err = e
+		return
+	}
+
+	if h == nil {
+		return io.Copy(w, httpResp.Body)
+	}
+	if written, err = io.Copy(io.MultiWriter(w, h), httpResp.Body); err != nil {
+		return
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); subtle.ConstantTimeCompare([]byte(sum), []byte(strings.ToLower(hashValue))) != 1 {
+		err = ErrDownloadHashMismatch
+		return
+	}
+	return
+}
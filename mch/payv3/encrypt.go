@@ -0,0 +1,79 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+)
+
+// 加密请求里的敏感信息(姓名, 证件号码, 银行账号等), 用微信支付平台证书的公钥做 RSA-OAEP 加密.
+//  请求里有加密的字段时, 需要设置 Header 为 Wechatpay-Serial: SerialNo, 见 Header.
+type SensitiveEncryptor struct {
+	SerialNo  string // 微信支付平台证书序列号
+	PublicKey *rsa.PublicKey
+}
+
+// 用微信支付平台证书创建 SensitiveEncryptor.
+func NewSensitiveEncryptor(cert *x509.Certificate) (enc *SensitiveEncryptor, err error) {
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		err = errors.New("certificate public key is not a rsa public key")
+		return
+	}
+	enc = &SensitiveEncryptor{
+		SerialNo:  CertificateSerialNo(cert),
+		PublicKey: publicKey,
+	}
+	return
+}
+
+// 加密 plaintext, 返回 base64 编码的密文.
+func (enc *SensitiveEncryptor) Encrypt(plaintext string) (ciphertext string, err error) {
+	sealed, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, enc.PublicKey, []byte(plaintext), nil)
+	if err != nil {
+		return
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// 加密 fields 指向的字符串, 空字符串不加密.
+func (enc *SensitiveEncryptor) EncryptFields(fields ...*string) (err error) {
+	for _, field := range fields {
+		if field == nil || *field == "" {
+			continue
+		}
+		if *field, err = enc.Encrypt(*field); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 请求需要的 Wechatpay-Serial header, 见 Request.Header.
+func (enc *SensitiveEncryptor) Header() http.Header {
+	header := make(http.Header, 1)
+	header.Set("Wechatpay-Serial", enc.SerialNo)
+	return header
+}
+
+// 用商户 API 证书私钥解密应答里的敏感信息, ciphertext 为 base64 编码的密文.
+func DecryptSensitive(privateKey *rsa.PrivateKey, ciphertext string) (plaintext string, err error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return
+	}
+	b, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, privateKey, sealed, nil)
+	if err != nil {
+		return
+	}
+	return string(b), nil
+}
@@ -0,0 +1,111 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 一个 APIv3 接口, Path 里的 {name} 为路径参数, 比如 /v3/vehicle/transactions/out-trade-no/{out_trade_no}.
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+// 用 params 替换 Path 里的路径参数(会做 PathEscape), 再加上 query, query 可以为 nil.
+func (ep Endpoint) URLPath(params map[string]string, query url.Values) (urlPath string, err error) {
+	path := ep.Path
+	for {
+		i := strings.IndexByte(path, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(path[i:], '}')
+		if j < 0 {
+			return "", fmt.Errorf("invalid endpoint path %q", ep.Path)
+		}
+		name := path[i+1 : i+j]
+		value, ok := params[name]
+		if !ok || value == "" {
+			return "", fmt.Errorf("missing path parameter %q for %s", name, ep.Path)
+		}
+		urlPath += path[:i] + url.PathEscape(value)
+		path = path[i+j+1:]
+	}
+	urlPath += path
+	if len(query) > 0 {
+		urlPath += "?" + query.Encode()
+	}
+	return
+}
+
+var endpoints = struct {
+	sync.RWMutex
+	m map[string]Endpoint
+}{
+	m: make(map[string]Endpoint),
+}
+
+// 注册一个接口, 之后可以通过 Client.Call 用 name 调用; 同名的接口会被覆盖.
+//  一般在 init 里注册, 本包已经注册了一些行业接口, 见 endpoint_vertical.go.
+func RegisterEndpoint(name string, ep Endpoint) {
+	if name == "" {
+		panic("empty endpoint name")
+	}
+	if ep.Method == "" || !strings.HasPrefix(ep.Path, "/") {
+		panic(fmt.Sprintf("invalid endpoint %q: %+v", name, ep))
+	}
+	ep.Method = strings.ToUpper(ep.Method)
+
+	endpoints.Lock()
+	endpoints.m[name] = ep
+	endpoints.Unlock()
+}
+
+// 查找注册的接口.
+func LookupEndpoint(name string) (ep Endpoint, ok bool) {
+	endpoints.RLock()
+	ep, ok = endpoints.m[name]
+	endpoints.RUnlock()
+	return
+}
+
+// 返回所有注册的接口名, 按字母排序.
+func EndpointNames() (names []string) {
+	endpoints.RLock()
+	for name := range endpoints.m {
+		names = append(names, name)
+	}
+	endpoints.RUnlock()
+	sort.Strings(names)
+	return
+}
+
+// 调用注册为 name 的接口, params 为路径参数, query 为查询参数, 都可以为 nil.
+//  request 为 nil 时不发送 http body, 应答的 json 解码到 response, response 为 nil 时忽略应答.
+func (clt *Client) Call(name string, params map[string]string, query url.Values, request interface{}, response interface{}) (err error) {
+	ep, ok := LookupEndpoint(name)
+	if !ok {
+		return fmt.Errorf("endpoint %q not registered", name)
+	}
+	urlPath, err := ep.URLPath(params, query)
+	if err != nil {
+		return
+	}
+	resp, err := clt.Do(&Request{
+		Method:  ep.Method,
+		URLPath: urlPath,
+		Body:    request,
+	})
+	if err != nil {
+		return
+	}
+	return resp.Unmarshal(response)
+}
@@ -0,0 +1,22 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+// 已经注册的行业接口, 请求和应答的字段见微信支付的文档.
+const (
+	// 停车服务
+	EndpointParkingFindService = "vehicle.parking.services.find"     // 查询车牌服务开通信息
+	EndpointParkingCreate      = "vehicle.parking.parkings.create"   // 创建停车入场
+	EndpointParkingPay         = "vehicle.transactions.parking"      // 扣费受理
+	EndpointParkingQueryOrder  = "vehicle.transactions.out-trade-no" // 查询订单, 路径参数 out_trade_no
+)
+
+func init() {
+	RegisterEndpoint(EndpointParkingFindService, Endpoint{Method: "GET", Path: "/v3/vehicle/parking/services/find"})
+	RegisterEndpoint(EndpointParkingCreate, Endpoint{Method: "POST", Path: "/v3/vehicle/parking/parkings"})
+	RegisterEndpoint(EndpointParkingPay, Endpoint{Method: "POST", Path: "/v3/vehicle/transactions/parking"})
+	RegisterEndpoint(EndpointParkingQueryOrder, Endpoint{Method: "GET", Path: "/v3/vehicle/transactions/out-trade-no/{out_trade_no}"})
+}
@@ -0,0 +1,15 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 APIv3 营销工具: 先享卡, 支付有礼.
+package marketing
+
+import (
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+type Client struct {
+	*payv3.Client
+}
@@ -0,0 +1,173 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package marketing
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+// 先享卡回调通知的 event_type
+const (
+	EventTypeDiscountCardUserAccepted   = "DISCOUNT_CARD.USER_ACCEPTED"   // 用户领取先享卡
+	EventTypeDiscountCardAgreementEnded = "DISCOUNT_CARD.AGREEMENT_ENDED" // 先享卡守约状态变化
+	EventTypeDiscountCardUserPaid       = "DISCOUNT_CARD.USER_PAID"       // 先享卡扣费状态变化
+)
+
+// 先享卡的状态
+const (
+	DiscountCardStateOngoing             = "ONGOING"              // 约定进行中
+	DiscountCardStateSettling            = "SETTLING"             // 约定到期核对中
+	DiscountCardStateSettlementCompleted = "SETTLEMENT_COMPLETED" // 已完成约定
+	DiscountCardStateExpired             = "EXPIRED"              // 未完成约定
+)
+
+// 预受理领卡请求的参数
+type DiscountCardPrepareParameters struct {
+	OutCardCode    string `json:"out_card_code"`    // 商户领卡号, 商户侧唯一
+	CardTemplateId string `json:"card_template_id"` // 先享卡模板ID
+	AppId          string `json:"appid"`
+	NotifyURL      string `json:"notify_url"`
+}
+
+// 预受理领卡请求, 返回的 prepareCardToken 用于小程序拉起领卡页面.
+func (clt Client) DiscountCardPrepare(para *DiscountCardPrepareParameters) (prepareCardToken string, err error) {
+	if para == nil {
+		err = errors.New("nil parameters")
+		return
+	}
+
+	var result struct {
+		PrepareCardToken string `json:"prepare_card_token"`
+	}
+	if err = clt.PostJSON("/v3/discount-card/cards", para, &result); err != nil {
+		return
+	}
+	prepareCardToken = result.PrepareCardToken
+	return
+}
+
+// 先享卡的时间范围
+type DiscountCardTimeRange struct {
+	BeginTime string `json:"begin_time"` // rfc3339
+	EndTime   string `json:"end_time"`   // rfc3339
+}
+
+// 先享卡的目标或者优惠的完成情况
+type DiscountCardRecord struct {
+	Name        string `json:"name"`
+	Count       int64  `json:"count,omitempty"`
+	Amount      int64  `json:"amount,omitempty"` // 单位为分
+	Description string `json:"description,omitempty"`
+}
+
+// 先享卡订单, 也是 EventTypeDiscountCardUserAccepted 等回调通知解密后的数据
+type DiscountCard struct {
+	CardId           string                `json:"card_id"`
+	CardTemplateId   string                `json:"card_template_id"`
+	OutCardCode      string                `json:"out_card_code"`
+	AppId            string                `json:"appid"`
+	MchId            string                `json:"mchid"`
+	OpenId           string                `json:"openid"`
+	TimeRange        DiscountCardTimeRange `json:"time_range"`
+	State            string                `json:"state"`             // DiscountCardStateOngoing...
+	UnfinishedReason string                `json:"unfinished_reason"` // 未完成约定的原因
+	TotalAmount      int64                 `json:"total_amount"`      // 享受优惠的总金额, 单位为分
+	CreateTime       string                `json:"create_time"`
+
+	Objectives     []DiscountCardRecord        `json:"objectives,omitempty"`      // 约定目标
+	Rewards        []DiscountCardRecord        `json:"rewards,omitempty"`         // 优惠
+	PayInformation *DiscountCardPayInformation `json:"pay_information,omitempty"` // 扣费信息
+}
+
+// 先享卡的扣费信息
+type DiscountCardPayInformation struct {
+	PayAmount     int64  `json:"pay_amount"` // 单位为分
+	PayState      string `json:"pay_state"`
+	TransactionId string `json:"transaction_id,omitempty"`
+	PayTime       string `json:"pay_time,omitempty"`
+}
+
+// 查询先享卡订单.
+func (clt Client) DiscountCard(outCardCode string) (card *DiscountCard, err error) {
+	if outCardCode == "" {
+		err = errors.New("empty outCardCode")
+		return
+	}
+
+	var result DiscountCard
+	if err = clt.GetJSON("/v3/discount-card/cards/"+url.PathEscape(outCardCode), &result); err != nil {
+		return
+	}
+	card = &result
+	return
+}
+
+// 增加用户记录的参数
+type DiscountCardUserRecordsParameters struct {
+	ObjectiveCompletionRecords []ObjectiveCompletionRecord `json:"objective_completion_records,omitempty"` // 目标完成记录
+	RewardUsageRecords         []RewardUsageRecord         `json:"reward_usage_records,omitempty"`         // 优惠使用记录
+}
+
+const (
+	RecordTypeIncrease = "INCREASE" // 增加
+	RecordTypeDecrease = "DECREASE" // 减少(比如退款)
+)
+
+// 目标完成记录
+type ObjectiveCompletionRecord struct {
+	SerialNo        string `json:"objective_completion_serial_no"` // 商户侧唯一
+	ObjectiveId     string `json:"objective_id"`
+	CompletionTime  string `json:"completion_time"` // rfc3339
+	CompletionType  string `json:"completion_type"` // RecordTypeIncrease, RecordTypeDecrease
+	Description     string `json:"description"`
+	CompletionCount int64  `json:"completion_count"`
+	Remark          string `json:"remark,omitempty"`
+}
+
+// 优惠使用记录
+type RewardUsageRecord struct {
+	SerialNo    string `json:"reward_usage_serial_no"` // 商户侧唯一
+	RewardId    string `json:"reward_id"`
+	UsageTime   string `json:"usage_time"` // rfc3339
+	UsageType   string `json:"usage_type"` // RecordTypeIncrease, RecordTypeDecrease
+	Description string `json:"description"`
+	UsageCount  int64  `json:"usage_count"`
+	Amount      int64  `json:"amount"` // 优惠金额, 单位为分
+	Remark      string `json:"remark,omitempty"`
+}
+
+// 增加用户记录, 用户完成约定目标或者使用优惠后调用.
+func (clt Client) DiscountCardAddUserRecords(outCardCode string, para *DiscountCardUserRecordsParameters) (err error) {
+	if outCardCode == "" {
+		return errors.New("empty outCardCode")
+	}
+	if para == nil {
+		return errors.New("nil parameters")
+	}
+	return clt.PostJSON("/v3/discount-card/cards/"+url.PathEscape(outCardCode)+"/add-user-records", para, nil)
+}
+
+// 解析先享卡的回调通知, 返回解密后的先享卡订单, 事件类型见 n.EventType.
+//
+//	NOTE: 非先享卡的回调通知返回错误.
+func ParseDiscountCardNotification(n *payv3.Notification) (card *DiscountCard, err error) {
+	switch n.EventType {
+	case EventTypeDiscountCardUserAccepted, EventTypeDiscountCardAgreementEnded, EventTypeDiscountCardUserPaid:
+	default:
+		err = errors.New("not a discount card notification: " + n.EventType)
+		return
+	}
+
+	var result DiscountCard
+	if err = n.Unmarshal(&result); err != nil {
+		return
+	}
+	card = &result
+	return
+}
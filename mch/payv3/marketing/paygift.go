@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package marketing
+
+import (
+	"errors"
+	"net/url"
+)
+
+// 支付有礼活动的投放目的
+const (
+	DeliveryPurposeOffLinePay  = "OFF_LINE_PAY"  // 拉用户回店消费
+	DeliveryPurposeJumpMiniApp = "JUMP_MINI_APP" // 引导用户前往小程序消费
+)
+
+// 支付有礼活动的奖品类型
+const (
+	AwardTypeBusifavor = "BUSIFAVOR" // 商家券
+)
+
+// 支付有礼活动的发券商户号选项
+const (
+	MerchantOptionInSeveralMchid = "IN_SEVERAL_MCHID" // 指定的商户号
+	MerchantOptionManualInput    = "MANUAL_INPUT_MCHID"
+)
+
+// 支付有礼活动的状态
+const (
+	PayGiftActivityStatusCreateActivity = "CREATE_ACTIVITY"    // 已创建
+	PayGiftActivityStatusOngoing        = "ONGOING_ACTIVITY"   // 进行中
+	PayGiftActivityStatusTerminated     = "TERMINATE_ACTIVITY" // 已终止
+	PayGiftActivityStatusStopped        = "STOP_ACTIVITY"      // 已暂停
+	PayGiftActivityStatusOverTime       = "OVER_TIME_ACTIVITY" // 已过期
+)
+
+// 活动基本信息
+type PayGiftActivityBaseInfo struct {
+	ActivityName        string                   `json:"activity_name"`
+	ActivitySecondTitle string                   `json:"activity_second_title"`
+	MerchantLogoURL     string                   `json:"merchant_logo_url"`
+	BackgroundColor     string                   `json:"background_color,omitempty"` // 比如 COLOR010
+	BeginTime           string                   `json:"begin_time"`                 // rfc3339
+	EndTime             string                   `json:"end_time"`                   // rfc3339
+	AvailablePeriods    *PayGiftAvailablePeriods `json:"available_periods,omitempty"`
+	OutRequestNo        string                   `json:"out_request_no"`   // 商户请求单号, 用于幂等
+	DeliveryPurpose     string                   `json:"delivery_purpose"` // DeliveryPurposeOffLinePay, DeliveryPurposeJumpMiniApp
+	MiniProgramsAppId   string                   `json:"mini_programs_appid,omitempty"`
+	MiniProgramsPath    string                   `json:"mini_programs_path,omitempty"`
+}
+
+// 活动的可用时间
+type PayGiftAvailablePeriods struct {
+	AvailableTime    []PayGiftTimeRange    `json:"available_time,omitempty"`
+	AvailableDayTime []PayGiftDayTimeRange `json:"available_day_time,omitempty"`
+}
+
+type PayGiftTimeRange struct {
+	BeginTime string `json:"begin_time"` // rfc3339
+	EndTime   string `json:"end_time"`   // rfc3339
+}
+
+type PayGiftDayTimeRange struct {
+	BeginDayTime string `json:"begin_day_time"` // 比如 110000
+	EndDayTime   string `json:"end_day_time"`   // 比如 135959
+}
+
+// 奖品
+type PayGiftAward struct {
+	StockId          string `json:"stock_id"`           // 商家券批次号
+	OriginalImageURL string `json:"original_image_url"` // 奖品原始图片, 需要通过图片上传接口获得
+	ThumbnailURL     string `json:"thumbnail_url,omitempty"`
+}
+
+// 满额送活动的送券规则
+type PayGiftAwardSendRule struct {
+	TransactionAmountMinimum int64          `json:"transaction_amount_minimum"` // 消费金额门槛, 单位为分
+	SendContent              string         `json:"send_content"`               // 目前只支持 SINGLE_COUPON
+	AwardType                string         `json:"award_type"`                 // AwardTypeBusifavor
+	AwardList                []PayGiftAward `json:"award_list"`
+	MerchantOption           string         `json:"merchant_option"` // MerchantOptionInSeveralMchid, MerchantOptionManualInput
+	MerchantIdList           []string       `json:"merchant_id_list,omitempty"`
+}
+
+// 活动的高级设置
+type PayGiftAdvancedSetting struct {
+	DeliveryUserCategory     string                           `json:"delivery_user_category,omitempty"` // 投放用户类别, BRAND_MEMBER 表示品牌会员
+	MerchantMemberAppId      string                           `json:"merchant_member_appid,omitempty"`
+	PaymentMode              *PayGiftPaymentMode              `json:"payment_mode,omitempty"`
+	PaymentMethodInformation *PayGiftPaymentMethodInformation `json:"payment_method_information,omitempty"`
+	GoodsTags                []string                         `json:"goods_tags,omitempty"`
+}
+
+// 支付模式
+type PayGiftPaymentMode struct {
+	PaymentSceneList []string `json:"payment_scene_list"` // APP_SCENE, SWING_CARD...
+}
+
+// 支付方式
+type PayGiftPaymentMethodInformation struct {
+	PaymentMethod    string `json:"payment_method"` // CFT, SPECIFIC_BANK_CARD
+	BankAbbreviation string `json:"bank_abbreviation,omitempty"`
+}
+
+// 创建全场满额送活动的参数
+type PayGiftActivityCreateParameters struct {
+	ActivityBaseInfo PayGiftActivityBaseInfo `json:"activity_base_info"`
+	AwardSendRule    PayGiftAwardSendRule    `json:"award_send_rule"`
+	AdvancedSetting  *PayGiftAdvancedSetting `json:"advanced_setting,omitempty"`
+}
+
+// 创建全场满额送活动.
+func (clt Client) CreatePayGiftActivity(para *PayGiftActivityCreateParameters) (activityId, createTime string, err error) {
+	if para == nil {
+		err = errors.New("nil parameters")
+		return
+	}
+	if para.ActivityBaseInfo.OutRequestNo == "" {
+		err = errors.New("empty out_request_no")
+		return
+	}
+	if len(para.AwardSendRule.AwardList) == 0 {
+		err = errors.New("empty award_list")
+		return
+	}
+
+	var result struct {
+		ActivityId string `json:"activity_id"`
+		CreateTime string `json:"create_time"`
+	}
+	if err = clt.PostJSON("/v3/marketing/paygiftactivity/unique-threshold-activity", para, &result); err != nil {
+		return
+	}
+	activityId = result.ActivityId
+	createTime = result.CreateTime
+	return
+}
+
+// 支付有礼活动详情
+type PayGiftActivity struct {
+	ActivityId       string                  `json:"activity_id"`
+	ActivityType     string                  `json:"activity_type"`
+	ActivityStatus   string                  `json:"activity_status"` // PayGiftActivityStatusOngoing...
+	CreatorMerchant  string                  `json:"creator_merchant"`
+	BelongMerchant   string                  `json:"belong_merchant"`
+	PauseTime        string                  `json:"pause_time,omitempty"`
+	RecoveryTime     string                  `json:"recovery_time,omitempty"`
+	CreateTime       string                  `json:"create_time"`
+	UpdateTime       string                  `json:"update_time"`
+	ActivityBaseInfo PayGiftActivityBaseInfo `json:"activity_base_info"`
+	AwardSendRule    struct {
+		FullSendRule *PayGiftAwardSendRule `json:"full_send_rule,omitempty"`
+	} `json:"award_send_rule"`
+	AdvancedSetting *PayGiftAdvancedSetting `json:"advanced_setting,omitempty"`
+}
+
+// 获取支付有礼活动详情.
+func (clt Client) PayGiftActivity(activityId string) (activity *PayGiftActivity, err error) {
+	if activityId == "" {
+		err = errors.New("empty activityId")
+		return
+	}
+
+	var result PayGiftActivity
+	if err = clt.GetJSON("/v3/marketing/paygiftactivity/activities/"+url.PathEscape(activityId), &result); err != nil {
+		return
+	}
+	activity = &result
+	return
+}
+
+// 终止支付有礼活动, 终止后不可恢复.
+func (clt Client) TerminatePayGiftActivity(activityId string) (terminateTime string, err error) {
+	if activityId == "" {
+		err = errors.New("empty activityId")
+		return
+	}
+
+	var result struct {
+		TerminateTime string `json:"terminate_time"`
+		ActivityId    string `json:"activity_id"`
+	}
+	if err = clt.PostJSON("/v3/marketing/paygiftactivity/activities/"+url.PathEscape(activityId)+"/terminate", struct{}{}, &result); err != nil {
+		return
+	}
+	terminateTime = result.TerminateTime
+	return
+}
@@ -0,0 +1,134 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	MediaImageSizeLimit = 2 << 20 // 图片最大 2M
+	MediaVideoSizeLimit = 5 << 20 // 视频最大 5M
+)
+
+// 上传图片(jpg, bmp, png), 返回 media_id, 用于特约商户进件等接口的图片字段.
+func (clt *Client) UploadImage(filename string, content []byte) (mediaId string, err error) {
+	if len(content) > MediaImageSizeLimit {
+		err = errors.New("image size exceeds 2M")
+		return
+	}
+	return clt.uploadMedia("/v3/merchant/media/upload", filename, content)
+}
+
+// 上传视频(avi, wmv, mpeg, mp4, mov, mkv, flv, f4v, m4v, rmvb), 返回 media_id.
+func (clt *Client) UploadVideo(filename string, content []byte) (mediaId string, err error) {
+	if len(content) > MediaVideoSizeLimit {
+		err = errors.New("video size exceeds 5M")
+		return
+	}
+	return clt.uploadMedia("/v3/merchant/media/video_upload", filename, content)
+}
+
+// 上传文件, 签名的 body 为 meta 的 json, 而不是整个 multipart/form-data.
+func (clt *Client) uploadMedia(urlPath, filename string, content []byte) (mediaId string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if len(content) == 0 {
+		err = errors.New("empty content")
+		return
+	}
+
+	hashsum := sha256.Sum256(content)
+	meta, err := json.Marshal(struct {
+		Filename string `json:"filename"`
+		SHA256   string `json:"sha256"`
+	}{
+		Filename: path.Base(filename),
+		SHA256:   hex.EncodeToString(hashsum[:]),
+	})
+	if err != nil {
+		return
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	metaHeader := make(textproto.MIMEHeader)
+	metaHeader.Set("Content-Disposition", `form-data; name="meta"`)
+	metaHeader.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(metaHeader)
+	if err != nil {
+		return
+	}
+	if _, err = part.Write(meta); err != nil {
+		return
+	}
+	fileHeader := make(textproto.MIMEHeader)
+	fileHeader.Set("Content-Disposition", `form-data; name="file"; filename="`+strings.Replace(path.Base(filename), `"`, "", -1)+`"`)
+	fileHeader.Set("Content-Type", http.DetectContentType(content))
+	if part, err = writer.CreatePart(fileHeader); err != nil {
+		return
+	}
+	if _, err = part.Write(content); err != nil {
+		return
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+
+	authorization, err := clt.authorization("POST", urlPath, meta, time.Now().Unix())
+	if err != nil {
+		return
+	}
+	httpReq, err := http.NewRequest("POST", clt.baseURL()+urlPath, body)
+	if err != nil {
+		return
+	}
+	httpReq.Header.Set("Authorization", authorization)
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	httpResp, err := clt.httpClient().Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		e := &Error{StatusCode: httpResp.StatusCode}
+		json.Unmarshal(respBody, e)
+		err = e
+		return
+	}
+	if err = clt.verifyResponse(httpResp.Header, respBody); err != nil {
+		return
+	}
+
+	var result struct {
+		MediaId string `json:"media_id"`
+	}
+	if err = json.Unmarshal(respBody, &result); err != nil {
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
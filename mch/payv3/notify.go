@@ -0,0 +1,245 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 回调通知
+type Notification struct {
+	Id           string         `json:"id"`
+	CreateTime   string         `json:"create_time"` // rfc3339
+	EventType    string         `json:"event_type"`  // 比如 TRANSACTION.SUCCESS
+	ResourceType string         `json:"resource_type"`
+	Summary      string         `json:"summary"`
+	Resource     NotifyResource `json:"resource"`
+
+	Plaintext []byte `json:"-"` // 解密后的 resource
+}
+
+// 回调通知加密的数据
+type NotifyResource struct {
+	Algorithm      string `json:"algorithm"` // AEAD_AES_256_GCM
+	Ciphertext     string `json:"ciphertext"`
+	AssociatedData string `json:"associated_data"`
+	Nonce          string `json:"nonce"`
+	OriginalType   string `json:"original_type"`
+}
+
+// 把解密后的 resource 解码到 v.
+func (n *Notification) Unmarshal(v interface{}) error {
+	if n.Plaintext == nil {
+		return errors.New("notification is not decrypted")
+	}
+	return json.Unmarshal(n.Plaintext, v)
+}
+
+// 解析回调通知.
+type NotifyHandler struct {
+	APIv3Key string   // APIv3 密钥, 32 字节
+	Verifier Verifier // 可以为 nil, 表示不验证回调通知的签名(不推荐)
+
+	// 可以为 nil; 不为 nil 时替代 APIv3Key, 用于密钥轮换或者从 KMS 获取密钥.
+	APIv3KeyProvider util.SecretProvider
+
+	// > 0 时检查 Wechatpay-Timestamp 和本地时间的差不超过 MaxClockSkew, 用于拒绝重放的回调通知.
+	MaxClockSkew time.Duration
+
+	// 严格模式, 无法验证的回调通知都返回 *NotifyVerifyError:
+	//  1. Verifier 为 nil;
+	//  2. 缺少 Wechatpay-Serial, Wechatpay-Timestamp, Wechatpay-Nonce, Wechatpay-Signature 任何一个 header;
+	//  3. MaxClockSkew <= 0 时按 DefaultNotifyMaxClockSkew 检查 Wechatpay-Timestamp.
+	Strict bool
+}
+
+// 严格模式下 Wechatpay-Timestamp 和本地时间默认允许的差
+const DefaultNotifyMaxClockSkew = 5 * time.Minute
+
+// 回调通知验证失败的原因, 见 NotifyVerifyError.
+var (
+	ErrNotifyVerifierNotSet   = errors.New("Verifier is not set")
+	ErrNotifyHeaderMissing    = errors.New("header is missing")
+	ErrNotifyTimestampInvalid = errors.New("Wechatpay-Timestamp is invalid")
+	ErrNotifyTimestampExpired = errors.New("Wechatpay-Timestamp is out of the allowed window")
+	ErrNotifySignatureInvalid = errors.New("Wechatpay-Signature is invalid")
+)
+
+// ParseRequest 读取的回调通知 body 的最大字节数, 超过时返回 ErrNotifyBodyTooLarge.
+var MaxNotifyBodySize int64 = 1 << 20
+
+var ErrNotifyBodyTooLarge = errors.New("payv3 notify: body too large")
+
+// ParseRequest 验证回调通知失败时返回的错误, 可以用 errors.Is(err, ErrNotifyXXX) 判断原因,
+// errors.Is 和 errors.As 也可以检查底层的错误 Err.
+type NotifyVerifyError struct {
+	Reason error  // ErrNotifyVerifierNotSet, ErrNotifyHeaderMissing 等
+	Header string // 相关的 header, 可能为空
+	Err    error  // 底层的错误, 可能为 nil
+}
+
+func (e *NotifyVerifyError) Error() string {
+	s := "payv3 notify: "
+	if e.Header != "" {
+		s += e.Header + ": "
+	}
+	s += e.Reason.Error()
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// 用于 errors.Is(err, ErrNotifyXXX).
+func (e *NotifyVerifyError) Is(target error) bool {
+	return target == e.Reason
+}
+
+func (e *NotifyVerifyError) Unwrap() error {
+	return e.Err
+}
+
+// 验证签名, 解析并解密回调通知.
+func (h *NotifyHandler) ParseRequest(r *http.Request) (n *Notification, err error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxNotifyBodySize+1))
+	if err != nil {
+		return
+	}
+	if int64(len(body)) > MaxNotifyBodySize {
+		err = ErrNotifyBodyTooLarge
+		return
+	}
+	if err = h.verify(r.Header, body); err != nil {
+		return
+	}
+
+	var notification Notification
+	if err = json.Unmarshal(body, &notification); err != nil {
+		return
+	}
+	apiV3Key := h.APIv3Key
+	if h.APIv3KeyProvider != nil {
+		if apiV3Key, err = h.APIv3KeyProvider.Secret(); err != nil {
+			return
+		}
+	}
+	res := &notification.Resource
+	if notification.Plaintext, err = DecryptAEADAES256GCM(apiV3Key, res.AssociatedData, res.Nonce, res.Ciphertext); err != nil {
+		return
+	}
+	n = &notification
+	return
+}
+
+func (h *NotifyHandler) verify(header http.Header, body []byte) error {
+	if h.Verifier == nil && !h.Strict {
+		return h.checkTimestamp(header.Get("Wechatpay-Timestamp"), false)
+	}
+	if h.Verifier == nil {
+		return &NotifyVerifyError{Reason: ErrNotifyVerifierNotSet}
+	}
+
+	var (
+		serial    = header.Get("Wechatpay-Serial")
+		timestamp = header.Get("Wechatpay-Timestamp")
+		nonce     = header.Get("Wechatpay-Nonce")
+		signature = header.Get("Wechatpay-Signature")
+	)
+	if signature == "" {
+		return &NotifyVerifyError{Reason: ErrNotifyHeaderMissing, Header: "Wechatpay-Signature"}
+	}
+	if h.Strict {
+		switch {
+		case serial == "":
+			return &NotifyVerifyError{Reason: ErrNotifyHeaderMissing, Header: "Wechatpay-Serial"}
+		case timestamp == "":
+			return &NotifyVerifyError{Reason: ErrNotifyHeaderMissing, Header: "Wechatpay-Timestamp"}
+		case nonce == "":
+			return &NotifyVerifyError{Reason: ErrNotifyHeaderMissing, Header: "Wechatpay-Nonce"}
+		}
+	}
+	if err := h.checkTimestamp(timestamp, h.Strict); err != nil {
+		return err
+	}
+	if err := h.Verifier.Verify(serial, buildMessage(timestamp, nonce, string(body)), signature); err != nil {
+		return &NotifyVerifyError{Reason: ErrNotifySignatureInvalid, Header: "Wechatpay-Signature", Err: err}
+	}
+	return nil
+}
+
+// 检查 Wechatpay-Timestamp 是否在允许的时间窗口内, MaxClockSkew <= 0 并且不是严格模式时不检查.
+func (h *NotifyHandler) checkTimestamp(timestamp string, strict bool) error {
+	maxClockSkew := h.MaxClockSkew
+	if maxClockSkew <= 0 {
+		if !strict {
+			return nil
+		}
+		maxClockSkew = DefaultNotifyMaxClockSkew
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return &NotifyVerifyError{Reason: ErrNotifyTimestampInvalid, Header: "Wechatpay-Timestamp", Err: err}
+	}
+	skew := time.Since(time.Unix(sec, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxClockSkew {
+		return &NotifyVerifyError{Reason: ErrNotifyTimestampExpired, Header: "Wechatpay-Timestamp"}
+	}
+	return nil
+}
+
+// 回复微信支付回调通知处理成功.
+func WriteNotifySuccess(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 回复微信支付回调通知处理失败, 微信支付会按照策略重新发送通知.
+func WriteNotifyFail(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    "FAIL",
+		Message: message,
+	})
+}
+
+// 用 APIv3 密钥解密回调通知的 resource 和平台证书, ciphertext 为 base64 编码.
+func DecryptAEADAES256GCM(apiV3Key, associatedData, nonce, ciphertext string) (plaintext []byte, err error) {
+	if len(apiV3Key) != 32 {
+		err = errors.New("the length of APIv3 key must be equal to 32")
+		return
+	}
+	cipherBytes, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return
+	}
+	block, err := aes.NewCipher([]byte(apiV3Key))
+	if err != nil {
+		return
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return
+	}
+	return gcm.Open(nil, []byte(nonce), cipherBytes, []byte(associatedData))
+}
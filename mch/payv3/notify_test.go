@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotifyVerifyError(t *testing.T) {
+	h := &NotifyHandler{MaxClockSkew: time.Minute}
+
+	r := httptest.NewRequest("POST", "/notify", bytes.NewReader([]byte("{}")))
+	r.Header.Set("Wechatpay-Timestamp", "not-a-number")
+	_, err := h.ParseRequest(r)
+	if !errors.Is(err, ErrNotifyTimestampInvalid) {
+		t.Errorf("want ErrNotifyTimestampInvalid, have %v", err)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("want the *strconv.NumError to be unwrapped, have %v", err)
+	}
+	if errors.Is(err, ErrNotifyTimestampExpired) {
+		t.Errorf("want not ErrNotifyTimestampExpired, have %v", err)
+	}
+}
+
+func TestNotifyBodyTooLarge(t *testing.T) {
+	h := &NotifyHandler{}
+	r := httptest.NewRequest("POST", "/notify", bytes.NewReader(make([]byte, MaxNotifyBodySize+1)))
+	if _, err := h.ParseRequest(r); err != ErrNotifyBodyTooLarge {
+		t.Errorf("want ErrNotifyBodyTooLarge, have %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// 任意 APIv3 接口的请求, 用于调用本包还没有封装的接口.
+type Request struct {
+	Method  string // GET, POST, PUT, PATCH, DELETE
+	URLPath string // 包含 query 的绝对路径, 比如 /v3/vehicle/parking/services/find?appid=xxx
+
+	// 可以为 nil, 表示没有 http body; []byte 和 json.RawMessage 原样发送, 其他类型用 json 编码.
+	Body interface{}
+
+	// 可以为 nil; 额外的 http header, 比如请求里有加密的敏感信息时需要设置 Wechatpay-Serial.
+	//  NOTE: Authorization, Accept, Content-Type 由 Client 设置.
+	Header http.Header
+}
+
+// 已经验证过签名的应答.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// 应答的 Request-ID, 联系微信支付排查问题时需要提供.
+func (resp *Response) RequestId() string {
+	return resp.Header.Get("Request-ID")
+}
+
+// 把应答的 json 解码到 v, v 为 nil 或者应答没有 body(比如 204 No Content)时什么都不做.
+func (resp *Response) Unmarshal(v interface{}) error {
+	if v == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Body, v)
+}
+
+// 把应答的 json 解码为 map, 用于不想定义结构体的场景.
+//  NOTE: 数字会被解码为 json.Number.
+func (resp *Response) Map() (m map[string]interface{}, err error) {
+	if len(resp.Body) == 0 {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(resp.Body))
+	decoder.UseNumber()
+	err = decoder.Decode(&m)
+	return
+}
+
+// 签名并发送 req, 返回验证过签名的应答; http 状态码不是 2xx 时返回 *Error.
+//  本包没有封装的接口(比如各种行业的接口)都可以通过 Do 调用, 签名和验签的逻辑和已经封装的接口完全相同.
+func (clt *Client) Do(req *Request) (resp *Response, err error) {
+	if req.Method == "" {
+		return nil, errors.New("empty Method")
+	}
+	if !strings.HasPrefix(req.URLPath, "/") {
+		return nil, errors.New("URLPath must be an absolute path")
+	}
+
+	var body []byte
+	switch v := req.Body.(type) {
+	case nil:
+	case []byte:
+		body = v
+	case json.RawMessage:
+		body = v
+	default:
+		if body, err = json.Marshal(v); err != nil {
+			return
+		}
+	}
+	return clt.do(strings.ToUpper(req.Method), req.URLPath, body, req.Header)
+}
@@ -0,0 +1,19 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 APIv3 电子回单: 商家转账的批次回单和明细回单.
+//  先申请(受理)电子回单, 微信支付异步生成, 查询到 signature_status 为 FINISHED 后用 download_url 下载,
+//  下载的文件用 hash_type, hash_value 校验完整性, 可以作为财务审计的凭证.
+//
+//  NOTE: 退款目前没有单独的电子回单接口, 退款的资金流水请使用资金账单.
+package receipt
+
+import (
+	"github.com/chanxuehong/wechat/mch/payv3"
+)
+
+type Client struct {
+	*payv3.Client
+}
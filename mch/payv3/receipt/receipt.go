@@ -0,0 +1,181 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package receipt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+)
+
+// 电子回单的 signature_status
+const (
+	SignatureStatusAccepted = "ACCEPTED" // 已受理, 生成中
+	SignatureStatusFinished = "FINISHED" // 已生成, 可以下载
+)
+
+// 明细回单的受理类型 accept_type
+const (
+	AcceptTypeBatchTransfer    = "BATCH_TRANSFER"     // 批量转账
+	AcceptTypeTransferToPocket = "TRANSFER_TO_POCKET" // 企业付款至零钱
+	AcceptTypeTransferToBank   = "TRANSFER_TO_BANK"   // 企业付款至银行卡
+)
+
+var ErrReceiptNotFinished = errors.New("receipt is not finished")
+
+// 电子回单, 批次回单没有 AcceptType 和 OutDetailNo.
+type Receipt struct {
+	AcceptType      string `json:"accept_type,omitempty"`
+	OutBatchNo      string `json:"out_batch_no"`
+	OutDetailNo     string `json:"out_detail_no,omitempty"`
+	SignatureNo     string `json:"signature_no"`     // 电子回单申请单号
+	SignatureStatus string `json:"signature_status"` // SignatureStatusAccepted, SignatureStatusFinished
+	HashType        string `json:"hash_type"`        // payv3.HashTypeSHA256 等
+	HashValue       string `json:"hash_value"`
+	DownloadURL     string `json:"download_url"`
+	CreateTime      string `json:"create_time"` // rfc3339
+	UpdateTime      string `json:"update_time"` // rfc3339
+}
+
+// 转账批次电子回单申请受理, outBatchNo 为商家批次单号.
+//  NOTE: 只能申请 2 年以内, 并且批次状态为 FINISHED 的回单.
+func (clt Client) ApplyBatchReceipt(outBatchNo string) (receipt *Receipt, err error) {
+	if outBatchNo == "" {
+		err = errors.New("empty outBatchNo")
+		return
+	}
+
+	var request = struct {
+		OutBatchNo string `json:"out_batch_no"`
+	}{
+		OutBatchNo: outBatchNo,
+	}
+
+	var result Receipt
+	if err = clt.PostJSON("/v3/transfer/bill-receipt", &request, &result); err != nil {
+		return
+	}
+	receipt = &result
+	return
+}
+
+// 查询转账批次电子回单.
+func (clt Client) BatchReceipt(outBatchNo string) (receipt *Receipt, err error) {
+	if outBatchNo == "" {
+		err = errors.New("empty outBatchNo")
+		return
+	}
+
+	var result Receipt
+	if err = clt.GetJSON("/v3/transfer/bill-receipt/"+url.PathEscape(outBatchNo), &result); err != nil {
+		return
+	}
+	receipt = &result
+	return
+}
+
+// 转账明细电子回单申请受理, acceptType 为 AcceptTypeBatchTransfer 等;
+// outBatchNo 为商家批次单号, acceptType 为 AcceptTypeBatchTransfer 时必须, outDetailNo 为商家明细单号.
+func (clt Client) ApplyDetailReceipt(acceptType, outBatchNo, outDetailNo string) (receipt *Receipt, err error) {
+	if acceptType == "" {
+		err = errors.New("empty acceptType")
+		return
+	}
+	if outDetailNo == "" {
+		err = errors.New("empty outDetailNo")
+		return
+	}
+
+	var request = struct {
+		AcceptType  string `json:"accept_type"`
+		OutBatchNo  string `json:"out_batch_no,omitempty"`
+		OutDetailNo string `json:"out_detail_no"`
+	}{
+		AcceptType:  acceptType,
+		OutBatchNo:  outBatchNo,
+		OutDetailNo: outDetailNo,
+	}
+
+	var result Receipt
+	if err = clt.PostJSON("/v3/transfer-detail/electronic-receipts", &request, &result); err != nil {
+		return
+	}
+	receipt = &result
+	return
+}
+
+// 查询转账明细电子回单, 参数同 ApplyDetailReceipt.
+func (clt Client) DetailReceipt(acceptType, outBatchNo, outDetailNo string) (receipt *Receipt, err error) {
+	if acceptType == "" {
+		err = errors.New("empty acceptType")
+		return
+	}
+	if outDetailNo == "" {
+		err = errors.New("empty outDetailNo")
+		return
+	}
+
+	query := url.Values{
+		"accept_type":   {acceptType},
+		"out_detail_no": {outDetailNo},
+	}
+	if outBatchNo != "" {
+		query.Set("out_batch_no", outBatchNo)
+	}
+
+	var result Receipt
+	if err = clt.GetJSON("/v3/transfer-detail/electronic-receipts?"+query.Encode(), &result); err != nil {
+		return
+	}
+	receipt = &result
+	return
+}
+
+// 下载已经生成的电子回单(pdf)到 w, 并用回单的 hash 校验文件的完整性, 见 payv3.Client.Download.
+//  receipt.SignatureStatus 不是 SignatureStatusFinished 时返回 ErrReceiptNotFinished.
+func (clt Client) Download(receipt *Receipt, w io.Writer) (written int64, err error) {
+	if receipt == nil {
+		err = errors.New("nil Receipt")
+		return
+	}
+	if receipt.SignatureStatus != SignatureStatusFinished || receipt.DownloadURL == "" {
+		err = ErrReceiptNotFinished
+		return
+	}
+	if receipt.HashValue == "" {
+		err = errors.New("empty hash_value")
+		return
+	}
+	return clt.Client.Download(receipt.DownloadURL, receipt.HashType, receipt.HashValue, w)
+}
+
+// 轮询 query(比如 BatchReceipt, DetailReceipt 的闭包)直到回单生成, 每次间隔 interval(<= 0 时为 5 秒), ctx 取消时返回 ctx.Err().
+//  一般在申请受理之后调用:
+//   r, err := receipt.Wait(ctx, func() (*receipt.Receipt, error) { return clt.BatchReceipt(outBatchNo) }, 0)
+func Wait(ctx context.Context, query func() (*Receipt, error), interval time.Duration) (receipt *Receipt, err error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-timer.C:
+		}
+		if receipt, err = query(); err != nil {
+			return
+		}
+		if receipt.SignatureStatus == SignatureStatusFinished {
+			return
+		}
+		timer.Reset(interval)
+	}
+}
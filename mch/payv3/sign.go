@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mch/sign"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const authorizationSchema = "WECHATPAY2-SHA256-RSA2048"
+
+// 解析 PEM 格式的商户 API 证书私钥(apiclient_key.pem), 支持 PKCS#8 和 PKCS#1.
+func LoadPrivateKey(pemBytes []byte) (key *rsa.PrivateKey, err error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		err = errors.New("invalid private key pem")
+		return
+	}
+	if k, err2 := x509.ParsePKCS8PrivateKey(block.Bytes); err2 == nil {
+		var ok bool
+		if key, ok = k.(*rsa.PrivateKey); !ok {
+			err = errors.New("private key is not a rsa private key")
+		}
+		return
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// 解析 PEM 格式的证书, 比如微信支付平台证书.
+func LoadCertificate(pemBytes []byte) (cert *x509.Certificate, err error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		err = errors.New("invalid certificate pem")
+		return
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// 证书序列号, 大写的十六进制字符串, 和 Wechatpay-Serial 的格式一致.
+func CertificateSerialNo(cert *x509.Certificate) string {
+	return strings.ToUpper(hex.EncodeToString(cert.SerialNumber.Bytes()))
+}
+
+// SHA256 with RSA 签名, 返回 base64 编码的签名.
+func SignSHA256WithRSA(privateKey *rsa.PrivateKey, message []byte) (signature string, err error) {
+	hashsum := sha256.Sum256(message)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashsum[:])
+	if err != nil {
+		return
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// 验证 SHA256 with RSA 签名, signature 为 base64 编码的签名.
+func VerifySHA256WithRSA(publicKey *rsa.PublicKey, message []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+	hashsum := sha256.Sum256(message)
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashsum[:], sig)
+}
+
+// 验证应答和回调通知的签名, 一般用微信支付平台证书实现.
+type Verifier interface {
+	// serialNo 为 Wechatpay-Serial, message 为 timestamp + "\n" + nonce + "\n" + body + "\n"
+	Verify(serialNo string, message []byte, signature string) error
+}
+
+// 用微信支付平台证书验签的 Verifier, 证书序列号 => 证书的公钥.
+type CertificateVerifier map[string]*rsa.PublicKey
+
+// 创建 CertificateVerifier, 平台证书更换期间需要同时传入新旧证书.
+func NewCertificateVerifier(certs ...*x509.Certificate) (CertificateVerifier, error) {
+	verifier := make(CertificateVerifier, len(certs))
+	for _, cert := range certs {
+		publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("certificate public key is not a rsa public key")
+		}
+		verifier[CertificateSerialNo(cert)] = publicKey
+	}
+	return verifier, nil
+}
+
+func (verifier CertificateVerifier) Verify(serialNo string, message []byte, signature string) error {
+	publicKey, ok := verifier[strings.ToUpper(serialNo)]
+	if !ok {
+		return fmt.Errorf("certificate not found for serial no %q", serialNo)
+	}
+	return VerifySHA256WithRSA(publicKey, message, signature)
+}
+
+// 构造签名串, 每一行以 "\n" 结束.
+func buildMessage(lines ...string) []byte {
+	n := 0
+	for _, line := range lines {
+		n += len(line) + 1
+	}
+	buf := make([]byte, 0, n)
+	for _, line := range lines {
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// 商户 API 证书私钥的来源, 见 Client.PrivateKeyProvider.
+type PrivateKeyProvider interface {
+	// 返回证书序列号和私钥, 轮换证书时两者一起变化.
+	PrivateKey() (serialNo string, key *rsa.PrivateKey, err error)
+}
+
+var _ PrivateKeyProvider = (*PEMPrivateKeyProvider)(nil)
+
+// 从 SecretProvider 获取证书序列号和 PEM 格式的私钥, 私钥没有变化时不重复解析.
+type PEMPrivateKeyProvider struct {
+	SerialNo util.SecretProvider
+	PEM      util.SecretProvider
+
+	mutex sync.Mutex
+	pem   string
+	key   *rsa.PrivateKey
+}
+
+func (p *PEMPrivateKeyProvider) PrivateKey() (serialNo string, key *rsa.PrivateKey, err error) {
+	if serialNo, err = p.SerialNo.Secret(); err != nil {
+		return
+	}
+	pemStr, err := p.PEM.Secret()
+	if err != nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.key != nil && p.pem == pemStr {
+		key = p.key
+		return
+	}
+	if key, err = LoadPrivateKey([]byte(pemStr)); err != nil {
+		return
+	}
+	p.pem, p.key = pemStr, key
+	return
+}
+
+// 生成 Authorization 头, urlPath 为包含 query 的绝对路径, 比如 /v3/certificates?x=y.
+func (clt *Client) authorization(method, urlPath string, body []byte, timestamp int64) (string, error) {
+	nonce, err := sign.NonceStr()
+	if err != nil {
+		return "", err
+	}
+	nonce = strings.ToUpper(nonce)
+	serialNo, privateKey := clt.SerialNo, clt.PrivateKey
+	if clt.PrivateKeyProvider != nil {
+		if serialNo, privateKey, err = clt.PrivateKeyProvider.PrivateKey(); err != nil {
+			return "", err
+		}
+	}
+	timestampStr := strconv.FormatInt(timestamp, 10)
+	signature, err := SignSHA256WithRSA(privateKey, buildMessage(method, urlPath, timestampStr, nonce, string(body)))
+	if err != nil {
+		return "", err
+	}
+	return authorizationSchema +
+		` mchid="` + clt.MchId +
+		`",nonce_str="` + nonce +
+		`",signature="` + signature +
+		`",timestamp="` + timestampStr +
+		`",serial_no="` + serialNo + `"`, nil
+}
@@ -14,7 +14,7 @@ import (
 	"net/http"
 	"net/url"
 
-	"github.com/chanxuehong/util"
+	wechatutil "github.com/chanxuehong/wechat/util"
 )
 
 func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, srv Server, irh InvalidRequestHandler) {
@@ -26,7 +26,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			return
 		}
 
-		msg, err := util.ParseXMLToMap(bytes.NewReader(RawMsgXML))
+		msg, err := wechatutil.DecodeXMLToMap(bytes.NewReader(RawMsgXML))
 		if err != nil {
 			irh.ServeInvalidRequest(w, r, err)
 			return
@@ -67,7 +67,12 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				irh.ServeInvalidRequest(w, r, err)
 				return
 			}
-			signature2 := Sign(msg, srv.APIKey(), nil)
+			apiKey, err := serverAPIKey(srv)
+			if err != nil {
+				irh.ServeInvalidRequest(w, r, err)
+				return
+			}
+			signature2 := Sign(msg, apiKey, nil)
 			if len(signature1) != len(signature2) {
 				err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
 				irh.ServeInvalidRequest(w, r, err)
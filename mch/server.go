@@ -5,6 +5,13 @@
 
 package mch
 
+import (
+	"errors"
+	"fmt"
+
+	wechatutil "github.com/chanxuehong/wechat/util"
+)
+
 type Server interface {
 	AppId() string
 	MchId() string
@@ -16,9 +23,10 @@ type Server interface {
 var _ Server = (*DefaultServer)(nil)
 
 type DefaultServer struct {
-	appId  string
-	mchId  string
-	apiKey string
+	appId          string
+	mchId          string
+	apiKey         string
+	apiKeyProvider wechatutil.SecretProvider // 不为 nil 时替代 apiKey
 
 	messageHandler MessageHandler
 }
@@ -36,6 +44,24 @@ func NewDefaultServer(appId, mchId, apiKey string, handler MessageHandler) *Defa
 	}
 }
 
+// 创建一个 DefaultServer, 每次验证通知的签名时从 apiKey 获取 API 密钥.
+//  apiKey 返回错误时 APIKey 返回空字符串, ServeHTTP 不做签名验证, 直接拒绝通知.
+func NewDefaultServerWithSecretProvider(appId, mchId string, apiKey wechatutil.SecretProvider, handler MessageHandler) *DefaultServer {
+	if apiKey == nil {
+		panic("nil SecretProvider")
+	}
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	return &DefaultServer{
+		appId:          appId,
+		mchId:          mchId,
+		apiKeyProvider: apiKey,
+		messageHandler: handler,
+	}
+}
+
 func (srv *DefaultServer) AppId() string {
 	return srv.appId
 }
@@ -43,8 +69,36 @@ func (srv *DefaultServer) MchId() string {
 	return srv.mchId
 }
 func (srv *DefaultServer) APIKey() string {
-	return srv.apiKey
+	apiKey, err := srv.getAPIKey()
+	if err != nil {
+		LogInfoln("[WECHAT_SECRET] get APIKey failed:", err)
+		return ""
+	}
+	return apiKey
+}
+func (srv *DefaultServer) getAPIKey() (string, error) {
+	if srv.apiKeyProvider != nil {
+		return srv.apiKeyProvider.Secret()
+	}
+	return srv.apiKey, nil
 }
 func (srv *DefaultServer) MessageHandler() MessageHandler {
 	return srv.messageHandler
 }
+
+// 获取验证签名用的 API 密钥, 获取失败或者为空时返回错误, 不能用空的密钥验证签名.
+func serverAPIKey(srv Server) (apiKey string, err error) {
+	if s, ok := srv.(*DefaultServer); ok {
+		if apiKey, err = s.getAPIKey(); err != nil {
+			err = fmt.Errorf("get APIKey failed: %v", err)
+			return
+		}
+	} else {
+		apiKey = srv.APIKey()
+	}
+	if apiKey == "" {
+		err = errors.New("empty APIKey")
+		return
+	}
+	return
+}
@@ -6,46 +6,21 @@
 package mch
 
 import (
-	"bytes"
 	"crypto/md5"
-	"encoding/hex"
 	"hash"
-	"sort"
+
+	"github.com/chanxuehong/wechat/mch/sign"
 )
 
 // 微信支付签名.
 //  parameters: 待签名的参数集合
 //  apiKey:     API密钥
 //  fn:         func() hash.Hash, 如果 fn == nil 则默认用 md5.New
+//
+//  NOTE: 新代码请直接使用 sign 包, 比如 sign.MD5, sign.HMACSHA256.
 func Sign(parameters map[string]string, apiKey string, fn func() hash.Hash) string {
-	ks := make([]string, 0, len(parameters))
-	for k := range parameters {
-		if k == "sign" {
-			continue
-		}
-		ks = append(ks, k)
-	}
-	sort.Strings(ks)
-
 	if fn == nil {
 		fn = md5.New
 	}
-	h := fn()
-	signature := make([]byte, h.Size()*2)
-
-	for _, k := range ks {
-		v := parameters[k]
-		if v == "" {
-			continue
-		}
-		h.Write([]byte(k))
-		h.Write([]byte{'='})
-		h.Write([]byte(v))
-		h.Write([]byte{'&'})
-	}
-	h.Write([]byte("key="))
-	h.Write([]byte(apiKey))
-
-	hex.Encode(signature, h.Sum(nil))
-	return string(bytes.ToUpper(signature))
+	return sign.WithHash(parameters, apiKey, fn())
 }
@@ -0,0 +1,135 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付的参数签名: 参数按 key 的字典序排序, 过滤空值和 sign, 拼接为 k1=v1&k2=v2&key=API密钥 后计算 MD5 或者 HMAC-SHA256.
+package sign
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+)
+
+// 签名类型, 对应参数 sign_type
+const (
+	TypeMD5        = "MD5"
+	TypeHMACSHA256 = "HMAC-SHA256"
+)
+
+var (
+	ErrSignatureMissing  = errors.New("no sign parameter")
+	ErrSignatureMismatch = errors.New("check signature failed")
+)
+
+// 返回参与签名的 key, 按字典序排列; sign 和值为空的参数不参与签名.
+func Keys(parameters map[string]string) []string {
+	keys := make([]string, 0, len(parameters))
+	for k, v := range parameters {
+		if k == "sign" || v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 返回待签名的字符串 k1=v1&k2=v2&key=apiKey, 一般用于排查签名错误.
+func StringToSign(parameters map[string]string, apiKey string) string {
+	var buf bytes.Buffer
+	writeStringToSign(&buf, parameters, apiKey)
+	return buf.String()
+}
+
+func writeStringToSign(w io.StringWriter, parameters map[string]string, apiKey string) {
+	for _, k := range Keys(parameters) {
+		w.WriteString(k)
+		w.WriteString("=")
+		w.WriteString(parameters[k])
+		w.WriteString("&")
+	}
+	w.WriteString("key=")
+	w.WriteString(apiKey)
+}
+
+type hashWriter struct {
+	hash.Hash
+}
+
+func (w hashWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// 用 h 计算签名, 返回大写的十六进制字符串.
+func WithHash(parameters map[string]string, apiKey string, h hash.Hash) string {
+	writeStringToSign(hashWriter{h}, parameters, apiKey)
+	signature := make([]byte, hex.EncodedLen(h.Size()))
+	hex.Encode(signature, h.Sum(nil))
+	return string(bytes.ToUpper(signature))
+}
+
+// MD5 签名.
+func MD5(parameters map[string]string, apiKey string) string {
+	return WithHash(parameters, apiKey, md5.New())
+}
+
+// HMAC-SHA256 签名, apiKey 同时作为 HMAC 的密钥.
+func HMACSHA256(parameters map[string]string, apiKey string) string {
+	return WithHash(parameters, apiKey, hmac.New(sha256.New, []byte(apiKey)))
+}
+
+// 按 signType 签名, signType 为空时为 TypeMD5.
+func Sum(parameters map[string]string, apiKey, signType string) (signature string, err error) {
+	switch signType {
+	case "", TypeMD5:
+		signature = MD5(parameters, apiKey)
+	case TypeHMACSHA256:
+		signature = HMACSHA256(parameters, apiKey)
+	default:
+		err = fmt.Errorf("unsupported sign_type: %s", signType)
+	}
+	return
+}
+
+// 验证 parameters 里的 sign, signType 为空时为 TypeMD5.
+//  没有 sign 参数时返回 ErrSignatureMissing, 签名不一致时返回 ErrSignatureMismatch.
+func Verify(parameters map[string]string, apiKey, signType string) (err error) {
+	signature1, ok := parameters["sign"]
+	if !ok {
+		return ErrSignatureMissing
+	}
+	signature2, err := Sum(parameters, apiKey, signType)
+	if err != nil {
+		return
+	}
+	if !Equal(signature1, signature2) {
+		return ErrSignatureMismatch
+	}
+	return
+}
+
+// 常数时间比较两个签名, 防止时序攻击.
+func Equal(signature1, signature2 string) bool {
+	return subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) == 1
+}
+
+// 生成 32 个字符的随机字符串, 用于 nonce_str 等参数.
+//  NOTE: crypto/rand 出错时返回错误, 不能用可预测的值代替.
+func NonceStr() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package sign
+
+import (
+	"testing"
+)
+
+// 微信支付文档里的签名示例
+func TestSum(t *testing.T) {
+	parameters := map[string]string{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      "10000100",
+		"device_info": "1000",
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+		"attach":      "",
+	}
+	apiKey := "192006250b4c09247ec02edce69f6a2d"
+
+	wantString := "appid=wxd930ea5d5a258f4f&body=test&device_info=1000&mch_id=10000100&nonce_str=ibuaiVcKdpRxkhJA&key=192006250b4c09247ec02edce69f6a2d"
+	if have := StringToSign(parameters, apiKey); have != wantString {
+		t.Errorf("StringToSign:\nhave: %s\nwant: %s", have, wantString)
+		return
+	}
+
+	tests := []struct {
+		signType string
+		want     string
+	}{
+		{"", "9A0A8659F005D6984697E2CA0A9CF3B7"},
+		{TypeMD5, "9A0A8659F005D6984697E2CA0A9CF3B7"},
+		{TypeHMACSHA256, "6A9AE1657590FD6257D693A078E1C3E4BB6BA4DC30B23E0EE2496E54170DACD6"},
+	}
+	for _, tt := range tests {
+		have, err := Sum(parameters, apiKey, tt.signType)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if have != tt.want {
+			t.Errorf("Sum(%q):\nhave: %s\nwant: %s", tt.signType, have, tt.want)
+			return
+		}
+
+		parameters["sign"] = have
+		if err = Verify(parameters, apiKey, tt.signType); err != nil {
+			t.Errorf("Verify(%q): %v", tt.signType, err)
+			return
+		}
+		parameters["sign"] = have[1:]
+		if err = Verify(parameters, apiKey, tt.signType); err != ErrSignatureMismatch {
+			t.Errorf("Verify(%q): have %v, want ErrSignatureMismatch", tt.signType, err)
+			return
+		}
+		delete(parameters, "sign")
+	}
+}
@@ -0,0 +1,63 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"math/rand"
+	"time"
+)
+
+// DefaultAccessTokenServer 在 access_token 有效期的 TokenRefreshRatioMin 到 TokenRefreshRatioMax 之间
+// 随机选一个时间点主动刷新, 而不是等到接口返回 40001/42001 才刷新.
+//  随机是为了避免多个进程(或者多个公众号)在同一时刻刷新, 0 < TokenRefreshRatioMin <= TokenRefreshRatioMax < 1.
+var (
+	TokenRefreshRatioMin = 0.8
+	TokenRefreshRatioMax = 0.9
+)
+
+// 有效期为 expiresIn 秒的 access_token 在多久之后主动刷新.
+func tokenRefreshInterval(expiresIn int64) time.Duration {
+	ratio := TokenRefreshRatioMin
+	if d := TokenRefreshRatioMax - TokenRefreshRatioMin; d > 0 {
+		ratio += d * rand.Float64()
+	}
+	if ratio <= 0 || ratio >= 1 {
+		ratio = 0.85
+	}
+	interval := time.Duration(float64(expiresIn) * ratio * float64(time.Second))
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// 设置后统计 DefaultAccessTokenServer 从微信服务器获取 access_token 的次数, 包括后台主动刷新,
+// 见 MetricTokenServerRefreshTotal; m 为 nil 时不统计.
+//  NOTE: Client.Metrics 只统计因为 access_token 失效而刷新的次数.
+func (srv *DefaultAccessTokenServer) SetMetrics(m Metrics) {
+	srv.metrics.Store(metricsValue{m})
+}
+
+// atomic.Value 只能保存相同类型的值
+type metricsValue struct {
+	Metrics
+}
+
+// 统计一次从微信服务器获取 access_token, trigger 为 "daemon" 或者 "client".
+func (srv *DefaultAccessTokenServer) incRefresh(trigger string, err error) {
+	m, _ := srv.metrics.Load().(metricsValue)
+	if m.Metrics == nil {
+		return
+	}
+	outcome := MetricOutcomeSuccess
+	if err != nil {
+		outcome = MetricOutcomeFailure
+	}
+	m.IncCounter(MetricTokenServerRefreshTotal, map[string]string{
+		"trigger": trigger,
+		"outcome": outcome,
+	})
+}
@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenRefreshInterval(t *testing.T) {
+	defer func(min, max float64) {
+		TokenRefreshRatioMin, TokenRefreshRatioMax = min, max
+	}(TokenRefreshRatioMin, TokenRefreshRatioMax)
+
+	tests := []struct {
+		ratioMin, ratioMax float64
+		expiresIn          int64
+		wantMin, wantMax   time.Duration
+	}{
+		{0.8, 0.9, 7200, 5760 * time.Second, 6480 * time.Second},
+		{0.5, 0.5, 7200, 3600 * time.Second, 3600 * time.Second},
+		{0.9, 0.8, 7200, 6480 * time.Second, 6480 * time.Second}, // max < min 时使用 min
+		{0, 0, 7200, 6120 * time.Second, 6120 * time.Second},     // 不合法的比例使用 0.85
+		{1, 1, 7200, 6120 * time.Second, 6120 * time.Second},
+		{0.8, 0.9, 1, time.Second, time.Second}, // 至少 1 秒
+		{0.8, 0.9, 0, time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		TokenRefreshRatioMin, TokenRefreshRatioMax = tt.ratioMin, tt.ratioMax
+		for i := 0; i < 20; i++ {
+			if d := tokenRefreshInterval(tt.expiresIn); d < tt.wantMin || d > tt.wantMax {
+				t.Errorf("ratio [%v, %v], expiresIn %d: have %s, want in [%s, %s]",
+					tt.ratioMin, tt.ratioMax, tt.expiresIn, d, tt.wantMin, tt.wantMax)
+				break
+			}
+		}
+	}
+}
@@ -16,7 +16,10 @@ import (
 	"net/url"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/chanxuehong/wechat/util"
 )
 
 // access_token 中控服务器接口, see access_token_server.png
@@ -44,12 +47,18 @@ var _ AccessTokenServer = (*DefaultAccessTokenServer)(nil)
 //  1. 用于单进程环境.
 //  2. 因为 DefaultAccessTokenServer 同时也是一个简单的中控服务器, 而不是仅仅实现 AccessTokenServer 接口,
 //     所以整个系统只能存在一个 DefaultAccessTokenServer 实例!
+//  3. 在 access_token 过期前主动刷新, 见 TokenRefreshRatioMin, TokenRefreshRatioMax.
 type DefaultAccessTokenServer struct {
 	appId      string
 	appSecret  string
+	secret     util.SecretProvider // 不为 nil 时替代 appSecret, 见 NewDefaultAccessTokenServerWithSecretProvider
 	httpClient *http.Client
+	baseURL    string
+	fetcher    TokenFetcher // 不为 nil 时通过 fetcher 获取 access_token, 见 NewDefaultAccessTokenServerWithFetcher
+	metrics    atomic.Value // metricsValue, 见 SetMetrics
 
 	resetTickerChan chan time.Duration // 用于重置 tokenDaemon 里的 ticker
+	quitChan        chan struct{}      // 用于结束 tokenDaemon
 
 	tokenGet struct {
 		sync.Mutex
@@ -64,17 +73,47 @@ type DefaultAccessTokenServer struct {
 }
 
 // 创建一个新的 DefaultAccessTokenServer.
-//  如果 clt == nil 则默认使用 http.DefaultClient.
+//  如果 clt == nil 则默认使用 TextHttpClient.
 func NewDefaultAccessTokenServer(appId, appSecret string, clt *http.Client) (srv *DefaultAccessTokenServer) {
+	return NewDefaultAccessTokenServerWithBaseURL(appId, appSecret, "", clt)
+}
+
+// 创建一个新的 DefaultAccessTokenServer, 从 baseURL 获取 access_token, 见 Client.BaseURL.
+//  baseURL 为空时等同于 NewDefaultAccessTokenServer.
+func NewDefaultAccessTokenServerWithBaseURL(appId, appSecret, baseURL string, clt *http.Client) (srv *DefaultAccessTokenServer) {
 	if clt == nil {
-		clt = http.DefaultClient
+		clt = TextHttpClient
 	}
 
 	srv = &DefaultAccessTokenServer{
 		appId:           appId,
 		appSecret:       appSecret,
 		httpClient:      clt,
+		baseURL:         baseURL,
+		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+// 创建一个新的 DefaultAccessTokenServer, 每次从微信服务器获取 access_token 时从 secret 获取 AppSecret.
+//  如果 clt == nil 则默认使用 TextHttpClient.
+func NewDefaultAccessTokenServerWithSecretProvider(appId string, secret util.SecretProvider, clt *http.Client) (srv *DefaultAccessTokenServer) {
+	if secret == nil {
+		panic("nil SecretProvider")
+	}
+	if clt == nil {
+		clt = TextHttpClient
+	}
+
+	srv = &DefaultAccessTokenServer{
+		appId:           appId,
+		secret:          secret,
+		httpClient:      clt,
 		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
 	}
 
 	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
@@ -83,6 +122,11 @@ func NewDefaultAccessTokenServer(appId, appSecret string, clt *http.Client) (srv
 
 func (srv *DefaultAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
 
+// 结束后台刷新 access_token 的 goroutine, 只能调用一次.
+func (srv *DefaultAccessTokenServer) stop() {
+	close(srv.quitChan)
+}
+
 func (srv *DefaultAccessTokenServer) Token() (token string, err error) {
 	srv.tokenCache.RLock()
 	token = srv.tokenCache.Token
@@ -96,11 +140,17 @@ func (srv *DefaultAccessTokenServer) Token() (token string, err error) {
 
 func (srv *DefaultAccessTokenServer) TokenRefresh() (token string, err error) {
 	accessTokenInfo, cached, err := srv.getToken()
+	if !cached {
+		srv.incRefresh("client", err)
+	}
 	if err != nil {
 		return
 	}
 	if !cached {
-		srv.resetTickerChan <- time.Duration(accessTokenInfo.ExpiresIn) * time.Second
+		select {
+		case srv.resetTickerChan <- tokenRefreshInterval(accessTokenInfo.ExpiresIn):
+		case <-srv.quitChan:
+		}
 	}
 	token = accessTokenInfo.Token
 	return
@@ -112,22 +162,26 @@ NEW_TICK_DURATION:
 
 	for {
 		select {
+		case <-srv.quitChan:
+			ticker.Stop()
+			return
+
 		case tickDuration = <-srv.resetTickerChan:
 			ticker.Stop()
 			goto NEW_TICK_DURATION
 
 		case <-ticker.C:
 			accessTokenInfo, cached, err := srv.getToken()
+			if !cached {
+				srv.incRefresh("daemon", err)
+			}
 			if err != nil {
 				break
 			}
 			if !cached {
-				newTickDuration := time.Duration(accessTokenInfo.ExpiresIn) * time.Second
-				if tickDuration != newTickDuration {
-					tickDuration = newTickDuration
-					ticker.Stop()
-					goto NEW_TICK_DURATION
-				}
+				tickDuration = tokenRefreshInterval(accessTokenInfo.ExpiresIn)
+				ticker.Stop()
+				goto NEW_TICK_DURATION
 			}
 		}
 	}
@@ -158,10 +212,25 @@ func (srv *DefaultAccessTokenServer) getToken() (token accessTokenInfo, cached b
 		return
 	}
 
-	_url := "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=" + url.QueryEscape(srv.appId) +
-		"&secret=" + url.QueryEscape(srv.appSecret)
+	if srv.fetcher != nil {
+		return srv.getTokenFromFetcher(timeNowUnix)
+	}
+
+	appSecret := srv.appSecret
+	if srv.secret != nil {
+		if appSecret, err = srv.secret.Secret(); err != nil {
+			srv.tokenCache.Lock()
+			srv.tokenCache.Token = ""
+			srv.tokenCache.Unlock()
+			return
+		}
+	}
+
+	_url := ReplaceBaseURL("https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=", srv.baseURL) + url.QueryEscape(srv.appId) +
+		"&secret=" + url.QueryEscape(appSecret)
 	httpResp, err := srv.httpClient.Get(_url)
 	if err != nil {
+		err = util.RedactError(err)
 		srv.tokenCache.Lock()
 		srv.tokenCache.Token = ""
 		srv.tokenCache.Unlock()
@@ -210,7 +279,7 @@ func (srv *DefaultAccessTokenServer) getToken() (token accessTokenInfo, cached b
 		return
 	}
 
-	// 由于网络的延时, access_token 过期时间留了一个缓冲区
+	// 主动刷新的时间点见 tokenRefreshInterval, 已经给网络的延时留了足够的缓冲区, 这里不再减少 ExpiresIn
 	switch {
 	case result.ExpiresIn > 31556952: // 60*60*24*365.2425
 		srv.tokenCache.Lock()
@@ -219,14 +288,7 @@ func (srv *DefaultAccessTokenServer) getToken() (token accessTokenInfo, cached b
 
 		err = errors.New("expires_in too large: " + strconv.FormatInt(result.ExpiresIn, 10))
 		return
-	case result.ExpiresIn > 60*60:
-		result.ExpiresIn -= 60 * 10
-	case result.ExpiresIn > 60*30:
-		result.ExpiresIn -= 60 * 5
-	case result.ExpiresIn > 60*5:
-		result.ExpiresIn -= 60
 	case result.ExpiresIn > 60:
-		result.ExpiresIn -= 10
 	default:
 		srv.tokenCache.Lock()
 		srv.tokenCache.Token = ""
@@ -0,0 +1,272 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/batch"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 批量生成的一个二维码的参数.
+type QRCodeSpec struct {
+	// 下面两个字段同时只有一个有效, SceneString 不为空时使用 SceneString.
+	SceneId     uint32
+	SceneString string
+
+	ExpireSeconds int // 临时二维码的有效时间, 0 表示永久二维码
+}
+
+func (spec *QRCodeSpec) key() string {
+	var key string
+	if spec.SceneString != "" {
+		key = "str:" + spec.SceneString
+	} else {
+		key = "id:" + strconv.FormatUint(uint64(spec.SceneId), 10)
+	}
+	if spec.ExpireSeconds > 0 {
+		key += ":" + strconv.Itoa(spec.ExpireSeconds)
+	}
+	return key
+}
+
+// 二维码 ticket 的缓存, 重新执行同一批任务(比如上次中途失败)时跳过已经生成的二维码, 节省接口调用次数.
+//  key 由场景值和有效时间组成; 永久二维码的 expiresAt 为零值.
+type TicketCache interface {
+	Get(key string) (qrcode *TemporaryQRCode, ok bool, err error)
+	Set(key string, qrcode *TemporaryQRCode, expiresAt time.Time) error
+}
+
+var _ TicketCache = (*MemoryTicketCache)(nil)
+
+// 进程内的 TicketCache.
+type MemoryTicketCache struct {
+	mutex   sync.Mutex
+	entries map[string]memoryTicketEntry
+}
+
+type memoryTicketEntry struct {
+	qrcode    TemporaryQRCode
+	expiresAt time.Time
+}
+
+func NewMemoryTicketCache() *MemoryTicketCache {
+	return &MemoryTicketCache{
+		entries: make(map[string]memoryTicketEntry),
+	}
+}
+
+func (cache *MemoryTicketCache) Get(key string) (qrcode *TemporaryQRCode, ok bool, err error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(cache.entries, key)
+		ok = false
+		return
+	}
+	qrcode = &entry.qrcode
+	return
+}
+
+func (cache *MemoryTicketCache) Set(key string, qrcode *TemporaryQRCode, expiresAt time.Time) error {
+	cache.mutex.Lock()
+	cache.entries[key] = memoryTicketEntry{qrcode: *qrcode, expiresAt: expiresAt}
+	cache.mutex.Unlock()
+	return nil
+}
+
+// 保存二维码图片的接口, 可以是本地目录(DirObjectWriter), 也可以是 S3, OSS, COS 等对象存储的适配.
+type ObjectWriter interface {
+	PutObject(ctx context.Context, key string, body io.Reader, contentType string) error
+}
+
+var _ ObjectWriter = DirObjectWriter("")
+
+// 把图片保存到这个目录下, key 为相对路径.
+type DirObjectWriter string
+
+func (dir DirObjectWriter) PutObject(ctx context.Context, key string, body io.Reader, contentType string) (err error) {
+	path := filepath.Join(string(dir), filepath.FromSlash(key))
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		file.Close()
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+	_, err = io.Copy(file, body)
+	return
+}
+
+type BatchQRCodeOptions struct {
+	batch.Options // 并发数, 限流等, Endpoint 为空时为 "/cgi-bin/qrcode/create"
+
+	Retries       int           // 每个二维码失败后的重试次数, 只重试网络错误和 errcode -1, 45011 等临时错误
+	RetryInterval time.Duration // 第一次重试前的等待时间, 之后每次翻倍, 0 表示 1 秒
+
+	TicketCache TicketCache // 可以为 nil, 表示不缓存
+
+	// 可以为 nil, 表示只生成 ticket, 不下载图片.
+	Writer ObjectWriter
+	// 图片在 Writer 里的 key, 为 nil 时为 "qrcode_" + 场景值 + ".jpg".
+	ObjectKey func(spec *QRCodeSpec) string
+	// 下载图片的 http.Client, 为 nil 时使用 mp.MediaHttpClient.
+	HttpClient *http.Client
+}
+
+// 批量生成带参数的二维码, 用于线下推广等需要大量二维码的场景.
+//  返回的 qrcodes 和 specs 一一对应, 失败的二维码为零值, 错误见 result.Errors;
+//  永久二维码的 ExpireSeconds 为 0. opts 可以为 nil.
+func (clt Client) BatchCreateQRCode(ctx context.Context, specs []QRCodeSpec, opts *BatchQRCodeOptions) (qrcodes []TemporaryQRCode, result *batch.Result) {
+	if opts == nil {
+		opts = &BatchQRCodeOptions{}
+	}
+	batchOpts := opts.Options
+	if batchOpts.Endpoint == "" {
+		batchOpts.Endpoint = "/cgi-bin/qrcode/create"
+	}
+
+	qrcodes = make([]TemporaryQRCode, len(specs))
+	result = batch.Run(ctx, len(specs), &batchOpts, func(ctx context.Context, i int) error {
+		spec := &specs[i]
+		clt := Client{Client: clt.WithContext(ctx)}
+		qrcode, err := clt.createQRCodeWithRetry(ctx, spec, opts)
+		if err != nil {
+			return err
+		}
+		if opts.Writer != nil {
+			if err = clt.saveQRCodeImage(ctx, spec, qrcode.Ticket, opts); err != nil {
+				return err
+			}
+		}
+		qrcodes[i] = *qrcode
+		return nil
+	})
+	return
+}
+
+func (clt Client) createQRCodeWithRetry(ctx context.Context, spec *QRCodeSpec, opts *BatchQRCodeOptions) (qrcode *TemporaryQRCode, err error) {
+	key := spec.key()
+	if opts.TicketCache != nil {
+		var ok bool
+		if qrcode, ok, err = opts.TicketCache.Get(key); err != nil || ok {
+			return
+		}
+	}
+
+	interval := opts.RetryInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for attempt := 0; ; attempt++ {
+		qrcode, err = clt.createQRCode(spec)
+		if err == nil || attempt >= opts.Retries || !isRetryableQRCodeError(err) {
+			break
+		}
+		mp.LogInfoln("[WECHAT_RETRY] batch qrcode:", key, ", attempt:", attempt+1, ", err:", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+	if err != nil {
+		return
+	}
+
+	if opts.TicketCache != nil {
+		var expiresAt time.Time
+		if qrcode.ExpireSeconds > 0 {
+			expiresAt = time.Now().Add(time.Duration(qrcode.ExpireSeconds) * time.Second)
+		}
+		if err = opts.TicketCache.Set(key, qrcode, expiresAt); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (clt Client) createQRCode(spec *QRCodeSpec) (qrcode *TemporaryQRCode, err error) {
+	if spec.ExpireSeconds > 0 {
+		if spec.SceneString != "" {
+			return clt.CreateTemporaryQRCodeWithSceneString(spec.SceneString, spec.ExpireSeconds)
+		}
+		return clt.CreateTemporaryQRCode(spec.SceneId, spec.ExpireSeconds)
+	}
+
+	var permanent *PermanentQRCode
+	if spec.SceneString != "" {
+		permanent, err = clt.CreatePermanentQRCodeWithSceneString(spec.SceneString)
+	} else {
+		permanent, err = clt.CreatePermanentQRCode(spec.SceneId)
+	}
+	if err != nil {
+		return
+	}
+	qrcode = &TemporaryQRCode{PermanentQRCode: *permanent}
+	return
+}
+
+func (clt Client) saveQRCodeImage(ctx context.Context, spec *QRCodeSpec, ticket string, opts *BatchQRCodeOptions) (err error) {
+	httpClient := opts.HttpClient
+	if httpClient == nil {
+		httpClient = mp.MediaHttpClient
+	}
+	stream, err := OpenQRCode(ticket, httpClient)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+
+	var key string
+	if opts.ObjectKey != nil {
+		key = opts.ObjectKey(spec)
+	} else if spec.SceneString != "" {
+		key = "qrcode_" + spec.SceneString + ".jpg"
+	} else {
+		key = "qrcode_" + strconv.FormatUint(uint64(spec.SceneId), 10) + ".jpg"
+	}
+	if key == "" {
+		return errors.New("empty object key")
+	}
+	contentType := stream.ContentType
+	if contentType == "" {
+		contentType = "image/jpeg"
+	}
+	return opts.Writer.PutObject(ctx, key, stream, contentType)
+}
+
+// 网络错误(PostJSON 返回的 *mp.RequestError)和系统繁忙, 调用太频繁可以重试, 参数错误等其他错误不重试.
+func isRetryableQRCodeError(err error) bool {
+	switch e := err.(type) {
+	case *mp.RequestError:
+		return true
+	case *mp.Error:
+		return e.ErrCode == -1 || e.ErrCode == 45011
+	}
+	return false
+}
@@ -14,6 +14,7 @@ import (
 	"os"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 const (
@@ -155,6 +156,50 @@ func (clt Client) CreatePermanentQRCodeWithSceneString(SceneString string) (qrco
 	return
 }
 
+// 创建临时二维码
+//  SceneString:   场景值ID（字符串形式的ID），字符串类型，长度限制为1到64
+//  ExpireSeconds: 二维码有效时间，以秒为单位。
+func (clt Client) CreateTemporaryQRCodeWithSceneString(SceneString string, ExpireSeconds int) (qrcode *TemporaryQRCode, err error) {
+	if SceneString == "" {
+		err = errors.New("SceneString should not be empty")
+		return
+	}
+	if ExpireSeconds <= 0 {
+		err = errors.New("ExpireSeconds should be greater than 0")
+		return
+	}
+	var request struct {
+		ExpireSeconds int    `json:"expire_seconds"`
+		ActionName    string `json:"action_name"`
+		ActionInfo    struct {
+			Scene struct {
+				SceneString string `json:"scene_str"`
+			} `json:"scene"`
+		} `json:"action_info"`
+	}
+	request.ExpireSeconds = ExpireSeconds
+	request.ActionName = "QR_STR_SCENE"
+	request.ActionInfo.Scene.SceneString = SceneString
+
+	var result struct {
+		mp.Error
+		TemporaryQRCode
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/qrcode/create?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.TemporaryQRCode.SceneString = SceneString
+	qrcode = &result.TemporaryQRCode
+	return
+}
+
 // 通过ticket换取二维码, 写入到 writer.
 func (clt Client) QRCodeDownloadToWriter(ticket string, writer io.Writer) (err error) {
 	if ticket == "" {
@@ -231,6 +276,48 @@ func QRCodeDownload(ticket, filepath string, httpClient *http.Client) (err error
 	return qrcodeDownloadToWriter(ticket, file, httpClient)
 }
 
+// 通过ticket换取二维码图片流, 调用者负责关闭 body.
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func QRCodeStream(ticket string, httpClient *http.Client) (body io.ReadCloser, err error) {
+	if ticket == "" {
+		err = errors.New("empty ticket")
+		return
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpResp, err := httpClient.Get(QRCodePicURL(ticket))
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+	body = httpResp.Body
+	return
+}
+
+// 通过ticket换取二维码图片的文件流, 可以获取 Content-Type 和 Content-Length, 调用者负责关闭.
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func OpenQRCode(ticket string, httpClient *http.Client) (stream *util.DownloadStream, err error) {
+	if ticket == "" {
+		err = errors.New("empty ticket")
+		return
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpResp, err := httpClient.Get(QRCodePicURL(ticket))
+	if err != nil {
+		return
+	}
+	return mp.OpenDownloadResponse(httpResp)
+}
+
 // 二维码图片的URL, 可以GET此URL下载二维码或者在线显示此二维码.
 func QRCodePicURL(ticket string) string {
 	return "https://mp.weixin.qq.com/cgi-bin/showqrcode?ticket=" + url.QueryEscape(ticket)
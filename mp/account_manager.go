@@ -0,0 +1,198 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/cipher"
+)
+
+// 一个公众号的配置
+type Account struct {
+	AppId     string
+	AppSecret string
+
+	// 下面的字段用于接收消息(事件), 不需要接收消息时可以都为空.
+	OriId         string
+	Token         string
+	EncodedAESKey string         // 43 个字符, 明文模式下可以为空
+	Handler       MessageHandler // 为 nil 时不接收该公众号的消息(事件)
+}
+
+type managedAccount struct {
+	client      *Client
+	tokenServer *DefaultAccessTokenServer
+	server      Server // 可能为 nil
+}
+
+// 在一个进程里管理多个公众号, 每个公众号一个 Client, 所有的 Client 共用一个 http.Client.
+//
+//  NOTE:
+//  AccountManager 同时是一个 http.Handler, 根据回调 URL path 的最后一段(appid)把消息(事件)
+//  交给对应公众号的 MessageHandler 处理, 例如回调 URL 为 http://www.xxx.com/weixin/wx1234567890abcdef,
+//  那么就交给 AppId 为 wx1234567890abcdef 的公众号处理; 可以通过 AppIdFunc 修改.
+//
+//  AccountManager 并发安全, 可以在运行中动态增加和删除公众号.
+type AccountManager struct {
+	httpClient            *http.Client
+	invalidRequestHandler InvalidRequestHandler
+	interceptor           Interceptor
+
+	// 可以为 nil; 新建 Client 后调用, 一般用于设置 RetryPolicy, RateLimiter, Metrics 等.
+	ClientHook func(appId string, clt *Client)
+
+	// 可以为 nil; 从回调请求里获取 appid, 为 nil 时取 URL path 的最后一段.
+	AppIdFunc func(r *http.Request) string
+
+	rwmutex  sync.RWMutex
+	accounts map[string]*managedAccount
+}
+
+// clt, handler, interceptor 均可以为 nil, clt 为 nil 时使用 TextHttpClient.
+func NewAccountManager(clt *http.Client, handler InvalidRequestHandler, interceptor Interceptor) *AccountManager {
+	if clt == nil {
+		clt = TextHttpClient
+	}
+	if handler == nil {
+		handler = DefaultInvalidRequestHandler
+	}
+
+	return &AccountManager{
+		httpClient:            clt,
+		invalidRequestHandler: handler,
+		interceptor:           interceptor,
+		accounts:              make(map[string]*managedAccount),
+	}
+}
+
+// 增加一个公众号, 如果 AppId 已经存在则替换原来的配置.
+func (m *AccountManager) Add(account *Account) (err error) {
+	if account == nil {
+		return errors.New("nil Account")
+	}
+	if account.AppId == "" {
+		return errors.New("empty AppId")
+	}
+	if account.AppSecret == "" {
+		return errors.New("empty AppSecret")
+	}
+
+	var server Server
+	if account.Handler != nil {
+		var AESKey [32]byte
+		if account.EncodedAESKey != "" {
+			if AESKey, err = cipher.DecodeAESKey(account.EncodedAESKey); err != nil {
+				return
+			}
+		}
+		server = NewDefaultServer(account.OriId, account.Token, account.AppId, AESKey[:], account.Handler)
+	}
+
+	tokenServer := NewDefaultAccessTokenServer(account.AppId, account.AppSecret, m.httpClient)
+	entry := &managedAccount{
+		client:      NewClient(tokenServer, m.httpClient),
+		tokenServer: tokenServer,
+		server:      server,
+	}
+	if m.ClientHook != nil {
+		m.ClientHook(account.AppId, entry.client)
+	}
+
+	m.rwmutex.Lock()
+	old := m.accounts[account.AppId]
+	m.accounts[account.AppId] = entry
+	m.rwmutex.Unlock()
+
+	if old != nil {
+		old.tokenServer.stop()
+	}
+	return
+}
+
+// 删除 appId 对应的公众号, 并结束其后台刷新 access_token 的 goroutine.
+//  已经通过 Client 获取的 *Client 仍然可以使用, 但是不再自动刷新 access_token.
+func (m *AccountManager) Remove(appId string) {
+	m.rwmutex.Lock()
+	entry := m.accounts[appId]
+	delete(m.accounts, appId)
+	m.rwmutex.Unlock()
+
+	if entry != nil {
+		entry.tokenServer.stop()
+	}
+}
+
+// 获取 appId 对应的 Client, 不存在时返回 nil.
+func (m *AccountManager) Client(appId string) *Client {
+	m.rwmutex.RLock()
+	entry := m.accounts[appId]
+	m.rwmutex.RUnlock()
+
+	if entry == nil {
+		return nil
+	}
+	return entry.client
+}
+
+// 返回所有公众号的 AppId, 按字典序排序.
+func (m *AccountManager) AppIds() (appIds []string) {
+	m.rwmutex.RLock()
+	appIds = make([]string, 0, len(m.accounts))
+	for appId := range m.accounts {
+		appIds = append(appIds, appId)
+	}
+	m.rwmutex.RUnlock()
+
+	sort.Strings(appIds)
+	return
+}
+
+func (m *AccountManager) appId(r *http.Request) string {
+	if m.AppIdFunc != nil {
+		return m.AppIdFunc(r)
+	}
+	path := strings.TrimRight(r.URL.Path, "/")
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// 实现 http.Handler
+func (m *AccountManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	queryValues, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		m.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	if interceptor := m.interceptor; interceptor != nil && !interceptor.Intercept(w, r, queryValues) {
+		return
+	}
+
+	appId := m.appId(r)
+	if appId == "" {
+		err = fmt.Errorf("no appid in the url path %s", r.URL.Path)
+		m.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	m.rwmutex.RLock()
+	entry := m.accounts[appId]
+	m.rwmutex.RUnlock()
+
+	if entry == nil || entry.server == nil {
+		err = fmt.Errorf("Not found Server for appid == %s", appId)
+		m.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	ServeHTTP(w, r, queryValues, entry.server, m.invalidRequestHandler)
+}
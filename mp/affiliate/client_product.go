@@ -0,0 +1,176 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package affiliate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 导入商品(新增或者更新), 商品的处理是异步的, 返回的 statusTicket 用于查询导入结果.
+func (clt Client) ImportProduct(products []Product) (statusTicket string, err error) {
+	if len(products) <= 0 {
+		err = errors.New("empty products")
+		return
+	}
+	if len(products) > ProductImportCountLimit {
+		err = fmt.Errorf("the length of products must be less than or equal to %d", ProductImportCountLimit)
+		return
+	}
+
+	var request = struct {
+		Product []Product `json:"product"`
+	}{
+		Product: products,
+	}
+
+	var result struct {
+		mp.Error
+		StatusTicket string `json:"status_ticket"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/v2/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	statusTicket = result.StatusTicket
+	return
+}
+
+// 更新商品的上下架状态和库存, 其他字段保持不变.
+func (clt Client) UpdateProductSaleInfo(pid string, saleInfo ProductSaleInfo) (statusTicket string, err error) {
+	if pid == "" {
+		err = errors.New("empty pid")
+		return
+	}
+	products := []Product{
+		{
+			Pid:           pid,
+			SaleInfo:      &saleInfo,
+			PartialUpdate: 1,
+		},
+	}
+	return clt.ImportProduct(products)
+}
+
+// 查询商品导入的结果.
+func (clt Client) ImportStatus(statusTicket string) (status *ImportStatus, err error) {
+	if statusTicket == "" {
+		err = errors.New("empty statusTicket")
+		return
+	}
+
+	var request = struct {
+		StatusTicket string `json:"status_ticket"`
+	}{
+		StatusTicket: statusTicket,
+	}
+
+	var result struct {
+		mp.Error
+		Result ImportStatus `json:"result"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/v2/status?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	status = &result.Result
+	return
+}
+
+// 获取单个商品的信息.
+func (clt Client) ProductInfo(pid string) (product *Product, err error) {
+	if pid == "" {
+		err = errors.New("empty pid")
+		return
+	}
+
+	var request struct {
+		Product struct {
+			Pid string `json:"pid"`
+		} `json:"product"`
+	}
+	request.Product.Pid = pid
+
+	var result struct {
+		mp.Error
+		Product Product `json:"product"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/v2/getinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	product = &result.Product
+	return
+}
+
+// 分页获取商品信息返回的数据结构
+type ProductListResult struct {
+	TotalCount  int       `json:"total_num"`
+	PageContext string    `json:"page_context"` // 下一页的上下文, 作为下一次请求的 pageContext
+	Products    []Product `json:"product"`
+}
+
+// 分页获取商品信息.
+//  pageContext: 第一页为 "", 后续页为上一次返回的 ProductListResult.PageContext
+//  pageNum:     页码, 从 1 开始
+//  pageSize:    每页的商品个数, 最大值为 ProductPageSizeLimit
+func (clt Client) ProductList(pageContext string, pageNum, pageSize int) (rslt *ProductListResult, err error) {
+	if pageNum < 1 {
+		err = errors.New("pageNum should be greater than 0")
+		return
+	}
+	if pageSize < 1 || pageSize > ProductPageSizeLimit {
+		err = fmt.Errorf("pageSize should be in range [1, %d]", ProductPageSizeLimit)
+		return
+	}
+
+	var request = struct {
+		PageContext string `json:"page_context"`
+		PageNum     int    `json:"page_num"`
+		PageSize    int    `json:"page_size"`
+	}{
+		PageContext: pageContext,
+		PageNum:     pageNum,
+		PageSize:    pageSize,
+	}
+
+	var result struct {
+		mp.Error
+		ProductListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/v2/getinfobypage?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rslt = &result.ProductListResult
+	return
+}
@@ -0,0 +1,125 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package affiliate
+
+const (
+	ProductImportCountLimit = 50 // 每次导入的商品个数最大值为 50
+	ProductPageSizeLimit    = 100
+)
+
+const (
+	SaleStatusOn  = "on"  // 上架
+	SaleStatusOff = "off" // 下架
+)
+
+const (
+	LinkTypeH5  = 0 // H5 链接
+	LinkTypeWxa = 1 // 小程序链接
+)
+
+// 返佣商品
+type Product struct {
+	Pid      string `json:"pid"`                 // 必须; 商品的唯一标识, 同一个 appid 下唯一
+	Title    string `json:"title,omitempty"`     // 必须; 商品标题
+	SubTitle string `json:"sub_title,omitempty"` // 非必须; 商品副标题
+	Desc     string `json:"desc,omitempty"`      // 非必须; 商品描述
+	Brand    string `json:"brand,omitempty"`     // 非必须; 商品品牌
+
+	ImageInfo *ProductImageInfo `json:"image_info,omitempty"` // 必须; 商品图片
+	Category  *ProductCategory  `json:"category_info,omitempty"`
+	Official  *ProductCategory  `json:"official_category_info,omitempty"` // 非必须; 商品的官方类目
+	LinkInfo  *ProductLinkInfo  `json:"link_info,omitempty"`              // 必须; 商品的推广链接
+	PriceInfo *ProductPriceInfo `json:"price_info,omitempty"`             // 必须; 商品价格
+	SaleInfo  *ProductSaleInfo  `json:"sale_info,omitempty"`              // 必须; 商品售卖信息
+	ShopInfo  *ProductShopInfo  `json:"shop_info,omitempty"`              // 非必须; 商品所属店铺
+	SkuInfo   *ProductSkuInfo   `json:"sku_info,omitempty"`               // 非必须; 商品 SKU 信息
+
+	// 非必须; 部分更新, 为 1 时仅更新传入的字段
+	PartialUpdate int `json:"partial_update,omitempty"`
+}
+
+type ProductImageInfo struct {
+	MainImageList []ProductImage `json:"main_image_list,omitempty"` // 商品主图, 最多 9 张
+}
+
+type ProductImage struct {
+	URL string `json:"url"`
+}
+
+type ProductCategory struct {
+	CategoryItem []ProductCategoryItem `json:"category_item,omitempty"` // 由一级到末级依次排列
+}
+
+type ProductCategoryItem struct {
+	CategoryName string `json:"category_name"`
+}
+
+// 商品推广链接
+type ProductLinkInfo struct {
+	URL      string `json:"url"`                 // H5 链接或者小程序页面路径
+	WxaAppId string `json:"wxa_appid,omitempty"` // 小程序 appid, LinkType == LinkTypeWxa 时必须
+	LinkType int    `json:"link_type"`           // 链接类型, LinkTypeH5 或 LinkTypeWxa
+}
+
+// 商品价格, 单位为分
+type ProductPriceInfo struct {
+	MinPrice    int64 `json:"min_price"`
+	MaxPrice    int64 `json:"max_price,omitempty"`
+	MinOriPrice int64 `json:"min_ori_price,omitempty"`
+	MaxOriPrice int64 `json:"max_ori_price,omitempty"`
+}
+
+type ProductSaleInfo struct {
+	SaleStatus string `json:"sale_status"` // SaleStatusOn, SaleStatusOff
+	Stock      int64  `json:"stock"`       // 库存
+}
+
+type ProductShopInfo struct {
+	Source int `json:"source"`
+}
+
+type ProductSkuInfo struct {
+	SkuItem []ProductSku `json:"sku_item,omitempty"`
+}
+
+type ProductSku struct {
+	SkuId     string            `json:"sku_id"`
+	ImageInfo *ProductImageInfo `json:"image_info,omitempty"`
+	PriceInfo *ProductPriceInfo `json:"price_info,omitempty"`
+	SaleInfo  *ProductSaleInfo  `json:"sale_info,omitempty"`
+	ShopInfo  *ProductShopInfo  `json:"shop_info,omitempty"`
+
+	// sku 的属性, 比如 [{"name":"颜色","value":"红色"}]
+	SkuAttrList []ProductSkuAttr `json:"sku_attr_list,omitempty"`
+}
+
+type ProductSkuAttr struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// 商品导入的处理结果
+type ImportStatus struct {
+	SuccessCount int    `json:"succ_cnt"`  // 导入成功的商品个数
+	FailCount    int    `json:"fail_cnt"`  // 导入失败的商品个数
+	TotalCount   int    `json:"total_cnt"` // 导入的商品总数
+	Progress     string `json:"progress"`  // 导入进度, 比如 "100%"
+
+	Statuses []ProductImportStatus `json:"statuses,omitempty"`
+}
+
+// 是否处理完成.
+func (status *ImportStatus) Finished() bool {
+	return status.SuccessCount+status.FailCount >= status.TotalCount
+}
+
+// 单个商品的导入结果
+type ProductImportStatus struct {
+	Pid        string `json:"pid"`
+	Ret        int    `json:"ret"` // 0 表示成功
+	ErrMsg     string `json:"err_msg"`
+	ErrMsgZhCN string `json:"err_msg_zh_cn"`
+}
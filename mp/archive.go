@@ -0,0 +1,96 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// 一次接口调用的存档记录.
+//  NOTE: 不包含 access_token; Response 为最后一次(包括重试)收到的应答.
+type ArchiveRecord struct {
+	Time      time.Time       `json:"time"`               // 调用开始的时间
+	Duration  time.Duration   `json:"duration"`           // 调用的耗时(包括重试)
+	RequestId string          `json:"request_id"`         // 见 Client.RequestId
+	Method    string          `json:"method"`             // GET 或者 POST
+	Endpoint  string          `json:"endpoint"`           // 比如 "/cgi-bin/message/custom/send"
+	Request   json.RawMessage `json:"request,omitempty"`  // POST 的 json(不是 json 时为 json 字符串), GET 时为空
+	Response  json.RawMessage `json:"response,omitempty"` // 应答的 json(不是 json 时为 json 字符串), 没有收到应答时为空
+	ErrCode   int             `json:"errcode"`            // 应答的 errcode
+	Error     string          `json:"error,omitempty"`    // 请求过程中的错误, 比如网络错误
+}
+
+// 接口调用的存档接口, 用于需要留存和微信所有交互记录的场景.
+//  NOTE: 实现必须是并发安全的; Archive 是同步调用的, 耗时会计入接口调用的耗时.
+type Archiver interface {
+	// 返回错误不影响接口调用的结果, 错误只会通过 LogInfoln 输出.
+	Archive(record *ArchiveRecord) error
+}
+
+type archiveCall struct {
+	archiver Archiver
+	record   ArchiveRecord
+	respBuf  bytes.Buffer
+}
+
+// 开始一次接口调用的存档, Archiver 为 nil 时返回 nil, archiveCall 的方法都可以在 nil 上调用.
+func (clt *Client) startArchive(method, incompleteURL, requestId string, requestBytes []byte) *archiveCall {
+	if clt.Archiver == nil {
+		return nil
+	}
+	call := &archiveCall{
+		archiver: clt.Archiver,
+		record: ArchiveRecord{
+			Time:      time.Now(),
+			RequestId: requestId,
+			Method:    method,
+			Endpoint:  endpointOf(incompleteURL),
+		},
+	}
+	call.record.Request = archiveBody(requestBytes)
+	return call
+}
+
+// 返回读取 httpResp.Body 的 io.Reader, 同时记录读到的内容; 每次重试都会调用, 只保留最后一次的应答.
+func (call *archiveCall) body(r io.Reader) io.Reader {
+	if call == nil {
+		return r
+	}
+	call.respBuf.Reset()
+	return io.TeeReader(r, &call.respBuf)
+}
+
+// 把请求或者应答转换为 json.RawMessage, 不是 json 的内容(比如 Client.Do 的 xml)存为 json 字符串.
+func archiveBody(b []byte) json.RawMessage {
+	if b = bytes.TrimSpace(b); len(b) == 0 {
+		return nil
+	}
+	if json.Valid(b) {
+		return append(json.RawMessage(nil), b...)
+	}
+	s, _ := json.Marshal(string(b))
+	return s
+}
+
+func (call *archiveCall) done(response interface{}, err error) {
+	if call == nil {
+		return
+	}
+	record := &call.record
+	record.Duration = time.Since(record.Time)
+	record.Response = archiveBody(call.respBuf.Bytes())
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.ErrCode = errCodeOf(response)
+	}
+	if err := call.archiver.Archive(record); err != nil {
+		LogInfoln("[WECHAT_ARCHIVE] request_id:", record.RequestId, ", archive failed:", err)
+	}
+}
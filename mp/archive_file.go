@@ -0,0 +1,121 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var _ Archiver = (*FileArchiver)(nil)
+
+// 把存档记录按行写入 json 文件(每行一个 ArchiveRecord), 按天(北京时间)和文件大小滚动:
+//  Dir/Prefix-20060102.log, 超过 MaxSize 后依次为 Dir/Prefix-20060102.1.log, Dir/Prefix-20060102.2.log ...
+//  NOTE: 只负责写入, 不会删除旧文件, 留存期限由使用者自己管理.
+type FileArchiver struct {
+	dir     string
+	prefix  string
+	maxSize int64
+
+	mutex sync.Mutex
+	file  *os.File
+	day   string // 当前文件的日期, 20060102
+	seq   int    // 当前文件的序号
+	size  int64  // 当前文件的大小
+}
+
+// 创建 FileArchiver, dir 不存在时会创建; prefix 为空时为 "wechat_mp"; maxSize <= 0 表示只按天滚动.
+func NewFileArchiver(dir, prefix string, maxSize int64) (*FileArchiver, error) {
+	if dir == "" {
+		return nil, errors.New("empty dir")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if prefix == "" {
+		prefix = "wechat_mp"
+	}
+	return &FileArchiver{
+		dir:     dir,
+		prefix:  prefix,
+		maxSize: maxSize,
+	}, nil
+}
+
+func (a *FileArchiver) Archive(record *ArchiveRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err = a.rotate(record.Time.In(beijingLocation).Format("20060102"), int64(len(line))); err != nil {
+		return err
+	}
+	n, err := a.file.Write(line)
+	a.size += int64(n)
+	return err
+}
+
+// 需要时切换到新的文件, 调用者需要持有 mutex.
+func (a *FileArchiver) rotate(day string, n int64) (err error) {
+	if a.file != nil && a.day == day && (a.maxSize <= 0 || a.size+n <= a.maxSize || a.size == 0) {
+		return
+	}
+	switch {
+	case a.day != day:
+		a.day, a.seq = day, 0
+	case a.file != nil: // 当前文件写满了
+		a.seq++
+	}
+	if a.file != nil {
+		a.file.Close()
+		a.file = nil
+	}
+
+	for {
+		name := a.prefix + "-" + day + ".log"
+		if a.seq > 0 {
+			name = fmt.Sprintf("%s-%s.%d.log", a.prefix, day, a.seq)
+		}
+		file, err := os.OpenFile(filepath.Join(a.dir, name), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return err
+		}
+		// 进程重启后接着写当天最后一个没有写满的文件
+		if a.maxSize > 0 && info.Size() > 0 && info.Size()+n > a.maxSize {
+			file.Close()
+			a.seq++
+			continue
+		}
+		a.file, a.size = file, info.Size()
+		return nil
+	}
+}
+
+// 关闭当前文件, 之后的 Archive 会重新打开文件.
+func (a *FileArchiver) Close() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.file != nil {
+		err = a.file.Close()
+		a.file = nil
+	}
+	return
+}
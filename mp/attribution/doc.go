@@ -0,0 +1,13 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 关注来源统计, 根据关注, 取消关注和扫码事件按天统计每个渠道(二维码场景)的粉丝增长.
+//
+//  tracker := attribution.NewTracker(attribution.NewMemoryStore())
+//  tracker.ChannelFunc = func(scene string) string { ... } // 可选, 把二维码场景映射为渠道
+//  srv := mp.NewDefaultServer(oriId, token, appId, aesKey, tracker.Handler(mux))
+//  ...
+//  report, err := tracker.Report(begin, end)
+package attribution
@@ -0,0 +1,96 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package attribution
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 一个渠道在统计区间内的汇总
+type ChannelReport struct {
+	Channel      string   `json:"channel"`
+	Subscribes   int      `json:"subscribes"`   // 关注人数
+	Unsubscribes int      `json:"unsubscribes"` // 取消关注人数
+	Scans        int      `json:"scans"`        // 已关注用户扫码次数
+	NetGain      int      `json:"net_gain"`     // 净增人数, Subscribes - Unsubscribes
+	Daily        []Record `json:"daily"`        // 每天的统计, 按日期排序, 没有数据的日期不出现
+}
+
+// 关注来源报表
+type Report struct {
+	BeginDate util.Date       `json:"begin_date"`
+	EndDate   util.Date       `json:"end_date"`
+	Total     ChannelReport   `json:"total"`    // 所有渠道的汇总, Channel 为空
+	Channels  []ChannelReport `json:"channels"` // 按 Subscribes 从大到小排序
+}
+
+// 返回 beginDate 到 endDate(包括)每个渠道的关注来源报表.
+func (t *Tracker) Report(beginDate, endDate time.Time) (report *Report, err error) {
+	begin, end := util.NewDate(beginDate), util.NewDate(endDate)
+	if end.Before(begin.Time) {
+		err = fmt.Errorf("endDate %s is before beginDate %s", end, begin)
+		return
+	}
+	records, err := t.Store.Query(begin, end)
+	if err != nil {
+		return
+	}
+
+	report = &Report{
+		BeginDate: begin,
+		EndDate:   end,
+	}
+	channels := make(map[string]*ChannelReport)
+	totalDaily := make(map[string]*Record)
+	for _, record := range records {
+		channel := channels[record.Channel]
+		if channel == nil {
+			channel = &ChannelReport{Channel: record.Channel}
+			channels[record.Channel] = channel
+		}
+		channel.add(&record)
+		channel.Daily = append(channel.Daily, record)
+
+		day := totalDaily[record.Date.String()]
+		if day == nil {
+			day = &Record{Date: record.Date}
+			totalDaily[record.Date.String()] = day
+		}
+		day.Subscribes += record.Subscribes
+		day.Unsubscribes += record.Unsubscribes
+		day.Scans += record.Scans
+		report.Total.add(&record)
+	}
+
+	for _, day := range totalDaily {
+		report.Total.Daily = append(report.Total.Daily, *day)
+	}
+	sort.Slice(report.Total.Daily, func(i, j int) bool {
+		return report.Total.Daily[i].Date.Before(report.Total.Daily[j].Date.Time)
+	})
+
+	for _, channel := range channels {
+		report.Channels = append(report.Channels, *channel)
+	}
+	sort.Slice(report.Channels, func(i, j int) bool {
+		if report.Channels[i].Subscribes != report.Channels[j].Subscribes {
+			return report.Channels[i].Subscribes > report.Channels[j].Subscribes
+		}
+		return report.Channels[i].Channel < report.Channels[j].Channel
+	})
+	return
+}
+
+func (r *ChannelReport) add(record *Record) {
+	r.Subscribes += record.Subscribes
+	r.Unsubscribes += record.Unsubscribes
+	r.Scans += record.Scans
+	r.NetGain = r.Subscribes - r.Unsubscribes
+}
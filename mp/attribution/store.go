@@ -0,0 +1,117 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package attribution
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 统计的事件类型
+const (
+	KindSubscribe   = 1 // 关注
+	KindUnsubscribe = 2 // 取消关注, 归到用户关注时的渠道
+	KindScan        = 3 // 已关注用户扫码
+)
+
+// 一个渠道一天的统计
+type Record struct {
+	Date         util.Date `json:"date"`
+	Channel      string    `json:"channel"`
+	Subscribes   int       `json:"subscribes"`
+	Unsubscribes int       `json:"unsubscribes"`
+	Scans        int       `json:"scans"`
+}
+
+// 统计数据的存储, 多进程部署时需要用共享的存储(比如 redis, 数据库)实现.
+type Store interface {
+	// 给 date 这一天 channel 渠道的 kind 事件计数加 1.
+	Incr(date util.Date, channel string, kind int) error
+
+	// 记录和查询用户关注时的渠道, 用于把取消关注归到关注时的渠道; 没有记录时返回空字符串.
+	SetChannel(openId, channel string) error
+	Channel(openId string) (channel string, err error)
+
+	// 查询 [begin, end] 之间的统计, 返回的记录按 Date, Channel 排序.
+	Query(begin, end util.Date) ([]Record, error)
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// 单进程的 Store 实现, 进程重启后数据丢失.
+type MemoryStore struct {
+	mutex    sync.RWMutex
+	records  map[recordKey]*Record
+	channels map[string]string
+}
+
+type recordKey struct {
+	date    string // YYYYMMDD
+	channel string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records:  make(map[recordKey]*Record),
+		channels: make(map[string]string),
+	}
+}
+
+func (s *MemoryStore) Incr(date util.Date, channel string, kind int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := recordKey{date: date.String(), channel: channel}
+	record := s.records[key]
+	if record == nil {
+		record = &Record{Date: date, Channel: channel}
+		s.records[key] = record
+	}
+	switch kind {
+	case KindSubscribe:
+		record.Subscribes++
+	case KindUnsubscribe:
+		record.Unsubscribes++
+	case KindScan:
+		record.Scans++
+	}
+	return nil
+}
+
+func (s *MemoryStore) SetChannel(openId, channel string) error {
+	s.mutex.Lock()
+	s.channels[openId] = channel
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Channel(openId string) (channel string, err error) {
+	s.mutex.RLock()
+	channel = s.channels[openId]
+	s.mutex.RUnlock()
+	return
+}
+
+func (s *MemoryStore) Query(begin, end util.Date) (records []Record, err error) {
+	s.mutex.RLock()
+	for _, record := range s.records {
+		if record.Date.Before(begin.Time) || record.Date.After(end.Time) {
+			continue
+		}
+		records = append(records, *record)
+	}
+	s.mutex.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Date.Equal(records[j].Date.Time) {
+			return records[i].Date.Before(records[j].Date.Time)
+		}
+		return records[i].Channel < records[j].Channel
+	})
+	return
+}
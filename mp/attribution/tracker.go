@@ -0,0 +1,130 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package attribution
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+	"github.com/chanxuehong/wechat/mp/user"
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 普通关注(没有扫描带参数二维码)的渠道
+const ChannelDirect = "direct"
+
+// 根据关注, 取消关注和扫码事件统计关注来源.
+type Tracker struct {
+	Store Store
+
+	// 可以为 nil; 把二维码的场景值(scene_id 或者 scene_str)映射为渠道, 比如把 "shop_1001" 映射为 "shop",
+	// 返回空字符串表示不统计. 为 nil 时渠道就是场景值.
+	ChannelFunc func(scene string) string
+
+	// 可以为 nil; Handler 里统计出错时调用, 统计出错不影响消息的处理.
+	ErrorHandler func(msg *mp.MixedMessage, err error)
+}
+
+func NewTracker(store Store) *Tracker {
+	if store == nil {
+		panic("nil Store")
+	}
+	return &Tracker{
+		Store: store,
+	}
+}
+
+func (t *Tracker) channel(scene string) string {
+	if scene == "" {
+		return ChannelDirect
+	}
+	if t.ChannelFunc != nil {
+		return t.ChannelFunc(scene)
+	}
+	return scene
+}
+
+// 统计一个事件, 不是关注, 取消关注和扫码事件时直接返回 nil.
+func (t *Tracker) Observe(msg *mp.MixedMessage) (err error) {
+	if msg.MsgType != "event" {
+		return
+	}
+	date := util.NewDate(time.Unix(msg.CreateTime, 0))
+
+	switch msg.Event {
+	case request.EventTypeSubscribe:
+		var scene string
+		if strings.HasPrefix(msg.EventKey, "qrscene_") {
+			if scene, err = request.GetSubscribeByScanEvent(msg).Scene(); err != nil {
+				return
+			}
+		}
+		return t.subscribe(msg.FromUserName, t.channel(scene), date)
+	case request.EventTypeUnsubscribe:
+		channel, err := t.Store.Channel(msg.FromUserName)
+		if err != nil {
+			return err
+		}
+		if channel == "" {
+			return nil // 统计开始前关注的用户, 不知道来源
+		}
+		return t.Store.Incr(date, channel, KindUnsubscribe)
+	case request.EventTypeScan:
+		channel := t.channel(msg.EventKey)
+		if channel == "" {
+			return
+		}
+		return t.Store.Incr(date, channel, KindScan)
+	}
+	return
+}
+
+func (t *Tracker) subscribe(openId, channel string, date util.Date) (err error) {
+	if channel == "" {
+		return
+	}
+	if err = t.Store.SetChannel(openId, channel); err != nil {
+		return
+	}
+	return t.Store.Incr(date, channel, KindSubscribe)
+}
+
+// 用用户基本信息里的 subscribe_scene, qr_scene_str, qr_scene 补录关注来源, 一般用于统计开始前已经关注的用户.
+//  没有扫码的用户, 渠道为 subscribe_scene(比如 ADD_SCENE_SEARCH), 关注日期为 subscribe_time.
+func (t *Tracker) ObserveUser(info *user.UserInfo) (err error) {
+	if info.SubscribeTime == 0 {
+		return
+	}
+	var channel string
+	switch {
+	case info.QrSceneStr != "":
+		channel = t.channel(info.QrSceneStr)
+	case info.QrScene != 0:
+		channel = t.channel(strconv.FormatInt(info.QrScene, 10))
+	case info.SubscribeScene != "":
+		channel = info.SubscribeScene
+	default:
+		channel = ChannelDirect
+	}
+	return t.subscribe(info.OpenId, channel, util.NewDate(time.Unix(info.SubscribeTime, 0)))
+}
+
+// 返回一个先统计事件再交给 handler 处理的 MessageHandler.
+func (t *Tracker) Handler(handler mp.MessageHandler) mp.MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		if err := t.Observe(r.MixedMsg); err != nil && t.ErrorHandler != nil {
+			t.ErrorHandler(r.MixedMsg, err)
+		}
+		handler.ServeMessage(w, r)
+	})
+}
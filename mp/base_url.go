@@ -0,0 +1,26 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"strings"
+)
+
+// 公众号接口默认的 base url, 所有接口的 url 都以它开头.
+const DefaultBaseURL = "https://api.weixin.qq.com"
+
+// 把 rawURL 开头的 DefaultBaseURL 替换为 baseURL, 比如沙箱环境, 内部网关或者代理的地址.
+//  baseURL 为空, 或者 rawURL 不以 DefaultBaseURL 开头(比如 mp.weixin.qq.com 的接口)时原样返回 rawURL.
+func ReplaceBaseURL(rawURL, baseURL string) string {
+	if baseURL == "" || !strings.HasPrefix(rawURL, DefaultBaseURL) {
+		return rawURL
+	}
+	rest := rawURL[len(DefaultBaseURL):]
+	if rest != "" && rest[0] != '/' && rest[0] != '?' {
+		return rawURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + rest
+}
@@ -17,6 +17,20 @@ const (
 	EventTypeUserDelCard      = "user_del_card"       // 删除卡券事件
 	EventTypeUserViewCard     = "user_view_card"      // 进入会员卡事件推送
 	EventTypeUserConsumeCard  = "user_consume_card"   // 核销事件推送
+
+	EventTypeUserGiftingCard          = "user_gifting_card"            // 转赠事件推送
+	EventTypeUserPayFromPayCell       = "user_pay_from_pay_cell"       // 买单事件推送
+	EventTypeUserEnterSessionFromCard = "user_enter_session_from_card" // 从卡券进入公众号会话事件推送
+	EventTypeUpdateMemberCard         = "update_member_card"           // 会员卡内容更新事件
+	EventTypeSubmitMemberCardUserInfo = "submit_membercard_user_info"  // 会员卡激活事件推送
+	EventTypeCardSkuRemind            = "card_sku_remind"              // 库存报警事件
+)
+
+// 核销来源
+const (
+	ConsumeSourceFromAPI          = "FROM_API"           // 开发者 API 核销
+	ConsumeSourceFromMobileHelper = "FROM_MOBILE_HELPER" // 卡券商户助手核销
+	ConsumeSourceFromMP           = "FROM_MP"            // 公众平台核销
 )
 
 // 卡券通过审核，微信会把这个事件推送到开发者填写的URL
@@ -41,8 +55,9 @@ type CardNotPassCheckEvent struct {
 	XMLName struct{} `xml:"xml" json:"-"`
 	mp.MessageHeader
 
-	Event  string `xml:"Event"  json:"Event"`  // 事件类型, card_not_pass_check
-	CardId string `xml:"CardId" json:"CardId"` // 卡券ID
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, card_not_pass_check
+	CardId       string `xml:"CardId"       json:"CardId"`       // 卡券ID
+	RefuseReason string `xml:"RefuseReason" json:"RefuseReason"` // 审核不通过原因
 }
 
 func GetCardNotPassCheckEvent(msg *mp.MixedMessage) *CardNotPassCheckEvent {
@@ -50,6 +65,7 @@ func GetCardNotPassCheckEvent(msg *mp.MixedMessage) *CardNotPassCheckEvent {
 		MessageHeader: msg.MessageHeader,
 		Event:         msg.Event,
 		CardId:        msg.CardId,
+		RefuseReason:  msg.RefuseReason,
 	}
 }
 
@@ -64,6 +80,10 @@ type UserGetCardEvent struct {
 	FriendUserName string `xml:"FriendUserName" json:"FriendUserName"` // 赠送方账号（一个OpenID），"IsGiveByFriend”为1 时填写该参数。
 	UserCardCode   string `xml:"UserCardCode"   json:"UserCardCode"`   // code 序列号。自定义code 及非自定义code的卡券被领取后都支持事件推送。
 	OuterId        int64  `xml:"OuterId"        json:"OuterId"`        // 领取场景值，用于领取渠道数据统计。可在生成二维码接口及添加JS API 接口中自定义该字段的整型值。
+
+	OldUserCardCode     string `xml:"OldUserCardCode"     json:"OldUserCardCode"`     // 为保证安全，微信会在转赠发生后变更该卡券的code号，该字段表示转赠前的code。
+	IsRestoreMemberCard int    `xml:"IsRestoreMemberCard" json:"IsRestoreMemberCard"` // 用户删除会员卡后可重新找回，当用户本次操作为找回时，该值为1，否则为0
+	OuterStr            string `xml:"OuterStr"            json:"OuterStr"`            // 领取场景值，用于领取渠道数据统计。可在生成二维码接口及添加Addcard接口中自定义该字段的字符串值。
 }
 
 func GetUserGetCardEvent(msg *mp.MixedMessage) *UserGetCardEvent {
@@ -75,6 +95,10 @@ func GetUserGetCardEvent(msg *mp.MixedMessage) *UserGetCardEvent {
 		FriendUserName: msg.FriendUserName,
 		UserCardCode:   msg.UserCardCode,
 		OuterId:        msg.OuterId,
+
+		OldUserCardCode:     msg.OldUserCardCode,
+		IsRestoreMemberCard: msg.IsRestoreMemberCard,
+		OuterStr:            msg.OuterStr,
 	}
 }
 
@@ -121,9 +145,13 @@ type UserConsumeCardEvent struct {
 	XMLName struct{} `xml:"xml" json:"-"`
 	mp.MessageHeader
 
-	Event        string `xml:"Event"          json:"Event"`        // 事件类型, user_consume_card
-	CardId       string `xml:"CardId"         json:"CardId"`       // 卡券ID
-	UserCardCode string `xml:"UserCardCode"   json:"UserCardCode"` // 商户自定义code 值。非自定code 推送为空串
+	Event         string `xml:"Event"         json:"Event"`         // 事件类型, user_consume_card
+	CardId        string `xml:"CardId"        json:"CardId"`        // 卡券ID
+	UserCardCode  string `xml:"UserCardCode"  json:"UserCardCode"`  // 商户自定义code 值。非自定code 推送为空串
+	ConsumeSource string `xml:"ConsumeSource" json:"ConsumeSource"` // 核销来源, ConsumeSourceFromAPI...
+	LocationName  string `xml:"LocationName"  json:"LocationName"`  // 门店名称，当前卡券核销的门店名称（只有通过自助核销和买单核销时才会出现该字段）
+	StaffOpenId   string `xml:"StaffOpenId"   json:"StaffOpenId"`   // 核销该卡券核销员的openid（只有通过卡券商户助手核销时才会出现）
+	OuterStr      string `xml:"OuterStr"      json:"OuterStr"`      // 用户领取卡券时的场景值
 }
 
 func GetUserConsumeCardEvent(msg *mp.MixedMessage) *UserConsumeCardEvent {
@@ -132,5 +160,141 @@ func GetUserConsumeCardEvent(msg *mp.MixedMessage) *UserConsumeCardEvent {
 		Event:         msg.Event,
 		CardId:        msg.CardId,
 		UserCardCode:  msg.UserCardCode,
+		ConsumeSource: msg.ConsumeSource,
+		LocationName:  msg.LocationName,
+		StaffOpenId:   msg.StaffOpenId,
+		OuterStr:      msg.OuterStr,
+	}
+}
+
+// 用户在转赠卡券时，微信会把这个事件推送到开发者填写的URL
+type UserGiftingCardEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event          string `xml:"Event"          json:"Event"`          // 事件类型, user_gifting_card
+	CardId         string `xml:"CardId"         json:"CardId"`         // 卡券ID
+	UserCardCode   string `xml:"UserCardCode"   json:"UserCardCode"`   // code 序列号
+	FriendUserName string `xml:"FriendUserName" json:"FriendUserName"` // 接收卡券用户的openid
+	IsReturnBack   int    `xml:"IsReturnBack"   json:"IsReturnBack"`   // 是否转赠退回，0 代表不是，1 代表是。
+	IsChatRoom     int    `xml:"IsChatRoom"     json:"IsChatRoom"`     // 是否是群转赠
+}
+
+func GetUserGiftingCardEvent(msg *mp.MixedMessage) *UserGiftingCardEvent {
+	return &UserGiftingCardEvent{
+		MessageHeader:  msg.MessageHeader,
+		Event:          msg.Event,
+		CardId:         msg.CardId,
+		UserCardCode:   msg.UserCardCode,
+		FriendUserName: msg.FriendUserName,
+		IsReturnBack:   msg.IsReturnBack,
+		IsChatRoom:     msg.IsChatRoom,
+	}
+}
+
+// 用户在使用买单功能时，微信会把这个事件推送到开发者填写的URL
+type UserPayFromPayCellEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, user_pay_from_pay_cell
+	CardId       string `xml:"CardId"       json:"CardId"`       // 卡券ID
+	UserCardCode string `xml:"UserCardCode" json:"UserCardCode"` // 卡券Code码
+	TransId      string `xml:"TransId"      json:"TransId"`      // 微信支付交易订单号（只有使用买单功能核销的卡券才会出现）
+	LocationId   int64  `xml:"LocationId"   json:"LocationId"`   // 门店ID
+	Fee          string `xml:"Fee"          json:"Fee"`          // 实付金额，单位为分
+	OriginalFee  string `xml:"OriginalFee"  json:"OriginalFee"`  // 应付金额，单位为分
+}
+
+func GetUserPayFromPayCellEvent(msg *mp.MixedMessage) *UserPayFromPayCellEvent {
+	return &UserPayFromPayCellEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		CardId:        msg.CardId,
+		UserCardCode:  msg.UserCardCode,
+		TransId:       msg.TransId,
+		LocationId:    msg.LocationId,
+		Fee:           msg.Fee,
+		OriginalFee:   msg.OriginalFee,
+	}
+}
+
+// 用户在卡券里点击查看公众号进入会话时，微信会把这个事件推送到开发者填写的URL
+type UserEnterSessionFromCardEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, user_enter_session_from_card
+	CardId       string `xml:"CardId"       json:"CardId"`       // 卡券ID
+	UserCardCode string `xml:"UserCardCode" json:"UserCardCode"` // 卡券Code码
+}
+
+func GetUserEnterSessionFromCardEvent(msg *mp.MixedMessage) *UserEnterSessionFromCardEvent {
+	return &UserEnterSessionFromCardEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		CardId:        msg.CardId,
+		UserCardCode:  msg.UserCardCode,
+	}
+}
+
+// 会员卡的积分或者余额发生变动时，微信会把这个事件推送到开发者填写的URL
+type UpdateMemberCardEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event         string `xml:"Event"         json:"Event"`         // 事件类型, update_member_card
+	CardId        string `xml:"CardId"        json:"CardId"`        // 卡券ID
+	UserCardCode  string `xml:"UserCardCode"  json:"UserCardCode"`  // 卡券Code码
+	ModifyBonus   int    `xml:"ModifyBonus"   json:"ModifyBonus"`   // 变动的积分值
+	ModifyBalance int    `xml:"ModifyBalance" json:"ModifyBalance"` // 变动的余额值
+}
+
+func GetUpdateMemberCardEvent(msg *mp.MixedMessage) *UpdateMemberCardEvent {
+	return &UpdateMemberCardEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		CardId:        msg.CardId,
+		UserCardCode:  msg.UserCardCode,
+		ModifyBonus:   msg.ModifyBonus,
+		ModifyBalance: msg.ModifyBalance,
+	}
+}
+
+// 用户通过一键激活的方式提交信息并点击激活时，微信会把这个事件推送到开发者填写的URL
+type SubmitMemberCardUserInfoEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, submit_membercard_user_info
+	CardId       string `xml:"CardId"       json:"CardId"`       // 卡券ID
+	UserCardCode string `xml:"UserCardCode" json:"UserCardCode"` // 卡券Code码
+}
+
+func GetSubmitMemberCardUserInfoEvent(msg *mp.MixedMessage) *SubmitMemberCardUserInfoEvent {
+	return &SubmitMemberCardUserInfoEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		CardId:        msg.CardId,
+		UserCardCode:  msg.UserCardCode,
+	}
+}
+
+// 卡券库存不足时(初始库存数大于200且当前库存小于等于100)，微信会把这个事件推送到开发者填写的URL
+type CardSkuRemindEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event  string `xml:"Event"  json:"Event"`  // 事件类型, card_sku_remind
+	CardId string `xml:"CardId" json:"CardId"` // 卡券ID
+	Detail string `xml:"Detail" json:"Detail"` // 报警详细信息
+}
+
+func GetCardSkuRemindEvent(msg *mp.MixedMessage) *CardSkuRemindEvent {
+	return &CardSkuRemindEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		CardId:        msg.CardId,
+		Detail:        msg.Detail,
 	}
 }
@@ -7,6 +7,7 @@ package card
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 
 	"github.com/chanxuehong/wechat/mp"
@@ -62,3 +63,50 @@ func (clt Client) CardQRCodeCreate(qrcodeInfo *CardQRCodeInfo) (ticket string, e
 	ticket = result.Ticket
 	return
 }
+
+const CardQRCodeMultipleCardCountLimit = 5 // 一个二维码最多包含 5 张卡券
+
+// 卡券投放, 创建一个包含多张卡券的二维码(QR_MULTIPLE_CARD).
+//  cards 的个数不能超过 CardQRCodeMultipleCardCountLimit; showQRCodeURL 为二维码图片的 url, 可以直接使用.
+func (clt Client) CardQRCodeCreateMultiple(cards []CardQRCodeInfo, expireSeconds int) (ticket, showQRCodeURL string, err error) {
+	if len(cards) <= 0 {
+		err = errors.New("empty cards")
+		return
+	}
+	if len(cards) > CardQRCodeMultipleCardCountLimit {
+		err = fmt.Errorf("the length of cards must be less than or equal to %d", CardQRCodeMultipleCardCountLimit)
+		return
+	}
+
+	var request struct {
+		ActionName    string `json:"action_name"`
+		ExpireSeconds int    `json:"expire_seconds,omitempty"`
+		ActionInfo    struct {
+			MultipleCard struct {
+				CardList []CardQRCodeInfo `json:"card_list"`
+			} `json:"multiple_card"`
+		} `json:"action_info"`
+	}
+	request.ActionName = "QR_MULTIPLE_CARD"
+	request.ExpireSeconds = expireSeconds
+	request.ActionInfo.MultipleCard.CardList = cards
+
+	var result struct {
+		mp.Error
+		Ticket        string `json:"ticket"`
+		ShowQRCodeURL string `json:"show_qrcode_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/qrcode/create?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	ticket = result.Ticket
+	showQRCodeURL = result.ShowQRCodeURL
+	return
+}
@@ -9,11 +9,13 @@ package mp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	wechatjson "github.com/chanxuehong/wechat/json"
 )
@@ -22,16 +24,48 @@ import (
 type Client struct {
 	AccessTokenServer
 	HttpClient *http.Client
+
+	RetryPolicy *RetryPolicy // 可以为 nil, 表示除了 access_token 失效以外不重试
+	RateLimiter RateLimiter  // 可以为 nil, 表示不限流
+	Metrics     Metrics      // 可以为 nil; 不为 nil 时统计每个接口的调用次数, 耗时, errcode 和 access_token 的刷新次数
+	Tracer      Tracer       // 可以为 nil
+
+	IPWhitelistWatcher *IPWhitelistWatcher // 可以为 nil; 不为 nil 时把 errcode 40164(IP 不在白名单中)报告给它
+	Archiver           Archiver            // 可以为 nil; 不为 nil 时把每次 PostJSON, GetJSON 调用的请求和应答交给它存档
+
+	// 可以为空; 不为空时替换所有接口 url 开头的 DefaultBaseURL, 用于沙箱环境, 内部网关或者代理, 见 ReplaceBaseURL.
+	BaseURL string
+
+	// 每次请求的 request id, 会出现在日志和 RequestError 里.
+	// RequestId 不为空时所有请求都使用它(见 WithRequestId), 否则调用 RequestIdGenerator 生成,
+	// RequestIdGenerator 为 nil 时使用 NewRequestId.
+	RequestId          string
+	RequestIdGenerator func() string
+
+	// 可以为 nil; 不为 nil 时所有请求(包括重试)都绑定到 Context, Context 取消后请求立即返回, 见 WithContext.
+	Context context.Context
+
+	// 每次接口调用(包括重试, 重试前的退避和限流的等待)的总超时, 0 表示只受 HttpClient 的超时限制; 和 Context 同时设置时先到期的生效.
+	//  NOTE: 不包括 AccessTokenServer 获取 access_token 的时间, 它由 AccessTokenServer 自己的 http.Client 控制.
+	Timeout time.Duration
+
+	// 为 true 时通过 LogInfoln 打印每个请求和应答(access_token 等敏感参数被隐藏), 见 util.DumpTransport.
+	// 运行时可以随时打开, 不需要 wechatdebug 编译标签.
+	DebugMode bool
+
+	// 为 true 时日志(LogInfoln)和错误里不隐藏 access_token, secret 等敏感参数, 只用于本地调试!
+	//  NOTE: 只影响这个 Client 自己的日志和错误, util.DumpTransport 和 AccessTokenServer 总是隐藏敏感参数.
+	DisableRedaction bool
 }
 
 // 创建一个新的 Client.
-//  如果 clt == nil 则默认用 http.DefaultClient
+//  如果 clt == nil 则默认用 TextHttpClient
 func NewClient(srv AccessTokenServer, clt *http.Client) *Client {
 	if srv == nil {
 		panic("nil AccessTokenServer")
 	}
 	if clt == nil {
-		clt = http.DefaultClient
+		clt = TextHttpClient
 	}
 
 	return &Client{
@@ -52,6 +86,11 @@ func NewClient(srv AccessTokenServer, clt *http.Client) *Client {
 //          ...
 //      }
 func (clt *Client) PostJSON(incompleteURL string, request interface{}, response interface{}) (err error) {
+	if clt.Timeout > 0 {
+		var cancel func()
+		clt, cancel = clt.withTimeout()
+		defer cancel()
+	}
 	buf := textBufferPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer textBufferPool.Put(buf)
@@ -61,26 +100,60 @@ func (clt *Client) PostJSON(incompleteURL string, request interface{}, response
 	}
 	requestBytes := buf.Bytes()
 
+	requestId := clt.requestId()
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestId: requestId, Err: err}
+		}
+	}()
+	clt, done := clt.startCall(incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+	archive := clt.startArchive("POST", incompleteURL, requestId, requestBytes)
+	defer func() {
+		archive.done(response, err)
+	}()
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	attempt := 1 // 用于 RetryPolicy
 RETRY:
+	if clt.Context != nil {
+		if err = clt.Context.Err(); err != nil {
+			return
+		}
+	}
+	if err = clt.waitRateLimiter(incompleteURL); err != nil {
+		return
+	}
 	finalURL := incompleteURL + url.QueryEscape(token)
+	clt.incAttempt(incompleteURL)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	httpResp, err := clt.HttpPost(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		if clt.RetryPolicy.shouldRetry(attempt, httpResp.StatusCode, ErrCodeOK) {
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", http.Status:", httpResp.Status, ", attempt:", attempt)
+			httpResp.Body.Close()
+			if err = clt.RetryPolicy.wait(clt.Context, attempt); err != nil {
+				return
+			}
+			attempt++
+			goto RETRY
+		}
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
-	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+	if err = json.NewDecoder(archive.body(httpResp.Body)).Decode(response); err != nil {
 		return
 	}
 
@@ -99,8 +172,8 @@ RETRY:
 		return
 	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -108,14 +181,25 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", clt.maskToken(token))
 		fallthrough
 	default:
+		if clt.RetryPolicy.shouldRetry(attempt, http.StatusOK, int(ErrCode)) {
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", attempt:", attempt)
+			if err = clt.RetryPolicy.wait(clt.Context, attempt); err != nil {
+				return
+			}
+			attempt++
+
+			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
+			goto RETRY
+		}
 		return
 	}
 }
@@ -131,26 +215,65 @@ RETRY:
 //          ...
 //      }
 func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err error) {
+	if clt.Timeout > 0 {
+		var cancel func()
+		clt, cancel = clt.withTimeout()
+		defer cancel()
+	}
+	requestId := clt.requestId()
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestId: requestId, Err: err}
+		}
+	}()
+	clt, done := clt.startCall(incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+	archive := clt.startArchive("GET", incompleteURL, requestId, nil)
+	defer func() {
+		archive.done(response, err)
+	}()
+
 	token, err := clt.Token()
 	if err != nil {
 		return
 	}
 
 	hasRetried := false
+	attempt := 1 // 用于 RetryPolicy
 RETRY:
+	if clt.Context != nil {
+		if err = clt.Context.Err(); err != nil {
+			return
+		}
+	}
+	if err = clt.waitRateLimiter(incompleteURL); err != nil {
+		return
+	}
 	finalURL := incompleteURL + url.QueryEscape(token)
+	clt.incAttempt(incompleteURL)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.HttpGet(finalURL)
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		if clt.RetryPolicy.shouldRetry(attempt, httpResp.StatusCode, ErrCodeOK) {
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", http.Status:", httpResp.Status, ", attempt:", attempt)
+			httpResp.Body.Close()
+			if err = clt.RetryPolicy.wait(clt.Context, attempt); err != nil {
+				return
+			}
+			attempt++
+			goto RETRY
+		}
 		return fmt.Errorf("http.Status: %s", httpResp.Status)
 	}
 
-	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+	if err = json.NewDecoder(archive.body(httpResp.Body)).Decode(response); err != nil {
 		return
 	}
 
@@ -169,8 +292,8 @@ RETRY:
 		return
 	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -178,14 +301,25 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", clt.maskToken(token))
 		fallthrough
 	default:
+		if clt.RetryPolicy.shouldRetry(attempt, http.StatusOK, int(ErrCode)) {
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", attempt:", attempt)
+			if err = clt.RetryPolicy.wait(clt.Context, attempt); err != nil {
+				return
+			}
+			attempt++
+
+			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
+			goto RETRY
+		}
 		return
 	}
 }
@@ -0,0 +1,108 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// 保留原始 json 的应答, 可以作为 PostJSON, GetJSON 的 response, 用于调用本包没有封装的接口.
+type RawResponse struct {
+	Error
+	Body json.RawMessage `json:"-"` // 完整的应答 json, 包括 errcode 和 errmsg
+}
+
+func (resp *RawResponse) UnmarshalJSON(b []byte) error {
+	resp.Body = append(resp.Body[:0], b...)
+	return json.Unmarshal(b, &resp.Error)
+}
+
+// 用 Body 解码到 v, v 为 nil 时什么都不做.
+func (resp *RawResponse) Unmarshal(v interface{}) error {
+	if v == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Body, v)
+}
+
+// 构造 PostJSON, GetJSON 用的 incompleteURL, access_token 总是最后一个查询参数.
+//  path 为 api.weixin.qq.com 的接口路径, 比如 "/cgi-bin/user/info"; 其他域名的接口可以是完整的 url, 比如 "https://mp.weixin.qq.com/xxx".
+//  path 可以带查询参数, query 为其他的查询参数, 可以为 nil; path 和 query 里的 access_token 会被忽略.
+func CallURL(path string, query url.Values) (incompleteURL string, err error) {
+	var u *url.URL
+	switch {
+	case strings.HasPrefix(path, "/"):
+		u, err = url.Parse(DefaultBaseURL + path)
+	case strings.HasPrefix(path, "https://"), strings.HasPrefix(path, "http://"):
+		u, err = url.Parse(path)
+	default:
+		err = fmt.Errorf("invalid path %q", path)
+	}
+	if err != nil {
+		return
+	}
+
+	q := u.Query()
+	for k, vs := range query {
+		q[k] = append(q[k], vs...)
+	}
+	q.Del("access_token")
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	incompleteURL = u.String() + "?"
+	if len(q) > 0 {
+		incompleteURL += q.Encode() + "&"
+	}
+	incompleteURL += "access_token="
+	return
+}
+
+// 调用本包没有封装的接口, 和封装好的接口一样处理 access_token 的填入和刷新, 限流, 重试, request_id 和错误码,
+// 不需要自己拼接 url 再调用 PostJSON, GetJSON.
+//  method 为 "GET" 或者 "POST", POST 时把 request 编码为 json 作为 http body, GET 时 request 必须为 nil.
+//  path 和 query 见 CallURL.
+//  errcode 不为 0 时返回 *Error, 否则把应答的 json 解码到 response, response 为 nil 时忽略应答.
+//
+//  var result struct {
+//      OpenId string `json:"openid"`
+//  }
+//  err := clt.Call("GET", "/cgi-bin/user/info", url.Values{"openid": {openId}, "lang": {"zh_CN"}}, nil, &result)
+func (clt *Client) Call(method, path string, query url.Values, request interface{}, response interface{}) (err error) {
+	incompleteURL, err := CallURL(path, query)
+	if err != nil {
+		return
+	}
+
+	var result RawResponse
+	switch strings.ToUpper(method) {
+	case "GET":
+		if request != nil {
+			return errors.New("GET request must not have a body")
+		}
+		err = clt.GetJSON(incompleteURL, &result)
+	case "POST":
+		if request == nil {
+			request = struct{}{}
+		}
+		err = clt.PostJSON(incompleteURL, request, &result)
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+	if err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	return result.Unmarshal(response)
+}
@@ -0,0 +1,155 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// 发起一个原始的 http 请求, 和封装好的接口一样处理 access_token 的填入和刷新(失效时刷新后重试一次), 限流和 request_id,
+// 但是不解析应答, 直接返回 *http.Response, 用于调用本包没有封装的新接口, 或者需要应答的 http 头, 非 json 的应答(比如文件流)等场景.
+//  ctx 可以为 nil, 表示使用 clt.Context; method 为 http 方法, 比如 "GET", "POST".
+//  path 和 CallURL 一样, 可以是接口路径(比如 "/cgi-bin/user/info?openid=xxx"), 也可以是完整的 url; path 里的 access_token 会被忽略.
+//  body 可以为 nil; 不为 nil 时会被完整读取以便重试, contentType 为 body 的 Content-Type, 为空时不设置.
+//
+//  NOTE:
+//  1. 调用者负责关闭返回的 Response.Body;
+//  2. 不检查 http 状态码和 errcode, 除了 access_token 失效以外不重试, 也不使用 RetryPolicy;
+//  3. 应答的 Content-Type 为 application/json 或 text/plain 时 Body 已经被读到内存里, 可以放心地重复检查;
+//  4. 和 PostJSON 一样统计 Metrics, Tracer, IPWhitelistWatcher 和 Archiver, 其中 errcode 和存档的应答只在 Body 被读到内存里时才有.
+func (clt *Client) Do(ctx context.Context, method, path string, body io.Reader, contentType string) (httpResp *http.Response, err error) {
+	incompleteURL, err := CallURL(path, nil)
+	if err != nil {
+		return
+	}
+	if ctx != nil {
+		clt = clt.WithContext(ctx)
+	}
+	if clt.Timeout > 0 {
+		var cancel func()
+		clt, cancel = clt.withTimeout()
+		defer func() {
+			if httpResp == nil {
+				cancel()
+				return
+			}
+			httpResp.Body = &cancelReadCloser{ReadCloser: httpResp.Body, cancel: cancel}
+		}()
+	}
+
+	var bodyBytes []byte
+	if body != nil {
+		if bodyBytes, err = ioutil.ReadAll(body); err != nil {
+			return
+		}
+	}
+
+	requestId := clt.requestId()
+	defer func() {
+		if err != nil {
+			httpResp = nil
+			err = &RequestError{RequestId: requestId, Err: err}
+		}
+	}()
+	var result Error // json 应答里的 errcode, 用于 Metrics, Tracer 和 Archiver
+	clt, done := clt.startCall(incompleteURL, requestId)
+	defer func() {
+		done(&result, err)
+	}()
+	archive := clt.startArchive(method, incompleteURL, requestId, bodyBytes)
+	defer func() {
+		archive.done(&result, err)
+	}()
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+RETRY:
+	if clt.Context != nil {
+		if err = clt.Context.Err(); err != nil {
+			return
+		}
+	}
+	if err = clt.waitRateLimiter(incompleteURL); err != nil {
+		return
+	}
+	finalURL := incompleteURL + url.QueryEscape(token)
+	clt.incAttempt(incompleteURL)
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+	req, err := clt.newHttpRequest(method, finalURL, reqBody)
+	if err != nil {
+		return
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	result = Error{}
+	if httpResp, err = clt.httpClient().Do(req); err != nil {
+		err = clt.redactError(err)
+		return
+	}
+
+	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
+	if ContentType != "text/plain" && ContentType != "application/json" { // 文件流等, 原样返回
+		return
+	}
+
+	respBody, err := ioutil.ReadAll(archive.body(httpResp.Body))
+	httpResp.Body.Close()
+	if err != nil {
+		return
+	}
+	httpResp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	if json.Unmarshal(respBody, &result) != nil {
+		result = Error{}
+		return // 不是 json, 原样返回
+	}
+	switch result.ErrCode {
+	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", clt.maskToken(token))
+
+		if !hasRetried {
+			hasRetried = true
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			clt.incTokenRefresh(incompleteURL)
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", clt.maskToken(token))
+			goto RETRY
+		}
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", clt.maskToken(token))
+	}
+	return
+}
+
+// 关闭 Body 的时候取消 Client.Timeout 对应的 context.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (rc *cancelReadCloser) Close() error {
+	err := rc.ReadCloser.Close()
+	rc.cancel()
+	return err
+}
@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+type recordingArchiver []*mp.ArchiveRecord
+
+func (a *recordingArchiver) Archive(record *mp.ArchiveRecord) error {
+	*a = append(*a, record)
+	return nil
+}
+
+func TestClientDoHooks(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("/cgi-bin/test", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") == "token" {
+			w.Write(wechattest.ErrorJSON(mp.ErrCodeInvalidCredential, "invalid credential"))
+			return
+		}
+		w.Write(wechattest.ErrorJSON(45009, "api freq out of limit"))
+	})
+
+	monitor := mp.NewQuotaMonitor(func(*mp.QuotaAlert) {})
+	var archiver recordingArchiver
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.Metrics = monitor
+	clt.Archiver = &archiver
+
+	httpResp, err := clt.Do(nil, "POST", "/cgi-bin/test", strings.NewReader("<xml/>"), "text/xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpResp.Body.Close()
+
+	if n := monitor.Used("/cgi-bin/test"); n != 2 {
+		t.Errorf("have %d attempts, want 2", n)
+	}
+	if len(archiver) != 1 {
+		t.Fatalf("have %d archive records, want 1", len(archiver))
+	}
+	record := archiver[0]
+	var request string
+	json.Unmarshal(record.Request, &request)
+	if record.Method != "POST" || record.Endpoint != "/cgi-bin/test" || record.ErrCode != 45009 ||
+		request != "<xml/>" || !strings.Contains(string(record.Response), "45009") {
+		t.Errorf("have archive record %+v, request %s, response %s", record, record.Request, record.Response)
+	}
+}
@@ -0,0 +1,163 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	// 调用微信接口的次数, labels: endpoint, errcode, outcome
+	MetricClientRequestsTotal = "wechat_mp_client_requests_total"
+	// 实际发送到微信服务器的 http 请求次数, 重试和刷新 access_token 后的重新请求都单独计数, labels: endpoint
+	MetricClientAttemptsTotal = "wechat_mp_client_attempts_total"
+	// 调用微信接口的耗时(秒, 包括重试), labels: endpoint, outcome
+	MetricClientRequestDuration = "wechat_mp_client_request_duration_seconds"
+	// 因为 access_token 失效而刷新 access_token 的次数, labels: endpoint
+	MetricClientTokenRefreshTotal = "wechat_mp_client_token_refresh_total"
+	// DefaultAccessTokenServer 从微信服务器获取 access_token 的次数, labels: trigger(daemon, client), outcome
+	MetricTokenServerRefreshTotal = "wechat_mp_token_server_refresh_total"
+)
+
+// outcome 除了 MetricOutcomeSuccess, MetricOutcomeFailure 以外还有 MetricOutcomeErrCode.
+//  MetricOutcomeFailure: 网络错误, http 状态码错误, 解析错误等, errcode 为空
+//  MetricOutcomeErrCode: 微信服务器返回了非 0 的 errcode
+const MetricOutcomeErrCode = "errcode"
+
+// 链路追踪接口, 可以很方便的适配 OpenTelemetry:
+//  StartSpan 里调用 otel Tracer.Start, Span.End 里设置 attributes 和 status 然后调用 span.End().
+//  NOTE: 实现必须是并发安全的.
+type Tracer interface {
+	// 开始一次接口调用, name 为 endpoint, 比如 "/cgi-bin/message/custom/send"
+	StartSpan(name string, attrs map[string]string) Span
+}
+
+// 可以关联上级 span 的 Tracer, Client 优先调用 StartSpanContext.
+type ContextTracer interface {
+	Tracer
+
+	// 同 StartSpan, ctx 为请求绑定的 context(Client.Context, 为 nil 时为 context.Background()).
+	// 返回的 spanCtx 为包含了新 span 的 context, 这次调用的 http 请求都绑定到它, 以便 otelhttp 等传播 trace.
+	StartSpanContext(ctx context.Context, name string, attrs map[string]string) (spanCtx context.Context, span Span)
+}
+
+type Span interface {
+	// 结束接口调用, errCode 为微信服务器返回的 errcode, err 为请求过程中的错误
+	End(errCode int, err error)
+}
+
+// 开始一次接口调用的统计, 返回的函数在调用结束时调用.
+//  requestId 会作为 span 的 wechat.request_id 属性和指标的 exemplar.
+//  Tracer 实现了 ContextTracer 时 clt2 为绑定到 span context 的 Client 浅拷贝, 否则为 clt 本身.
+func (clt *Client) startCall(incompleteURL, requestId string) (clt2 *Client, done func(response interface{}, err error)) {
+	clt2 = clt
+	if clt.Metrics == nil && clt.Tracer == nil && clt.IPWhitelistWatcher == nil {
+		return clt2, func(interface{}, error) {}
+	}
+
+	endpoint := endpointOf(incompleteURL)
+	var span Span
+	if clt.Tracer != nil {
+		attrs := map[string]string{"wechat.endpoint": endpoint}
+		if requestId != "" {
+			attrs["wechat.request_id"] = requestId
+		}
+		if tracer, ok := clt.Tracer.(ContextTracer); ok {
+			ctx := clt.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			var spanCtx context.Context
+			if spanCtx, span = tracer.StartSpanContext(ctx, endpoint, attrs); spanCtx != nil {
+				clt2 = clt.WithContext(spanCtx)
+			}
+		} else {
+			span = clt.Tracer.StartSpan(endpoint, attrs)
+		}
+	}
+	begin := time.Now()
+
+	return clt2, func(response interface{}, err error) {
+		errCode := 0
+		outcome := MetricOutcomeSuccess
+		if err != nil {
+			outcome = MetricOutcomeFailure
+		} else if errCode = errCodeOf(response); errCode != ErrCodeOK {
+			outcome = MetricOutcomeErrCode
+		}
+
+		if clt.IPWhitelistWatcher != nil {
+			// 获取 access_token 时的 40164 通过 err 返回, Observe 会忽略其他错误
+			if err != nil {
+				clt.IPWhitelistWatcher.Observe(endpoint, err)
+			} else if errCode == ErrCodeInvalidIP {
+				clt.IPWhitelistWatcher.Observe(endpoint, &Error{ErrCode: errCode, ErrMsg: errMsgOf(response)})
+			}
+		}
+		if span != nil {
+			span.End(errCode, err)
+		}
+		if clt.Metrics == nil {
+			return
+		}
+		errCodeLabel := ""
+		if err == nil {
+			errCodeLabel = strconv.Itoa(errCode)
+		}
+		observeCall(clt.Metrics, requestId, map[string]string{
+			"endpoint": endpoint,
+			"errcode":  errCodeLabel,
+			"outcome":  outcome,
+		}, map[string]string{
+			"endpoint": endpoint,
+			"outcome":  outcome,
+		}, time.Since(begin).Seconds())
+	}
+}
+
+func (clt *Client) incAttempt(incompleteURL string) {
+	if clt.Metrics == nil {
+		return
+	}
+	clt.Metrics.IncCounter(MetricClientAttemptsTotal, map[string]string{
+		"endpoint": endpointOf(incompleteURL),
+	})
+}
+
+func (clt *Client) incTokenRefresh(incompleteURL string) {
+	if clt.Metrics == nil {
+		return
+	}
+	clt.Metrics.IncCounter(MetricClientTokenRefreshTotal, map[string]string{
+		"endpoint": endpointOf(incompleteURL),
+	})
+}
+
+// 获取 response 里的 errcode, response 的格式要求同 PostJSON.
+func errCodeOf(response interface{}) int {
+	v := reflect.ValueOf(response)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrCodeOK
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct || v.NumField() == 0 {
+		return ErrCodeOK
+	}
+	if f := v.Field(0); f.Kind() == reflect.Struct {
+		v = f
+	}
+	if v.NumField() == 0 {
+		return ErrCodeOK
+	}
+	switch f := v.Field(0); f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(f.Int())
+	}
+	return ErrCodeOK
+}
@@ -0,0 +1,122 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	attrs []map[string]string
+}
+
+func (t *recordingTracer) StartSpan(name string, attrs map[string]string) mp.Span {
+	t.mu.Lock()
+	t.attrs = append(t.attrs, attrs)
+	t.mu.Unlock()
+	return nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(errCode int, err error) {}
+
+type exemplarMetrics struct {
+	mp.NopMetrics
+
+	mu        sync.Mutex
+	exemplars []map[string]string
+}
+
+func (m *exemplarMetrics) IncCounterWithExemplar(name string, labels, exemplar map[string]string) {
+	m.mu.Lock()
+	m.exemplars = append(m.exemplars, exemplar)
+	m.mu.Unlock()
+}
+
+func (m *exemplarMetrics) ObserveHistogramWithExemplar(name string, labels map[string]string, value float64, exemplar map[string]string) {
+	m.mu.Lock()
+	m.exemplars = append(m.exemplars, exemplar)
+	m.mu.Unlock()
+}
+
+func TestClientRequestIdInSpanAndExemplar(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/test", mp.ErrCodeOK, "ok")
+
+	tracer := &recordingTracer{}
+	metrics := &exemplarMetrics{}
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.Tracer = tracer
+	// 通过 MultiMetrics 转发 exemplar
+	clt.Metrics = mp.MultiMetrics{metrics, mp.NopMetrics{}}
+	clt = clt.WithRequestId("req-1")
+
+	var result mp.Error
+	if err := clt.GetJSON(testIncompleteURL, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tracer.attrs) != 1 || tracer.attrs[0]["wechat.request_id"] != "req-1" {
+		t.Errorf("span attrs: have %v, want wechat.request_id=req-1", tracer.attrs)
+	}
+	if len(metrics.exemplars) != 2 {
+		t.Fatalf("exemplars: have %d, want 2", len(metrics.exemplars))
+	}
+	for _, exemplar := range metrics.exemplars {
+		if exemplar["request_id"] != "req-1" {
+			t.Errorf("exemplar: have %v, want request_id=req-1", exemplar)
+		}
+	}
+}
+
+type spanContextKey struct{}
+
+// 在 context 里放入 span 名字的 ContextTracer
+type contextTracer struct{}
+
+func (contextTracer) StartSpan(name string, attrs map[string]string) mp.Span {
+	return nopSpan{}
+}
+
+func (contextTracer) StartSpanContext(ctx context.Context, name string, attrs map[string]string) (context.Context, mp.Span) {
+	return context.WithValue(ctx, spanContextKey{}, name), nopSpan{}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClientSpanContext(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/test", mp.ErrCodeOK, "ok")
+
+	var spans []interface{}
+	transport := srv.Client().Transport
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		spans = append(spans, r.Context().Value(spanContextKey{}))
+		return transport.RoundTrip(r)
+	})}
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), httpClient)
+	clt.Tracer = contextTracer{}
+
+	var result mp.Error
+	if err := clt.GetJSON(testIncompleteURL, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 1 || spans[0] != "/cgi-bin/test" {
+		t.Errorf("http request context: have spans %v, want [/cgi-bin/test]", spans)
+	}
+}
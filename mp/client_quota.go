@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+)
+
+// 公众号调用或第三方平台帮公众号调用对公众号的所有api调用（包括第三方帮其调用）次数进行清零.
+//  NOTE: 每个帐号每月共10次清零操作机会，清零生效一次即用掉一次机会.
+func (clt *Client) ClearQuota(appId string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+
+	var request = struct {
+		AppId string `json:"appid"`
+	}{
+		AppId: appId,
+	}
+
+	var result Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/clear_quota?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 接口的每日调用次数
+type Quota struct {
+	DailyLimit int `json:"daily_limit"` // 当天该账号可调用该接口的次数
+	Used       int `json:"used"`        // 当天已经调用的次数
+	Remain     int `json:"remain"`      // 当天剩余调用次数
+}
+
+// 查询接口的每日调用次数.
+//  cgiPath 为 api 的请求地址, 例如 "/cgi-bin/message/custom/send", 不要前缀 "https://api.weixin.qq.com", 也不要参数.
+func (clt *Client) GetQuota(cgiPath string) (quota *Quota, err error) {
+	if cgiPath == "" {
+		err = errors.New("empty cgiPath")
+		return
+	}
+
+	var request = struct {
+		CgiPath string `json:"cgi_path"`
+	}{
+		CgiPath: cgiPath,
+	}
+
+	var result struct {
+		Error
+		Quota Quota `json:"quota"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/openapi/quota/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	quota = &result.Quota
+	return
+}
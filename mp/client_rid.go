@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+)
+
+// 通过 rid 查询到的请求信息
+type RIDRequest struct {
+	InvokeTime   int64  `json:"invoke_time"`   // 发起请求的时间戳
+	CostInMs     int64  `json:"cost_in_ms"`    // 请求毫秒级耗时
+	RequestURL   string `json:"request_url"`   // 请求的URL参数
+	RequestBody  string `json:"request_body"`  // post请求的请求参数
+	ResponseBody string `json:"response_body"` // 接口请求返回参数
+	ClientIP     string `json:"client_ip"`     // 接口请求的客户端ip
+}
+
+// 查询 rid 信息.
+//  rid 是接口报错时 errmsg 里面的 "rid: xxx", 可以用 Error.RID 获取; 只能查询本帐号最近7天的请求.
+func (clt *Client) GetRID(rid string) (request *RIDRequest, err error) {
+	if rid == "" {
+		err = errors.New("empty rid")
+		return
+	}
+
+	var req = struct {
+		RID string `json:"rid"`
+	}{
+		RID: rid,
+	}
+
+	var result struct {
+		Error
+		Request RIDRequest `json:"request"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/openapi/rid/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	request = &result.Request
+	return
+}
@@ -0,0 +1,85 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+const testIncompleteURL = "https://api.weixin.qq.com/cgi-bin/test?access_token="
+
+func newBusyClient() (*mp.Client, *wechattest.Server) {
+	srv := wechattest.NewServer()
+	srv.HandleError("/cgi-bin/test", mp.ErrCodeSystemBusy, "system error")
+
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.RetryPolicy = &mp.RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+	}
+	return clt, srv
+}
+
+func TestClientTimeoutIncludesRetryBackoff(t *testing.T) {
+	clt, srv := newBusyClient()
+	defer srv.Close()
+	clt.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	var result mp.Error
+	err := clt.GetJSON(testIncompleteURL, &result)
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("GetJSON returned after %s, want about %s", elapsed, clt.Timeout)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("have error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := len(srv.RequestsTo("/cgi-bin/test")); n != 1 {
+		t.Errorf("have %d requests, want 1", n)
+	}
+}
+
+func TestClientContextCancelsRetryBackoff(t *testing.T) {
+	clt, srv := newBusyClient()
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	var result mp.Error
+	err := clt.WithContext(ctx).PostJSON(testIncompleteURL, struct{}{}, &result)
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("PostJSON returned after %s, want about 100ms", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("have error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTokenBucketLimiterWaitContext(t *testing.T) {
+	limiter := mp.NewTokenBucketLimiter(mp.RateLimit{Rate: 0.1, Burst: 1})
+	if err := limiter.Wait("/cgi-bin/test"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.WaitContext(ctx, "/cgi-bin/test"); err != context.DeadlineExceeded {
+		t.Errorf("have error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitContext returned after %s", elapsed)
+	}
+}
@@ -49,6 +49,11 @@ type MultipartFormField struct {
 //          ...
 //      }
 func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFormField, response interface{}) (err error) {
+	if clt.Timeout > 0 {
+		var cancel func()
+		clt, cancel = clt.withTimeout()
+		defer cancel()
+	}
 	bodyBuf := mediaBufferPool.Get().(*bytes.Buffer)
 	bodyBuf.Reset()
 	defer mediaBufferPool.Put(bodyBuf)
@@ -82,6 +87,17 @@ func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFor
 
 	bodyBytes := bodyBuf.Bytes()
 
+	requestId := clt.requestId()
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestId: requestId, Err: err}
+		}
+	}()
+	clt, done := clt.startCall(incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -89,9 +105,18 @@ func (clt *Client) PostMultipartForm(incompleteURL string, fields []MultipartFor
 
 	hasRetried := false
 RETRY:
+	if clt.Context != nil {
+		if err = clt.Context.Err(); err != nil {
+			return
+		}
+	}
+	if err = clt.waitRateLimiter(incompleteURL); err != nil {
+		return
+	}
 	finalURL := incompleteURL + url.QueryEscape(token)
+	clt.incAttempt(incompleteURL)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
+	httpResp, err := clt.HttpPost(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return
 	}
@@ -106,8 +131,8 @@ RETRY:
 	if err != nil {
 		return
 	}
-	LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
-	LogInfoln("[WECHAT_DEBUG] response json:", string(respBody))
+	clt.logInfoln("[WECHAT_DEBUG] request_id:", requestId, ", request url:", finalURL)
+	clt.logInfoln("[WECHAT_DEBUG] request_id:", requestId, ", response json:", string(respBody))
 
 	if err = json.Unmarshal(respBody, response); err != nil {
 		return
@@ -128,8 +153,8 @@ RETRY:
 		return
 	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
-		LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		LogInfoln("[WECHAT_RETRY] current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", clt.maskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -137,14 +162,165 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			LogInfoln("[WECHAT_RETRY] new token:", token)
+			clt.incTokenRefresh(incompleteURL)
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", clt.maskToken(token))
+
+			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
+			goto RETRY
+		}
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", clt.maskToken(token))
+		fallthrough
+	default:
+		return
+	}
+}
+
+// 通用上传接口, 和 PostMultipartForm 不同的是 http body 是边编码边发送的, 不会把整个文件读入内存,
+// 适合上传大文件(比如视频).
+//
+//  NOTE:
+//  1. 其他要求同 PostMultipartForm;
+//  2. 只有所有 field 的 Value 都实现了 io.Seeker 时 access_token 失效才会重试一次, 否则直接返回错误.
+func (clt *Client) PostMultipartFormStream(incompleteURL string, fields []MultipartFormField, response interface{}) (err error) {
+	if clt.Timeout > 0 {
+		var cancel func()
+		clt, cancel = clt.withTimeout()
+		defer cancel()
+	}
+	var offsets []int64 // 所有 field.Value 的初始位置, 用于重试
+	for _, field := range fields {
+		seeker, ok := field.Value.(io.Seeker)
+		if !ok {
+			offsets = nil
+			break
+		}
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, offset)
+	}
+
+	requestId := clt.requestId()
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestId: requestId, Err: err}
+		}
+	}()
+	clt, done := clt.startCall(incompleteURL, requestId)
+	defer func() {
+		done(response, err)
+	}()
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+RETRY:
+	if clt.Context != nil {
+		if err = clt.Context.Err(); err != nil {
+			return
+		}
+	}
+	if err = clt.waitRateLimiter(incompleteURL); err != nil {
+		return
+	}
+	finalURL := incompleteURL + url.QueryEscape(token)
+	clt.incAttempt(incompleteURL)
+
+	pipeReader, pipeWriter := io.Pipe()
+	multipartWriter := multipart.NewWriter(pipeWriter)
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		pipeWriter.CloseWithError(writeMultipartForm(multipartWriter, fields))
+	}()
+
+	httpResp, err := clt.HttpPost(finalURL, multipartWriter.FormDataContentType(), pipeReader)
+	pipeReader.Close() // 出错时结束写 goroutine
+	<-writerDone       // 等待写 goroutine 不再读取 fields, 之后才能 Seek 重试
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return
+	}
+	clt.logInfoln("[WECHAT_DEBUG] request_id:", requestId, ", request url:", finalURL)
+	clt.logInfoln("[WECHAT_DEBUG] request_id:", requestId, ", response json:", string(respBody))
+
+	if err = json.Unmarshal(respBody, response); err != nil {
+		return
+	}
+
+	var ErrorStructValue reflect.Value // Error
+
+	// 下面的代码对 response 有特定要求, 见此函数 NOTE
+	responseStructValue := reflect.ValueOf(response).Elem()
+	if v := responseStructValue.Field(0); v.Kind() == reflect.Struct {
+		ErrorStructValue = v
+	} else {
+		ErrorStructValue = responseStructValue
+	}
+
+	switch ErrCode := ErrorStructValue.Field(0).Int(); ErrCode {
+	case ErrCodeOK:
+		return
+	case ErrCodeInvalidCredential, ErrCodeAccessTokenExpired:
+		ErrMsg := ErrorStructValue.Field(1).String()
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", err_code:", ErrCode, ", err_msg:", ErrMsg)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", current token:", clt.maskToken(token))
+
+		if !hasRetried && offsets != nil {
+			hasRetried = true
+
+			for i, field := range fields {
+				if _, err = field.Value.(io.Seeker).Seek(offsets[i], io.SeekStart); err != nil {
+					return
+				}
+			}
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			clt.incTokenRefresh(incompleteURL)
+			clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", new token:", clt.maskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		clt.logInfoln("[WECHAT_RETRY] request_id:", requestId, ", fallthrough, current token:", clt.maskToken(token))
 		fallthrough
 	default:
 		return
 	}
 }
+
+func writeMultipartForm(multipartWriter *multipart.Writer, fields []MultipartFormField) (err error) {
+	for _, field := range fields {
+		var partWriter io.Writer
+		switch field.ContentType {
+		case 0: // 文件
+			partWriter, err = multipartWriter.CreateFormFile(field.FieldName, field.FileName)
+		case 1: // 文本
+			partWriter, err = multipartWriter.CreateFormField(field.FieldName)
+		default:
+			continue
+		}
+		if err != nil {
+			return
+		}
+		if _, err = io.Copy(partWriter, field.Value); err != nil {
+			return
+		}
+	}
+	return multipartWriter.Close()
+}
@@ -0,0 +1,101 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+// 第一次请求在发送 body 的同时就返回 40001(和微信服务器一样不等待上传完成), 之后的请求读取完整的文件.
+type earlyReplyTransport struct {
+	requests int
+	uploads  [][]byte
+}
+
+func (t *earlyReplyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	if t.requests == 1 {
+		io.CopyN(ioutil.Discard, req.Body, 100<<10)
+		go io.Copy(ioutil.Discard, req.Body)
+		time.Sleep(time.Millisecond) // 写 goroutine 正在读取文件
+		return jsonResponse(wechattest.ErrorJSON(mp.ErrCodeInvalidCredential, "invalid credential")), nil
+	}
+
+	defer req.Body.Close()
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	part, err := multipart.NewReader(req.Body, params["boundary"]).NextPart()
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(part)
+	if err != nil {
+		return nil, err
+	}
+	t.uploads = append(t.uploads, b)
+	return jsonResponse(wechattest.ErrorJSON(mp.ErrCodeOK, "ok")), nil
+}
+
+// 读取本地大文件时 Read 可能会阻塞, 重试前必须等待上一次请求的写 goroutine 结束.
+type slowReader struct {
+	r *bytes.Reader
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(5 * time.Millisecond)
+	return r.r.Read(p)
+}
+
+func (r slowReader) Seek(offset int64, whence int) (int64, error) {
+	return r.r.Seek(offset, whence)
+}
+
+func jsonResponse(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestPostMultipartFormStreamRetry(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 64<<10) // 1MB
+
+	transport := &earlyReplyTransport{}
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), &http.Client{Transport: transport})
+	fields := []mp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "media",
+		FileName:    "video.mp4",
+		Value:       slowReader{bytes.NewReader(content)},
+	}}
+	var result mp.Error
+	if err := clt.PostMultipartFormStream("https://api.weixin.qq.com/cgi-bin/media/upload?access_token=", fields, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.ErrCode != mp.ErrCodeOK {
+		t.Fatalf("have errcode %d", result.ErrCode)
+	}
+	if transport.requests != 2 {
+		t.Fatalf("have %d requests, want 2", transport.requests)
+	}
+	if len(transport.uploads) != 1 || !bytes.Equal(transport.uploads[0], content) {
+		t.Fatal("the retried upload body is corrupted")
+	}
+}
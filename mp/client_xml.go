@@ -0,0 +1,68 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 用 util.EncodeXMLFromMap 把 request 编码为 xml, POST 到微信服务器, 然后用 util.DecodeXMLToMap 解析微信服务器返回的 xml,
+// 用于少数使用 xml 的接口.
+//  path 见 CallURL, access_token 的填入和刷新, 限流, request_id 和 Do 一样.
+//
+//  NOTE:
+//  1. 一般不用调用这个方法, 请直接调用高层次的封装方法;
+//  2. 微信服务器返回 json 的错误时(比如 access_token 无效), errcode 不为 0 返回 *Error;
+//  3. 除了 access_token 失效以外不重试, 也不使用 RetryPolicy.
+func (clt *Client) PostXML(path string, request map[string]string) (response map[string]string, err error) {
+	buf := textBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer textBufferPool.Put(buf)
+
+	if err = util.EncodeXMLFromMap(buf, request); err != nil {
+		return
+	}
+
+	httpResp, err := clt.Do(nil, "POST", path, buf, "text/xml; charset=utf-8")
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var result Error
+	if response, err = decodeXMLResponse(httpResp, &result); err != nil {
+		return
+	}
+	if result.ErrCode != ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 解析 xml 接口的应答; 应答为 json 时(微信服务器的错误一般是 json)解析到 result, 此时 response 为 nil.
+func decodeXMLResponse(httpResp *http.Response, result *Error) (response map[string]string, err error) {
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, util.MaxXMLSize+1))
+	if err != nil {
+		return
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		err = json.Unmarshal(body, result)
+		return
+	}
+	return util.DecodeXMLToMap(bytes.NewReader(body))
+}
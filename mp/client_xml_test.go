@@ -0,0 +1,56 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestClientPostXML(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleXML("/cgi-bin/xml", `<xml><result><![CDATA[ok]]></result></xml>`)
+	srv.HandleError("/cgi-bin/xml_error", 45009, "api freq out of limit")
+	srv.InvalidateToken("token")
+
+	atSrv := wechattest.NewAccessTokenServer("token")
+	clt := mp.NewClient(atSrv, srv.Client())
+
+	resp, err := clt.PostXML("/cgi-bin/xml", map[string]string{"openid": "o1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"result": "ok"}; !reflect.DeepEqual(resp, want) {
+		t.Errorf("have response %v, want %v", resp, want)
+	}
+	if n := atSrv.RefreshCount(); n != 1 {
+		t.Errorf("have %d token refreshes, want 1", n)
+	}
+	requests := srv.RequestsTo("/cgi-bin/xml")
+	if len(requests) != 2 {
+		t.Fatalf("have %d requests, want 2", len(requests))
+	}
+	body, err := util.DecodeXMLToMap(bytes.NewReader(requests[1].Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["openid"] != "o1" {
+		t.Errorf("have request body %v", body)
+	}
+
+	_, err = clt.PostXML("/cgi-bin/xml_error", nil)
+	var e *mp.Error
+	if !errors.As(err, &e) || e.ErrCode != 45009 {
+		t.Errorf("have error %v, want errcode 45009", err)
+	}
+}
@@ -17,12 +17,18 @@ import (
 	"reflect"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 type Client struct {
 	AccessTokenServer
 	AppId      string
 	HttpClient *http.Client
+
+	BaseURL string // 可以为空; 不为空时替换所有接口 url 开头的 mp.DefaultBaseURL, 见 mp.ReplaceBaseURL
+
+	// 为 true 时通过 mp.LogInfoln 打印每个请求和应答(component_access_token 等敏感参数被隐藏), 见 mp.Client.DebugMode.
+	DebugMode bool
 }
 
 // 创建一个新的 Client.
@@ -70,13 +76,14 @@ func (clt *Client) PostJSON(incompleteURL string, request interface{}, response
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := mp.ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
 	mp.LogInfoln("[WECHAT_DEBUG] request url:", finalURL)
 	mp.LogInfoln("[WECHAT_DEBUG] request json:", string(requestBytes))
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	httpResp, err := clt.httpClient().Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
 	if err != nil {
+		err = util.RedactError(err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -111,7 +118,7 @@ RETRY:
 	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -119,12 +126,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			mp.LogInfoln("[WECHAT_RETRY] new token:", util.MaskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
 		return
@@ -149,10 +156,11 @@ func (clt *Client) GetJSON(incompleteURL string, response interface{}) (err erro
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := mp.ReplaceBaseURL(incompleteURL, clt.BaseURL) + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.httpClient().Get(finalURL)
 	if err != nil {
+		err = util.RedactError(err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -188,7 +196,7 @@ RETRY:
 	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired:
 		ErrMsg := ErrorStructValue.Field(1).String()
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", ErrCode, ", err_msg:", ErrMsg)
-		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -196,12 +204,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			mp.LogInfoln("[WECHAT_RETRY] new token:", util.MaskToken(token))
 
 			responseStructValue.Set(reflect.New(responseStructValue.Type()).Elem())
 			goto RETRY
 		}
-		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
 		return
@@ -18,8 +18,8 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/chanxuehong/wechat/cipher"
 	"github.com/chanxuehong/wechat/mp"
-	"github.com/chanxuehong/wechat/util"
 )
 
 // 微信服务器请求 http body
@@ -91,7 +91,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			token := srv.Token()
 
 			// 验证签名
-			msgSignature2 := util.MsgSign(token, timestampStr, nonce, requestHttpBody.EncryptedMsg)
+			msgSignature2 := cipher.MsgSign(token, timestampStr, nonce, requestHttpBody.EncryptedMsg)
 			if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 				err = fmt.Errorf("check msg_signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 				irh.ServeInvalidRequest(w, r, err)
@@ -107,7 +107,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 			aesKey := srv.CurrentAESKey()
 
-			random, rawMsgXML, err := util.AESDecryptMsg(encryptedMsgBytes, appId, aesKey)
+			random, rawMsgXML, err := cipher.Decrypt(encryptedMsgBytes, appId, aesKey)
 			if err != nil {
 				// 尝试用上一次的 AESKey 来解密
 				lastAESKey, isLastAESKeyValid := srv.LastAESKey()
@@ -118,7 +118,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 				aesKey = lastAESKey // NOTE
 
-				random, rawMsgXML, err = util.AESDecryptMsg(encryptedMsgBytes, appId, aesKey)
+				random, rawMsgXML, err = cipher.Decrypt(encryptedMsgBytes, appId, aesKey)
 				if err != nil {
 					irh.ServeInvalidRequest(w, r, err)
 					return
@@ -196,7 +196,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			return
 		}
 
-		signature2 := util.Sign(srv.Token(), timestamp, nonce)
+		signature2 := cipher.Sign(srv.Token(), timestamp, nonce)
 		if subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) != 1 {
 			err := fmt.Errorf("check signature failed, input: %s, local: %s", signature1, signature2)
 			irh.ServeInvalidRequest(w, r, err)
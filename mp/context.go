@@ -0,0 +1,102 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// 返回一个 Client 的浅拷贝, 之后通过它发起的请求都绑定到 ctx, ctx 取消后请求(包括重试)立即返回 ctx.Err().
+//  一般在消息处理函数里这样用, 微信服务器断开回调连接后, 还没有完成的接口调用会被取消:
+//
+//  func(w http.ResponseWriter, r *mp.Request) {
+//      clt := wechatClient.WithContext(r.Context())
+//      ...
+//  }
+func (clt *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	clt2 := *clt
+	clt2.Context = ctx
+	return &clt2
+}
+
+// 返回一个绑定了 Timeout 的 Client 浅拷贝, 用完后必须调用 cancel.
+func (clt *Client) withTimeout() (clt2 *Client, cancel func()) {
+	parent := clt.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	c := *clt
+	c.Context, cancel = context.WithTimeout(parent, clt.Timeout)
+	c.Timeout = 0
+	return &c, cancel
+}
+
+func (clt *Client) newHttpRequest(method, url string, body io.Reader) (req *http.Request, err error) {
+	if req, err = http.NewRequest(method, ReplaceBaseURL(url, clt.BaseURL), body); err != nil {
+		return
+	}
+	if clt.Context != nil {
+		req = req.WithContext(clt.Context)
+	}
+	return
+}
+
+// 同 HttpClient.Post, 但是请求绑定到 Context(如果不为 nil).
+func (clt *Client) HttpPost(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := clt.newHttpRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := clt.httpClient().Do(req)
+	return resp, clt.redactError(err)
+}
+
+// 同 HttpClient.Get, 但是请求绑定到 Context(如果不为 nil).
+func (clt *Client) HttpGet(url string) (*http.Response, error) {
+	req, err := clt.newHttpRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := clt.httpClient().Do(req)
+	return resp, clt.redactError(err)
+}
+
+// ==============================================================================
+
+// 回调请求的 context, HttpRequest 为 nil 时返回 context.Background().
+//  微信服务器断开回调连接(比如超过 5 秒没有回复)后 context 会被取消.
+func (r *Request) Context() context.Context {
+	if r.HttpRequest != nil {
+		return r.HttpRequest.Context()
+	}
+	return context.Background()
+}
+
+// 返回一个不随回调连接取消, 但是 timeout 后超时的 context, 用于回复微信服务器以后的异步处理.
+//  返回的 context 保留了回调请求 context 里的值(比如链路追踪信息), 用完后必须调用 cancel.
+func (r *Request) DetachedContext(timeout time.Duration) (ctx context.Context, cancel context.CancelFunc) {
+	return context.WithTimeout(detachedContext{parent: r.Context()}, timeout)
+}
+
+// 只继承 parent 的值, 不继承 parent 的 Deadline 和取消.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{}                   { return nil }
+func (detachedContext) Err() error                              { return nil }
+
+func (ctx detachedContext) Value(key interface{}) interface{} {
+	return ctx.parent.Value(key)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 type ArticleBaseData struct {
@@ -24,7 +25,7 @@ type ArticleBaseData struct {
 
 // 图文群发每日数据
 type ArticleSummaryData struct {
-	RefDate string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	RefDate util.DashDate `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
 
 	// 这里的msgid实际上是由msgid（图文消息id）和index（消息次序索引）组成，
 	// 例如12003_3， 其中12003是msgid，即一次群发的id消息的；
@@ -40,6 +41,9 @@ func (clt Client) GetArticleSummary(req *Request) (list []ArticleSummaryData, er
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(ArticleSummaryMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -61,12 +65,12 @@ func (clt Client) GetArticleSummary(req *Request) (list []ArticleSummaryData, er
 
 // 图文群发总数据
 type ArticleTotalData struct {
-	RefDate string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
-	MsgId   string `json:"msgid"`    // 同 ArticleSummaryData.MsgId
-	Title   string `json:"title"`
+	RefDate util.DashDate `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	MsgId   string        `json:"msgid"`    // 同 ArticleSummaryData.MsgId
+	Title   string        `json:"title"`
 	Details []struct {
-		StatDate   string `json:"stat_date"`   // 统计的日期，在getarticletotal接口中，ref_date指的是文章群发出日期， 而stat_date是数据统计日期
-		TargetUser int    `json:"target_user"` // 送达人数，一般约等于总粉丝数（需排除黑名单或其他异常情况下无法收到消息的粉丝）
+		StatDate   util.DashDate `json:"stat_date"`   // 统计的日期，在getarticletotal接口中，ref_date指的是文章群发出日期， 而stat_date是数据统计日期
+		TargetUser int           `json:"target_user"` // 送达人数，一般约等于总粉丝数（需排除黑名单或其他异常情况下无法收到消息的粉丝）
 		ArticleBaseData
 	} `json:"details"`
 }
@@ -77,6 +81,9 @@ func (clt Client) GetArticleTotal(req *Request) (list []ArticleTotalData, err er
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(ArticleTotalMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -98,8 +105,8 @@ func (clt Client) GetArticleTotal(req *Request) (list []ArticleTotalData, err er
 
 // 图文统计数据
 type UserReadData struct {
-	RefDate    string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
-	UserSource int    `json:"user_source"`
+	RefDate    util.DashDate `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	UserSource int           `json:"user_source"`
 	ArticleBaseData
 }
 
@@ -109,6 +116,9 @@ func (clt Client) GetUserRead(req *Request) (list []UserReadData, err error) {
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserReadMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -140,6 +150,9 @@ func (clt Client) GetUserReadHour(req *Request) (list []UserReadHourData, err er
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserReadHourMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -161,10 +174,10 @@ func (clt Client) GetUserReadHour(req *Request) (list []UserReadHourData, err er
 
 // 图文分享转发数据
 type UserShareData struct {
-	RefDate    string `json:"ref_date"`    // 数据的日期, YYYY-MM-DD 格式
-	ShareScene int    `json:"share_scene"` // 分享的场景, 1代表好友转发 2代表朋友圈 3代表腾讯微博 255代表其他
-	ShareUser  int    `json:"share_user"`  // 分享的人数
-	ShareCount int    `json:"share_count"` // 分享的次数
+	RefDate    util.DashDate `json:"ref_date"`    // 数据的日期, YYYY-MM-DD 格式
+	ShareScene int           `json:"share_scene"` // 分享的场景, 1代表好友转发 2代表朋友圈 3代表腾讯微博 255代表其他
+	ShareUser  int           `json:"share_user"`  // 分享的人数
+	ShareCount int           `json:"share_count"` // 分享的次数
 }
 
 // 获取图文分享转发数据.
@@ -173,6 +186,9 @@ func (clt Client) GetUserShare(req *Request) (list []UserShareData, err error) {
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserShareMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -204,6 +220,9 @@ func (clt Client) GetUserShareHour(req *Request) (list []UserShareHourData, err
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserShareHourMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
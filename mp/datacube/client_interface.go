@@ -9,15 +9,16 @@ import (
 	"errors"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 接口分析数据
 type InterfaceSummaryData struct {
-	RefDate       string `json:"ref_date"`        // 数据的日期, YYYY-MM-DD 格式
-	CallbackCount int    `json:"callback_count"`  // 通过服务器配置地址获得消息后，被动回复用户消息的次数
-	FailCount     int    `json:"fail_count"`      // 上述动作的失败次数
-	TotalTimeCost int    `json:"total_time_cost"` // 总耗时，除以callback_count即为平均耗时
-	MaxTimeCost   int    `json:"max_time_cost"`   // 最大耗时
+	RefDate       util.DashDate `json:"ref_date"`        // 数据的日期, YYYY-MM-DD 格式
+	CallbackCount int           `json:"callback_count"`  // 通过服务器配置地址获得消息后，被动回复用户消息的次数
+	FailCount     int           `json:"fail_count"`      // 上述动作的失败次数
+	TotalTimeCost int           `json:"total_time_cost"` // 总耗时，除以callback_count即为平均耗时
+	MaxTimeCost   int           `json:"max_time_cost"`   // 最大耗时
 }
 
 // 获取接口分析数据.
@@ -26,6 +27,9 @@ func (clt Client) GetInterfaceSummary(req *Request) (list []InterfaceSummaryData
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(InterfaceSummaryMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -56,6 +60,9 @@ func (clt Client) GetInterfaceSummaryHour(req *Request) (list []InterfaceSummary
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(InterfaceSummaryHourMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -9,11 +9,12 @@ import (
 	"errors"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 消息发送概况数据
 type UpstreamMsgData struct {
-	RefDate string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	RefDate util.DashDate `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
 
 	// 消息类型，代表含义如下：
 	// 1代表文字
@@ -32,6 +33,9 @@ func (clt Client) GetUpstreamMsg(req *Request) (list []UpstreamMsgData, err erro
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -63,6 +67,9 @@ func (clt Client) GetUpstreamMsgHour(req *Request) (list []UpstreamMsgHourData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgHourMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -93,6 +100,9 @@ func (clt Client) GetUpstreamMsgWeek(req *Request) (list []UpstreamMsgWeekData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgWeekMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -123,6 +133,9 @@ func (clt Client) GetUpstreamMsgMonth(req *Request) (list []UpstreamMsgMonthData
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgMonthMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -144,9 +157,9 @@ func (clt Client) GetUpstreamMsgMonth(req *Request) (list []UpstreamMsgMonthData
 
 // 消息发送分布数据
 type UpstreamMsgDistData struct {
-	RefDate       string `json:"ref_date"`       // 数据的日期, YYYY-MM-DD 格式
-	CountInterval int    `json:"count_interval"` // 当日发送消息量分布的区间，0代表 “0”，1代表“1-5”，2代表“6-10”，3代表“10次以上”
-	MsgUser       int    `json:"msg_user"`       // 上行发送了（向公众号发送了）消息的用户数
+	RefDate       util.DashDate `json:"ref_date"`       // 数据的日期, YYYY-MM-DD 格式
+	CountInterval int           `json:"count_interval"` // 当日发送消息量分布的区间，0代表 “0”，1代表“1-5”，2代表“6-10”，3代表“10次以上”
+	MsgUser       int           `json:"msg_user"`       // 上行发送了（向公众号发送了）消息的用户数
 }
 
 // 获取消息发送分布数据.
@@ -155,6 +168,9 @@ func (clt Client) GetUpstreamMsgDist(req *Request) (list []UpstreamMsgDistData,
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgDistMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -185,6 +201,9 @@ func (clt Client) GetUpstreamMsgDistWeek(req *Request) (list []UpstreamMsgDistWe
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgDistWeekMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -215,6 +234,9 @@ func (clt Client) GetUpstreamMsgDistMonth(req *Request) (list []UpstreamMsgDistM
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UpstreamMsgDistMonthMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -9,11 +9,12 @@ import (
 	"errors"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 用户增减数据
 type UserSummaryData struct {
-	RefDate string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	RefDate util.DashDate `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
 
 	// 用户的渠道，数值代表的含义如下：
 	// 0  代表其他
@@ -34,6 +35,9 @@ func (clt Client) GetUserSummary(req *Request) (list []UserSummaryData, err erro
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserSummaryMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
@@ -55,8 +59,8 @@ func (clt Client) GetUserSummary(req *Request) (list []UserSummaryData, err erro
 
 // 累计用户数据
 type UserCumulateData struct {
-	RefDate      string `json:"ref_date"`      // 数据的日期, YYYY-MM-DD 格式
-	CumulateUser int    `json:"cumulate_user"` // 总用户量
+	RefDate      util.DashDate `json:"ref_date"`      // 数据的日期, YYYY-MM-DD 格式
+	CumulateUser int           `json:"cumulate_user"` // 总用户量
 }
 
 // 获取累计用户数据.
@@ -65,6 +69,9 @@ func (clt Client) GetUserCumulate(req *Request) (list []UserCumulateData, err er
 		err = errors.New("nil Request")
 		return
 	}
+	if err = req.CheckDateRange(UserCumulateMaxDays); err != nil {
+		return
+	}
 
 	var result struct {
 		mp.Error
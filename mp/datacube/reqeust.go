@@ -6,6 +6,8 @@
 package datacube
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -29,3 +31,101 @@ func NewRequest(BeginDate, EndDate time.Time) *Request {
 		EndDate:   EndDate.Format("2006-01-02"),
 	}
 }
+
+// 各个接口的最大时间跨度(天)
+const (
+	UserSummaryMaxDays          = 7
+	UserCumulateMaxDays         = 7
+	ArticleSummaryMaxDays       = 1
+	ArticleTotalMaxDays         = 1
+	UserReadMaxDays             = 3
+	UserReadHourMaxDays         = 1
+	UserShareMaxDays            = 7
+	UserShareHourMaxDays        = 1
+	UpstreamMsgMaxDays          = 7
+	UpstreamMsgHourMaxDays      = 1
+	UpstreamMsgWeekMaxDays      = 30
+	UpstreamMsgMonthMaxDays     = 30
+	UpstreamMsgDistMaxDays      = 15
+	UpstreamMsgDistWeekMaxDays  = 30
+	UpstreamMsgDistMonthMaxDays = 30
+	InterfaceSummaryMaxDays     = 30
+	InterfaceSummaryHourMaxDays = 1
+)
+
+const dateLayout = "2006-01-02"
+
+// 统计数据按北京时间计算日期
+var beijingLocation = time.FixedZone("Asia/Shanghai", 8*60*60)
+
+// 创建只查询一天的 Request.
+func NewDayRequest(date time.Time) *Request {
+	return NewRequest(date, date)
+}
+
+// 创建查询 endDate 以及之前共 days 天的 Request.
+func NewLastDaysRequest(endDate time.Time, days int) *Request {
+	return NewRequest(endDate.AddDate(0, 0, 1-days), endDate)
+}
+
+// 解析 BeginDate, EndDate.
+func (req *Request) dates() (begin, end time.Time, err error) {
+	if begin, err = time.ParseInLocation(dateLayout, req.BeginDate, beijingLocation); err != nil {
+		err = fmt.Errorf("invalid begin_date %q: %v", req.BeginDate, err)
+		return
+	}
+	if end, err = time.ParseInLocation(dateLayout, req.EndDate, beijingLocation); err != nil {
+		err = fmt.Errorf("invalid end_date %q: %v", req.EndDate, err)
+		return
+	}
+	return
+}
+
+// 查询的天数, 包括 BeginDate 和 EndDate.
+func (req *Request) Days() (days int, err error) {
+	begin, end, err := req.dates()
+	if err != nil {
+		return
+	}
+	return int(end.Sub(begin)/(24*time.Hour)) + 1, nil
+}
+
+// 检查日期格式, BeginDate <= EndDate <= 昨天, 并且时间跨度不超过 maxDays.
+func (req *Request) CheckDateRange(maxDays int) (err error) {
+	begin, end, err := req.dates()
+	if err != nil {
+		return
+	}
+	if end.Before(begin) {
+		return fmt.Errorf("end_date %s is before begin_date %s", req.EndDate, req.BeginDate)
+	}
+	today := time.Now().In(beijingLocation).Format(dateLayout)
+	if req.EndDate >= today {
+		return fmt.Errorf("end_date %s must be before today %s", req.EndDate, today)
+	}
+	if days := int(end.Sub(begin)/(24*time.Hour)) + 1; days > maxDays {
+		return fmt.Errorf("the date range must be less than or equal to %d days, now is %d days", maxDays, days)
+	}
+	return
+}
+
+// 把 req 按 maxDays 拆分成多个 Request, 用于查询超过接口最大时间跨度的数据.
+func (req *Request) Split(maxDays int) (reqs []*Request, err error) {
+	if maxDays <= 0 {
+		err = errors.New("maxDays must be greater than 0")
+		return
+	}
+	begin, end, err := req.dates()
+	if err != nil {
+		return
+	}
+	for !begin.After(end) {
+		chunkEnd := begin.AddDate(0, 0, maxDays-1)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		reqs = append(reqs, NewRequest(begin, chunkEnd))
+		begin = chunkEnd.AddDate(0, 0, 1)
+	}
+	return
+}
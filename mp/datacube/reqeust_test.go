@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package datacube
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRequestCheckDateRange(t *testing.T) {
+	today := time.Now().In(beijingLocation)
+	day := func(n int) string { // 今天之后第 n 天
+		return today.AddDate(0, 0, n).Format(dateLayout)
+	}
+
+	tests := []struct {
+		name    string
+		req     Request
+		maxDays int
+		wantErr bool
+	}{
+		{"yesterday", Request{day(-1), day(-1)}, 1, false},
+		{"max days", Request{day(-7), day(-1)}, 7, false},
+		{"too many days", Request{day(-8), day(-1)}, 7, true},
+		{"today", Request{day(0), day(0)}, 1, true},
+		{"end before begin", Request{day(-1), day(-2)}, 7, true},
+		{"invalid begin_date", Request{"20200101", day(-1)}, 7, true},
+		{"invalid end_date", Request{day(-2), "2020-13-01"}, 7, true},
+		{"empty", Request{}, 7, true},
+	}
+	for _, tt := range tests {
+		err := tt.req.CheckDateRange(tt.maxDays)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: %+v.CheckDateRange(%d): have error %v, want error %v", tt.name, tt.req, tt.maxDays, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRequestSplit(t *testing.T) {
+	tests := []struct {
+		req     Request
+		maxDays int
+		want    []Request
+	}{
+		{Request{"2020-01-01", "2020-01-01"}, 7, []Request{{"2020-01-01", "2020-01-01"}}},
+		{Request{"2020-01-01", "2020-01-07"}, 7, []Request{{"2020-01-01", "2020-01-07"}}},
+		{
+			Request{"2020-02-25", "2020-03-03"}, 3,
+			[]Request{{"2020-02-25", "2020-02-27"}, {"2020-02-28", "2020-03-01"}, {"2020-03-02", "2020-03-03"}},
+		},
+		{Request{"2020-01-02", "2020-01-01"}, 7, nil},
+	}
+	for _, tt := range tests {
+		reqs, err := tt.req.Split(tt.maxDays)
+		if err != nil {
+			t.Errorf("%+v.Split(%d): %v", tt.req, tt.maxDays, err)
+			continue
+		}
+		var have []Request
+		for _, req := range reqs {
+			have = append(have, *req)
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%+v.Split(%d): have %v, want %v", tt.req, tt.maxDays, have, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 下载接口返回了 errcode 为 0 的 json 而不是文件, 比如视频素材返回的是下载地址, Body 为完整的 json.
+type UnexpectedJSONError struct {
+	Body []byte
+}
+
+func (e *UnexpectedJSONError) Error() string {
+	return "unexpected json response: " + string(e.Body)
+}
+
+// 检查下载接口的应答, 是文件时返回文件流, 调用者负责关闭.
+//  状态码不是 200 时返回错误; 返回的是 json 时(按 Content-Type 判断, 以及 Content-Type 不可靠时按内容是否以 {"errcode": 或者 {"errmsg": 开头判断),
+//  errcode 不为 0 返回 *Error, 否则返回 *UnexpectedJSONError. 出错时 httpResp.Body 已经被关闭.
+func OpenDownloadResponse(httpResp *http.Response) (stream *util.DownloadStream, err error) {
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	stream = util.NewDownloadStream(httpResp)
+	isJSON := stream.ContentType == "application/json" || stream.ContentType == "text/plain"
+	if !isJSON {
+		if isJSON, err = stream.HasPrefix(`{"errcode":`, `{"errmsg":`); err != nil {
+			stream.Close()
+			stream = nil
+			return
+		}
+	}
+	if !isJSON {
+		return
+	}
+
+	body, err := ioutil.ReadAll(stream)
+	stream.Close()
+	stream = nil
+	if err != nil {
+		return
+	}
+	var result Error
+	if err = json.Unmarshal(body, &result); err != nil {
+		return
+	}
+	if result.ErrCode != ErrCodeOK {
+		err = &result
+		return
+	}
+	err = &UnexpectedJSONError{Body: body}
+	return
+}
+
+// 用 Client.Do 请求下载接口, 返回文件流(包括 Content-Type, Content-Length), 文件不会整个读入内存, 调用者负责关闭.
+//  参数见 Client.Do; 错误见 OpenDownloadResponse, access_token 失效时会刷新后重试一次.
+func (clt *Client) OpenDownload(ctx context.Context, method, path string, body io.Reader, contentType string) (stream *util.DownloadStream, err error) {
+	var bodyBytes []byte
+	if body != nil {
+		if bodyBytes, err = ioutil.ReadAll(body); err != nil {
+			return
+		}
+	}
+
+	hasRetried := false
+RETRY:
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+	httpResp, err := clt.Do(ctx, method, path, reqBody, contentType)
+	if err != nil {
+		return
+	}
+	stream, err = OpenDownloadResponse(httpResp)
+	if err == nil {
+		return
+	}
+
+	// Client.Do 只能按 Content-Type 识别 access_token 失效, 这里补充按内容识别的情况
+	if e, ok := err.(*Error); ok && !hasRetried && (e.ErrCode == ErrCodeInvalidCredential || e.ErrCode == ErrCodeAccessTokenExpired) {
+		hasRetried = true
+		LogInfoln("[WECHAT_RETRY] err_code:", e.ErrCode, ", err_msg:", e.ErrMsg)
+		if _, err = clt.TokenRefresh(); err != nil {
+			return
+		}
+		goto RETRY
+	}
+	return
+}
+
+// 下载文件写入 w, 参数见 OpenDownload, 返回写入的字节数.
+func (clt *Client) DownloadToWriter(ctx context.Context, method, path string, body io.Reader, contentType string, w io.Writer) (written int64, err error) {
+	stream, err := clt.OpenDownload(ctx, method, path, body, contentType)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+	return stream.WriteTo(w)
+}
@@ -0,0 +1,251 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package draft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ArticleTypeNews    = "news"    // 图文消息
+	ArticleTypeNewsPic = "newspic" // 图片消息
+)
+
+const (
+	DraftArticleCountLimit = 8  // 一个草稿最多 8 篇文章
+	DraftPageSizeLimit     = 20 // 获取草稿列表, 每次最多返回 20 个
+)
+
+// 图片消息里的图片
+type ImageInfo struct {
+	ImageList []struct {
+		ImageMediaId string `json:"image_media_id"` // 永久素材的 media_id
+	} `json:"image_list"`
+}
+
+// 草稿里的文章
+type Article struct {
+	ArticleType        string     `json:"article_type,omitempty"`       // ArticleTypeNews(默认), ArticleTypeNewsPic
+	Title              string     `json:"title"`                        // 必须; 标题
+	Author             string     `json:"author,omitempty"`             // 作者
+	Digest             string     `json:"digest,omitempty"`             // 图文消息的摘要, 仅有单图文消息才有摘要, 多图文此处为空
+	Content            string     `json:"content"`                      // 必须; 图文消息的具体内容, 支持 HTML 标签, 必须少于 2 万字符, 小于 1M, 且此处会去除 JS
+	ContentSourceURL   string     `json:"content_source_url,omitempty"` // 原文地址, 即点击"阅读原文"后的 URL
+	ThumbMediaId       string     `json:"thumb_media_id,omitempty"`     // 图文消息必须; 封面图片素材id(必须是永久 media_id)
+	NeedOpenComment    int        `json:"need_open_comment"`            // 是否打开评论, 0 不打开, 1 打开
+	OnlyFansCanComment int        `json:"only_fans_can_comment"`        // 是否粉丝才可评论, 0 所有人可评论, 1 粉丝才可评论
+	PicCrop2351        string     `json:"pic_crop_235_1,omitempty"`     // 封面裁剪为 2.35:1 规格的坐标字段, 如 "0.1945_0_1_0.5236"
+	PicCrop11          string     `json:"pic_crop_1_1,omitempty"`       // 封面裁剪为 1:1 规格的坐标字段
+	ImageInfo          *ImageInfo `json:"image_info,omitempty"`         // 图片消息必须; 最多 20 张, 首张为封面
+	URL                string     `json:"url,omitempty"`                // 草稿的临时链接, 获取时才有
+	ThumbURL           string     `json:"thumb_url,omitempty"`          // 封面图片的 URL, 获取时才有
+}
+
+func (article *Article) SetNeedOpenComment(b bool) {
+	if b {
+		article.NeedOpenComment = 1
+	} else {
+		article.NeedOpenComment = 0
+	}
+}
+
+func (article *Article) SetOnlyFansCanComment(b bool) {
+	if b {
+		article.OnlyFansCanComment = 1
+	} else {
+		article.OnlyFansCanComment = 0
+	}
+}
+
+// 新建草稿, 返回草稿的 media_id.
+func (clt Client) Add(articles []Article) (mediaId string, err error) {
+	if len(articles) == 0 {
+		err = errors.New("empty articles")
+		return
+	}
+	if len(articles) > DraftArticleCountLimit {
+		err = fmt.Errorf("草稿的文章个数不能超过 %d, 现在为 %d", DraftArticleCountLimit, len(articles))
+		return
+	}
+
+	var request = struct {
+		Articles []Article `json:"articles"`
+	}{
+		Articles: articles,
+	}
+
+	var result struct {
+		mp.Error
+		MediaId string `json:"media_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
+
+// 获取草稿.
+func (clt Client) Get(mediaId string) (articles []Article, err error) {
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		Articles []Article `json:"news_item"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	articles = result.Articles
+	return
+}
+
+// 修改草稿里的一篇文章, index 为文章在草稿里的位置, 从 0 开始.
+func (clt Client) Update(mediaId string, index int, article *Article) (err error) {
+	if article == nil {
+		return errors.New("nil article")
+	}
+
+	var request = struct {
+		MediaId string   `json:"media_id"`
+		Index   int      `json:"index"`
+		Article *Article `json:"articles"`
+	}{
+		MediaId: mediaId,
+		Index:   index,
+		Article: article,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除草稿.
+func (clt Client) Delete(mediaId string) (err error) {
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取草稿的总数.
+func (clt Client) Count() (total int, err error) {
+	var result struct {
+		mp.Error
+		TotalCount int `json:"total_count"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/count?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalCount
+	return
+}
+
+type DraftInfo struct {
+	MediaId string `json:"media_id"`
+	Content struct {
+		Articles   []Article `json:"news_item,omitempty"`
+		CreateTime int64     `json:"create_time"`
+		UpdateTime int64     `json:"update_time"`
+	} `json:"content"`
+	UpdateTime int64 `json:"update_time"` // 最后更新时间
+}
+
+// 获取草稿列表.
+//
+//  offset:       从全部草稿的该偏移位置开始返回，0表示从第一个草稿返回
+//  count:        返回草稿的数量，取值在1到20之间
+//  noContent:    为 true 时不返回文章的 Content 字段
+//
+//  TotalCount:   草稿的总数
+//  ItemCount:    本次调用获取的草稿的数量
+//  Items:        本次调用获取的草稿
+func (clt Client) BatchGet(offset, count int, noContent bool) (TotalCount, ItemCount int, Items []DraftInfo, err error) {
+	var request = struct {
+		Offset    int `json:"offset"`
+		Count     int `json:"count"`
+		NoContent int `json:"no_content"`
+	}{
+		Offset: offset,
+		Count:  count,
+	}
+	if noContent {
+		request.NoContent = 1
+	}
+
+	var result struct {
+		mp.Error
+		TotalCount int         `json:"total_count"`
+		ItemCount  int         `json:"item_count"`
+		Items      []DraftInfo `json:"item"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/draft/batchget?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	TotalCount = result.TotalCount
+	ItemCount = result.ItemCount
+	Items = result.Items
+	return
+}
@@ -0,0 +1,50 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package draft
+
+import (
+	"context"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 逐个返回草稿的遍历器, 需要时自动拉取下一页, 用法见 util.Iterator.
+type DraftPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个草稿, 遍历结束时返回 util.Done.
+func (pager *DraftPager) Next(ctx context.Context) (item *DraftInfo, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*DraftInfo), nil
+}
+
+// 获取草稿的 DraftPager, offset 表示从该偏移位置开始遍历, noContent 见 BatchGet.
+//  NOTE: 第一次调用 Next 时才拉取数据, 每页拉取 DraftPageSizeLimit 个.
+func (clt Client) DraftPager(offset int, noContent bool) *DraftPager {
+	return &DraftPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			totalCount, itemCount, list, err := Client{Client: clt.WithContext(ctx)}.BatchGet(offset, DraftPageSizeLimit, noContent)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			if itemCount <= 0 { // 本次没有返回草稿时认为已经遍历完了(防止死循环)
+				offset = totalCount
+			} else {
+				offset += itemCount
+			}
+			hasNext = offset < totalCount
+			return
+		}),
+	}
+}
@@ -0,0 +1,39 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 全局返回码(errcode)的常量和中英文说明, 用于让日志和告警里的返回码可读.
+//  常量和说明表由 errcode.tsv 生成, 新增返回码请修改 errcode.tsv 后执行 go generate.
+package errcode
+
+//go:generate go run ../../tools/errcodegen -i errcode.tsv -o errcode_table.go -p errcode
+
+import (
+	"strconv"
+)
+
+// 返回码的说明
+type Info struct {
+	Code int
+	Name string // 常量名, 比如 APIUnauthorized
+	Zh   string // 中文说明
+	En   string // 英文说明
+}
+
+// 查找返回码的说明, 表里没有时返回 ok == false.
+func Lookup(code int) (info Info, ok bool) {
+	info, ok = table[code]
+	return
+}
+
+// 返回可读的返回码说明, 比如 Describe(48001) 返回
+//  "48001 APIUnauthorized: api 功能未授权, 请确认公众号已获得该接口 (api unauthorized)"
+// 表里没有的返回码返回 "12345 unknown errcode".
+func Describe(code int) string {
+	info, ok := table[code]
+	if !ok {
+		return strconv.Itoa(code) + " unknown errcode"
+	}
+	return strconv.Itoa(code) + " " + info.Name + ": " + info.Zh + " (" + info.En + ")"
+}
@@ -0,0 +1,259 @@
+// Code generated by errcodegen; DO NOT EDIT.
+
+package errcode
+
+const (
+	SystemBusy                 = -1    // 系统繁忙, 此时请开发者稍候再试
+	OK                         = 0     // 请求成功
+	InvalidCredential          = 40001 // 获取 access_token 时 AppSecret 错误, 或者 access_token 无效
+	InvalidGrantType           = 40002 // 不合法的凭证类型
+	InvalidOpenId              = 40003 // 不合法的 OpenID, 请确认用户已关注公众号, 或者不是其他公众号的 OpenID
+	InvalidMediaType           = 40004 // 不合法的媒体文件类型
+	InvalidFileType            = 40005 // 不合法的文件类型
+	InvalidFileSize            = 40006 // 不合法的文件大小
+	InvalidMediaId             = 40007 // 不合法的媒体文件 id
+	InvalidMessageType         = 40008 // 不合法的消息类型
+	InvalidImageSize           = 40009 // 不合法的图片文件大小
+	InvalidVoiceSize           = 40010 // 不合法的语音文件大小
+	InvalidVideoSize           = 40011 // 不合法的视频文件大小
+	InvalidThumbSize           = 40012 // 不合法的缩略图文件大小
+	InvalidAppId               = 40013 // 不合法的 AppID, 请检查 AppID 的正确性, 避免异常字符, 注意大小写
+	InvalidAccessToken         = 40014 // 不合法的 access_token, 请检查 access_token 的有效性(如是否过期)
+	InvalidMenuType            = 40015 // 不合法的菜单类型
+	InvalidButtonCount         = 40016 // 不合法的按钮个数
+	InvalidButtonType          = 40017 // 不合法的按钮类型
+	InvalidButtonNameLength    = 40018 // 不合法的按钮名字长度
+	InvalidButtonKeyLength     = 40019 // 不合法的按钮 KEY 长度
+	InvalidButtonURLLength     = 40020 // 不合法的按钮 URL 长度
+	InvalidMenuVersion         = 40021 // 不合法的菜单版本号
+	InvalidSubMenuLevel        = 40022 // 不合法的子菜单级数
+	InvalidSubButtonCount      = 40023 // 不合法的子菜单按钮个数
+	InvalidSubButtonType       = 40024 // 不合法的子菜单按钮类型
+	InvalidSubButtonNameLength = 40025 // 不合法的子菜单按钮名字长度
+	InvalidSubButtonKeyLength  = 40026 // 不合法的子菜单按钮 KEY 长度
+	InvalidSubButtonURLLength  = 40027 // 不合法的子菜单按钮 URL 长度
+	InvalidMenuUser            = 40028 // 不合法的自定义菜单使用用户
+	InvalidOAuthCode           = 40029 // 不合法的 oauth_code
+	InvalidRefreshToken        = 40030 // 不合法的 refresh_token
+	InvalidOpenIdList          = 40031 // 不合法的 openid 列表
+	InvalidOpenIdListSize      = 40032 // 不合法的 openid 列表长度
+	InvalidCharset             = 40033 // 不合法的请求字符, 不能包含 \uxxxx 格式的字符
+	InvalidParameter           = 40035 // 不合法的参数
+	InvalidTemplateIdSize      = 40036 // 不合法的 template_id 长度
+	InvalidTemplateId          = 40037 // 不合法的 template_id
+	InvalidRequestFormat       = 40038 // 不合法的请求格式
+	InvalidURLSize             = 40039 // 不合法的 URL 长度
+	InvalidURLDomain           = 40048 // 无效的 url, 一般是 url 的域名不在允许的范围内
+	InvalidGroupId             = 40050 // 不合法的分组 id
+	InvalidGroupName           = 40051 // 分组名字不合法
+	InvalidSubButtonURLDomain  = 40054 // 不合法的子菜单按钮 url 域名
+	InvalidButtonURLDomain     = 40055 // 不合法的菜单按钮 url 域名
+	InvalidURL                 = 40066 // 不合法的 url
+	InvalidArgs                = 40097 // 参数错误
+	InvalidAppSecret           = 40125 // 不合法的 AppSecret
+	OAuthCodeUsed              = 40163 // oauth_code 已经被使用
+	InvalidIP                  = 40164 // 调用接口的 IP 地址不在白名单中, 请在接口 IP 白名单中设置
+	AppSecretFrozen            = 40243 // AppSecret 已被冻结, 请登录公众平台解冻后再调用
+	AccessTokenMissing         = 41001 // 缺少 access_token 参数
+	AppIdMissing               = 41002 // 缺少 appid 参数
+	RefreshTokenMissing        = 41003 // 缺少 refresh_token 参数
+	AppSecretMissing           = 41004 // 缺少 secret 参数
+	MediaDataMissing           = 41005 // 缺少多媒体文件数据
+	MediaIdMissing             = 41006 // 缺少 media_id 参数
+	SubMenuDataMissing         = 41007 // 缺少子菜单数据
+	OAuthCodeMissing           = 41008 // 缺少 oauth code
+	OpenIdMissing              = 41009 // 缺少 openid
+	InvalidFormId              = 41028 // form_id 不正确, 或者过期
+	FormIdUsed                 = 41029 // form_id 已被使用
+	InvalidPage                = 41030 // page 路径不正确, 需要保证在现网版本小程序中存在
+	AccessTokenExpired         = 42001 // access_token 超时, 请检查 access_token 的有效期
+	RefreshTokenExpired        = 42002 // refresh_token 超时
+	OAuthCodeExpired           = 42003 // oauth_code 超时
+	AccessTokenRevoked         = 42007 // 用户修改微信密码, access_token 和 refresh_token 失效, 需要重新授权
+	RequireGET                 = 43001 // 需要 GET 请求
+	RequirePOST                = 43002 // 需要 POST 请求
+	RequireHTTPS               = 43003 // 需要 HTTPS 请求
+	RequireSubscribe           = 43004 // 需要接收者关注
+	RequireFriend              = 43005 // 需要好友关系
+	UserRefuseMessage          = 43101 // 用户拒绝接受消息, 如果用户之前曾经订阅过, 则表示用户取消了订阅关系
+	EmptyMediaData             = 44001 // 多媒体文件为空
+	EmptyPostData              = 44002 // POST 的数据包为空
+	EmptyNewsData              = 44003 // 图文消息内容为空
+	EmptyContent               = 44004 // 文本消息内容为空
+	MediaSizeOutOfLimit        = 45001 // 多媒体文件大小超过限制
+	ContentSizeOutOfLimit      = 45002 // 消息内容超过限制
+	TitleSizeOutOfLimit        = 45003 // 标题字段超过限制
+	DescriptionSizeOutOfLimit  = 45004 // 描述字段超过限制
+	URLSizeOutOfLimit          = 45005 // 链接字段超过限制
+	PicURLSizeOutOfLimit       = 45006 // 图片链接字段超过限制
+	PlaytimeOutOfLimit         = 45007 // 语音播放时间超过限制
+	ArticleSizeOutOfLimit      = 45008 // 图文消息超过限制
+	APIFreqOutOfLimit          = 45009 // 接口调用超过限制
+	CreateMenuLimit            = 45010 // 创建菜单个数超过限制
+	APIMinuteQuotaLimit        = 45011 // API 调用太频繁, 请稍候再试
+	ResponseOutOfTime          = 45015 // 回复时间超过限制
+	SystemGroupReadOnly        = 45016 // 系统分组, 不允许修改
+	GroupNameTooLong           = 45017 // 分组名字过长
+	GroupCountOutOfLimit       = 45018 // 分组数量超过上限
+	CustomMessageOutOfLimit    = 45047 // 客服接口下行条数超过上限
+	MenuMiniProgramNotLinked   = 45064 // 创建菜单包含未关联的小程序
+	ClientMsgIdExists          = 45065 // 相同 clientmsgid 已存在群发记录
+	MediaDataNotExist          = 46001 // 不存在媒体数据
+	MenuVersionNotExist        = 46002 // 不存在的菜单版本
+	MenuDataNotExist           = 46003 // 不存在的菜单数据
+	UserNotExist               = 46004 // 不存在的用户
+	DataFormatError            = 47001 // 解析 JSON/XML 内容错误
+	InvalidTemplateArgument    = 47003 // 模板参数不准确, 可能为空或者不满足规则
+	APIUnauthorized            = 48001 // api 功能未授权, 请确认公众号已获得该接口
+	APIBlocked                 = 48004 // api 接口被封禁, 请登录公众平台查看详情
+	MaterialReferenced         = 48005 // api 禁止删除被自动回复和自定义菜单引用的素材
+	ClearQuotaLimit            = 48006 // api 禁止清零调用次数, 因为清零次数达到上限
+	UserUnauthorized           = 50001 // 用户未授权该 api
+	UserLimited                = 50002 // 用户受限, 可能是违规后接口被封禁
+	PublishBlocked             = 53500 // 发布功能被封禁
+	PublishTooFrequent         = 53501 // 频繁请求发布
+	InvalidPublishId           = 53502 // Publish ID 无效
+	InvalidArticleId           = 53600 // Article ID 无效
+	ComponentIPNotAllowed      = 61004 // 访问 ip 不在第三方平台的白名单中
+	ComponentTokenRequired     = 61024 // 该接口需要使用第三方平台的 component_access_token
+	SystemError                = 61450 // 系统错误
+	InvalidKfParameter         = 61451 // 参数错误
+	InvalidKfAccount           = 61452 // 无效客服账号
+	KfAccountExists            = 61453 // 客服帐号已存在
+	InvalidKfAccountLength     = 61454 // 客服帐号名长度超过限制(仅允许 10 个英文字符, 不包括 @ 及 @ 后的公众号的微信号)
+	IllegalKfAccountCharacter  = 61455 // 客服帐号名包含非法字符(仅允许英文+数字)
+	KfAccountCountExceeded     = 61456 // 客服帐号个数超过限制(10 个客服账号)
+	InvalidKfAvatarType        = 61457 // 无效头像文件类型
+	InvalidDateFormat          = 61500 // 日期格式错误
+	InvalidDateRange           = 61501 // 日期范围错误
+	InvalidSignature           = 87009 // 无效的签名
+	AdminConfirmRequired       = 89503 // 此次调用需要管理员确认, 请耐心等候
+)
+
+var table = map[int]Info{
+	SystemBusy:                 {Code: SystemBusy, Name: "SystemBusy", Zh: "系统繁忙, 此时请开发者稍候再试", En: "system busy, please try again later"},
+	OK:                         {Code: OK, Name: "OK", Zh: "请求成功", En: "ok"},
+	InvalidCredential:          {Code: InvalidCredential, Name: "InvalidCredential", Zh: "获取 access_token 时 AppSecret 错误, 或者 access_token 无效", En: "invalid credential, wrong AppSecret or invalid access_token"},
+	InvalidGrantType:           {Code: InvalidGrantType, Name: "InvalidGrantType", Zh: "不合法的凭证类型", En: "invalid grant_type"},
+	InvalidOpenId:              {Code: InvalidOpenId, Name: "InvalidOpenId", Zh: "不合法的 OpenID, 请确认用户已关注公众号, 或者不是其他公众号的 OpenID", En: "invalid openid"},
+	InvalidMediaType:           {Code: InvalidMediaType, Name: "InvalidMediaType", Zh: "不合法的媒体文件类型", En: "invalid media type"},
+	InvalidFileType:            {Code: InvalidFileType, Name: "InvalidFileType", Zh: "不合法的文件类型", En: "invalid file type"},
+	InvalidFileSize:            {Code: InvalidFileSize, Name: "InvalidFileSize", Zh: "不合法的文件大小", En: "invalid file size"},
+	InvalidMediaId:             {Code: InvalidMediaId, Name: "InvalidMediaId", Zh: "不合法的媒体文件 id", En: "invalid media_id"},
+	InvalidMessageType:         {Code: InvalidMessageType, Name: "InvalidMessageType", Zh: "不合法的消息类型", En: "invalid message type"},
+	InvalidImageSize:           {Code: InvalidImageSize, Name: "InvalidImageSize", Zh: "不合法的图片文件大小", En: "invalid image size"},
+	InvalidVoiceSize:           {Code: InvalidVoiceSize, Name: "InvalidVoiceSize", Zh: "不合法的语音文件大小", En: "invalid voice size"},
+	InvalidVideoSize:           {Code: InvalidVideoSize, Name: "InvalidVideoSize", Zh: "不合法的视频文件大小", En: "invalid video size"},
+	InvalidThumbSize:           {Code: InvalidThumbSize, Name: "InvalidThumbSize", Zh: "不合法的缩略图文件大小", En: "invalid thumb size"},
+	InvalidAppId:               {Code: InvalidAppId, Name: "InvalidAppId", Zh: "不合法的 AppID, 请检查 AppID 的正确性, 避免异常字符, 注意大小写", En: "invalid appid"},
+	InvalidAccessToken:         {Code: InvalidAccessToken, Name: "InvalidAccessToken", Zh: "不合法的 access_token, 请检查 access_token 的有效性(如是否过期)", En: "invalid access_token"},
+	InvalidMenuType:            {Code: InvalidMenuType, Name: "InvalidMenuType", Zh: "不合法的菜单类型", En: "invalid menu type"},
+	InvalidButtonCount:         {Code: InvalidButtonCount, Name: "InvalidButtonCount", Zh: "不合法的按钮个数", En: "invalid button count"},
+	InvalidButtonType:          {Code: InvalidButtonType, Name: "InvalidButtonType", Zh: "不合法的按钮类型", En: "invalid button type"},
+	InvalidButtonNameLength:    {Code: InvalidButtonNameLength, Name: "InvalidButtonNameLength", Zh: "不合法的按钮名字长度", En: "invalid button name length"},
+	InvalidButtonKeyLength:     {Code: InvalidButtonKeyLength, Name: "InvalidButtonKeyLength", Zh: "不合法的按钮 KEY 长度", En: "invalid button key length"},
+	InvalidButtonURLLength:     {Code: InvalidButtonURLLength, Name: "InvalidButtonURLLength", Zh: "不合法的按钮 URL 长度", En: "invalid button url length"},
+	InvalidMenuVersion:         {Code: InvalidMenuVersion, Name: "InvalidMenuVersion", Zh: "不合法的菜单版本号", En: "invalid menu version"},
+	InvalidSubMenuLevel:        {Code: InvalidSubMenuLevel, Name: "InvalidSubMenuLevel", Zh: "不合法的子菜单级数", En: "invalid sub menu level"},
+	InvalidSubButtonCount:      {Code: InvalidSubButtonCount, Name: "InvalidSubButtonCount", Zh: "不合法的子菜单按钮个数", En: "invalid sub button count"},
+	InvalidSubButtonType:       {Code: InvalidSubButtonType, Name: "InvalidSubButtonType", Zh: "不合法的子菜单按钮类型", En: "invalid sub button type"},
+	InvalidSubButtonNameLength: {Code: InvalidSubButtonNameLength, Name: "InvalidSubButtonNameLength", Zh: "不合法的子菜单按钮名字长度", En: "invalid sub button name length"},
+	InvalidSubButtonKeyLength:  {Code: InvalidSubButtonKeyLength, Name: "InvalidSubButtonKeyLength", Zh: "不合法的子菜单按钮 KEY 长度", En: "invalid sub button key length"},
+	InvalidSubButtonURLLength:  {Code: InvalidSubButtonURLLength, Name: "InvalidSubButtonURLLength", Zh: "不合法的子菜单按钮 URL 长度", En: "invalid sub button url length"},
+	InvalidMenuUser:            {Code: InvalidMenuUser, Name: "InvalidMenuUser", Zh: "不合法的自定义菜单使用用户", En: "invalid custom menu user"},
+	InvalidOAuthCode:           {Code: InvalidOAuthCode, Name: "InvalidOAuthCode", Zh: "不合法的 oauth_code", En: "invalid oauth code"},
+	InvalidRefreshToken:        {Code: InvalidRefreshToken, Name: "InvalidRefreshToken", Zh: "不合法的 refresh_token", En: "invalid refresh_token"},
+	InvalidOpenIdList:          {Code: InvalidOpenIdList, Name: "InvalidOpenIdList", Zh: "不合法的 openid 列表", En: "invalid openid list"},
+	InvalidOpenIdListSize:      {Code: InvalidOpenIdListSize, Name: "InvalidOpenIdListSize", Zh: "不合法的 openid 列表长度", En: "invalid openid list size"},
+	InvalidCharset:             {Code: InvalidCharset, Name: "InvalidCharset", Zh: "不合法的请求字符, 不能包含 \\uxxxx 格式的字符", En: "invalid charset, \\uxxxx escapes are not allowed"},
+	InvalidParameter:           {Code: InvalidParameter, Name: "InvalidParameter", Zh: "不合法的参数", En: "invalid parameter"},
+	InvalidTemplateIdSize:      {Code: InvalidTemplateIdSize, Name: "InvalidTemplateIdSize", Zh: "不合法的 template_id 长度", En: "invalid template_id size"},
+	InvalidTemplateId:          {Code: InvalidTemplateId, Name: "InvalidTemplateId", Zh: "不合法的 template_id", En: "invalid template_id"},
+	InvalidRequestFormat:       {Code: InvalidRequestFormat, Name: "InvalidRequestFormat", Zh: "不合法的请求格式", En: "invalid request format"},
+	InvalidURLSize:             {Code: InvalidURLSize, Name: "InvalidURLSize", Zh: "不合法的 URL 长度", En: "invalid url size"},
+	InvalidURLDomain:           {Code: InvalidURLDomain, Name: "InvalidURLDomain", Zh: "无效的 url, 一般是 url 的域名不在允许的范围内", En: "invalid url domain"},
+	InvalidGroupId:             {Code: InvalidGroupId, Name: "InvalidGroupId", Zh: "不合法的分组 id", En: "invalid group id"},
+	InvalidGroupName:           {Code: InvalidGroupName, Name: "InvalidGroupName", Zh: "分组名字不合法", En: "invalid group name"},
+	InvalidSubButtonURLDomain:  {Code: InvalidSubButtonURLDomain, Name: "InvalidSubButtonURLDomain", Zh: "不合法的子菜单按钮 url 域名", En: "invalid sub button url domain"},
+	InvalidButtonURLDomain:     {Code: InvalidButtonURLDomain, Name: "InvalidButtonURLDomain", Zh: "不合法的菜单按钮 url 域名", En: "invalid button url domain"},
+	InvalidURL:                 {Code: InvalidURL, Name: "InvalidURL", Zh: "不合法的 url", En: "invalid url"},
+	InvalidArgs:                {Code: InvalidArgs, Name: "InvalidArgs", Zh: "参数错误", En: "invalid args"},
+	InvalidAppSecret:           {Code: InvalidAppSecret, Name: "InvalidAppSecret", Zh: "不合法的 AppSecret", En: "invalid appsecret"},
+	OAuthCodeUsed:              {Code: OAuthCodeUsed, Name: "OAuthCodeUsed", Zh: "oauth_code 已经被使用", En: "oauth code has been used"},
+	InvalidIP:                  {Code: InvalidIP, Name: "InvalidIP", Zh: "调用接口的 IP 地址不在白名单中, 请在接口 IP 白名单中设置", En: "invalid ip, not in the whitelist"},
+	AppSecretFrozen:            {Code: AppSecretFrozen, Name: "AppSecretFrozen", Zh: "AppSecret 已被冻结, 请登录公众平台解冻后再调用", En: "appsecret has been frozen"},
+	AccessTokenMissing:         {Code: AccessTokenMissing, Name: "AccessTokenMissing", Zh: "缺少 access_token 参数", En: "access_token missing"},
+	AppIdMissing:               {Code: AppIdMissing, Name: "AppIdMissing", Zh: "缺少 appid 参数", En: "appid missing"},
+	RefreshTokenMissing:        {Code: RefreshTokenMissing, Name: "RefreshTokenMissing", Zh: "缺少 refresh_token 参数", En: "refresh_token missing"},
+	AppSecretMissing:           {Code: AppSecretMissing, Name: "AppSecretMissing", Zh: "缺少 secret 参数", En: "appsecret missing"},
+	MediaDataMissing:           {Code: MediaDataMissing, Name: "MediaDataMissing", Zh: "缺少多媒体文件数据", En: "media data missing"},
+	MediaIdMissing:             {Code: MediaIdMissing, Name: "MediaIdMissing", Zh: "缺少 media_id 参数", En: "media_id missing"},
+	SubMenuDataMissing:         {Code: SubMenuDataMissing, Name: "SubMenuDataMissing", Zh: "缺少子菜单数据", En: "sub menu data missing"},
+	OAuthCodeMissing:           {Code: OAuthCodeMissing, Name: "OAuthCodeMissing", Zh: "缺少 oauth code", En: "oauth code missing"},
+	OpenIdMissing:              {Code: OpenIdMissing, Name: "OpenIdMissing", Zh: "缺少 openid", En: "openid missing"},
+	InvalidFormId:              {Code: InvalidFormId, Name: "InvalidFormId", Zh: "form_id 不正确, 或者过期", En: "invalid form_id"},
+	FormIdUsed:                 {Code: FormIdUsed, Name: "FormIdUsed", Zh: "form_id 已被使用", En: "form_id has been used"},
+	InvalidPage:                {Code: InvalidPage, Name: "InvalidPage", Zh: "page 路径不正确, 需要保证在现网版本小程序中存在", En: "invalid page"},
+	AccessTokenExpired:         {Code: AccessTokenExpired, Name: "AccessTokenExpired", Zh: "access_token 超时, 请检查 access_token 的有效期", En: "access_token expired"},
+	RefreshTokenExpired:        {Code: RefreshTokenExpired, Name: "RefreshTokenExpired", Zh: "refresh_token 超时", En: "refresh_token expired"},
+	OAuthCodeExpired:           {Code: OAuthCodeExpired, Name: "OAuthCodeExpired", Zh: "oauth_code 超时", En: "oauth code expired"},
+	AccessTokenRevoked:         {Code: AccessTokenRevoked, Name: "AccessTokenRevoked", Zh: "用户修改微信密码, access_token 和 refresh_token 失效, 需要重新授权", En: "access_token and refresh_token revoked, need to re-authorize"},
+	RequireGET:                 {Code: RequireGET, Name: "RequireGET", Zh: "需要 GET 请求", En: "require GET method"},
+	RequirePOST:                {Code: RequirePOST, Name: "RequirePOST", Zh: "需要 POST 请求", En: "require POST method"},
+	RequireHTTPS:               {Code: RequireHTTPS, Name: "RequireHTTPS", Zh: "需要 HTTPS 请求", En: "require https"},
+	RequireSubscribe:           {Code: RequireSubscribe, Name: "RequireSubscribe", Zh: "需要接收者关注", En: "require subscribe"},
+	RequireFriend:              {Code: RequireFriend, Name: "RequireFriend", Zh: "需要好友关系", En: "require friend relations"},
+	UserRefuseMessage:          {Code: UserRefuseMessage, Name: "UserRefuseMessage", Zh: "用户拒绝接受消息, 如果用户之前曾经订阅过, 则表示用户取消了订阅关系", En: "user refuse to accept the message"},
+	EmptyMediaData:             {Code: EmptyMediaData, Name: "EmptyMediaData", Zh: "多媒体文件为空", En: "empty media data"},
+	EmptyPostData:              {Code: EmptyPostData, Name: "EmptyPostData", Zh: "POST 的数据包为空", En: "empty post data"},
+	EmptyNewsData:              {Code: EmptyNewsData, Name: "EmptyNewsData", Zh: "图文消息内容为空", En: "empty news data"},
+	EmptyContent:               {Code: EmptyContent, Name: "EmptyContent", Zh: "文本消息内容为空", En: "empty content"},
+	MediaSizeOutOfLimit:        {Code: MediaSizeOutOfLimit, Name: "MediaSizeOutOfLimit", Zh: "多媒体文件大小超过限制", En: "media size out of limit"},
+	ContentSizeOutOfLimit:      {Code: ContentSizeOutOfLimit, Name: "ContentSizeOutOfLimit", Zh: "消息内容超过限制", En: "content size out of limit"},
+	TitleSizeOutOfLimit:        {Code: TitleSizeOutOfLimit, Name: "TitleSizeOutOfLimit", Zh: "标题字段超过限制", En: "title size out of limit"},
+	DescriptionSizeOutOfLimit:  {Code: DescriptionSizeOutOfLimit, Name: "DescriptionSizeOutOfLimit", Zh: "描述字段超过限制", En: "description size out of limit"},
+	URLSizeOutOfLimit:          {Code: URLSizeOutOfLimit, Name: "URLSizeOutOfLimit", Zh: "链接字段超过限制", En: "url size out of limit"},
+	PicURLSizeOutOfLimit:       {Code: PicURLSizeOutOfLimit, Name: "PicURLSizeOutOfLimit", Zh: "图片链接字段超过限制", En: "picurl size out of limit"},
+	PlaytimeOutOfLimit:         {Code: PlaytimeOutOfLimit, Name: "PlaytimeOutOfLimit", Zh: "语音播放时间超过限制", En: "playtime out of limit"},
+	ArticleSizeOutOfLimit:      {Code: ArticleSizeOutOfLimit, Name: "ArticleSizeOutOfLimit", Zh: "图文消息超过限制", En: "article size out of limit"},
+	APIFreqOutOfLimit:          {Code: APIFreqOutOfLimit, Name: "APIFreqOutOfLimit", Zh: "接口调用超过限制", En: "reach max api daily quota limit"},
+	CreateMenuLimit:            {Code: CreateMenuLimit, Name: "CreateMenuLimit", Zh: "创建菜单个数超过限制", En: "create menu limit"},
+	APIMinuteQuotaLimit:        {Code: APIMinuteQuotaLimit, Name: "APIMinuteQuotaLimit", Zh: "API 调用太频繁, 请稍候再试", En: "api minute-quota reach limit, must slower, retry next minute"},
+	ResponseOutOfTime:          {Code: ResponseOutOfTime, Name: "ResponseOutOfTime", Zh: "回复时间超过限制", En: "response out of time limit"},
+	SystemGroupReadOnly:        {Code: SystemGroupReadOnly, Name: "SystemGroupReadOnly", Zh: "系统分组, 不允许修改", En: "system group can not be changed"},
+	GroupNameTooLong:           {Code: GroupNameTooLong, Name: "GroupNameTooLong", Zh: "分组名字过长", En: "group name too long"},
+	GroupCountOutOfLimit:       {Code: GroupCountOutOfLimit, Name: "GroupCountOutOfLimit", Zh: "分组数量超过上限", En: "too many groups"},
+	CustomMessageOutOfLimit:    {Code: CustomMessageOutOfLimit, Name: "CustomMessageOutOfLimit", Zh: "客服接口下行条数超过上限", En: "out of response count limit"},
+	MenuMiniProgramNotLinked:   {Code: MenuMiniProgramNotLinked, Name: "MenuMiniProgramNotLinked", Zh: "创建菜单包含未关联的小程序", En: "no permission to use weapp in menu"},
+	ClientMsgIdExists:          {Code: ClientMsgIdExists, Name: "ClientMsgIdExists", Zh: "相同 clientmsgid 已存在群发记录", En: "clientmsgid exist"},
+	MediaDataNotExist:          {Code: MediaDataNotExist, Name: "MediaDataNotExist", Zh: "不存在媒体数据", En: "media data not exist"},
+	MenuVersionNotExist:        {Code: MenuVersionNotExist, Name: "MenuVersionNotExist", Zh: "不存在的菜单版本", En: "menu version not exist"},
+	MenuDataNotExist:           {Code: MenuDataNotExist, Name: "MenuDataNotExist", Zh: "不存在的菜单数据", En: "menu data not exist"},
+	UserNotExist:               {Code: UserNotExist, Name: "UserNotExist", Zh: "不存在的用户", En: "user not exist"},
+	DataFormatError:            {Code: DataFormatError, Name: "DataFormatError", Zh: "解析 JSON/XML 内容错误", En: "data format error"},
+	InvalidTemplateArgument:    {Code: InvalidTemplateArgument, Name: "InvalidTemplateArgument", Zh: "模板参数不准确, 可能为空或者不满足规则", En: "argument invalid"},
+	APIUnauthorized:            {Code: APIUnauthorized, Name: "APIUnauthorized", Zh: "api 功能未授权, 请确认公众号已获得该接口", En: "api unauthorized"},
+	APIBlocked:                 {Code: APIBlocked, Name: "APIBlocked", Zh: "api 接口被封禁, 请登录公众平台查看详情", En: "api forbidden"},
+	MaterialReferenced:         {Code: MaterialReferenced, Name: "MaterialReferenced", Zh: "api 禁止删除被自动回复和自定义菜单引用的素材", En: "forbid to delete material used by auto-reply or menu"},
+	ClearQuotaLimit:            {Code: ClearQuotaLimit, Name: "ClearQuotaLimit", Zh: "api 禁止清零调用次数, 因为清零次数达到上限", En: "forbid to clear quota because of reaching the limit"},
+	UserUnauthorized:           {Code: UserUnauthorized, Name: "UserUnauthorized", Zh: "用户未授权该 api", En: "user unauthorized"},
+	UserLimited:                {Code: UserLimited, Name: "UserLimited", Zh: "用户受限, 可能是违规后接口被封禁", En: "user limited"},
+	PublishBlocked:             {Code: PublishBlocked, Name: "PublishBlocked", Zh: "发布功能被封禁", En: "publish function blocked"},
+	PublishTooFrequent:         {Code: PublishTooFrequent, Name: "PublishTooFrequent", Zh: "频繁请求发布", En: "publish too frequently"},
+	InvalidPublishId:           {Code: InvalidPublishId, Name: "InvalidPublishId", Zh: "Publish ID 无效", En: "invalid publish_id"},
+	InvalidArticleId:           {Code: InvalidArticleId, Name: "InvalidArticleId", Zh: "Article ID 无效", En: "invalid article_id"},
+	ComponentIPNotAllowed:      {Code: ComponentIPNotAllowed, Name: "ComponentIPNotAllowed", Zh: "访问 ip 不在第三方平台的白名单中", En: "access clientip is not registered"},
+	ComponentTokenRequired:     {Code: ComponentTokenRequired, Name: "ComponentTokenRequired", Zh: "该接口需要使用第三方平台的 component_access_token", En: "must use component token"},
+	SystemError:                {Code: SystemError, Name: "SystemError", Zh: "系统错误", En: "system error"},
+	InvalidKfParameter:         {Code: InvalidKfParameter, Name: "InvalidKfParameter", Zh: "参数错误", En: "invalid parameter"},
+	InvalidKfAccount:           {Code: InvalidKfAccount, Name: "InvalidKfAccount", Zh: "无效客服账号", En: "invalid kf_account"},
+	KfAccountExists:            {Code: KfAccountExists, Name: "KfAccountExists", Zh: "客服帐号已存在", En: "kf_account existed"},
+	InvalidKfAccountLength:     {Code: InvalidKfAccountLength, Name: "InvalidKfAccountLength", Zh: "客服帐号名长度超过限制(仅允许 10 个英文字符, 不包括 @ 及 @ 后的公众号的微信号)", En: "invalid kf_account length"},
+	IllegalKfAccountCharacter:  {Code: IllegalKfAccountCharacter, Name: "IllegalKfAccountCharacter", Zh: "客服帐号名包含非法字符(仅允许英文+数字)", En: "illegal character in kf_account"},
+	KfAccountCountExceeded:     {Code: KfAccountCountExceeded, Name: "KfAccountCountExceeded", Zh: "客服帐号个数超过限制(10 个客服账号)", En: "kf_account count exceeded"},
+	InvalidKfAvatarType:        {Code: InvalidKfAvatarType, Name: "InvalidKfAvatarType", Zh: "无效头像文件类型", En: "invalid file type"},
+	InvalidDateFormat:          {Code: InvalidDateFormat, Name: "InvalidDateFormat", Zh: "日期格式错误", En: "date format error"},
+	InvalidDateRange:           {Code: InvalidDateRange, Name: "InvalidDateRange", Zh: "日期范围错误", En: "date range error"},
+	InvalidSignature:           {Code: InvalidSignature, Name: "InvalidSignature", Zh: "无效的签名", En: "invalid signature"},
+	AdminConfirmRequired:       {Code: AdminConfirmRequired, Name: "AdminConfirmRequired", Zh: "此次调用需要管理员确认, 请耐心等候", En: "need admin confirm"},
+}
@@ -0,0 +1,17 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 把拉取到的数据(比如 dkf.Record 聊天记录, user.UserInfo 用户信息)导出为 CSV 或者 NDJSON(每行一个 json 对象),
+// 方便交给数据分析工具处理.
+//  字段名为结构体字段的 json tag 名(比如 openid, nickname), 可以选择导出哪些字段和字段的顺序:
+//
+//  w, err := export.NewWriter(file, export.FormatCSV, []string{"openid", "nickname", "subscribe_time"})
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  if err = export.WriteAll(w, userinfos); err != nil {
+//      // TODO: 增加你的代码
+//  }
+package export
@@ -0,0 +1,145 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package export
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// 结构体里可以导出的一个字段
+type field struct {
+	name  string // json tag 名
+	index []int  // reflect.Value.FieldByIndex 的参数
+}
+
+// 返回 t(结构体类型)里所有可以导出的字段, 按声明的顺序, 匿名嵌入的结构体会被展开.
+func structFields(t reflect.Type) (fields []field) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if j := strings.IndexByte(tag, ','); j >= 0 {
+			name = tag[:j]
+		}
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, f := range structFields(ft) {
+					f.index = append([]int{i}, f.index...)
+					fields = append(fields, f)
+				}
+				continue
+			}
+		}
+		if sf.PkgPath != "" { // 非导出字段
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: []int{i}})
+	}
+	return
+}
+
+// 按 names 选择 t 的字段, names 为空时返回所有字段.
+func selectFields(t reflect.Type, names []string) (fields []field, err error) {
+	all := structFields(t)
+	if len(names) == 0 {
+		if len(all) == 0 {
+			err = fmt.Errorf("%s has no exported field", t)
+		}
+		return all, err
+	}
+
+	m := make(map[string]field, len(all))
+	for _, f := range all {
+		if _, ok := m[f.name]; !ok {
+			m[f.name] = f
+		}
+	}
+	fields = make([]field, 0, len(names))
+	for _, name := range names {
+		f, ok := m[name]
+		if !ok {
+			return nil, fmt.Errorf("%s has no field %q", t, name)
+		}
+		fields = append(fields, f)
+	}
+	return
+}
+
+// 返回 v 对应的结构体的 reflect.Value, v 必须是结构体或者结构体的指针.
+func structValue(v interface{}) (rv reflect.Value, err error) {
+	rv = reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			err = errors.New("nil pointer")
+			return
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%T is not a struct", v)
+	}
+	return
+}
+
+// 获取 rv 的字段值, 中间的 nil 指针返回无效的 reflect.Value.
+func fieldValue(rv reflect.Value, f field) reflect.Value {
+	for i, x := range f.index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+// 一组字段, 第一次写入时根据类型确定, 之后写入的值必须是同样的类型.
+type fieldSet struct {
+	names  []string
+	typ    reflect.Type
+	fields []field
+}
+
+func (fs *fieldSet) resolve(v interface{}) (rv reflect.Value, err error) {
+	if rv, err = structValue(v); err != nil {
+		return
+	}
+	if fs.typ == nil {
+		if fs.fields, err = selectFields(rv.Type(), fs.names); err != nil {
+			return
+		}
+		fs.typ = rv.Type()
+		return
+	}
+	if rv.Type() != fs.typ {
+		err = fmt.Errorf("type mismatch, have: %s, want: %s", rv.Type(), fs.typ)
+	}
+	return
+}
+
+func (fs *fieldSet) header() []string {
+	names := make([]string, len(fs.fields))
+	for i, f := range fs.fields {
+		names[i] = f.name
+	}
+	return names
+}
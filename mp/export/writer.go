@@ -0,0 +1,197 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// 导出的格式
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+type Writer interface {
+	// 写入一条记录, v 为结构体或者结构体的指针, 同一个 Writer 写入的记录必须是同样的类型.
+	Write(v interface{}) error
+
+	// 把缓存的数据写入底层的 io.Writer, 写完所有的记录后必须调用.
+	Flush() error
+}
+
+// 创建 format 格式的 Writer, fields 为导出的字段(json tag 名), 为空时导出所有的字段.
+func NewWriter(w io.Writer, format string, fields []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w, fields), nil
+	case FormatNDJSON:
+		return NewNDJSONWriter(w, fields), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// 把 slice(结构体或者结构体指针的 slice, 比如 []user.UserInfo)的每个元素写入 w, 然后调用 w.Flush.
+func WriteAll(w Writer, slice interface{}) (err error) {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("%T is not a slice", slice)
+	}
+	for i, n := 0, rv.Len(); i < n; i++ {
+		if err = w.Write(rv.Index(i).Interface()); err != nil {
+			return
+		}
+	}
+	return w.Flush()
+}
+
+var _ Writer = (*CSVWriter)(nil)
+
+// CSV 格式的 Writer, 第一行为表头(字段名).
+//  字符串, 数字和 bool 直接输出, nil 指针, slice 和 map 输出空串, 其他类型(比如 slice)输出 json.
+type CSVWriter struct {
+	// 为 true 时在表头之前写入 UTF-8 BOM, 这样 Excel 能正确显示中文; 必须在第一次 Write 之前设置.
+	BOM bool
+
+	w       io.Writer
+	csv     *csv.Writer
+	fields  fieldSet
+	started bool
+	record  []string
+}
+
+func NewCSVWriter(w io.Writer, fields []string) *CSVWriter {
+	return &CSVWriter{
+		w:      w,
+		csv:    csv.NewWriter(w),
+		fields: fieldSet{names: fields},
+	}
+}
+
+func (w *CSVWriter) Write(v interface{}) (err error) {
+	rv, err := w.fields.resolve(v)
+	if err != nil {
+		return
+	}
+	if !w.started {
+		w.started = true
+		if w.BOM {
+			if _, err = io.WriteString(w.w, "\xEF\xBB\xBF"); err != nil {
+				return
+			}
+		}
+		if err = w.csv.Write(w.fields.header()); err != nil {
+			return
+		}
+		w.record = make([]string, len(w.fields.fields))
+	}
+	for i, f := range w.fields.fields {
+		if w.record[i], err = formatValue(fieldValue(rv, f)); err != nil {
+			return
+		}
+	}
+	return w.csv.Write(w.record)
+}
+
+func (w *CSVWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func formatValue(rv reflect.Value) (string, error) {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return "", nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return "", nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return "", nil
+		}
+	}
+	b, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+var _ Writer = (*NDJSONWriter)(nil)
+
+// NDJSON 格式的 Writer, 每行一个 json 对象, 对象的 key 按选择的字段的顺序排列.
+type NDJSONWriter struct {
+	w      *bufio.Writer
+	fields fieldSet
+	buf    bytes.Buffer
+}
+
+func NewNDJSONWriter(w io.Writer, fields []string) *NDJSONWriter {
+	return &NDJSONWriter{
+		w:      bufio.NewWriter(w),
+		fields: fieldSet{names: fields},
+	}
+}
+
+func (w *NDJSONWriter) Write(v interface{}) (err error) {
+	rv, err := w.fields.resolve(v)
+	if err != nil {
+		return
+	}
+
+	buf := &w.buf
+	buf.Reset()
+	buf.WriteByte('{')
+	for i, f := range w.fields.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		fv := fieldValue(rv, f)
+		if !fv.IsValid() {
+			buf.WriteString("null")
+			continue
+		}
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("}\n")
+	_, err = w.w.Write(buf.Bytes())
+	return
+}
+
+func (w *NDJSONWriter) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,174 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/draft"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const PublishedPageSizeLimit = 20 // 获取已发布的图文列表, 每次最多返回 20 个
+
+// 已发布的文章
+type Article struct {
+	draft.Article
+	IsDeleted bool `json:"is_deleted"` // 该文章是否被删除
+}
+
+// 获取已发布的图文, articleId 为发布成功后的 article_id.
+func (clt Client) GetArticle(articleId string) (articles []Article, err error) {
+	if articleId == "" {
+		err = errors.New("empty articleId")
+		return
+	}
+
+	var request = struct {
+		ArticleId string `json:"article_id"`
+	}{
+		ArticleId: articleId,
+	}
+
+	var result struct {
+		mp.Error
+		Articles []Article `json:"news_item"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/getarticle?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	articles = result.Articles
+	return
+}
+
+// 删除已发布的文章, index 为要删除的文章在图文里的序号(从 1 开始), 为 0 时删除整个图文. 此操作不可逆.
+func (clt Client) Delete(articleId string, index int) (err error) {
+	if articleId == "" {
+		return errors.New("empty articleId")
+	}
+
+	var request = struct {
+		ArticleId string `json:"article_id"`
+		Index     int    `json:"index,omitempty"`
+	}{
+		ArticleId: articleId,
+		Index:     index,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+type PublishedInfo struct {
+	ArticleId string `json:"article_id"`
+	Content   struct {
+		Articles   []Article `json:"news_item,omitempty"`
+		CreateTime int64     `json:"create_time"`
+		UpdateTime int64     `json:"update_time"`
+	} `json:"content"`
+	UpdateTime int64 `json:"update_time"` // 最后更新时间
+}
+
+// 获取成功发布的图文列表.
+//
+//  offset:       从全部图文的该偏移位置开始返回，0表示从第一个图文返回
+//  count:        返回图文的数量，取值在1到20之间
+//  noContent:    为 true 时不返回文章的 Content 字段
+//
+//  TotalCount:   成功发布的图文总数
+//  ItemCount:    本次调用获取的图文的数量
+//  Items:        本次调用获取的图文
+func (clt Client) BatchGet(offset, count int, noContent bool) (TotalCount, ItemCount int, Items []PublishedInfo, err error) {
+	var request = struct {
+		Offset    int `json:"offset"`
+		Count     int `json:"count"`
+		NoContent int `json:"no_content"`
+	}{
+		Offset: offset,
+		Count:  count,
+	}
+	if noContent {
+		request.NoContent = 1
+	}
+
+	var result struct {
+		mp.Error
+		TotalCount int             `json:"total_count"`
+		ItemCount  int             `json:"item_count"`
+		Items      []PublishedInfo `json:"item"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/batchget?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	TotalCount = result.TotalCount
+	ItemCount = result.ItemCount
+	Items = result.Items
+	return
+}
+
+// 逐个返回已发布图文的遍历器, 需要时自动拉取下一页, 用法见 util.Iterator.
+type PublishedPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个已发布的图文, 遍历结束时返回 util.Done.
+func (pager *PublishedPager) Next(ctx context.Context) (item *PublishedInfo, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*PublishedInfo), nil
+}
+
+// 获取已发布图文的 PublishedPager, offset 表示从该偏移位置开始遍历, noContent 见 BatchGet.
+//  NOTE: 第一次调用 Next 时才拉取数据, 每页拉取 PublishedPageSizeLimit 个.
+func (clt Client) PublishedPager(offset int, noContent bool) *PublishedPager {
+	return &PublishedPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			totalCount, itemCount, list, err := Client{Client: clt.WithContext(ctx)}.BatchGet(offset, PublishedPageSizeLimit, noContent)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			if itemCount <= 0 { // 本次没有返回图文时认为已经遍历完了(防止死循环)
+				offset = totalCount
+			} else {
+				offset += itemCount
+			}
+			hasNext = offset < totalCount
+			return
+		}),
+	}
+}
@@ -0,0 +1,7 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 发布能力, 把草稿箱(见 mp/draft)里的图文发布出去(不会推送给用户, 也不占用群发次数), 以及管理已发布的图文.
+package freepublish
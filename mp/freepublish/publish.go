@@ -0,0 +1,172 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 发布状态
+const (
+	PublishStatusSuccess        = 0 // 发布成功
+	PublishStatusPublishing     = 1 // 发布中
+	PublishStatusOriginalFailed = 2 // 原创失败
+	PublishStatusFailed         = 3 // 常规失败
+	PublishStatusAuditRejected  = 4 // 平台审核不通过
+	PublishStatusDeletedByUser  = 5 // 成功后用户删除所有文章
+	PublishStatusBannedBySystem = 6 // 成功后系统封禁所有文章
+)
+
+// 发布草稿箱里的图文, mediaId 为草稿的 media_id; 发布结果通过 PUBLISHJOBFINISH 事件推送, 或者调用 Get 查询.
+func (clt Client) Submit(mediaId string) (publishId, msgDataId string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		PublishId string `json:"publish_id"`
+		MsgDataId string `json:"msg_data_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/submit?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	publishId = result.PublishId
+	msgDataId = result.MsgDataId
+	return
+}
+
+// 发布的文章
+type PublishedArticle struct {
+	Index      int    `json:"idx"` // 文章在图文里的序号, 从 1 开始
+	ArticleURL string `json:"article_url"`
+}
+
+// 发布任务的状态
+type PublishStatus struct {
+	PublishId     string `json:"publish_id"`
+	PublishStatus int    `json:"publish_status"` // PublishStatusSuccess...
+	ArticleId     string `json:"article_id"`     // 发布成功时才有
+	ArticleDetail struct {
+		Count int                `json:"count"`
+		Items []PublishedArticle `json:"item"`
+	} `json:"article_detail"`
+	FailIndex []int `json:"fail_idx"` // 原创失败或者审核不通过的文章序号
+}
+
+// 是否已经结束(不再是发布中).
+func (status *PublishStatus) Finished() bool {
+	return status.PublishStatus != PublishStatusPublishing
+}
+
+// 发布成功的所有文章的 url.
+func (status *PublishStatus) ArticleURLs() []string {
+	urls := make([]string, 0, len(status.ArticleDetail.Items))
+	for _, item := range status.ArticleDetail.Items {
+		urls = append(urls, item.ArticleURL)
+	}
+	return urls
+}
+
+// 查询发布任务的状态.
+func (clt Client) Get(publishId string) (status *PublishStatus, err error) {
+	if publishId == "" {
+		err = errors.New("empty publishId")
+		return
+	}
+
+	var request = struct {
+		PublishId string `json:"publish_id"`
+	}{
+		PublishId: publishId,
+	}
+
+	var result struct {
+		mp.Error
+		PublishStatus
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	status = &result.PublishStatus
+	return
+}
+
+var ErrWaitForPublishTimeout = errors.New("wait for publish timeout")
+
+// 发布失败, Status 为最后一次查询到的状态
+type PublishError struct {
+	Status *PublishStatus
+}
+
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("publish %s failed, publish_status: %d, fail_idx: %v", e.Status.PublishId, e.Status.PublishStatus, e.Status.FailIndex)
+}
+
+const (
+	waitForPublishMinInterval = 2 * time.Second
+	waitForPublishMaxInterval = 30 * time.Second
+)
+
+// 轮询发布任务的状态, 直到发布成功, 失败或者超时.
+//  发布成功返回 status, status.ArticleURLs() 为文章的 url;
+//  发布失败返回 *PublishError, 超时返回 ErrWaitForPublishTimeout, 两种情况都同时返回最后一次查询到的 status.
+//  查询的间隔从 2 秒开始, 每次翻倍, 最长 30 秒.
+func (clt Client) WaitForPublish(publishId string, timeout time.Duration) (status *PublishStatus, err error) {
+	deadline := time.Now().Add(timeout)
+	interval := waitForPublishMinInterval
+	for {
+		if status, err = clt.Get(publishId); err != nil {
+			return
+		}
+		switch status.PublishStatus {
+		case PublishStatusSuccess:
+			return
+		case PublishStatusPublishing:
+		default:
+			err = &PublishError{Status: status}
+			return
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			err = ErrWaitForPublishTimeout
+			return
+		}
+		if interval > remaining {
+			interval = remaining
+		}
+		time.Sleep(interval)
+		if interval *= 2; interval > waitForPublishMaxInterval {
+			interval = waitForPublishMaxInterval
+		}
+	}
+}
@@ -12,7 +12,7 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/chanxuehong/wechat/util"
+	"github.com/chanxuehong/wechat/cipher"
 )
 
 // 回复消息给微信服务器(明文模式).
@@ -56,7 +56,7 @@ func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 		return
 	}
 
-	encryptedMsg := util.AESEncryptMsg(r.Random, rawMsgXML, r.AppId, r.AESKey)
+	encryptedMsg := cipher.Encrypt(r.Random, rawMsgXML, r.AppId, r.AESKey)
 	base64EncryptedMsg := base64.StdEncoding.EncodeToString(encryptedMsg)
 
 	responseHttpBody := ResponseHttpBody{
@@ -66,7 +66,19 @@ func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 	}
 
 	TimestampStr := strconv.FormatInt(responseHttpBody.Timestamp, 10)
-	responseHttpBody.MsgSignature = util.MsgSign(r.Token, TimestampStr, responseHttpBody.Nonce, responseHttpBody.EncryptedMsg)
+	responseHttpBody.MsgSignature = cipher.MsgSign(r.Token, TimestampStr, responseHttpBody.Nonce, responseHttpBody.EncryptedMsg)
 
 	return xml.NewEncoder(w).Encode(&responseHttpBody)
 }
+
+// 回复消息给微信服务器, 根据 Request.EncryptType 自动选择明文模式或者安全模式.
+//  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合消息的格式).
+func WriteResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
+	if r == nil {
+		return errors.New("nil Request")
+	}
+	if r.EncryptType == "aes" {
+		return WriteAESResponse(w, r, msg)
+	}
+	return WriteRawResponse(w, r, msg)
+}
@@ -0,0 +1,253 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 开票授权页的来源
+const (
+	SourceApp = "app" // app 拉起
+	SourceWeb = "web" // 微信 h5 页面
+	SourceWxa = "wxa" // 小程序
+	SourceWap = "wap" // 普通网页
+)
+
+// 授权类型
+const (
+	AuthTypeInvoice        = 0 // 开票授权
+	AuthTypeInvoiceByField = 1 // 填写字段开票授权
+	AuthTypeReceive        = 2 // 领票授权
+)
+
+type GetAuthURLRequest struct {
+	SPAppId     string `json:"s_pappid"`               // 必须; 开票平台在微信的标识号, 见 GetInvoiceURL
+	OrderId     string `json:"order_id"`               // 必须; 订单id, 在商户内单笔开票请求的唯一识别号
+	Money       int64  `json:"money"`                  // 必须; 订单金额, 以分为单位
+	Timestamp   int64  `json:"timestamp"`              // 必须; 时间戳
+	Source      string `json:"source"`                 // 必须; SourceApp, SourceWeb...
+	RedirectURL string `json:"redirect_url,omitempty"` // 可选; 授权成功后跳转页面, source 为 SourceWxa 时无效
+	Ticket      string `json:"ticket"`                 // 必须; 类型为 wx_card 的 api_ticket
+	Type        int    `json:"type"`                   // 必须; AuthTypeInvoice, AuthTypeInvoiceByField, AuthTypeReceive
+}
+
+// 获取开票授权页链接.
+//  source 为 SourceWxa 时还会返回小程序的 appid, 用于跳转到开票授权小程序.
+func (clt Client) GetAuthURL(req *GetAuthURLRequest) (authURL, appId string, err error) {
+	if req == nil {
+		err = errors.New("nil GetAuthURLRequest")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		AuthURL string `json:"auth_url"`
+		AppId   string `json:"appid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/getauthurl?access_token="
+	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	authURL = result.AuthURL
+	appId = result.AppId
+	return
+}
+
+// 自定义字段
+type CustomField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// 用户填写的授权信息
+type UserAuthInfo struct {
+	// 个人抬头, 与 BizField 二选一
+	UserField *struct {
+		Title       string        `json:"title"`
+		Phone       string        `json:"phone"`
+		Email       string        `json:"email"`
+		CustomField []CustomField `json:"custom_field,omitempty"`
+	} `json:"user_field,omitempty"`
+
+	// 单位抬头
+	BizField *struct {
+		Title       string        `json:"title"`
+		TaxNo       string        `json:"tax_no"`    // 税号
+		Addr        string        `json:"addr"`      // 单位地址
+		Phone       string        `json:"phone"`     // 单位电话
+		BankType    string        `json:"bank_type"` // 开户银行
+		BankNo      string        `json:"bank_no"`   // 银行账号
+		CustomField []CustomField `json:"custom_field,omitempty"`
+	} `json:"biz_field,omitempty"`
+}
+
+// 授权状态
+const (
+	InvoiceStatusAuthSuccess = "auth success" // 授权成功
+	InvoiceStatusAuthFail    = "auth fail"    // 授权失败
+)
+
+// 授权完成情况
+type AuthData struct {
+	InvoiceStatus string       `json:"invoice_status"` // InvoiceStatusAuthSuccess, InvoiceStatusAuthFail...
+	AuthTime      int64        `json:"auth_time"`      // 授权时间, unixtime
+	UserAuthInfo  UserAuthInfo `json:"user_auth_info"`
+}
+
+// 查询授权完成状态.
+func (clt Client) GetAuthData(sPAppId, orderId string) (data *AuthData, err error) {
+	var request = struct {
+		SPAppId string `json:"s_pappid"`
+		OrderId string `json:"order_id"`
+	}{
+		SPAppId: sPAppId,
+		OrderId: orderId,
+	}
+
+	var result struct {
+		mp.Error
+		AuthData
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/getauthdata?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.AuthData
+	return
+}
+
+// 拒绝开票, 用户收到拒绝原因后可以重新提交.
+//  reason 为拒绝原因, jumpURL 为可选的跳转链接, 用户点击后进入商户的页面重新发起开票.
+func (clt Client) RejectInsert(sPAppId, orderId, reason, jumpURL string) (err error) {
+	if reason == "" {
+		err = errors.New("empty reason")
+		return
+	}
+
+	var request = struct {
+		SPAppId string `json:"s_pappid"`
+		OrderId string `json:"order_id"`
+		Reason  string `json:"reason"`
+		URL     string `json:"url,omitempty"`
+	}{
+		SPAppId: sPAppId,
+		OrderId: orderId,
+		Reason:  reason,
+		URL:     jumpURL,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/rejectinsert?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 商户联系方式
+type Contact struct {
+	TimeOut int    `json:"time_out"` // 开票超时时间, 单位为秒
+	Phone   string `json:"phone"`    // 联系电话
+}
+
+// 设置商户联系方式, 用于开票失败时用户联系商户.
+func (clt Client) SetContact(contact *Contact) (err error) {
+	if contact == nil {
+		err = errors.New("nil Contact")
+		return
+	}
+
+	var request = struct {
+		Contact *Contact `json:"contact"`
+	}{
+		Contact: contact,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/setbizattr?action=set_contact&access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询商户联系方式.
+func (clt Client) GetContact() (contact *Contact, err error) {
+	var request = struct{}{}
+
+	var result struct {
+		mp.Error
+		Contact Contact `json:"contact"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/setbizattr?action=get_contact&access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	contact = &result.Contact
+	return
+}
+
+// 获取开票平台的开票链接, 返回的 sPAppId 为链接里的 s_pappid 参数, 开票平台需要把它提供给商户.
+func (clt Client) GetInvoiceURL() (invoiceURL, sPAppId string, err error) {
+	var request = struct{}{}
+
+	var result struct {
+		mp.Error
+		InvoiceURL string `json:"invoice_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/seturl?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	invoiceURL = result.InvoiceURL
+
+	u, err := url.Parse(invoiceURL)
+	if err != nil {
+		return
+	}
+	sPAppId = u.Query().Get("s_pappid")
+	return
+}
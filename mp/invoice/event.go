@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	// 推送到公众号URL上的事件类型
+	EventTypeUserAuthorizeInvoice = "user_authorize_invoice" // 用户授权开票事件
+	EventTypeUpdateInvoiceStatus  = "update_invoice_status"  // 发票状态更新事件
+	EventTypeSubmitInvoiceTitle   = "submit_invoice_title"   // 用户提交发票抬头事件
+)
+
+// 用户完成开票授权后, 微信会把这个事件推送到商户填写的URL
+type UserAuthorizeInvoiceEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event          string `xml:"Event"          json:"Event"`          // 事件类型, user_authorize_invoice
+	SuccOrderId    string `xml:"SuccOrderId"    json:"SuccOrderId"`    // 授权成功的订单号, 与失败订单号两者必显示其一
+	FailOrderId    string `xml:"FailOrderId"    json:"FailOrderId"`    // 授权失败的订单号
+	AuthorizeAppId string `xml:"AuthorizeAppId" json:"AuthorizeAppId"` // 获取授权页链接的AppId
+	Source         string `xml:"Source"         json:"Source"`         // 授权来源, SourceApp, SourceWeb...
+}
+
+func GetUserAuthorizeInvoiceEvent(msg *mp.MixedMessage) *UserAuthorizeInvoiceEvent {
+	return &UserAuthorizeInvoiceEvent{
+		MessageHeader:  msg.MessageHeader,
+		Event:          msg.Event,
+		SuccOrderId:    msg.SuccOrderId,
+		FailOrderId:    msg.FailOrderId,
+		AuthorizeAppId: msg.AuthorizeAppId,
+		Source:         msg.Source,
+	}
+}
+
+// 发票的报销状态更新后, 微信会把这个事件推送到开票平台填写的URL
+type UpdateInvoiceStatusEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event  string `xml:"Event"  json:"Event"`  // 事件类型, update_invoice_status
+	Status string `xml:"Status" json:"Status"` // 发票报销状态, ReimburseStatusInit, ReimburseStatusLock...
+	CardId string `xml:"CardId" json:"CardId"` // 发票 card_id
+	Code   string `xml:"Code"   json:"Code"`   // 发票 code
+}
+
+func GetUpdateInvoiceStatusEvent(msg *mp.MixedMessage) *UpdateInvoiceStatusEvent {
+	return &UpdateInvoiceStatusEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		Status:        msg.Status,
+		CardId:        msg.CardId,
+		Code:          msg.Code,
+	}
+}
+
+// 抬头类型
+const (
+	TitleTypeUser = "0" // 个人
+	TitleTypeBiz  = "1" // 单位
+)
+
+// 用户通过扫描商户的抬头二维码提交抬头后, 微信会把这个事件推送到商户填写的URL
+type SubmitInvoiceTitleEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.MessageHeader
+
+	Event     string `xml:"Event"      json:"Event"`      // 事件类型, submit_invoice_title
+	Title     string `xml:"title"      json:"title"`      // 抬头
+	Phone     string `xml:"phone"      json:"phone"`      // 联系方式
+	TaxNo     string `xml:"tax_no"     json:"tax_no"`     // 税号
+	Addr      string `xml:"addr"       json:"addr"`       // 地址
+	BankType  string `xml:"bank_type"  json:"bank_type"`  // 银行类型
+	BankNo    string `xml:"bank_no"    json:"bank_no"`    // 银行号码
+	Attach    string `xml:"attach"     json:"attach"`     // 附加字段
+	TitleType string `xml:"title_type" json:"title_type"` // TitleTypeUser, TitleTypeBiz
+}
+
+func GetSubmitInvoiceTitleEvent(msg *mp.MixedMessage) *SubmitInvoiceTitleEvent {
+	return &SubmitInvoiceTitleEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		Title:         msg.InvoiceTitle,
+		Phone:         msg.InvoicePhone,
+		TaxNo:         msg.InvoiceTaxNo,
+		Addr:          msg.InvoiceAddr,
+		BankType:      msg.InvoiceBankType,
+		BankNo:        msg.InvoiceBankNo,
+		Attach:        msg.InvoiceAttach,
+		TitleType:     msg.InvoiceTitleType,
+	}
+}
@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 发票的商品信息
+type InvoiceItem struct {
+	Name  string `json:"name"`           // 项目的名称
+	Num   int    `json:"num,omitempty"`  // 项目的数量
+	Unit  string `json:"unit,omitempty"` // 项目的单位, 如个
+	Price int64  `json:"price"`          // 项目的单价, 以分为单位
+}
+
+// 发票的具体内容
+type InvoiceUserData struct {
+	Fee                   int64         `json:"fee"`                           // 发票的金额, 以分为单位
+	Title                 string        `json:"title"`                         // 发票的抬头
+	BillingTime           int64         `json:"billing_time"`                  // 发票的开票时间, unixtime
+	BillingNo             string        `json:"billing_no"`                    // 发票的发票代码
+	BillingCode           string        `json:"billing_code"`                  // 发票的发票号码
+	Info                  []InvoiceItem `json:"info,omitempty"`                // 商品信息
+	FeeWithoutTax         int64         `json:"fee_without_tax"`               // 不含税金额, 以分为单位
+	Tax                   int64         `json:"tax"`                           // 税额, 以分为单位
+	SPDFMediaId           string        `json:"s_pdf_media_id"`                // 发票PDF文件的 s_media_id, 见 SetPDF
+	STripPDFMediaId       string        `json:"s_trip_pdf_media_id,omitempty"` // 其它消费凭证附件的 s_media_id
+	CheckCode             string        `json:"check_code"`                    // 校验码
+	BuyerNumber           string        `json:"buyer_number,omitempty"`        // 购买方纳税人识别号
+	BuyerAddressAndPhone  string        `json:"buyer_address_and_phone,omitempty"`
+	BuyerBankAccount      string        `json:"buyer_bank_account,omitempty"`
+	SellerNumber          string        `json:"seller_number,omitempty"` // 销售方纳税人识别号
+	SellerAddressAndPhone string        `json:"seller_address_and_phone,omitempty"`
+	SellerBankAccount     string        `json:"seller_bank_account,omitempty"`
+	Remarks               string        `json:"remarks,omitempty"` // 备注
+	Cashier               string        `json:"cashier,omitempty"` // 收款人
+	Maker                 string        `json:"maker,omitempty"`   // 开票人
+}
+
+type InsertRequest struct {
+	OrderId string `json:"order_id"` // 必须; 发票 order_id, 即 GetAuthURL 的 order_id
+	CardId  string `json:"card_id"`  // 必须; 发票的卡券模板 card_id
+	AppId   string `json:"appid"`    // 必须; 商户的公众号 appid
+	CardExt struct {
+		NonceStr string `json:"nonce_str"` // 必须; 随机字符串, 防止重复
+		UserCard struct {
+			InvoiceUserData InvoiceUserData `json:"invoice_user_data"`
+		} `json:"user_card"`
+	} `json:"card_ext"`
+}
+
+// 将电子发票卡券插入用户卡包, 返回发票的 code 和用户的 openid, unionid.
+func (clt Client) Insert(req *InsertRequest) (code, openId, unionId string, err error) {
+	if req == nil {
+		err = errors.New("nil InsertRequest")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Code    string `json:"code"`
+		OpenId  string `json:"openid"`
+		UnionId string `json:"unionid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/insert?access_token="
+	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	code = result.Code
+	openId = result.OpenId
+	unionId = result.UnionId
+	return
+}
+
+// 发票的报销状态
+const (
+	ReimburseStatusInit    = "INVOICE_REIMBURSE_INIT"    // 发票初始状态, 未锁定
+	ReimburseStatusLock    = "INVOICE_REIMBURSE_LOCK"    // 发票已锁定, 报销中
+	ReimburseStatusClosure = "INVOICE_REIMBURSE_CLOSURE" // 发票已核销, 已报销
+)
+
+// 更新发票卡券的报销状态.
+func (clt Client) UpdateStatus(cardId, code, reimburseStatus string) (err error) {
+	var request = struct {
+		CardId          string `json:"card_id"`
+		Code            string `json:"code"`
+		ReimburseStatus string `json:"reimburse_status"`
+	}{
+		CardId:          cardId,
+		Code:            code,
+		ReimburseStatus: reimburseStatus,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/platform/updatestatus?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,92 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 上传发票PDF文件, 返回的 sMediaId 用于 Insert 关联发票PDF.
+func (clt Client) SetPDF(_filepath string) (sMediaId string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.SetPDFFromReader(filepath.Base(_filepath), file)
+}
+
+// 上传发票PDF文件, 返回的 sMediaId 用于 Insert 关联发票PDF.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt Client) SetPDFFromReader(filename string, reader io.Reader) (sMediaId string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		SMediaId string `json:"s_media_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/platform/setpdf?access_token="
+	fields := []mp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "pdf",
+		FileName:    filename,
+		Value:       reader,
+	}}
+	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	sMediaId = result.SMediaId
+	return
+}
+
+// 查询已上传的发票PDF文件, 返回PDF的下载链接和链接的过期时间(unixtime).
+func (clt Client) GetPDF(sMediaId string) (pdfURL string, pdfURLExpireTime int64, err error) {
+	var request = struct {
+		Action   string `json:"action"`
+		SMediaId string `json:"s_media_id"`
+	}{
+		Action:   "get_url",
+		SMediaId: sMediaId,
+	}
+
+	var result struct {
+		mp.Error
+		PDFURL           string `json:"pdf_url"`
+		PDFURLExpireTime int64  `json:"pdf_url_expire_time"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/invoice/platform/getpdf?action=get_url&access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pdfURL = result.PDFURL
+	pdfURLExpireTime = result.PDFURLExpireTime
+	return
+}
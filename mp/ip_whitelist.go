@@ -0,0 +1,260 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ErrCodeInvalidIP = 40164 // 调用接口的 IP 地址不在白名单中
+
+// 调用接口的 IP 不在公众号的 IP 白名单中(errcode 40164).
+type NotWhitelistedIPError struct {
+	IP  string // 从 errmsg 中解析出来的 IP, 解析失败时为空
+	Err *Error
+}
+
+func (e *NotWhitelistedIPError) Error() string {
+	return fmt.Sprintf("ip %s not in whitelist, %s", e.IP, e.Err.Error())
+}
+
+func (e *NotWhitelistedIPError) Unwrap() error {
+	return e.Err
+}
+
+// 比如 "invalid ip 1.2.3.4 ipv6 ::ffff:1.2.3.4, not in whitelist rid: 5f1b9f0a-1a2b3c4d-5e6f7a8b"
+var invalidIPRegexp = regexp.MustCompile(`invalid ip ([0-9a-fA-F.:]+)`)
+
+// 如果 err 是(或者包装了) errcode 为 ErrCodeInvalidIP 的 *Error, 返回对应的 *NotWhitelistedIPError.
+func AsNotWhitelistedIPError(err error) (*NotWhitelistedIPError, bool) {
+	var ipErr *NotWhitelistedIPError
+	if errors.As(err, &ipErr) {
+		return ipErr, true
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.ErrCode != ErrCodeInvalidIP {
+		return nil, false
+	}
+	return newNotWhitelistedIPError(e), true
+}
+
+func newNotWhitelistedIPError(e *Error) *NotWhitelistedIPError {
+	ipErr := &NotWhitelistedIPError{Err: e}
+	if m := invalidIPRegexp.FindStringSubmatch(e.ErrMsg); m != nil {
+		ipErr.IP = strings.TrimRight(m[1], ".:")
+	}
+	return ipErr
+}
+
+// ==============================================================================
+
+// 获取服务器的出口 IP.
+type EgressIPResolver interface {
+	EgressIP() (ip string, err error)
+}
+
+type EgressIPResolverFunc func() (string, error)
+
+func (fn EgressIPResolverFunc) EgressIP() (string, error) {
+	return fn()
+}
+
+// 通过 http GET 一个返回纯文本 IP 的服务(比如 https://api.ipify.org)来获取出口 IP.
+type HTTPEgressIPResolver struct {
+	URL        string
+	HttpClient *http.Client // 如果 HttpClient == nil 则默认用 http.DefaultClient
+}
+
+func (r *HTTPEgressIPResolver) EgressIP() (ip string, err error) {
+	httpClient := r.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Get(r.URL)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, 256))
+	if err != nil {
+		return
+	}
+	ip = strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		err = fmt.Errorf("invalid ip %q", ip)
+		ip = ""
+		return
+	}
+	return
+}
+
+// ==============================================================================
+
+// IPWhitelistWatcher 报告的 IP 白名单漂移.
+type IPWhitelistDrift struct {
+	Time     time.Time
+	Endpoint string // 出错的接口, 比如 "/cgi-bin/message/custom/send"; 出口 IP 变化时为空
+
+	// 出错时为微信服务器返回的错误; 出口 IP 变化时为 nil
+	Err *NotWhitelistedIPError
+
+	EgressIP         string // 当前的出口 IP, Resolver 为 nil 或者还没有获取成功时为空
+	PreviousEgressIP string // 出口 IP 变化时为变化前的 IP
+}
+
+// IP 白名单漂移检测, 设置到 Client.IPWhitelistWatcher 后 Client 会把 errcode 40164 报告给它,
+// Start 以后还会定时通过 Resolver 获取出口 IP, 出口 IP 变化时也会报告.
+//  NOTE: OnDrift 可能被并发调用.
+type IPWhitelistWatcher struct {
+	Resolver EgressIPResolver        // 可以为 nil, 表示不检查出口 IP
+	Interval time.Duration           // 检查出口 IP 的间隔, <= 0 时为 5 分钟
+	OnDrift  func(*IPWhitelistDrift) // 不能为 nil
+
+	mu        sync.Mutex
+	egressIP  string
+	stopChan  chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// 创建一个 IPWhitelistWatcher, resolver 可以为 nil.
+func NewIPWhitelistWatcher(resolver EgressIPResolver, interval time.Duration, onDrift func(*IPWhitelistDrift)) *IPWhitelistWatcher {
+	if onDrift == nil {
+		panic("nil onDrift")
+	}
+	return &IPWhitelistWatcher{
+		Resolver: resolver,
+		Interval: interval,
+		OnDrift:  onDrift,
+	}
+}
+
+// 当前的出口 IP.
+func (w *IPWhitelistWatcher) EgressIP() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.egressIP
+}
+
+// 开始定时检查出口 IP, Resolver 为 nil 时什么也不做.
+func (w *IPWhitelistWatcher) Start() {
+	if w.Resolver == nil {
+		return
+	}
+	w.startOnce.Do(func() {
+		w.mu.Lock()
+		w.stopChan = make(chan struct{})
+		w.mu.Unlock()
+		w.Check()
+		go w.checkDaemon()
+	})
+}
+
+// 停止定时检查出口 IP.
+func (w *IPWhitelistWatcher) Stop() {
+	w.mu.Lock()
+	stopChan := w.stopChan
+	w.mu.Unlock()
+	if stopChan == nil {
+		return
+	}
+	w.stopOnce.Do(func() {
+		close(stopChan)
+	})
+}
+
+func (w *IPWhitelistWatcher) checkDaemon() {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.Check()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// 获取一次出口 IP, 出口 IP 变化时报告.
+func (w *IPWhitelistWatcher) Check() (err error) {
+	if w.Resolver == nil {
+		return
+	}
+	ip, err := w.Resolver.EgressIP()
+	if err != nil {
+		LogInfoln("[WECHAT_IP_WHITELIST] resolve egress ip failed:", err)
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.egressIP
+	w.egressIP = ip
+	w.mu.Unlock()
+
+	if previous != "" && previous != ip {
+		w.OnDrift(&IPWhitelistDrift{
+			Time:             time.Now(),
+			EgressIP:         ip,
+			PreviousEgressIP: previous,
+		})
+	}
+	return
+}
+
+// 报告一个错误, 如果 err 不是 IP 白名单错误则忽略.
+//  一般不用调用这个方法, 设置了 Client.IPWhitelistWatcher 后 Client 会自动报告.
+func (w *IPWhitelistWatcher) Observe(endpoint string, err error) {
+	ipErr, ok := AsNotWhitelistedIPError(err)
+	if !ok {
+		return
+	}
+	w.OnDrift(&IPWhitelistDrift{
+		Time:     time.Now(),
+		Endpoint: endpoint,
+		Err:      ipErr,
+		EgressIP: w.EgressIP(),
+	})
+}
+
+// 获取 response 里的 errmsg, response 的格式要求同 PostJSON.
+func errMsgOf(response interface{}) string {
+	v := reflect.ValueOf(response)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ""
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct || v.NumField() == 0 {
+		return ""
+	}
+	if f := v.Field(0); f.Kind() == reflect.Struct {
+		v = f
+	}
+	if v.NumField() < 2 || v.Field(1).Kind() != reflect.String {
+		return ""
+	}
+	return v.Field(1).String()
+}
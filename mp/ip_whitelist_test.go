@@ -0,0 +1,56 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+const invalidIPErrMsg = "invalid ip 1.2.3.4 ipv6 ::ffff:1.2.3.4, not in whitelist rid: 5f1b9f0a-1a2b3c4d-5e6f7a8b"
+
+func TestIPWhitelistWatcherObserve(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokenErr error // AccessTokenServer 返回的错误
+		errCode  int64 // 接口返回的 errcode
+		want     bool
+	}{
+		{"ok", nil, mp.ErrCodeOK, false},
+		{"other errcode", nil, mp.ErrCodeSystemBusy, false},
+		{"api 40164", nil, mp.ErrCodeInvalidIP, true},
+		{"access_token 40164", &mp.Error{ErrCode: mp.ErrCodeInvalidIP, ErrMsg: invalidIPErrMsg}, mp.ErrCodeOK, true},
+		{"access_token other error", &mp.Error{ErrCode: mp.ErrCodeSystemBusy, ErrMsg: "system error"}, mp.ErrCodeOK, false},
+	}
+	for _, tt := range tests {
+		srv := wechattest.NewServer()
+		srv.HandleError("/cgi-bin/test", tt.errCode, invalidIPErrMsg)
+
+		tokenServer := wechattest.NewAccessTokenServer("token")
+		tokenServer.SetError(tt.tokenErr)
+		var drifts []*mp.IPWhitelistDrift
+		clt := mp.NewClient(tokenServer, srv.Client())
+		clt.IPWhitelistWatcher = mp.NewIPWhitelistWatcher(nil, 0, func(drift *mp.IPWhitelistDrift) {
+			drifts = append(drifts, drift)
+		})
+
+		var result mp.Error
+		clt.GetJSON(testIncompleteURL, &result)
+		srv.Close()
+
+		if have := len(drifts) == 1; have != tt.want {
+			t.Errorf("%s: have %d drifts, want reported %v", tt.name, len(drifts), tt.want)
+			continue
+		}
+		if tt.want {
+			if drift := drifts[0]; drift.Endpoint != "/cgi-bin/test" || drift.Err == nil || drift.Err.IP != "1.2.3.4" {
+				t.Errorf("%s: unexpected drift %+v", tt.name, drift)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"errors"
+)
+
+// wx.config 需要的参数, 可以直接 json 序列化后给前端使用.
+type WxConfig struct {
+	AppId     string `json:"appId"`
+	Timestamp string `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// ConfigSigner 通过 TicketServer 获取 jsapi_ticket, 生成 wx.config 需要的参数.
+type ConfigSigner struct {
+	appId        string
+	ticketServer TicketServer
+	signCache    *SignCache
+}
+
+// 创建 ConfigSigner.
+//  appId:        公众号的 appid
+//  ticketServer: 一般是 DefaultTicketServer, 分布式环境下可以是自己实现的中控服务器
+func NewConfigSigner(appId string, ticketServer TicketServer) *ConfigSigner {
+	if appId == "" {
+		panic("empty appId")
+	}
+	if ticketServer == nil {
+		panic("nil TicketServer")
+	}
+	return &ConfigSigner{
+		appId:        appId,
+		ticketServer: ticketServer,
+		signCache:    NewSignCache(0),
+	}
+}
+
+// 获取 url 对应页面的 wx.config 参数.
+//  url 为调用 wx.config 的页面的完整地址(不包括 '#' 及其后面部分), 会先经过 NormalizeURL 规范化.
+func (signer *ConfigSigner) WxConfig(url string) (config *WxConfig, err error) {
+	ticket, err := signer.ticketServer.Ticket()
+	if err != nil {
+		return
+	}
+	return signer.wxConfig(ticket, url)
+}
+
+// 前端 wx.config 提示签名错误(jsapi_ticket 已经失效)时, 刷新 jsapi_ticket 后重新获取 wx.config 参数.
+func (signer *ConfigSigner) WxConfigRefresh(url string) (config *WxConfig, err error) {
+	ticket, err := signer.ticketServer.TicketRefresh()
+	if err != nil {
+		return
+	}
+	return signer.wxConfig(ticket, url)
+}
+
+func (signer *ConfigSigner) wxConfig(ticket, url string) (config *WxConfig, err error) {
+	if ticket == "" {
+		err = errors.New("empty jsapi_ticket")
+		return
+	}
+	sig, err := signer.signCache.Sign(ticket, url)
+	if err != nil {
+		return
+	}
+	config = &WxConfig{
+		AppId:     signer.appId,
+		Timestamp: sig.Timestamp,
+		NonceStr:  sig.NonceStr,
+		Signature: sig.Signature,
+	}
+	return
+}
@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 规范化参与 wx.config 签名的 url, 和前端 location.href.split('#')[0] 的结果保持一致:
+//  1. 必须是 http 或 https 的绝对地址;
+//  2. 去掉 '#' 及其后面的部分;
+//  3. scheme 和 host 转为小写, 去掉默认端口(http:80, https:443);
+//  4. path 和 query 保持原样, 不做任何解码或编码(浏览器也不会).
+func NormalizeURL(rawURL string) (normalized string, err error) {
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		err = errors.New("url must be an absolute http or https url: " + rawURL)
+		return
+	}
+	if u.Host == "" {
+		err = errors.New("url must have a host: " + rawURL)
+		return
+	}
+	if u.User != nil {
+		err = errors.New("url must not contain userinfo: " + rawURL)
+		return
+	}
+
+	host := strings.ToLower(u.Host)
+	switch {
+	case scheme == "http" && strings.HasSuffix(host, ":80"):
+		host = host[:len(host)-len(":80")]
+	case scheme == "https" && strings.HasSuffix(host, ":443"):
+		host = host[:len(host)-len(":443")]
+	}
+
+	// 保留原始的 path 和 query
+	rest := rawURL[strings.Index(rawURL, "//")+2:]
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		rest = rest[i:]
+	} else {
+		rest = ""
+	}
+	if rest == "" || rest[0] == '?' {
+		rest = "/" + rest
+	}
+	normalized = scheme + "://" + host + rest
+	return
+}
+
+// 根据 http 请求构造当前页面的 url, 支持反向代理设置的 X-Forwarded-Proto 和 X-Forwarded-Host.
+//  NOTE: 只有在签名接口和页面是同一个请求时才适用, SPA 应该由前端传入 location.href.split('#')[0].
+func PageURL(r *http.Request) (string, error) {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.SplitN(proto, ",", 2)[0])
+	}
+	host := r.Host
+	if fwdHost := r.Header.Get("X-Forwarded-Host"); fwdHost != "" {
+		host = strings.TrimSpace(strings.SplitN(fwdHost, ",", 2)[0])
+	}
+	return NormalizeURL(scheme + "://" + host + r.RequestURI)
+}
+
+// wx.config 需要的签名参数
+type Signature struct {
+	NonceStr  string `json:"nonceStr"`
+	Timestamp string `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+// 以规范化后的 url 为 key 缓存签名结果, jsapi_ticket 变化时缓存自动失效.
+type SignCache struct {
+	maxEntries int
+
+	mutex   sync.Mutex
+	ticket  string
+	entries map[string]*Signature
+}
+
+// 创建 SignCache, maxEntries 为缓存的最大条目数, 超过时清空缓存.
+func NewSignCache(maxEntries int) *SignCache {
+	if maxEntries <= 0 {
+		maxEntries = 1024
+	}
+	return &SignCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*Signature),
+	}
+}
+
+// 规范化 rawURL 并返回对应的签名.
+func (cache *SignCache) Sign(jsapiTicket, rawURL string) (sig *Signature, err error) {
+	if jsapiTicket == "" {
+		err = errors.New("empty jsapiTicket")
+		return
+	}
+	pageURL, err := NormalizeURL(rawURL)
+	if err != nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.ticket != jsapiTicket {
+		cache.ticket = jsapiTicket
+		cache.entries = make(map[string]*Signature)
+	}
+	if sig = cache.entries[pageURL]; sig != nil {
+		return
+	}
+
+	nonceStr, err := newNonceStr()
+	if err != nil {
+		return
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	sig = &Signature{
+		NonceStr:  nonceStr,
+		Timestamp: timestamp,
+		Signature: WXConfigSign(jsapiTicket, nonceStr, timestamp, pageURL),
+	}
+
+	if len(cache.entries) >= cache.maxEntries {
+		cache.entries = make(map[string]*Signature)
+	}
+	cache.entries[pageURL] = sig
+	return
+}
+
+func newNonceStr() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,122 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp/media"
+)
+
+// 上传图文消息内的图片, 返回的 url 可以用在图文素材的 content 里.
+//  NOTE: 图文消息 content 里的外部图片会被过滤, 必须先通过这个接口上传.
+func (clt Client) UploadArticleImage(filepath string) (imageURL string, err error) {
+	info, err := media.Client{Client: clt.Client}.UploadImagePermanent(filepath)
+	if err != nil {
+		return
+	}
+	imageURL = info.URL
+	return
+}
+
+// 上传图文消息内的图片, 返回的 url 可以用在图文素材的 content 里.
+func (clt Client) UploadArticleImageFromReader(filename string, reader io.Reader) (imageURL string, err error) {
+	info, err := media.Client{Client: clt.Client}.UploadImagePermanentFromReader(filename, reader)
+	if err != nil {
+		return
+	}
+	imageURL = info.URL
+	return
+}
+
+var imgSrcRegexp = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*)("[^"]*"|'[^']*')`)
+
+// 替换 content 里所有 <img> 的 src, rewrite 返回新的 src; 同一个 src 只调用一次 rewrite.
+func RewriteImageURLs(content string, rewrite func(src string) (newSrc string, err error)) (newContent string, err error) {
+	rewritten := make(map[string]string)
+	newContent = imgSrcRegexp.ReplaceAllStringFunc(content, func(match string) string {
+		if err != nil {
+			return match
+		}
+		sub := imgSrcRegexp.FindStringSubmatch(match)
+		quote, src := sub[2][:1], sub[2][1:len(sub[2])-1]
+		newSrc, ok := rewritten[src]
+		if !ok {
+			if newSrc, err = rewrite(src); err != nil {
+				err = fmt.Errorf("rewrite image %q: %v", src, err)
+				return match
+			}
+			rewritten[src] = newSrc
+		}
+		return sub[1] + quote + newSrc + quote
+	})
+	if err != nil {
+		newContent = ""
+	}
+	return
+}
+
+// 是否是微信服务器上的图片, 这些图片不需要重新上传.
+func IsWechatImageURL(src string) bool {
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "mmbiz.qpic.cn" || host == "mmbiz.qlogo.cn" || strings.HasSuffix(host, ".qpic.cn")
+}
+
+// 把 content 里不在微信服务器上的图片上传到微信服务器, 并替换成上传后的 url.
+//  open 打开 src 对应的图片, 如果 open == nil 则用 clt.HttpClient 下载 http(s) 图片.
+func (clt Client) UploadContentImages(content string, open func(src string) (io.ReadCloser, error)) (newContent string, err error) {
+	if open == nil {
+		open = clt.downloadImage
+	}
+	return RewriteImageURLs(content, func(src string) (string, error) {
+		if IsWechatImageURL(src) {
+			return src, nil
+		}
+		reader, err := open(src)
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+		return clt.UploadArticleImageFromReader(imageFilename(src), reader)
+	})
+}
+
+func (clt Client) downloadImage(src string) (io.ReadCloser, error) {
+	httpClient := clt.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		return nil, fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+	return httpResp.Body, nil
+}
+
+// uploadimg 只支持 jpg/png, 根据 src 的扩展名生成文件名.
+func imageFilename(src string) string {
+	ext := ".jpg"
+	if u, err := url.Parse(src); err == nil {
+		if e := strings.ToLower(path.Ext(u.Path)); e == ".png" || e == ".jpeg" {
+			ext = e
+		}
+	}
+	return "image" + ext
+}
@@ -16,6 +16,7 @@ import (
 	"os"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 下载多媒体到文件.
@@ -42,6 +43,21 @@ func (clt Client) DownloadMaterialToWriter(mediaId string, writer io.Writer) err
 	return clt.downloadMaterialToWriter(mediaId, writer)
 }
 
+// 打开素材的文件流, 可以获取 Content-Type 和 Content-Length, 数据不会整个读入内存, 调用者负责关闭.
+//  NOTE: 图文素材和视频素材返回的是 json, 这时返回 *mp.UnexpectedJSONError, Body 为完整的 json.
+func (clt Client) OpenMaterial(mediaId string) (stream *util.DownloadStream, err error) {
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+	requestBody, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+	return clt.OpenDownload(nil, "POST", "/cgi-bin/material/get_material", bytes.NewReader(requestBody), "application/json; charset=utf-8")
+}
+
 var (
 	errRespBeginCode = []byte(`{"errcode":`)
 	errRespBeginMsg  = []byte(`{"errmsg":"`)
@@ -69,7 +85,7 @@ func (clt Client) downloadMaterialToWriter(mediaId string, writer io.Writer) (er
 RETRY:
 	finalURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	httpResp, err := clt.HttpPost(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
 	if err != nil {
 		return
 	}
@@ -114,7 +130,7 @@ RETRY:
 		return // 基本不会出现
 	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
-		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -122,12 +138,12 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			mp.LogInfoln("[WECHAT_RETRY] new token:", util.MaskToken(token))
 
 			result = mp.Error{}
 			goto RETRY
 		}
-		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
 		err = &result
@@ -107,7 +107,13 @@ func (clt Client) uploadMaterialFromReader(materialType, filename string, reader
 		FileName:    filename,
 		Value:       reader,
 	}}
-	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+	// 文件(io.Seeker)边读边上传, 避免大文件占用内存
+	if _, ok := reader.(io.Seeker); ok {
+		err = clt.PostMultipartFormStream(incompleteURL, fields, &result)
+	} else {
+		err = clt.PostMultipartForm(incompleteURL, fields, &result)
+	}
+	if err != nil {
 		return
 	}
 
@@ -181,7 +187,8 @@ func (clt Client) uploadVideoFromReader(filename string, reader io.Reader,
 			Value:       bytes.NewReader(descBytes),
 		},
 	}
-	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+	// 视频一般比较大, 边读边上传, 避免占用内存
+	if err = clt.PostMultipartFormStream(incompleteURL, fields, &result); err != nil {
 		return
 	}
 
@@ -0,0 +1,162 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"fmt"
+)
+
+const (
+	MaterialPageSizeLimit = 20 // 获取素材列表, 每次最多返回 20 个
+)
+
+// 素材遍历器(图片, 视频, 语音, 缩略图)
+//
+//  iter, err := Client.MaterialIterator(material.MaterialTypeImage, 0, 20)
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//
+//  for iter.HasNext() {
+//      items, err := iter.NextPage()
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+type MaterialIterator struct {
+	clt          Client
+	materialType string
+	pageSize     int
+
+	nextOffset int            // 下一页的 offset
+	totalCount int            // 最近一次获取的素材总数
+	lastItems  []MaterialInfo // 创建的时候获取的第一页
+
+	nextPageCalled bool // NextPage() 是否调用过
+}
+
+func (iter *MaterialIterator) Total() int {
+	return iter.totalCount
+}
+
+func (iter *MaterialIterator) HasNext() bool {
+	if !iter.nextPageCalled { // 还没有调用 NextPage(), 从创建的时候获取的数据来判断
+		return len(iter.lastItems) > 0
+	}
+	return iter.nextOffset < iter.totalCount
+}
+
+func (iter *MaterialIterator) NextPage() (items []MaterialInfo, err error) {
+	if !iter.nextPageCalled { // 还没有调用 NextPage(), 从创建的时候获取的数据中获取
+		items = iter.lastItems
+		iter.lastItems = nil
+		iter.nextPageCalled = true
+		return
+	}
+
+	totalCount, itemCount, items, err := iter.clt.BatchGetMaterial(iter.materialType, iter.nextOffset, iter.pageSize)
+	if err != nil {
+		return
+	}
+	iter.totalCount = totalCount
+	iter.nextOffset = nextOffset(iter.nextOffset, itemCount, totalCount)
+	return
+}
+
+// 获取素材遍历器, materialType 可以是 MaterialTypeImage, MaterialTypeVideo, MaterialTypeVoice, MaterialTypeThumb;
+// offset 表示从该偏移位置开始遍历, pageSize 取值在 1 到 MaterialPageSizeLimit 之间.
+func (clt Client) MaterialIterator(materialType string, offset, pageSize int) (iter *MaterialIterator, err error) {
+	if pageSize <= 0 || pageSize > MaterialPageSizeLimit {
+		err = fmt.Errorf("pageSize must be in [1, %d]", MaterialPageSizeLimit)
+		return
+	}
+
+	totalCount, itemCount, items, err := clt.BatchGetMaterial(materialType, offset, pageSize)
+	if err != nil {
+		return
+	}
+
+	iter = &MaterialIterator{
+		clt:          clt,
+		materialType: materialType,
+		pageSize:     pageSize,
+		nextOffset:   nextOffset(offset, itemCount, totalCount),
+		totalCount:   totalCount,
+		lastItems:    items,
+	}
+	return
+}
+
+// 图文素材遍历器, 用法同 MaterialIterator.
+type NewsIterator struct {
+	clt      Client
+	pageSize int
+
+	nextOffset int
+	totalCount int
+	lastItems  []NewsInfo
+
+	nextPageCalled bool
+}
+
+func (iter *NewsIterator) Total() int {
+	return iter.totalCount
+}
+
+func (iter *NewsIterator) HasNext() bool {
+	if !iter.nextPageCalled {
+		return len(iter.lastItems) > 0
+	}
+	return iter.nextOffset < iter.totalCount
+}
+
+func (iter *NewsIterator) NextPage() (items []NewsInfo, err error) {
+	if !iter.nextPageCalled {
+		items = iter.lastItems
+		iter.lastItems = nil
+		iter.nextPageCalled = true
+		return
+	}
+
+	totalCount, itemCount, items, err := iter.clt.BatchGetNews(iter.nextOffset, iter.pageSize)
+	if err != nil {
+		return
+	}
+	iter.totalCount = totalCount
+	iter.nextOffset = nextOffset(iter.nextOffset, itemCount, totalCount)
+	return
+}
+
+// 获取图文素材遍历器, offset 表示从该偏移位置开始遍历, pageSize 取值在 1 到 MaterialPageSizeLimit 之间.
+func (clt Client) NewsIterator(offset, pageSize int) (iter *NewsIterator, err error) {
+	if pageSize <= 0 || pageSize > MaterialPageSizeLimit {
+		err = fmt.Errorf("pageSize must be in [1, %d]", MaterialPageSizeLimit)
+		return
+	}
+
+	totalCount, itemCount, items, err := clt.BatchGetNews(offset, pageSize)
+	if err != nil {
+		return
+	}
+
+	iter = &NewsIterator{
+		clt:        clt,
+		pageSize:   pageSize,
+		nextOffset: nextOffset(offset, itemCount, totalCount),
+		totalCount: totalCount,
+		lastItems:  items,
+	}
+	return
+}
+
+// 计算下一页的 offset, 本次没有返回素材时认为已经遍历完了(防止死循环).
+func nextOffset(offset, itemCount, totalCount int) int {
+	if itemCount <= 0 {
+		return totalCount
+	}
+	return offset + itemCount
+}
@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"context"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 逐个返回素材的遍历器(图片, 视频, 语音, 缩略图), 需要时自动拉取下一页, 用法见 util.Iterator.
+type MaterialPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个素材, 遍历结束时返回 util.Done.
+func (pager *MaterialPager) Next(ctx context.Context) (item *MaterialInfo, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*MaterialInfo), nil
+}
+
+// 获取素材的 MaterialPager, materialType 可以是 MaterialTypeImage, MaterialTypeVideo, MaterialTypeVoice, MaterialTypeThumb;
+// offset 表示从该偏移位置开始遍历.
+//  NOTE: 第一次调用 Next 时才拉取数据, 每页拉取 MaterialPageSizeLimit 个.
+func (clt Client) MaterialPager(materialType string, offset int) *MaterialPager {
+	return &MaterialPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			totalCount, itemCount, list, err := Client{Client: clt.WithContext(ctx)}.BatchGetMaterial(materialType, offset, MaterialPageSizeLimit)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			offset = nextOffset(offset, itemCount, totalCount)
+			hasNext = offset < totalCount
+			return
+		}),
+	}
+}
+
+// 逐个返回图文素材的遍历器, 用法同 MaterialPager.
+type NewsPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个图文素材, 遍历结束时返回 util.Done.
+func (pager *NewsPager) Next(ctx context.Context) (item *NewsInfo, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*NewsInfo), nil
+}
+
+// 获取图文素材的 NewsPager, offset 表示从该偏移位置开始遍历.
+func (clt Client) NewsPager(offset int) *NewsPager {
+	return &NewsPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			totalCount, itemCount, list, err := Client{Client: clt.WithContext(ctx)}.BatchGetNews(offset, MaterialPageSizeLimit)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			offset = nextOffset(offset, itemCount, totalCount)
+			hasNext = offset < totalCount
+			return
+		}),
+	}
+}
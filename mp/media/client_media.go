@@ -16,10 +16,11 @@ import (
 	"os"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 下载多媒体到文件.
-//  请注意，视频文件不支持下载
+//  NOTE: 视频消息素材返回的是下载地址, 会自动从该地址下载; 数据直接写入文件, 不会整个读入内存
 func (clt Client) DownloadMedia(mediaId, filepath string) (err error) {
 	file, err := os.Create(filepath)
 	if err != nil {
@@ -36,7 +37,7 @@ func (clt Client) DownloadMedia(mediaId, filepath string) (err error) {
 }
 
 // 下载多媒体到 io.Writer.
-//  请注意，视频文件不支持下载
+//  NOTE: 视频消息素材返回的是下载地址, 会自动从该地址下载; 数据直接写入 writer, 不会整个读入内存
 func (clt Client) DownloadMediaToWriter(mediaId string, writer io.Writer) error {
 	if writer == nil {
 		return errors.New("nil writer")
@@ -44,6 +45,32 @@ func (clt Client) DownloadMediaToWriter(mediaId string, writer io.Writer) error
 	return clt.downloadMediaToWriter(mediaId, writer)
 }
 
+// 打开多媒体的文件流, 可以获取 Content-Type 和 Content-Length, 数据不会整个读入内存, 调用者负责关闭.
+//  NOTE: 视频消息素材返回的是下载地址, 会自动打开该地址.
+func (clt Client) OpenMedia(mediaId string) (stream *util.DownloadStream, err error) {
+	stream, err = clt.OpenDownload(nil, "GET", "/cgi-bin/media/get?media_id="+url.QueryEscape(mediaId), nil, "")
+	e, ok := err.(*mp.UnexpectedJSONError)
+	if !ok {
+		return
+	}
+
+	var result struct {
+		VideoURL string `json:"video_url"`
+	}
+	if err = json.Unmarshal(e.Body, &result); err != nil {
+		return
+	}
+	if result.VideoURL == "" {
+		err = e
+		return
+	}
+	httpResp, err := clt.HttpGet(result.VideoURL)
+	if err != nil {
+		return
+	}
+	return mp.OpenDownloadResponse(httpResp)
+}
+
 // 下载多媒体到 io.Writer.
 func (clt Client) downloadMediaToWriter(mediaId string, writer io.Writer) (err error) {
 	token, err := clt.Token()
@@ -56,7 +83,7 @@ RETRY:
 	finalURL := "https://api.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) +
 		"&access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.HttpGet(finalURL)
 	if err != nil {
 		return
 	}
@@ -72,18 +99,24 @@ RETRY:
 		return
 	}
 
-	// 返回的是错误信息
-	var result mp.Error
+	// 返回的是错误信息, 或者视频的下载地址
+	var result struct {
+		mp.Error
+		VideoURL string `json:"video_url"`
+	}
 	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
 		return
 	}
 
 	switch result.ErrCode {
 	case mp.ErrCodeOK:
+		if result.VideoURL != "" { // 视频消息素材返回的是下载地址
+			return clt.downloadURLToWriter(result.VideoURL, writer)
+		}
 		return // 基本不会出现
 	case mp.ErrCodeInvalidCredential, mp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
 		mp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
-		mp.LogInfoln("[WECHAT_RETRY] current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] current token:", util.MaskToken(token))
 
 		if !hasRetried {
 			hasRetried = true
@@ -91,19 +124,35 @@ RETRY:
 			if token, err = clt.TokenRefresh(); err != nil {
 				return
 			}
-			mp.LogInfoln("[WECHAT_RETRY] new token:", token)
+			mp.LogInfoln("[WECHAT_RETRY] new token:", util.MaskToken(token))
 
-			result = mp.Error{}
+			result.Error = mp.Error{}
+			result.VideoURL = ""
 			goto RETRY
 		}
-		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", token)
+		mp.LogInfoln("[WECHAT_RETRY] fallthrough, current token:", util.MaskToken(token))
 		fallthrough
 	default:
-		err = &result
+		err = &result.Error
 		return
 	}
 }
 
+// 下载视频的下载地址到 io.Writer
+func (clt Client) downloadURLToWriter(videoURL string, writer io.Writer) (err error) {
+	httpResp, err := clt.HttpGet(videoURL)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+	_, err = io.Copy(writer, httpResp.Body)
+	return
+}
+
 // 创建图文消息素材.
 func (clt Client) CreateNews(articles []Article) (info *MediaInfo, err error) {
 	if len(articles) == 0 {
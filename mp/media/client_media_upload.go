@@ -97,7 +97,13 @@ func (clt Client) uploadMediaFromReader(mediaType, filename string, reader io.Re
 		FileName:    filename,
 		Value:       reader,
 	}}
-	if err = clt.PostMultipartForm(incompleteURL, fields, &result); err != nil {
+	// 文件(io.Seeker)和视频边读边上传, 避免大文件占用内存
+	if _, ok := reader.(io.Seeker); ok || mediaType == MediaTypeVideo {
+		err = clt.PostMultipartFormStream(incompleteURL, fields, &result)
+	} else {
+		err = clt.PostMultipartForm(incompleteURL, fields, &result)
+	}
+	if err != nil {
 		return
 	}
 
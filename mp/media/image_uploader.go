@@ -0,0 +1,174 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 临时素材在微信服务器上保存3天, 缓存时提前一个小时过期, 避免使用的时候刚好失效.
+const TemporaryMediaCacheTTL = 3*24*time.Hour - time.Hour
+
+// ImageUploader 把外部图片(http/https 地址或者本地文件)上传到微信服务器, 并缓存上传的结果,
+// 同一个图片不会重复上传. 得到的 media_id 可以用于客服消息, 被动回复消息等;
+// 得到的 url 可以用于图文消息内容, 卡券, 模板卡片等.
+type ImageUploader struct {
+	clt Client
+
+	mutex    sync.Mutex
+	mediaIds map[string]imageUploaderEntry // 临时素材, 有过期时间
+	urls     map[string]string             // uploadimg 上传的图片, 永久有效
+}
+
+type imageUploaderEntry struct {
+	MediaId   string
+	ExpiresAt time.Time
+}
+
+func NewImageUploader(clt Client) *ImageUploader {
+	if clt.Client == nil {
+		panic("nil mp.Client")
+	}
+	return &ImageUploader{
+		clt:      clt,
+		mediaIds: make(map[string]imageUploaderEntry),
+		urls:     make(map[string]string),
+	}
+}
+
+// 获取图片 source 对应的临时素材 media_id, 没有缓存或者已经过期则上传.
+//  source 为 http/https 地址或者本地文件路径.
+func (uploader *ImageUploader) MediaId(source string) (mediaId string, err error) {
+	if source == "" {
+		err = errors.New("empty source")
+		return
+	}
+
+	uploader.mutex.Lock()
+	entry, ok := uploader.mediaIds[source]
+	uploader.mutex.Unlock()
+	if ok && time.Now().Before(entry.ExpiresAt) {
+		mediaId = entry.MediaId
+		return
+	}
+
+	var info *MediaInfo
+	err = uploader.open(source, func(filename string, reader io.Reader) (err error) {
+		info, err = uploader.clt.UploadImageFromReader(filename, reader)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	createdAt := time.Now()
+	if info.CreatedAt > 0 {
+		createdAt = time.Unix(info.CreatedAt, 0)
+	}
+	uploader.mutex.Lock()
+	uploader.mediaIds[source] = imageUploaderEntry{
+		MediaId:   info.MediaId,
+		ExpiresAt: createdAt.Add(TemporaryMediaCacheTTL),
+	}
+	uploader.mutex.Unlock()
+
+	mediaId = info.MediaId
+	return
+}
+
+// 获取图片 source 通过 uploadimg 接口上传后的 url, 没有缓存则上传.
+//  source 为 http/https 地址或者本地文件路径.
+func (uploader *ImageUploader) ImageURL(source string) (imageURL string, err error) {
+	if source == "" {
+		err = errors.New("empty source")
+		return
+	}
+
+	uploader.mutex.Lock()
+	imageURL = uploader.urls[source]
+	uploader.mutex.Unlock()
+	if imageURL != "" {
+		return
+	}
+
+	var info ImageInfo
+	err = uploader.open(source, func(filename string, reader io.Reader) (err error) {
+		info, err = uploader.clt.UploadImagePermanentFromReader(filename, reader)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	uploader.mutex.Lock()
+	uploader.urls[source] = info.URL
+	uploader.mutex.Unlock()
+
+	imageURL = info.URL
+	return
+}
+
+// 删除 source 的缓存, 比如图片内容有更新.
+func (uploader *ImageUploader) Forget(source string) {
+	uploader.mutex.Lock()
+	delete(uploader.mediaIds, source)
+	delete(uploader.urls, source)
+	uploader.mutex.Unlock()
+}
+
+// 打开 source 并调用 upload.
+func (uploader *ImageUploader) open(source string, upload func(filename string, reader io.Reader) error) (err error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		u, err := url.Parse(source)
+		if err != nil {
+			return err
+		}
+
+		httpClient := uploader.clt.HttpClient
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+		httpResp, err := httpClient.Get(source)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+
+		if httpResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("http.Status: %s", httpResp.Status)
+		}
+		return upload(imageFilename(path.Base(u.Path)), httpResp.Body)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return upload(imageFilename(filepath.Base(source)), file)
+}
+
+// 微信服务器根据文件名的后缀判断图片格式, 没有后缀的默认为 jpg.
+func imageFilename(name string) string {
+	if name == "" || name == "." || name == "/" {
+		name = "image"
+	}
+	if path.Ext(name) == "" {
+		name += ".jpg"
+	}
+	return name
+}
@@ -0,0 +1,107 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+// 语音转码, 比如调用 ffmpeg 把 amr/speex 转换为 mp3, wav 给语音识别服务使用.
+type VoiceTranscoder interface {
+	// data 为 format(amr, speex 等)格式的语音, 返回转码后的语音和格式.
+	Transcode(data []byte, format string) (out []byte, outFormat string, err error)
+}
+
+type VoiceTranscoderFunc func(data []byte, format string) (out []byte, outFormat string, err error)
+
+func (fn VoiceTranscoderFunc) Transcode(data []byte, format string) (out []byte, outFormat string, err error) {
+	return fn(data, format)
+}
+
+// 下载(和转码)好的语音消息
+type VoiceMessage struct {
+	*request.Voice
+
+	DataFormat string // Data 的格式, 没有转码时等于 Voice.Format
+	Data       []byte // 语音数据
+	Path       string // 保存的文件路径, VoiceHandler.Dir 为空时为空
+}
+
+// 处理语音消息的 MessageHandler, 先下载语音, 然后(可选)转码, 再交给 Handler 处理.
+//  一般这样注册:
+//   mux.MessageHandle(request.MsgTypeVoice, &media.VoiceHandler{Client: clt, Handler: handleVoice})
+//
+//  NOTE: 下载和转码都在回调请求里同步进行, 总耗时超过 5 秒微信服务器会重新推送,
+//  耗时较长时建议配合 mp.NewDedupMessageHandler 使用.
+type VoiceHandler struct {
+	Client     *mp.Client
+	Transcoder VoiceTranscoder // 可以为 nil, 表示不转码
+
+	// 不为空时把语音保存到该目录, 文件名为 MsgId.格式, 比如 6198370837645950976.mp3;
+	// Handler 返回后文件不会被删除, 由调用者负责清理.
+	Dir string
+
+	Handler func(w http.ResponseWriter, r *mp.Request, voice *VoiceMessage)
+
+	// 可以为 nil; 下载, 转码或者保存失败时调用, 为 nil 时记录日志并回复空串.
+	ErrorHandler func(w http.ResponseWriter, r *mp.Request, err error)
+}
+
+func (h *VoiceHandler) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	voice, err := h.prepare(r)
+	if err != nil {
+		if h.ErrorHandler != nil {
+			h.ErrorHandler(w, r, err)
+			return
+		}
+		mp.LogInfoln("[WECHAT_VOICE] prepare voice message failed:", err)
+		return
+	}
+	h.Handler(w, r, voice)
+}
+
+func (h *VoiceHandler) prepare(r *mp.Request) (voice *VoiceMessage, err error) {
+	voice = &VoiceMessage{
+		Voice:      request.GetVoice(r.MixedMsg),
+		DataFormat: r.MixedMsg.Format,
+	}
+
+	var buf bytes.Buffer
+	clt := Client{Client: h.Client.WithContext(r.Context())}
+	if err = clt.DownloadMediaToWriter(voice.MediaId, &buf); err != nil {
+		return
+	}
+	voice.Data = buf.Bytes()
+
+	if h.Transcoder != nil {
+		if voice.Data, voice.DataFormat, err = h.Transcoder.Transcode(voice.Data, voice.DataFormat); err != nil {
+			return
+		}
+	}
+
+	if h.Dir != "" {
+		if err = os.MkdirAll(h.Dir, 0755); err != nil {
+			return
+		}
+		name := strconv.FormatInt(voice.MsgId, 10)
+		if voice.DataFormat != "" {
+			name += "." + voice.DataFormat
+		}
+		voice.Path = filepath.Join(h.Dir, name)
+		if err = ioutil.WriteFile(voice.Path, voice.Data, 0644); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,224 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package menu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/errcode"
+)
+
+const (
+	ApplyOpMenuCreate            = "menu_create"             // 创建(覆盖)默认菜单
+	ApplyOpMenuDelete            = "menu_delete"             // 删除全部菜单, 包括个性化菜单
+	ApplyOpConditionalMenuAdd    = "conditional_menu_add"    // 创建个性化菜单
+	ApplyOpConditionalMenuDelete = "conditional_menu_delete" // 删除个性化菜单
+)
+
+// 菜单的期望状态(声明式), 可以用 json 保存在代码仓库里.
+//  Buttons 为空表示不要菜单, 此时 ConditionalMenus 也必须为空(个性化菜单依赖默认菜单).
+type MenuSpec struct {
+	Buttons          []Button `json:"button,omitempty"`          // 默认菜单
+	ConditionalMenus []Menu   `json:"conditionalmenu,omitempty"` // 个性化菜单, MatchRule 不能为 nil, 忽略 MenuId
+}
+
+// 把菜单调整到期望状态需要执行的一个操作.
+type ApplyAction struct {
+	Op string // ApplyOpMenuCreate...
+
+	Buttons []Button `json:",omitempty"` // ApplyOpMenuCreate
+	Menu    *Menu    `json:",omitempty"` // ApplyOpConditionalMenuAdd
+	MenuId  int64    `json:",omitempty"` // ApplyOpConditionalMenuDelete
+}
+
+// 用于 dry-run 输出.
+func (action *ApplyAction) String() string {
+	switch action.Op {
+	case ApplyOpMenuCreate:
+		return fmt.Sprintf("%s buttons=%s", action.Op, buttonNames(action.Buttons))
+	case ApplyOpConditionalMenuAdd:
+		rule, _ := json.Marshal(action.Menu.MatchRule)
+		return fmt.Sprintf("%s matchrule=%s buttons=%s", action.Op, rule, buttonNames(action.Menu.Buttons))
+	case ApplyOpConditionalMenuDelete:
+		return fmt.Sprintf("%s menuid=%d", action.Op, action.MenuId)
+	default:
+		return action.Op
+	}
+}
+
+func buttonNames(buttons []Button) string {
+	names := make([]string, 0, len(buttons))
+	for i := range buttons {
+		names = append(names, buttons[i].Name)
+	}
+	b, _ := json.Marshal(names)
+	return string(b)
+}
+
+// 获取当前的菜单, 没有菜单时返回空的 MenuSpec.
+func (clt Client) CurrentMenuSpec() (spec *MenuSpec, err error) {
+	menu, conditionalMenus, err := clt.GetMenuWithConditional()
+	if err != nil {
+		if e, ok := err.(*mp.Error); ok && e.ErrCode == errcode.MenuDataNotExist {
+			return &MenuSpec{}, nil
+		}
+		return
+	}
+	spec = &MenuSpec{
+		Buttons:          menu.Buttons,
+		ConditionalMenus: conditionalMenus,
+	}
+	return
+}
+
+// 比较当前的菜单 current 和期望的菜单 desired, 返回需要执行的最少的操作.
+// 返回的操作已经按照执行顺序排好:
+//  1. 删除全部菜单(desired 没有菜单时, 只有这一个操作)
+//  2. 创建默认菜单
+//  3. 删除个性化菜单
+//  4. 创建个性化菜单
+//  NOTE: 个性化菜单不能修改, 按钮或者匹配规则变化时先删除再创建;
+//  菜单按照 json 编码后的内容比较, 所以按钮的顺序也是有意义的.
+func DiffMenu(current, desired *MenuSpec) (actions []ApplyAction, err error) {
+	if current == nil {
+		err = errors.New("nil current")
+		return
+	}
+	if desired == nil {
+		err = errors.New("nil desired")
+		return
+	}
+
+	if len(desired.Buttons) == 0 {
+		if len(desired.ConditionalMenus) > 0 {
+			err = errors.New("conditional menus require a default menu")
+			return
+		}
+		if len(current.Buttons) > 0 || len(current.ConditionalMenus) > 0 {
+			actions = append(actions, ApplyAction{Op: ApplyOpMenuDelete})
+		}
+		return
+	}
+
+	haveButtons, err := menuKey(current.Buttons, nil)
+	if err != nil {
+		return
+	}
+	wantButtons, err := menuKey(desired.Buttons, nil)
+	if err != nil {
+		return
+	}
+	if haveButtons != wantButtons {
+		actions = append(actions, ApplyAction{Op: ApplyOpMenuCreate, Buttons: desired.Buttons})
+	}
+
+	// 个性化菜单按照 (匹配规则, 按钮) 匹配, 重复的也按个数匹配
+	wants := make(map[string]int, len(desired.ConditionalMenus))
+	for i := range desired.ConditionalMenus {
+		menu := &desired.ConditionalMenus[i]
+		if menu.MatchRule == nil {
+			err = fmt.Errorf("nil MatchRule for conditional menu %d", i)
+			return
+		}
+		var key string
+		if key, err = menuKey(menu.Buttons, menu.MatchRule); err != nil {
+			return
+		}
+		wants[key]++
+	}
+	var adds []ApplyAction
+	haves := make(map[string]int, len(current.ConditionalMenus))
+	for i := range current.ConditionalMenus {
+		menu := &current.ConditionalMenus[i]
+		var key string
+		if key, err = menuKey(menu.Buttons, menu.MatchRule); err != nil {
+			return
+		}
+		if haves[key] < wants[key] {
+			haves[key]++
+			continue
+		}
+		actions = append(actions, ApplyAction{Op: ApplyOpConditionalMenuDelete, MenuId: menu.MenuId})
+	}
+	for i := range desired.ConditionalMenus {
+		menu := &desired.ConditionalMenus[i]
+		key, _ := menuKey(menu.Buttons, menu.MatchRule)
+		if haves[key] > 0 {
+			haves[key]--
+			continue
+		}
+		adds = append(adds, ApplyAction{
+			Op: ApplyOpConditionalMenuAdd,
+			Menu: &Menu{
+				Buttons:   menu.Buttons,
+				MatchRule: menu.MatchRule,
+			},
+		})
+	}
+	actions = append(actions, adds...)
+	return
+}
+
+// 菜单用于比较的 key, 空的 sub_button 和没有 sub_button 等价.
+func menuKey(buttons []Button, rule *MatchRule) (key string, err error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if err = encoder.Encode(buttons); err != nil {
+		return
+	}
+	if rule != nil {
+		if err = encoder.Encode(rule); err != nil {
+			return
+		}
+	}
+	return buf.String(), nil
+}
+
+// 依次执行 actions, 返回成功执行的个数; 出错时立即返回, 之后的操作不会执行.
+func (clt Client) ApplyActions(actions []ApplyAction) (done int, err error) {
+	for i := range actions {
+		action := &actions[i]
+		switch action.Op {
+		case ApplyOpMenuCreate:
+			err = clt.CreateMenu(Menu{Buttons: action.Buttons})
+		case ApplyOpMenuDelete:
+			err = clt.DeleteMenu()
+		case ApplyOpConditionalMenuAdd:
+			_, err = clt.AddConditionalMenu(action.Menu)
+		case ApplyOpConditionalMenuDelete:
+			err = clt.DeleteConditionalMenu(action.MenuId)
+		default:
+			err = fmt.Errorf("unknown apply op: %s", action.Op)
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %v", action, err)
+			return
+		}
+		done++
+	}
+	return
+}
+
+// 把菜单调整到 desired, 菜单没有变化时不调用任何修改接口.
+// dryRun 为 true 时只返回需要执行的操作, 不做任何修改, 可以逐个打印 actions 检查.
+func (clt Client) Apply(desired *MenuSpec, dryRun bool) (actions []ApplyAction, err error) {
+	current, err := clt.CurrentMenuSpec()
+	if err != nil {
+		return
+	}
+	if actions, err = DiffMenu(current, desired); err != nil {
+		return
+	}
+	if dryRun {
+		return
+	}
+	_, err = clt.ApplyActions(actions)
+	return
+}
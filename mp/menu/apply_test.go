@@ -0,0 +1,122 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package menu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMenu(t *testing.T) {
+	a := []Button{{Type: "click", Name: "a", Key: "a"}}
+	b := []Button{{Type: "click", Name: "b", Key: "b"}}
+	ab := []Button{{Type: "click", Name: "a", Key: "a"}, {Type: "click", Name: "b", Key: "b"}}
+	ba := []Button{{Type: "click", Name: "b", Key: "b"}, {Type: "click", Name: "a", Key: "a"}}
+	emptySub := []Button{{Type: "click", Name: "a", Key: "a", SubButtons: []Button{}}}
+	tag1 := &MatchRule{TagId: "1"}
+	tag2 := &MatchRule{TagId: "2"}
+
+	tests := []struct {
+		name             string
+		current, desired MenuSpec
+		want             []string
+	}{
+		{
+			name:    "no change",
+			current: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1, MenuId: 100}}},
+			desired: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1}}},
+		},
+		{
+			name:    "empty sub_button is the same as no sub_button",
+			current: MenuSpec{Buttons: a},
+			desired: MenuSpec{Buttons: emptySub},
+		},
+		{
+			name:    "create from nothing",
+			desired: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1}}},
+			want: []string{
+				`menu_create buttons=["a"]`,
+				`conditional_menu_add matchrule={"tag_id":"1"} buttons=["b"]`,
+			},
+		},
+		{
+			name:    "delete everything",
+			current: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1, MenuId: 100}}},
+			want:    []string{"menu_delete"},
+		},
+		{
+			name: "nothing to delete",
+		},
+		{
+			name:    "button order matters",
+			current: MenuSpec{Buttons: ab},
+			desired: MenuSpec{Buttons: ba},
+			want:    []string{`menu_create buttons=["b","a"]`},
+		},
+		{
+			name:    "conditional menu changed: delete then add",
+			current: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1, MenuId: 100}}},
+			desired: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag2}}},
+			want: []string{
+				"conditional_menu_delete menuid=100",
+				`conditional_menu_add matchrule={"tag_id":"2"} buttons=["b"]`,
+			},
+		},
+		{
+			name: "duplicate conditional menus are matched by count",
+			current: MenuSpec{Buttons: a, ConditionalMenus: []Menu{
+				{Buttons: b, MatchRule: tag1, MenuId: 100},
+				{Buttons: b, MatchRule: tag1, MenuId: 101},
+				{Buttons: b, MatchRule: tag1, MenuId: 102},
+			}},
+			desired: MenuSpec{Buttons: a, ConditionalMenus: []Menu{
+				{Buttons: b, MatchRule: tag1},
+				{Buttons: b, MatchRule: tag1},
+			}},
+			want: []string{"conditional_menu_delete menuid=102"},
+		},
+		{
+			name:    "default menu and conditional menu changed",
+			current: MenuSpec{Buttons: a, ConditionalMenus: []Menu{{Buttons: a, MatchRule: tag1, MenuId: 100}}},
+			desired: MenuSpec{Buttons: b, ConditionalMenus: []Menu{{Buttons: b, MatchRule: tag1}, {Buttons: a, MatchRule: tag1}}},
+			want: []string{
+				`menu_create buttons=["b"]`,
+				`conditional_menu_add matchrule={"tag_id":"1"} buttons=["b"]`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		actions, err := DiffMenu(&tt.current, &tt.desired)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var have []string
+		for i := range actions {
+			have = append(have, actions[i].String())
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s:\nhave: %q\nwant: %q", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestDiffMenuInvalid(t *testing.T) {
+	tests := []struct {
+		name             string
+		current, desired *MenuSpec
+	}{
+		{"nil current", nil, &MenuSpec{}},
+		{"nil desired", &MenuSpec{}, nil},
+		{"conditional menus without default menu", &MenuSpec{}, &MenuSpec{ConditionalMenus: []Menu{{MatchRule: &MatchRule{TagId: "1"}}}}},
+		{"nil MatchRule", &MenuSpec{}, &MenuSpec{Buttons: []Button{{Name: "a"}}, ConditionalMenus: []Menu{{Buttons: []Button{{Name: "b"}}}}}},
+	}
+	for _, tt := range tests {
+		if _, err := DiffMenu(tt.current, tt.desired); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+}
@@ -6,6 +6,8 @@
 package menu
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/chanxuehong/wechat/mp"
@@ -97,3 +99,107 @@ func (clt Client) GetCurrentSelfMenuInfo() (info MenuInfo, isMenuOpen bool, err
 	}
 	return
 }
+
+// 创建个性化菜单, menu.MatchRule 不能为 nil.
+func (clt Client) AddConditionalMenu(menu *Menu) (menuId int64, err error) {
+	if menu == nil {
+		err = errors.New("nil menu")
+		return
+	}
+	if menu.MatchRule == nil {
+		err = errors.New("nil menu.MatchRule")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		MenuId json.Number `json:"menuid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/addconditional?access_token="
+	if err = clt.PostJSON(incompleteURL, menu, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	return result.MenuId.Int64()
+}
+
+// 删除个性化菜单
+func (clt Client) DeleteConditionalMenu(menuId int64) (err error) {
+	var request = struct {
+		MenuId int64 `json:"menuid,string"`
+	}{
+		MenuId: menuId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/delconditional?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 测试个性化菜单匹配结果.
+//  userId: 可以是粉丝的OpenID，也可以是粉丝的微信号
+func (clt Client) TryMatch(userId string) (buttons []Button, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		UserId string `json:"user_id"`
+	}{
+		UserId: userId,
+	}
+
+	var result struct {
+		mp.Error
+		Buttons []Button `json:"button"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/trymatch?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	buttons = result.Buttons
+	return
+}
+
+// 获取自定义菜单, 包括默认菜单和全部个性化菜单.
+func (clt Client) GetMenuWithConditional() (menu Menu, conditionalMenus []Menu, err error) {
+	var result struct {
+		mp.Error
+		Menu             Menu   `json:"menu"`
+		ConditionalMenus []Menu `json:"conditionalmenu"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/get?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	menu = result.Menu
+	conditionalMenus = result.ConditionalMenus
+	return
+}
@@ -42,6 +42,8 @@ const (
 	ButtonTypePicWeixin       = "pic_weixin"         // 微信相册发图
 	ButtonTypeLocationSelect  = "location_select"    // 发送位置
 
+	ButtonTypeMiniProgram = "miniprogram" // 跳转小程序
+
 	// 专门给第三方平台旗下未微信认证（具体而言，是资质认证未通过）的订阅号准备的事件类型，
 	// 它们是没有事件推送的，能力相对受限，其他类型的公众号不必使用。
 	ButtonTypeMediaId     = "media_id"     // 下发消息
@@ -49,7 +51,20 @@ const (
 )
 
 type Menu struct {
-	Buttons []Button `json:"button,omitempty"` // 一级菜单数组，个数应为1~3个
+	Buttons   []Button   `json:"button,omitempty"`    // 一级菜单数组，个数应为1~3个
+	MatchRule *MatchRule `json:"matchrule,omitempty"` // 个性化菜单的匹配规则, 普通菜单为 nil
+	MenuId    int64      `json:"menuid,omitempty"`    // 个性化菜单的id, 查询时返回
+}
+
+// 个性化菜单的匹配规则, 各字段都是非必须的, 但是至少要有一个不为空
+type MatchRule struct {
+	TagId              string `json:"tag_id,omitempty"`               // 用户标签的id
+	Sex                string `json:"sex,omitempty"`                  // 性别：男（1）女（2）
+	Country            string `json:"country,omitempty"`              // 国家信息
+	Province           string `json:"province,omitempty"`             // 省份信息, 要求 country 不为空
+	City               string `json:"city,omitempty"`                 // 城市信息, 要求 province 不为空
+	ClientPlatformType string `json:"client_platform_type,omitempty"` // 客户端版本：IOS(1), Android(2),Others(3)
+	Language           string `json:"language,omitempty"`             // 语言信息, 比如 zh_CN
 }
 
 // 菜单的按钮
@@ -59,6 +74,8 @@ type Button struct {
 	Key        string   `json:"key,omitempty"`        // 非必须; 菜单KEY值，用于消息接口推送，不超过128字节
 	URL        string   `json:"url,omitempty"`        // 非必须; 网页链接，用户点击菜单可打开链接，不超过256字节
 	MediaId    string   `json:"media_id,omitempty"`   // 非必须; 调用新增永久素材接口返回的合法media_id
+	AppId      string   `json:"appid,omitempty"`      // 非必须; 小程序的appid, miniprogram 类型必须
+	PagePath   string   `json:"pagepath,omitempty"`   // 非必须; 小程序的页面路径, miniprogram 类型必须
 	SubButtons []Button `json:"sub_button,omitempty"` // 非必须; 二级菜单数组，个数应为1~5个
 }
 
@@ -71,6 +88,8 @@ func (btn *Button) SetAsSubMenuButton(name string, subButtons []Button) {
 	btn.Key = ""
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 }
 
 // 设置 btn 指向的 Button 为 click 类型按钮
@@ -81,6 +100,8 @@ func (btn *Button) SetAsClickButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -92,6 +113,8 @@ func (btn *Button) SetAsViewButton(name, url string) {
 
 	btn.Key = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -103,6 +126,8 @@ func (btn *Button) SetAsScanCodePushButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -114,6 +139,8 @@ func (btn *Button) SetAsScanCodeWaitMsgButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -125,6 +152,8 @@ func (btn *Button) SetAsPicSysPhotoButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -136,6 +165,8 @@ func (btn *Button) SetAsPicPhotoOrAlbumButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -147,6 +178,8 @@ func (btn *Button) SetAsPicWeixinButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -158,6 +191,8 @@ func (btn *Button) SetAsLocationSelectButton(name, key string) {
 
 	btn.URL = ""
 	btn.MediaId = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -169,6 +204,8 @@ func (btn *Button) SetAsMediaIdButton(name, mediaId string) {
 
 	btn.Key = ""
 	btn.URL = ""
+	btn.AppId = ""
+	btn.PagePath = ""
 	btn.SubButtons = nil
 }
 
@@ -180,5 +217,21 @@ func (btn *Button) SetAsViewLimitedButton(name, mediaId string) {
 
 	btn.Key = ""
 	btn.URL = ""
+	btn.AppId = ""
+	btn.PagePath = ""
+	btn.SubButtons = nil
+}
+
+// 设置 btn 指向的 Button 为 miniprogram 类型按钮.
+//  url: 不支持小程序的老版本客户端将打开本url
+func (btn *Button) SetAsMiniProgramButton(name, url, appId, pagePath string) {
+	btn.Name = name
+	btn.Type = ButtonTypeMiniProgram
+	btn.URL = url
+	btn.AppId = appId
+	btn.PagePath = pagePath
+
+	btn.Key = ""
+	btn.MediaId = ""
 	btn.SubButtons = nil
 }
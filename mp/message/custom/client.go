@@ -74,6 +74,30 @@ func (clt Client) SendNews(msg *News) (err error) {
 	return clt.send(msg)
 }
 
+// 发送客服消息, 图文(点击跳转到图文消息页面).
+func (clt Client) SendMPNews(msg *MPNews) error {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.send(msg)
+}
+
+// 发送客服消息, 卡券.
+func (clt Client) SendWxCard(msg *WxCard) error {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.send(msg)
+}
+
+// 发送客服消息, 小程序卡片.
+func (clt Client) SendMiniProgramPage(msg *MiniProgramPage) error {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.send(msg)
+}
+
 func (clt Client) send(msg interface{}) (err error) {
 	var result mp.Error
 
@@ -4,4 +4,5 @@
 // @authors     chanxuehong(chanxuehong@gmail.com)
 
 // 客服主动回复消息.
+//  客服帐号的管理(添加, 修改, 删除, 获取列表, 上传头像)请使用 github.com/chanxuehong/wechat/mp/dkf.
 package custom
@@ -17,6 +17,10 @@ const (
 	MsgTypeVideo = "video" // 视频消息
 	MsgTypeMusic = "music" // 音乐消息
 	MsgTypeNews  = "news"  // 图文消息
+
+	MsgTypeMPNews          = "mpnews"          // 图文消息(点击跳转到图文消息页面)
+	MsgTypeWxCard          = "wxcard"          // 卡券
+	MsgTypeMiniProgramPage = "miniprogrampage" // 小程序卡片
 )
 
 type MessageHeader struct {
@@ -261,3 +265,100 @@ func (this *News) CheckValid() (err error) {
 	}
 	return
 }
+
+// 图文消息(点击跳转到图文消息页面)
+type MPNews struct {
+	MessageHeader
+
+	MPNews struct {
+		MediaId string `json:"media_id"` // 图文消息(素材)的 media_id
+	} `json:"mpnews"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+// 新建图文消息(点击跳转到图文消息页面).
+//  mediaId 是图文消息(素材)的 media_id;
+//  如果不指定客服则 kfAccount 留空.
+func NewMPNews(toUser, mediaId, kfAccount string) (news *MPNews) {
+	news = &MPNews{
+		MessageHeader: MessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeMPNews,
+		},
+	}
+	news.MPNews.MediaId = mediaId
+
+	if kfAccount != "" {
+		news.CustomService = &CustomService{
+			KfAccount: kfAccount,
+		}
+	}
+	return
+}
+
+// 卡券消息, 特别注意客服消息接口投放卡券仅支持非自定义Code码和导入code模式的卡券的卡券
+type WxCard struct {
+	MessageHeader
+
+	WxCard struct {
+		CardId string `json:"card_id"`
+	} `json:"wxcard"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+// 新建卡券消息.
+//  如果不指定客服则 kfAccount 留空.
+func NewWxCard(toUser, cardId, kfAccount string) (card *WxCard) {
+	card = &WxCard{
+		MessageHeader: MessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeWxCard,
+		},
+	}
+	card.WxCard.CardId = cardId
+
+	if kfAccount != "" {
+		card.CustomService = &CustomService{
+			KfAccount: kfAccount,
+		}
+	}
+	return
+}
+
+// 小程序卡片消息, 要求小程序与公众号已关联
+type MiniProgramPage struct {
+	MessageHeader
+
+	MiniProgramPage struct {
+		Title        string `json:"title"`          // 小程序卡片的标题
+		AppId        string `json:"appid"`          // 小程序的appid
+		PagePath     string `json:"pagepath"`       // 小程序的页面路径，跟app.json对齐，支持参数，比如pages/index/index?foo=bar
+		ThumbMediaId string `json:"thumb_media_id"` // 缩略图/小程序卡片图片的媒体ID，小程序卡片图片建议大小为520*416
+	} `json:"miniprogrampage"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+// 新建小程序卡片消息.
+//  如果不指定客服则 kfAccount 留空.
+func NewMiniProgramPage(toUser, title, appId, pagePath, thumbMediaId, kfAccount string) (page *MiniProgramPage) {
+	page = &MiniProgramPage{
+		MessageHeader: MessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeMiniProgramPage,
+		},
+	}
+	page.MiniProgramPage.Title = title
+	page.MiniProgramPage.AppId = appId
+	page.MiniProgramPage.PagePath = pagePath
+	page.MiniProgramPage.ThumbMediaId = thumbMediaId
+
+	if kfAccount != "" {
+		page.CustomService = &CustomService{
+			KfAccount: kfAccount,
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package custom
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	TypingCommandTyping       = "Typing"       // 对用户下发"正在输入"状态
+	TypingCommandCancelTyping = "CancelTyping" // 取消对用户的"正在输入"状态
+)
+
+// 客服输入状态.
+//  command: TypingCommandTyping, TypingCommandCancelTyping
+func (clt Client) Typing(toUser, command string) (err error) {
+	if toUser == "" {
+		return errors.New("empty toUser")
+	}
+	if command != TypingCommandTyping && command != TypingCommandCancelTyping {
+		return errors.New("invalid command: " + command)
+	}
+
+	var request = struct {
+		ToUser  string `json:"touser"`
+		Command string `json:"command"`
+	}{
+		ToUser:  toUser,
+		Command: command,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/custom/typing?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,144 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package custom
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/menu"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+// 用户和公众号互动后允许发送客服消息的时间, 超过后发送客服消息会返回 45015(回复时间超过限制).
+const DefaultMessageWindow = 48 * time.Hour
+
+// 用户最后一次互动时间的存储接口.
+type InteractionStore interface {
+	// 获取 openId 最后一次互动的时间, 没有记录时返回零值.
+	LastInteraction(openId string) (t time.Time, err error)
+	// 记录 openId 最后一次互动的时间.
+	SetLastInteraction(openId string, t time.Time) (err error)
+}
+
+// MessageWindowTracker 从回调消息中记录用户最后一次互动的时间, 用于在调用客服消息接口之前判断是否还在允许的时间内.
+// 能够打开客服消息窗口的互动有: 用户发送消息, 关注公众号, 扫描二维码, 点击菜单(click, scancode_push, scancode_waitmsg).
+type MessageWindowTracker struct {
+	store  InteractionStore
+	window time.Duration
+}
+
+// 创建 MessageWindowTracker.
+//  store 为 nil 时使用内存存储(只适用于单进程环境); window <= 0 时为 DefaultMessageWindow.
+func NewMessageWindowTracker(store InteractionStore, window time.Duration) *MessageWindowTracker {
+	if store == nil {
+		store = NewMemoryInteractionStore()
+	}
+	if window <= 0 {
+		window = DefaultMessageWindow
+	}
+	return &MessageWindowTracker{
+		store:  store,
+		window: window,
+	}
+}
+
+// 包装 handler, 在处理消息之前记录用户的互动, 一般用来包装 MessageServeMux:
+//  srv := mp.NewDefaultServer(oriId, token, appId, aesKey, tracker.Handler(mux))
+func (tracker *MessageWindowTracker) Handler(handler mp.MessageHandler) mp.MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		tracker.Record(r)
+		handler.ServeMessage(w, r)
+	})
+}
+
+// 如果 r 是能够打开客服消息窗口的互动则记录下来, 记录失败会被忽略(只是判断不准确).
+func (tracker *MessageWindowTracker) Record(r *mp.Request) {
+	msg := r.MixedMsg
+	if msg == nil || msg.FromUserName == "" || !isInteraction(msg) {
+		return
+	}
+	t := time.Unix(msg.CreateTime, 0)
+	if msg.CreateTime <= 0 {
+		t = time.Now()
+	}
+	if err := tracker.store.SetLastInteraction(msg.FromUserName, t); err != nil {
+		mp.LogInfoln("[WECHAT_ERROR] record interaction failed:", err)
+	}
+}
+
+func isInteraction(msg *mp.MixedMessage) bool {
+	switch msg.MsgType {
+	case request.MsgTypeText, request.MsgTypeImage, request.MsgTypeVoice, request.MsgTypeVideo,
+		request.MsgTypeShortVideo, request.MsgTypeLocation, request.MsgTypeLink:
+		return true
+	case "event":
+		switch msg.Event {
+		case request.EventTypeSubscribe, request.EventTypeScan,
+			menu.EventTypeClick, menu.EventTypeScanCodePush, menu.EventTypeScanCodeWaitMsg:
+			return true
+		}
+	}
+	return false
+}
+
+// 判断现在是否还可以给 openId 发送客服消息.
+func (tracker *MessageWindowTracker) CanSendCustomMessage(openId string) (ok bool, err error) {
+	last, err := tracker.store.LastInteraction(openId)
+	if err != nil || last.IsZero() {
+		return
+	}
+	ok = time.Since(last) < tracker.window
+	return
+}
+
+// 客服消息窗口关闭的时间, 没有互动记录时返回零值.
+func (tracker *MessageWindowTracker) WindowDeadline(openId string) (deadline time.Time, err error) {
+	last, err := tracker.store.LastInteraction(openId)
+	if err != nil || last.IsZero() {
+		return
+	}
+	deadline = last.Add(tracker.window)
+	return
+}
+
+// =============================================================================
+
+var _ InteractionStore = (*MemoryInteractionStore)(nil)
+
+// InteractionStore 的简单实现, 保存在内存里, 用于单进程环境.
+//  NOTE: 不会主动清理过期的记录, 用户很多时请使用外部存储.
+type MemoryInteractionStore struct {
+	rwmutex sync.RWMutex
+	times   map[string]time.Time
+}
+
+func NewMemoryInteractionStore() *MemoryInteractionStore {
+	return &MemoryInteractionStore{
+		times: make(map[string]time.Time),
+	}
+}
+
+func (store *MemoryInteractionStore) LastInteraction(openId string) (t time.Time, err error) {
+	store.rwmutex.RLock()
+	t = store.times[openId]
+	store.rwmutex.RUnlock()
+	return
+}
+
+func (store *MemoryInteractionStore) SetLastInteraction(openId string, t time.Time) (err error) {
+	store.rwmutex.Lock()
+	if t.After(store.times[openId]) {
+		store.times[openId] = t
+	}
+	store.rwmutex.Unlock()
+	return
+}
@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass2users
+
+import (
+	"errors"
+	"fmt"
+)
+
+const ToUserCountMinimum = 2 // 根据 OpenID 列表群发, 每次至少 2 个用户
+
+// Text, Image, Voice, Video, News 都实现了 Message
+type Message interface {
+	messageHeader() *MessageHeader
+}
+
+func (header *MessageHeader) messageHeader() *MessageHeader {
+	return header
+}
+
+// 群发的一批用户和结果
+type ChunkResult struct {
+	ToUser []string
+	MsgId  int64
+	Err    error
+}
+
+// 把 msg.ToUser 按 chunkSize 分批群发, 如果 chunkSize <= 0 则为 ToUserCountLimit.
+//  每一批至少 ToUserCountMinimum 个用户, 最后一批不足时合并到前一批(不超过 ToUserCountLimit), 否则从前一批借用户;
+//  某一批失败后继续发送后面的批次, 所有批次的结果见 results, err 为第一个失败批次的错误.
+//  NOTE: 调用期间会修改 msg.ToUser, 返回前恢复.
+func (clt Client) SendChunked(msg Message, chunkSize int) (results []ChunkResult, err error) {
+	if msg == nil {
+		err = errors.New("msg == nil")
+		return
+	}
+	if chunkSize <= 0 || chunkSize > ToUserCountLimit {
+		chunkSize = ToUserCountLimit
+	}
+	if chunkSize < ToUserCountMinimum {
+		err = fmt.Errorf("chunkSize must be greater than or equal to %d", ToUserCountMinimum)
+		return
+	}
+
+	header := msg.messageHeader()
+	toUser := header.ToUser
+	defer func() {
+		header.ToUser = toUser
+	}()
+	if len(toUser) < ToUserCountMinimum {
+		err = fmt.Errorf("用户列表的长度不能小于 %d, 现在为 %d", ToUserCountMinimum, len(toUser))
+		return
+	}
+
+	for _, chunk := range splitToUser(toUser, chunkSize) {
+		header.ToUser = chunk
+		msgid, sendErr := clt.send(msg)
+		results = append(results, ChunkResult{
+			ToUser: chunk,
+			MsgId:  msgid,
+			Err:    sendErr,
+		})
+		if sendErr != nil && err == nil {
+			err = sendErr
+		}
+	}
+	return
+}
+
+// 按 chunkSize 分批, 保证每一批至少 ToUserCountMinimum 个(要求 len(toUser) >= ToUserCountMinimum).
+func splitToUser(toUser []string, chunkSize int) (chunks [][]string) {
+	for len(toUser) > 0 {
+		n := chunkSize
+		if rest := len(toUser) - n; rest > 0 && rest < ToUserCountMinimum {
+			if len(toUser) <= ToUserCountLimit {
+				n = len(toUser) // 剩下的合并到这一批
+			} else {
+				n -= ToUserCountMinimum - rest // 从这一批借给最后一批
+			}
+		}
+		if n > len(toUser) {
+			n = len(toUser)
+		}
+		chunks = append(chunks, toUser[:n:n])
+		toUser = toUser[n:]
+	}
+	return
+}
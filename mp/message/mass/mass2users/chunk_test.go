@@ -0,0 +1,50 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass2users
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestSplitToUser(t *testing.T) {
+	tests := []struct {
+		total     int
+		chunkSize int
+		want      []int // 每一批的个数
+	}{
+		{2, 10, []int{2}},
+		{10, 10, []int{10}},
+		{20, 10, []int{10, 10}},
+		{12, 10, []int{10, 2}},
+		{11, 10, []int{11}},     // 最后一批不足 2 个, 合并到前一批
+		{21, 10, []int{10, 11}}, // 同上
+		{5, 2, []int{2, 3}},     // 同上
+		{ToUserCountLimit + 1, ToUserCountLimit, []int{ToUserCountLimit - 1, 2}}, // 合并后超过上限, 从前一批借
+		{2*ToUserCountLimit + 1, ToUserCountLimit, []int{ToUserCountLimit, ToUserCountLimit - 1, 2}},
+	}
+	for _, tt := range tests {
+		toUser := make([]string, tt.total)
+		for i := range toUser {
+			toUser[i] = strconv.Itoa(i)
+		}
+		chunks := splitToUser(toUser, tt.chunkSize)
+
+		var sizes []int
+		var joined []string
+		for _, chunk := range chunks {
+			sizes = append(sizes, len(chunk))
+			joined = append(joined, chunk...)
+		}
+		if !reflect.DeepEqual(sizes, tt.want) {
+			t.Errorf("splitToUser(%d, %d): have sizes %v, want %v", tt.total, tt.chunkSize, sizes, tt.want)
+		}
+		if !reflect.DeepEqual(joined, toUser) {
+			t.Errorf("splitToUser(%d, %d): chunks do not cover toUser in order", tt.total, tt.chunkSize)
+		}
+	}
+}
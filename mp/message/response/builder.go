@@ -0,0 +1,73 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// Builder 根据收到的消息(事件)构造被动回复的消息, 自动填充 ToUserName, FromUserName 和 CreateTime.
+type Builder struct {
+	to        string
+	from      string
+	timestamp int64
+}
+
+// 根据收到的消息(事件)创建 Builder, 回复消息的 ToUserName 和 FromUserName 与收到的消息互换.
+func NewBuilder(r *mp.Request) *Builder {
+	return &Builder{
+		to:        r.MixedMsg.FromUserName,
+		from:      r.MixedMsg.ToUserName,
+		timestamp: time.Now().Unix(),
+	}
+}
+
+func (b *Builder) Text(content string) *Text {
+	return NewText(b.to, b.from, b.timestamp, content)
+}
+
+func (b *Builder) Image(mediaId string) *Image {
+	return NewImage(b.to, b.from, b.timestamp, mediaId)
+}
+
+func (b *Builder) Voice(mediaId string) *Voice {
+	return NewVoice(b.to, b.from, b.timestamp, mediaId)
+}
+
+func (b *Builder) Video(mediaId, title, description string) *Video {
+	return NewVideo(b.to, b.from, b.timestamp, mediaId, title, description)
+}
+
+func (b *Builder) Music(thumbMediaId, musicURL, HQMusicURL, title, description string) *Music {
+	return NewMusic(b.to, b.from, b.timestamp, thumbMediaId, musicURL, HQMusicURL, title, description)
+}
+
+//  NOTE: articles 的个数不能超过 NewsArticleCountLimit, 可以调用 News.CheckValid 检查.
+func (b *Builder) News(articles ...Article) *News {
+	return NewNews(b.to, b.from, b.timestamp, articles)
+}
+
+// 如果不指定客服则 kfAccount 留空.
+func (b *Builder) TransferToCustomerService(kfAccount string) *TransferToCustomerService {
+	return NewTransferToCustomerService(b.to, b.from, b.timestamp, kfAccount)
+}
+
+// 回复消息给微信服务器, 公众号开启安全模式(Request.EncryptType == "aes")时自动加密并签名.
+func Write(w http.ResponseWriter, r *mp.Request, msg interface{}) (err error) {
+	if msg == nil {
+		return errors.New("nil message")
+	}
+	if news, ok := msg.(*News); ok && news != nil {
+		if err = news.CheckValid(); err != nil {
+			return
+		}
+	}
+	return mp.WriteResponse(w, r, msg)
+}
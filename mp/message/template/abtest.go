@@ -0,0 +1,297 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/batch"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// A/B 实验加在跳转 URL(或者小程序 pagepath)上的查询参数的名称, 落地页据此调用 ABSender.RecordClick.
+//  比如 http://www.xxx.com/promo?ab_experiment=spring&ab_variant=B
+const (
+	URLQueryExperimentKeyName = "ab_experiment"
+	URLQueryVariantKeyName    = "ab_variant"
+)
+
+// A/B 实验的一个分组
+type Variant struct {
+	Name        string       // 必须, 分组名称, 实验内唯一
+	Weight      int          // 分组的权重, <= 0 时当作 1
+	TemplateId  string       // 必须, 模版ID
+	URL         string       // 可选, 点击跳转的 URL
+	MiniProgram *MiniProgram // 可选, 跳转的小程序
+}
+
+// A/B 实验, 同一个用户在同一个实验里总是分到同一个分组.
+type Experiment struct {
+	Name     string
+	Variants []Variant
+}
+
+// 根据 openId 确定性地选择分组, 没有分组时返回 nil.
+func (e *Experiment) Assign(openId string) *Variant {
+	total := 0
+	for i := range e.Variants {
+		total += variantWeight(&e.Variants[i])
+	}
+	if total == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(e.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(openId))
+	n := int(h.Sum32() % uint32(total))
+	for i := range e.Variants {
+		if n -= variantWeight(&e.Variants[i]); n < 0 {
+			return &e.Variants[i]
+		}
+	}
+	return nil
+}
+
+func variantWeight(v *Variant) int {
+	if v.Weight <= 0 {
+		return 1
+	}
+	return v.Weight
+}
+
+// 一个分组的统计
+type VariantStats struct {
+	Variant      string `json:"variant"`
+	Sent         int    `json:"sent"`          // 调用发送接口成功的次数
+	SendFailed   int    `json:"send_failed"`   // 调用发送接口失败的次数
+	Delivered    int    `json:"delivered"`     // 送达成功, 来自 TEMPLATESENDJOBFINISH 事件
+	UserBlocked  int    `json:"user_blocked"`  // 用户拒收
+	SystemFailed int    `json:"system_failed"` // 其他原因送达失败
+	Clicked      int    `json:"clicked"`       // 点击次数, 来自 RecordClick
+	Expired      int    `json:"expired"`       // 超过 ABSender.PendingTTL 还没有收到 TEMPLATESENDJOBFINISH 事件
+}
+
+// ABSender.PendingTTL 为 0 时等待 TEMPLATESENDJOBFINISH 事件的时间
+const DefaultABPendingTTL = 24 * time.Hour
+
+// 按 Experiment 分组发送模板消息, 并统计每个分组的送达和点击.
+//
+//  NOTE:
+//  1. 送达统计依赖 TEMPLATESENDJOBFINISH 事件, 需要把 Handler 返回的 MessageHandler 用于接收消息;
+//     事件通过发送时返回的 msgid 关联到分组.
+//  2. 点击统计依赖落地页调用 RecordClick, 发送时会在 URL 和小程序 pagepath 上加上实验和分组的查询参数.
+//  3. 统计数据保存在内存里, 多进程部署时发送和接收事件需要在同一个进程, 或者自行汇总.
+//  4. 发送成功的 msgid 保存到收到事件为止, 超过 PendingTTL 没有收到事件的记为 Expired 并删除.
+type ABSender struct {
+	PendingTTL time.Duration // 等待 TEMPLATESENDJOBFINISH 事件的时间, 为 0 时使用 DefaultABPendingTTL
+
+	clt        *mp.Client
+	experiment *Experiment
+
+	mutex     sync.Mutex
+	stats     map[string]*VariantStats
+	msgIds    map[int64]pendingMsg // msgid -> 分组, 收到 TEMPLATESENDJOBFINISH 事件或者过期后删除
+	lastSweep time.Time
+}
+
+type pendingMsg struct {
+	variant string
+	sentAt  time.Time
+}
+
+func NewABSender(clt *mp.Client, experiment *Experiment) *ABSender {
+	if clt == nil {
+		panic("nil mp.Client")
+	}
+	if experiment == nil || len(experiment.Variants) == 0 {
+		panic("empty Experiment")
+	}
+
+	stats := make(map[string]*VariantStats, len(experiment.Variants))
+	for _, v := range experiment.Variants {
+		stats[v.Name] = &VariantStats{Variant: v.Name}
+	}
+	return &ABSender{
+		clt:        clt,
+		experiment: experiment,
+		stats:      stats,
+		msgIds:     make(map[int64]pendingMsg),
+	}
+}
+
+// 给 openIds 里的每个用户发送模板消息, 模板ID, URL 和小程序由用户所在的分组决定, 其他字段(比如 data)取自 msg.
+//  opts 可以为 nil, 见 batch.Run.
+func (s *ABSender) Send(ctx context.Context, openIds []string, msg *TemplateMessage, opts *batch.Options) (result *batch.Result) {
+	return batch.Run(ctx, len(openIds), opts, func(ctx context.Context, i int) (err error) {
+		if msg == nil {
+			return errors.New("nil TemplateMessage")
+		}
+		variant := s.experiment.Assign(openIds[i])
+
+		m := *msg
+		m.ToUser = openIds[i]
+		m.TemplateId = variant.TemplateId
+		m.URL = s.decorate(variant.URL, variant.Name)
+		m.MiniProgram = nil
+		if variant.MiniProgram != nil {
+			m.MiniProgram = &MiniProgram{
+				AppId:    variant.MiniProgram.AppId,
+				PagePath: s.decorate(variant.MiniProgram.PagePath, variant.Name),
+			}
+		}
+
+		msgid, err := (Client{Client: s.clt.WithContext(ctx)}).Send(&m)
+
+		now := time.Now()
+		s.mutex.Lock()
+		if err != nil {
+			s.stats[variant.Name].SendFailed++
+		} else {
+			s.stats[variant.Name].Sent++
+			s.msgIds[msgid] = pendingMsg{variant: variant.Name, sentAt: now}
+		}
+		s.sweep(now)
+		s.mutex.Unlock()
+		return
+	})
+}
+
+// 在 rawURL 上加上实验和分组的查询参数, rawURL 为空时返回空.
+func (s *ABSender) decorate(rawURL, variant string) string {
+	if rawURL == "" {
+		return ""
+	}
+	query := URLQueryExperimentKeyName + "=" + url.QueryEscape(s.experiment.Name) +
+		"&" + URLQueryVariantKeyName + "=" + url.QueryEscape(variant)
+
+	fragment := ""
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		rawURL, fragment = rawURL[:i], rawURL[i:]
+	}
+	if strings.IndexByte(rawURL, '?') >= 0 {
+		return rawURL + "&" + query + fragment
+	}
+	return rawURL + "?" + query + fragment
+}
+
+// 统计一个 TEMPLATESENDJOBFINISH 事件, 不是本 ABSender 发送的消息时返回 false.
+func (s *ABSender) Observe(msg *mp.MixedMessage) bool {
+	if msg.MsgType != "event" || msg.Event != EventTypeTemplateSendJobFinish {
+		return false
+	}
+	event := GetTemplateSendJobFinishEvent(msg)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep(time.Now())
+	pending, ok := s.msgIds[event.MsgId]
+	if !ok {
+		return false
+	}
+	delete(s.msgIds, event.MsgId)
+
+	stats := s.stats[pending.variant]
+	switch event.Status {
+	case TemplateSendStatusSuccess:
+		stats.Delivered++
+	case TemplateSendStatusFailedUserBlock:
+		stats.UserBlocked++
+	default:
+		stats.SystemFailed++
+	}
+	return true
+}
+
+// 删除超过 PendingTTL 的 msgid, 计入 Expired; 至多每分钟检查一次.
+//  NOTE: 调用者负责加锁.
+func (s *ABSender) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	ttl := s.PendingTTL
+	if ttl <= 0 {
+		ttl = DefaultABPendingTTL
+	}
+	for msgid, pending := range s.msgIds {
+		if now.Sub(pending.sentAt) >= ttl {
+			delete(s.msgIds, msgid)
+			s.stats[pending.variant].Expired++
+		}
+	}
+}
+
+// 返回还在等待 TEMPLATESENDJOBFINISH 事件的消息个数.
+func (s *ABSender) Pending() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep(time.Now())
+	return len(s.msgIds)
+}
+
+// 不再等待已发送消息的 TEMPLATESENDJOBFINISH 事件, 比如实验结束后; 删除的消息计入 Expired.
+func (s *ABSender) ClearPending() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, pending := range s.msgIds {
+		s.stats[pending.variant].Expired++
+	}
+	s.msgIds = make(map[int64]pendingMsg)
+}
+
+// 返回一个先统计 TEMPLATESENDJOBFINISH 事件再交给 handler 处理的 MessageHandler.
+func (s *ABSender) Handler(handler mp.MessageHandler) mp.MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		s.Observe(r.MixedMsg)
+		handler.ServeMessage(w, r)
+	})
+}
+
+// 根据落地页请求 URL 上的实验和分组参数统计一次点击, 不是本实验的请求时返回 false.
+func (s *ABSender) RecordClick(r *http.Request) bool {
+	query := r.URL.Query()
+	if query.Get(URLQueryExperimentKeyName) != s.experiment.Name {
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := s.stats[query.Get(URLQueryVariantKeyName)]
+	if stats == nil {
+		return false
+	}
+	stats.Clicked++
+	return true
+}
+
+// 返回每个分组的统计, 顺序和 Experiment.Variants 相同.
+func (s *ABSender) Stats() (stats []VariantStats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats = make([]VariantStats, 0, len(s.experiment.Variants))
+	for _, v := range s.experiment.Variants {
+		stats = append(stats, *s.stats[v.Name])
+	}
+	return
+}
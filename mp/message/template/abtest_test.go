@@ -0,0 +1,40 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestABSenderPending(t *testing.T) {
+	s := NewABSender(&mp.Client{}, &Experiment{Name: "e", Variants: []Variant{{Name: "A"}, {Name: "B"}}})
+	s.PendingTTL = time.Hour
+
+	now := time.Now()
+	s.msgIds[1] = pendingMsg{variant: "A", sentAt: now.Add(-2 * time.Hour)}
+	s.msgIds[2] = pendingMsg{variant: "B", sentAt: now.Add(-time.Minute)}
+	s.msgIds[3] = pendingMsg{variant: "B", sentAt: now}
+
+	if n := s.Pending(); n != 2 {
+		t.Fatalf("Pending after sweep: have %d, want 2", n)
+	}
+	s.sweep(now.Add(30 * time.Second)) // 一分钟内不重复检查
+	if n := len(s.msgIds); n != 2 {
+		t.Fatalf("sweep within a minute: have %d pending, want 2", n)
+	}
+
+	s.ClearPending()
+	if n := s.Pending(); n != 0 {
+		t.Fatalf("Pending after ClearPending: have %d, want 0", n)
+	}
+	stats := s.Stats()
+	if stats[0].Expired != 1 || stats[1].Expired != 2 {
+		t.Errorf("Expired: have A=%d B=%d, want A=1 B=2", stats[0].Expired, stats[1].Expired)
+	}
+}
@@ -0,0 +1,40 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"encoding/json"
+)
+
+// 模板数据里的一项, 对应模板内容里的 {{xxx.DATA}}
+type DataItem struct {
+	Value string `json:"value"`
+	Color string `json:"color,omitempty"` // 可选, 比如 #173177
+}
+
+// 模板数据, key 为模板内容里 {{xxx.DATA}} 的 xxx
+type Data map[string]DataItem
+
+// 设置 key 对应的值, color 可以为 "".
+func (data Data) Set(key, value, color string) Data {
+	data[key] = DataItem{
+		Value: value,
+		Color: color,
+	}
+	return data
+}
+
+// 设置模板消息的数据, data 一般为 Data, 也可以是自定义的结构体, 会被 encoding/json marshal.
+func (msg *TemplateMessage) SetData(data interface{}) (err error) {
+	msg.RawJSONData, err = json.Marshal(data)
+	return
+}
+
+// 设置一次性订阅消息的数据, data 一般为 Data, 也可以是自定义的结构体, 会被 encoding/json marshal.
+func (msg *SubscribeMessage) SetData(data interface{}) (err error) {
+	msg.RawJSONData, err = json.Marshal(data)
+	return
+}
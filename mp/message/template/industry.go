@@ -0,0 +1,37 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type Industry struct {
+	FirstClass  string `json:"first_class"`  // 主行业
+	SecondClass string `json:"second_class"` // 副行业
+}
+
+// 获取设置的行业信息
+func (clt Client) GetIndustry() (primary, secondary Industry, err error) {
+	var result struct {
+		mp.Error
+		PrimaryIndustry   Industry `json:"primary_industry"`
+		SecondaryIndustry Industry `json:"secondary_industry"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/get_industry?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	primary = result.PrimaryIndustry
+	secondary = result.SecondaryIndustry
+	return
+}
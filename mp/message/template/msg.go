@@ -15,6 +15,27 @@ type TemplateMessage struct {
 	URL        string `json:"url,omitempty"`      // 可选, 用户点击后跳转的URL，该URL必须处于开发者在公众平台网站中设置的域中
 	TopColor   string `json:"topcolor,omitempty"` // 可选, 整个消息的颜色, 可以不设置
 
-	// 必须, JSON 格式的 []byte, 满足特定的模板需求
+	MiniProgram *MiniProgram `json:"miniprogram,omitempty"` // 可选, 跳转小程序所需数据, 优先级高于 URL
+
+	// 必须, JSON 格式的 []byte, 满足特定的模板需求, 可以通过 SetData 设置
+	RawJSONData json.RawMessage `json:"data"`
+}
+
+// 模板消息跳转的小程序
+type MiniProgram struct {
+	AppId    string `json:"appid"`              // 必须, 所需跳转到的小程序appid（该小程序appid必须与发模板消息的公众号是绑定关联关系）
+	PagePath string `json:"pagepath,omitempty"` // 可选, 所需跳转到小程序的具体页面路径，支持带参数,（示例index?foo=bar）
+}
+
+// 一次性订阅消息
+type SubscribeMessage struct {
+	ToUser      string       `json:"touser"`                // 必须, 接受者OpenID
+	TemplateId  string       `json:"template_id"`           // 必须, 订阅消息模板ID
+	URL         string       `json:"url,omitempty"`         // 可选, 点击消息跳转的链接
+	MiniProgram *MiniProgram `json:"miniprogram,omitempty"` // 可选, 跳转小程序所需数据
+	Scene       string       `json:"scene"`                 // 必须, 订阅场景值, 和用户授权时的 scene 一致
+	Title       string       `json:"title"`                 // 必须, 消息标题，15字以内
+
+	// 必须, 消息正文, 目前只支持 {"content":{"value":"xxx","color":"#000000"}}, 可以通过 SetData 设置
 	RawJSONData json.RawMessage `json:"data"`
 }
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 构造一次性订阅消息的用户授权页面地址, 用户同意后会跳转到
+//  redirectURL?openid=OPENID&template_id=TEMPLATE_ID&action=ACTION&scene=SCENE&reserved=RESERVED
+//  scene:    0-10000 的整数值, 用来标识订阅场景值
+//  reserved: 用于保持请求和回调的状态, 可以为 ""
+func SubscribeAuthURL(appId string, scene int, templateId, redirectURL, reserved string) string {
+	return "https://mp.weixin.qq.com/mp/subscribemsg?action=get_confirm&appid=" + url.QueryEscape(appId) +
+		"&scene=" + strconv.Itoa(scene) +
+		"&template_id=" + url.QueryEscape(templateId) +
+		"&redirect_url=" + url.QueryEscape(redirectURL) +
+		"&reserved=" + url.QueryEscape(reserved) +
+		"#wechat_redirect"
+}
+
+// 发送一次性订阅消息, 用户每次授权只能下发一条.
+func (clt Client) SendSubscribe(msg *SubscribeMessage) (err error) {
+	if msg == nil {
+		return errors.New("nil SubscribeMessage")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/template/subscribe?access_token="
+	if err = clt.PostJSON(incompleteURL, msg, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,246 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 模板内容里的 {{xxx.DATA}}
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\.DATA\s*\}\}`)
+
+// 返回模板内容 content 里所有 {{xxx.DATA}} 的 xxx, 按出现的顺序, 不重复.
+func Placeholders(content string) (keys []string) {
+	seen := make(map[string]bool)
+	for _, m := range placeholderRegexp.FindAllStringSubmatch(content, -1) {
+		if key := m[1]; !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return
+}
+
+// 模板数据里一项的错误.
+type DataError struct {
+	Key    string
+	Value  string
+	Reason string
+}
+
+func (e *DataError) Error() string {
+	return "template data " + strconv.Quote(e.Key) + ": " + e.Reason
+}
+
+// ValidateData 返回的所有错误, 按 Key 排序.
+type DataErrors []*DataError
+
+func (errs DataErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// 参数类型的限制, 见订阅通知(和新版模板消息)的参数值内容限制说明.
+type dataRule struct {
+	maxLen int               // 最大字符数
+	check  func(string) bool // 可以为 nil
+	desc   string            // check 失败时的说明
+}
+
+var dataRules = map[string]dataRule{
+	"thing":            {maxLen: 20},
+	"number":           {maxLen: 32, check: isNumber, desc: "must be a number"},
+	"letter":           {maxLen: 32, check: isLetters, desc: "must be letters"},
+	"symbol":           {maxLen: 5},
+	"character_string": {maxLen: 32, check: isNotHan, desc: "must be digits, letters or symbols"},
+	"amount":           {maxLen: 12}, // 1 个币种符号 + 10 位以内的数字, 可以带小数, 结尾可以带"元"
+	"phone_number":     {maxLen: 17, check: isNotHan, desc: "must be digits or symbols"},
+	"car_number":       {maxLen: 8},
+	"name":             {maxLen: 20}, // 10 个以内的汉字或 20 个以内的字母
+	"phrase":           {maxLen: 5, check: isHan, desc: "must be Chinese characters"},
+}
+
+// 按模板内容 content 校验模板数据, 在调用接口之前发现会导致 47003(模板参数不准确)的错误:
+//  1. data 里的每个 key 都必须是 content 里的参数, content 里的每个参数都必须在 data 里;
+//  2. 参数名为 thing1, phrase2 这种 类型+序号 的格式时, 按类型检查长度和内容, 比如 thing 最多 20 个字符, phrase 只能是 5 个以内的汉字;
+//     其他的参数名(比如 first, keyword1, remark)不做检查.
+//
+//  没有错误时返回 nil, 否则返回 DataErrors.
+func ValidateData(content string, data Data) error {
+	var errs DataErrors
+
+	keys := Placeholders(content)
+	placeholders := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		placeholders[key] = true
+		if _, ok := data[key]; !ok {
+			errs = append(errs, &DataError{Key: key, Reason: "missing"})
+		}
+	}
+
+	for key, item := range data {
+		if !placeholders[key] {
+			errs = append(errs, &DataError{Key: key, Value: item.Value, Reason: "not in the template"})
+			continue
+		}
+		if e := validateDataItem(key, item.Value); e != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Key < errs[j].Key })
+	return errs
+}
+
+func validateDataItem(key, value string) *DataError {
+	rule, ok := dataRules[strings.TrimRight(key, "0123456789")]
+	if !ok {
+		return nil
+	}
+	if value == "" {
+		return &DataError{Key: key, Value: value, Reason: "empty value"}
+	}
+	if n := utf8.RuneCountInString(value); n > rule.maxLen {
+		return &DataError{
+			Key:    key,
+			Value:  value,
+			Reason: "too long: " + strconv.Itoa(n) + " characters, the limit is " + strconv.Itoa(rule.maxLen),
+		}
+	}
+	if rule.check != nil && !rule.check(value) {
+		return &DataError{Key: key, Value: value, Reason: rule.desc}
+	}
+	return nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isHan(s string) bool {
+	for _, r := range s {
+		if !unicode.Is(unicode.Han, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNotHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// 模板消息的校验器, 缓存账号下的模板列表(GetAllPrivateTemplate), 按模板内容校验消息的数据.
+//  NOTE: 模板列表变化(添加, 删除模板)后需要调用 Refresh; 找不到的 template_id 会自动刷新一次.
+type Validator struct {
+	clt Client
+
+	rwmutex   sync.RWMutex
+	templates map[string]*Template // template_id => *Template
+}
+
+func NewValidator(clt *mp.Client) *Validator {
+	if clt == nil {
+		panic("nil mp.Client")
+	}
+	return &Validator{
+		clt: Client{Client: clt},
+	}
+}
+
+// 重新获取账号下的模板列表.
+func (v *Validator) Refresh() (err error) {
+	list, err := v.clt.GetAllPrivateTemplate()
+	if err != nil {
+		return
+	}
+	templates := make(map[string]*Template, len(list))
+	for i := range list {
+		templates[list[i].TemplateId] = &list[i]
+	}
+
+	v.rwmutex.Lock()
+	v.templates = templates
+	v.rwmutex.Unlock()
+	return
+}
+
+// 获取 templateId 对应的模板, 没有缓存时刷新一次模板列表.
+func (v *Validator) Template(templateId string) (tpl *Template, err error) {
+	v.rwmutex.RLock()
+	tpl = v.templates[templateId]
+	v.rwmutex.RUnlock()
+	if tpl != nil {
+		return
+	}
+
+	if err = v.Refresh(); err != nil {
+		return
+	}
+	v.rwmutex.RLock()
+	tpl = v.templates[templateId]
+	v.rwmutex.RUnlock()
+	if tpl == nil {
+		err = errors.New("template not found: " + templateId)
+	}
+	return
+}
+
+// 按 templateId 对应模板的内容校验 data, 见 ValidateData.
+func (v *Validator) Validate(templateId string, data Data) (err error) {
+	tpl, err := v.Template(templateId)
+	if err != nil {
+		return
+	}
+	return ValidateData(tpl.Content, data)
+}
+
+// 校验模板消息, msg.RawJSONData 必须是 Data 的格式.
+//  一般在 Send 之前调用:
+//   if err := validator.ValidateMessage(msg); err != nil {
+//       return err
+//   }
+func (v *Validator) ValidateMessage(msg *TemplateMessage) (err error) {
+	if msg == nil {
+		return errors.New("nil TemplateMessage")
+	}
+	var data Data
+	if err = json.Unmarshal(msg.RawJSONData, &data); err != nil {
+		return
+	}
+	return v.Validate(msg.TemplateId, data)
+}
@@ -0,0 +1,87 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	content := "{{first.DATA}}\n商品: {{ thing1.DATA }}\n金额: {{amount2.DATA}}\n{{first.DATA}}{{remark}}"
+	want := []string{"first", "thing1", "amount2"}
+	if have := Placeholders(content); !reflect.DeepEqual(have, want) {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		data    Data
+		want    []string // DataErrors 的 Key:Reason
+	}{
+		{
+			name:    "valid",
+			content: "{{first.DATA}}{{thing1.DATA}}{{number2.DATA}}{{phrase3.DATA}}{{character_string4.DATA}}",
+			data: Data{
+				"first":             {Value: "任意内容, 不做检查, 长度也不限制"},
+				"thing1":            {Value: "二十个字以内的事物"},
+				"number2":           {Value: "12.5"},
+				"phrase3":           {Value: "已完成"},
+				"character_string4": {Value: "A-123_b"},
+			},
+		},
+		{
+			name:    "missing and unknown keys",
+			content: "{{thing1.DATA}}{{thing2.DATA}}",
+			data:    Data{"thing1": {Value: "a"}, "keyword1": {Value: "b"}},
+			want:    []string{"keyword1:not in the template", "thing2:missing"},
+		},
+		{
+			name:    "too long",
+			content: "{{thing1.DATA}}{{phrase2.DATA}}",
+			data:    Data{"thing1": {Value: strings.Repeat("字", 21)}, "phrase2": {Value: "五个字以内"}},
+			want:    []string{"thing1:too long: 21 characters, the limit is 20"},
+		},
+		{
+			name:    "wrong content",
+			content: "{{number1.DATA}}{{letter2.DATA}}{{phrase3.DATA}}{{phone_number4.DATA}}",
+			data: Data{
+				"number1":       {Value: "12a"},
+				"letter2":       {Value: "abc1"},
+				"phrase3":       {Value: "ok"},
+				"phone_number4": {Value: "电话"},
+			},
+			want: []string{
+				"letter2:must be letters",
+				"number1:must be a number",
+				"phone_number4:must be digits or symbols",
+				"phrase3:must be Chinese characters",
+			},
+		},
+		{
+			name:    "empty value",
+			content: "{{thing1.DATA}}{{remark.DATA}}",
+			data:    Data{"thing1": {}, "remark": {}},
+			want:    []string{"thing1:empty value"},
+		},
+	}
+	for _, tt := range tests {
+		err := ValidateData(tt.content, tt.data)
+		var have []string
+		if err != nil {
+			for _, e := range err.(DataErrors) {
+				have = append(have, e.Key+":"+e.Reason)
+			}
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s:\nhave: %q\nwant: %q", tt.name, have, tt.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bufio"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 默认的直方图 buckets, 单位为秒
+var DefaultHistogramBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var _ Metrics = (*PrometheusMetrics)(nil)
+
+// 不依赖 prometheus client 的 Metrics 实现, 同时也是一个 http.Handler,
+// 以 Prometheus 的 text 格式输出收集的指标:
+//  metrics := mp.NewPrometheusMetrics(nil)
+//  clt.Metrics = metrics
+//  http.Handle("/metrics", metrics)
+//  NOTE: 已经使用 prometheus client 的项目可以自己实现 Metrics, 把指标注册到 prometheus.Registerer.
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mutex      sync.Mutex
+	counters   map[string]map[string]float64
+	histograms map[string]map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // 和 buckets 一一对应, 非累积
+	sum    float64
+	count  uint64
+}
+
+// 创建 PrometheusMetrics, buckets 为 nil 时使用 DefaultHistogramBuckets.
+func NewPrometheusMetrics(buckets []float64) *PrometheusMetrics {
+	if buckets == nil {
+		buckets = DefaultHistogramBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusMetrics{
+		buckets:    buckets,
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*histogram),
+	}
+}
+
+func (m *PrometheusMetrics) IncCounter(name string, labels map[string]string) {
+	key := formatLabels(labels)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	series := m.counters[name]
+	if series == nil {
+		series = make(map[string]float64)
+		m.counters[name] = series
+	}
+	series[key]++
+}
+
+func (m *PrometheusMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	key := formatLabels(labels)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	series := m.histograms[name]
+	if series == nil {
+		series = make(map[string]*histogram)
+		m.histograms[name] = series
+	}
+	h := series[key]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		series[key] = h
+	}
+	for i, bound := range m.buckets {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// 以 Prometheus 的 text 格式输出所有指标.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		series := m.counters[name]
+		keys := make([]string, 0, len(series))
+		for key := range series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		bw.WriteString("# TYPE " + name + " counter\n")
+		for _, key := range keys {
+			bw.WriteString(name + braces(key) + " " + formatFloat(series[key]) + "\n")
+		}
+	}
+	names = names[:0]
+	for name := range m.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		series := m.histograms[name]
+		keys := make([]string, 0, len(series))
+		for key := range series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		bw.WriteString("# TYPE " + name + " histogram\n")
+		for _, key := range keys {
+			h := series[key]
+			var cumulative uint64
+			for i, bound := range m.buckets {
+				cumulative += h.counts[i]
+				bw.WriteString(name + "_bucket" + braces(joinLabels(key, `le="`+formatFloat(bound)+`"`)) +
+					" " + strconv.FormatUint(cumulative, 10) + "\n")
+			}
+			bw.WriteString(name + "_bucket" + braces(joinLabels(key, `le="+Inf"`)) + " " + strconv.FormatUint(h.count, 10) + "\n")
+			bw.WriteString(name + "_sum" + braces(key) + " " + formatFloat(h.sum) + "\n")
+			bw.WriteString(name + "_count" + braces(key) + " " + strconv.FormatUint(h.count, 10) + "\n")
+		}
+	}
+}
+
+// 把 labels 按 key 排序后格式化为 k1="v1",k2="v2"
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+`="`+escapeLabelValue(labels[k])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
+
+func joinLabels(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "," + b
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
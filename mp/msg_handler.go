@@ -120,6 +120,53 @@ type MixedMessage struct {
 	FriendUserName string `xml:"FriendUserName" json:"FriendUserName"`
 	UserCardCode   string `xml:"UserCardCode"   json:"UserCardCode"`
 	OuterId        int64  `xml:"OuterId"        json:"OuterId"`
+	OuterStr       string `xml:"OuterStr"       json:"OuterStr"`
+
+	OldUserCardCode     string `xml:"OldUserCardCode"     json:"OldUserCardCode"`
+	IsRestoreMemberCard int    `xml:"IsRestoreMemberCard" json:"IsRestoreMemberCard"`
+	RefuseReason        string `xml:"RefuseReason"        json:"RefuseReason"`
+	ConsumeSource       string `xml:"ConsumeSource"       json:"ConsumeSource"`
+	LocationName        string `xml:"LocationName"        json:"LocationName"`
+	StaffOpenId         string `xml:"StaffOpenId"         json:"StaffOpenId"`
+	IsReturnBack        int    `xml:"IsReturnBack"        json:"IsReturnBack"`
+	IsChatRoom          int    `xml:"IsChatRoom"          json:"IsChatRoom"`
+	Detail              string `xml:"Detail"              json:"Detail"`
+	TransId             string `xml:"TransId"             json:"TransId"`
+	LocationId          int64  `xml:"LocationId"          json:"LocationId"`
+	Fee                 string `xml:"Fee"                 json:"Fee"`
+	OriginalFee         string `xml:"OriginalFee"         json:"OriginalFee"`
+	ModifyBonus         int    `xml:"ModifyBonus"         json:"ModifyBonus"`
+	ModifyBalance       int    `xml:"ModifyBalance"       json:"ModifyBalance"`
+
+	// shakearound
+	ChosenBeacon struct {
+		UUID     string  `xml:"Uuid"     json:"Uuid"`
+		Major    int     `xml:"Major"    json:"Major"`
+		Minor    int     `xml:"Minor"    json:"Minor"`
+		Distance float64 `xml:"Distance" json:"Distance"`
+	} `xml:"ChosenBeacon" json:"ChosenBeacon"`
+	AroundBeacons []struct {
+		UUID     string  `xml:"Uuid"     json:"Uuid"`
+		Major    int     `xml:"Major"    json:"Major"`
+		Minor    int     `xml:"Minor"    json:"Minor"`
+		Distance float64 `xml:"Distance" json:"Distance"`
+	} `xml:"AroundBeacons>AroundBeacon,omitempty" json:"AroundBeacons,omitempty"`
+
+	// invoice
+	SuccOrderId    string `xml:"SuccOrderId"    json:"SuccOrderId"`
+	FailOrderId    string `xml:"FailOrderId"    json:"FailOrderId"`
+	AuthorizeAppId string `xml:"AuthorizeAppId" json:"AuthorizeAppId"`
+	Source         string `xml:"Source"         json:"Source"`
+	Code           string `xml:"Code"           json:"Code"`
+
+	InvoiceTitle     string `xml:"title"      json:"title"`
+	InvoicePhone     string `xml:"phone"      json:"phone"`
+	InvoiceTaxNo     string `xml:"tax_no"     json:"tax_no"`
+	InvoiceAddr      string `xml:"addr"       json:"addr"`
+	InvoiceBankType  string `xml:"bank_type"  json:"bank_type"`
+	InvoiceBankNo    string `xml:"bank_no"    json:"bank_no"`
+	InvoiceAttach    string `xml:"attach"     json:"attach"`
+	InvoiceTitleType string `xml:"title_type" json:"title_type"`
 
 	// poi
 	UniqId string `xml:"UniqId" json:"UniqId"`
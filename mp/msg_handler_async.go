@@ -0,0 +1,225 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 异步处理队列满时的策略
+const (
+	AsyncOverflowSync = iota // 在回调请求里同步处理, 可能超过 5 秒
+	AsyncOverflowDrop        // 丢弃消息, 回复空串
+)
+
+type AsyncOptions struct {
+	Workers   int // 处理消息的 goroutine 数, <= 0 时为 8
+	QueueSize int // 等待处理的消息数, <= 0 时为 1024
+	Overflow  int // 队列满时的策略, AsyncOverflowSync 或 AsyncOverflowDrop
+
+	// 回调请求等待处理结果的时间, 在这个时间内处理完就把 handler 的回复(被动回复)发给微信服务器,
+	// 否则先回复空串, handler 在后台继续处理, 之后的回复被丢弃.
+	// <= 0 表示不等待, 总是立即回复空串; 因为微信服务器 5 秒超时, 超过 4 秒时为 4 秒.
+	ReplyTimeout time.Duration
+
+	// 后台处理的超时, handler 里 Request.Context() 不随回调连接取消, 而是在 TaskTimeout 后取消, <= 0 时为 1 分钟.
+	TaskTimeout time.Duration
+}
+
+// 异步处理消息(事件)的 MessageHandler, 把消息放入有界队列交给后台的 goroutine 处理,
+// 避免处理较慢的业务逻辑超过微信服务器的 5 秒超时; 处理得快的话仍然可以被动回复, 见 AsyncOptions.ReplyTimeout.
+//
+//  NOTE: 立即回复后微信服务器不会重新推送, 后台处理失败需要自行补偿.
+type AsyncMessageHandler struct {
+	handler MessageHandler
+	opts    AsyncOptions
+
+	rwmutex sync.RWMutex
+	closed  bool
+	queue   chan *asyncTask
+	wg      sync.WaitGroup
+}
+
+type asyncTask struct {
+	w    *asyncResponseWriter
+	r    *Request
+	done chan struct{}
+}
+
+// 创建 AsyncMessageHandler 并启动后台的 goroutine, opts 可以为 nil.
+func NewAsyncMessageHandler(handler MessageHandler, opts *AsyncOptions) *AsyncMessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	h := &AsyncMessageHandler{handler: handler}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Workers <= 0 {
+		h.opts.Workers = 8
+	}
+	if h.opts.QueueSize <= 0 {
+		h.opts.QueueSize = 1024
+	}
+	if h.opts.ReplyTimeout > 4*time.Second {
+		h.opts.ReplyTimeout = 4 * time.Second
+	}
+	if h.opts.TaskTimeout <= 0 {
+		h.opts.TaskTimeout = time.Minute
+	}
+
+	h.queue = make(chan *asyncTask, h.opts.QueueSize)
+	h.wg.Add(h.opts.Workers)
+	for i := 0; i < h.opts.Workers; i++ {
+		go h.worker()
+	}
+	return h
+}
+
+func (h *AsyncMessageHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	task := &asyncTask{
+		w:    newAsyncResponseWriter(),
+		r:    r,
+		done: make(chan struct{}),
+	}
+
+	h.rwmutex.RLock()
+	queued := false
+	if !h.closed {
+		select {
+		case h.queue <- task:
+			queued = true
+		default:
+		}
+	}
+	h.rwmutex.RUnlock()
+
+	if !queued {
+		if h.opts.Overflow == AsyncOverflowDrop {
+			LogInfoln("[WECHAT_ASYNC] queue is full, drop message:", DedupKey(r.MixedMsg))
+			return
+		}
+		h.handler.ServeMessage(w, r)
+		return
+	}
+
+	if h.opts.ReplyTimeout <= 0 {
+		task.w.abandon()
+		return
+	}
+	timer := time.NewTimer(h.opts.ReplyTimeout)
+	defer timer.Stop()
+	select {
+	case <-task.done:
+		task.w.flushTo(w)
+	case <-timer.C:
+		task.w.abandon()
+	}
+}
+
+func (h *AsyncMessageHandler) worker() {
+	defer h.wg.Done()
+	for task := range h.queue {
+		h.run(task)
+	}
+}
+
+func (h *AsyncMessageHandler) run(task *asyncTask) {
+	defer close(task.done)
+	defer func() {
+		if e := recover(); e != nil {
+			LogInfoln("[WECHAT_ASYNC] handler panic:", e)
+		}
+	}()
+
+	ctx, cancel := task.r.DetachedContext(h.opts.TaskTimeout)
+	defer cancel()
+
+	r := *task.r
+	if r.HttpRequest != nil {
+		r.HttpRequest = r.HttpRequest.WithContext(ctx)
+	}
+	h.handler.ServeMessage(task.w, &r)
+}
+
+// 不再接收新的消息(之后的消息按 Overflow 策略处理), 等待队列里的消息处理完后返回.
+func (h *AsyncMessageHandler) Close() {
+	h.rwmutex.Lock()
+	if h.closed {
+		h.rwmutex.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.queue)
+	h.rwmutex.Unlock()
+
+	h.wg.Wait()
+}
+
+// 缓存 handler 的回复, 在 ReplyTimeout 内处理完才写入真正的 http.ResponseWriter.
+type asyncResponseWriter struct {
+	mutex     sync.Mutex
+	header    http.Header
+	status    int
+	body      bytes.Buffer
+	abandoned bool // 已经回复了微信服务器, 之后的写入都丢弃
+}
+
+func newAsyncResponseWriter() *asyncResponseWriter {
+	return &asyncResponseWriter{
+		header: make(http.Header),
+	}
+}
+
+func (w *asyncResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *asyncResponseWriter) WriteHeader(status int) {
+	w.mutex.Lock()
+	if w.status == 0 {
+		w.status = status
+	}
+	w.mutex.Unlock()
+}
+
+func (w *asyncResponseWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.abandoned {
+		return len(p), nil
+	}
+	return w.body.Write(p)
+}
+
+func (w *asyncResponseWriter) abandon() {
+	w.mutex.Lock()
+	w.abandoned = true
+	w.body.Reset()
+	w.mutex.Unlock()
+}
+
+func (w *asyncResponseWriter) flushTo(dst http.ResponseWriter) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for k, v := range w.header {
+		dst.Header()[k] = v
+	}
+	if w.status != 0 {
+		dst.WriteHeader(w.status)
+	}
+	dst.Write(w.body.Bytes())
+	w.abandoned = true
+}
@@ -0,0 +1,88 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestAsyncMessageHandlerReply(t *testing.T) {
+	tests := []struct {
+		name         string
+		replyTimeout time.Duration
+		delay        time.Duration // handler 处理的时间
+		want         string
+	}{
+		{"reply in time", time.Second, 0, "reply"},
+		{"reply too late", 10 * time.Millisecond, 100 * time.Millisecond, ""},
+		{"never wait", 0, 0, ""},
+	}
+	for _, tt := range tests {
+		var calls int32
+		h := mp.NewAsyncMessageHandler(mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+			time.Sleep(tt.delay)
+			io.WriteString(w, "reply")
+			atomic.AddInt32(&calls, 1)
+		}), &mp.AsyncOptions{ReplyTimeout: tt.replyTimeout})
+
+		w := httptest.NewRecorder()
+		h.ServeMessage(w, &mp.Request{MixedMsg: textMessage("u1", 1)})
+		h.Close()
+
+		if have := w.Body.String(); have != tt.want {
+			t.Errorf("%s: have reply %q, want %q", tt.name, have, tt.want)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("%s: have %d calls, want 1", tt.name, n)
+		}
+	}
+}
+
+func TestAsyncMessageHandlerOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		overflow  int
+		wantCalls int32
+		wantSync  bool // 第三个消息是否在 ServeMessage 里同步处理
+	}{
+		{"sync", mp.AsyncOverflowSync, 3, true},
+		{"drop", mp.AsyncOverflowDrop, 2, false},
+	}
+	for _, tt := range tests {
+		var calls int32
+		started, block := make(chan struct{}), make(chan struct{})
+		h := mp.NewAsyncMessageHandler(mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+			if r.MixedMsg.MsgId == 1 {
+				close(started)
+				<-block
+			}
+			io.WriteString(w, "reply")
+			atomic.AddInt32(&calls, 1)
+		}), &mp.AsyncOptions{Workers: 1, QueueSize: 1, Overflow: tt.overflow})
+
+		h.ServeMessage(httptest.NewRecorder(), &mp.Request{MixedMsg: textMessage("u1", 1)}) // 唯一的 worker 阻塞在这个消息上
+		<-started
+		h.ServeMessage(httptest.NewRecorder(), &mp.Request{MixedMsg: textMessage("u1", 2)}) // 占满队列
+		w := httptest.NewRecorder()
+		h.ServeMessage(w, &mp.Request{MixedMsg: textMessage("u1", 3)}) // 队列已满
+
+		if sync := w.Body.String() == "reply"; sync != tt.wantSync {
+			t.Errorf("%s: have sync %v, want %v", tt.name, sync, tt.wantSync)
+		}
+		close(block)
+		h.Close()
+		if n := atomic.LoadInt32(&calls); n != tt.wantCalls {
+			t.Errorf("%s: have %d calls, want %d", tt.name, n, tt.wantCalls)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 消息(事件)去重的存储, 多进程部署时需要用共享的存储实现, 比如 redis 的 SET key 1 NX EX ttl.
+type DedupStore interface {
+	// key 不存在时保存 key, ttl 之后过期, 返回 true; key 已经存在时返回 false.
+	//  NOTE: 必须是原子操作, 否则并发的重复推送仍然会被处理多次.
+	SetIfAbsent(key string, ttl time.Duration) (ok bool, err error)
+}
+
+// 微信服务器 5 秒内收不到响应会重新推送, 一共推送 3 次, 默认的去重时间远大于这个时间.
+const DefaultDedupTTL = 5 * time.Minute
+
+// 返回消息(事件)去重的 key.
+//  普通消息由 FromUserName + MsgId 确定; 事件没有 MsgId, 由 FromUserName + CreateTime + Event(+ EventKey) 确定.
+func DedupKey(msg *MixedMessage) string {
+	if msg.MsgId != 0 {
+		return "msg:" + msg.FromUserName + ":" + strconv.FormatInt(msg.MsgId, 10)
+	}
+	if msg.MsgID != 0 {
+		return "msg:" + msg.FromUserName + ":" + strconv.FormatInt(msg.MsgID, 10)
+	}
+	return "event:" + msg.FromUserName + ":" + strconv.FormatInt(msg.CreateTime, 10) + ":" + msg.Event + ":" + msg.EventKey
+}
+
+// 创建一个去重的 MessageHandler, 同一个消息(事件)只交给 handler 处理一次, 重复的推送直接回复空串.
+//  ttl <= 0 时为 DefaultDedupTTL.
+//
+//  NOTE:
+//  1. 去重是在交给 handler 之前做的, 所以 handler 处理失败(包括 panic)后微信服务器的重新推送也不会再处理,
+//     也就是至多处理一次;
+//  2. store 出错时不去重, 照常交给 handler 处理.
+//
+//  一般用来包装 MessageServeMux:
+//   srv := mp.NewDefaultServer(oriId, token, appId, aesKey, mp.NewDedupMessageHandler(mux, mp.NewMemoryDedupStore(10000), 0))
+func NewDedupMessageHandler(handler MessageHandler, store DedupStore, ttl time.Duration) MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if store == nil {
+		panic("nil DedupStore")
+	}
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &dedupMessageHandler{
+		handler: handler,
+		store:   store,
+		ttl:     ttl,
+	}
+}
+
+type dedupMessageHandler struct {
+	handler MessageHandler
+	store   DedupStore
+	ttl     time.Duration
+}
+
+func (h *dedupMessageHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	key := r.AppId + ":" + DedupKey(r.MixedMsg)
+	ok, err := h.store.SetIfAbsent(key, h.ttl)
+	if err != nil {
+		LogInfoln("[WECHAT_DEDUP] store failed:", err)
+		ok = true
+	}
+	if !ok {
+		return
+	}
+	h.handler.ServeMessage(w, r)
+}
+
+var _ DedupStore = (*MemoryDedupStore)(nil)
+
+// 单进程的 DedupStore 实现, 最多保存 capacity 个 key, 超过后淘汰最早保存的 key.
+type MemoryDedupStore struct {
+	capacity int
+
+	mutex sync.Mutex
+	list  *list.List // 按保存的时间排序, 最早的在前面, 元素为 *dedupEntry
+	keys  map[string]*list.Element
+}
+
+type dedupEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// capacity <= 0 时为 10000.
+func NewMemoryDedupStore(capacity int) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &MemoryDedupStore{
+		capacity: capacity,
+		list:     list.New(),
+		keys:     make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryDedupStore) SetIfAbsent(key string, ttl time.Duration) (ok bool, err error) {
+	now := time.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// 删除过期的 key
+	for e := s.list.Front(); e != nil; e = s.list.Front() {
+		entry := e.Value.(*dedupEntry)
+		if now.Before(entry.expiresAt) {
+			break
+		}
+		s.list.Remove(e)
+		delete(s.keys, entry.key)
+	}
+
+	if e := s.keys[key]; e != nil {
+		if now.Before(e.Value.(*dedupEntry).expiresAt) {
+			return false, nil
+		}
+		s.list.Remove(e)
+		delete(s.keys, key)
+	}
+	for s.list.Len() >= s.capacity {
+		e := s.list.Front()
+		s.list.Remove(e)
+		delete(s.keys, e.Value.(*dedupEntry).key)
+	}
+	s.keys[key] = s.list.PushBack(&dedupEntry{key: key, expiresAt: now.Add(ttl)})
+	return true, nil
+}
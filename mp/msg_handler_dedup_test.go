@@ -0,0 +1,143 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func textMessage(fromUserName string, msgId int64) *mp.MixedMessage {
+	msg := &mp.MixedMessage{MsgId: msgId}
+	msg.FromUserName = fromUserName
+	msg.CreateTime = 1500000000
+	msg.MsgType = "text"
+	return msg
+}
+
+func eventMessage(fromUserName string, createTime int64, event, eventKey string) *mp.MixedMessage {
+	msg := &mp.MixedMessage{Event: event, EventKey: eventKey}
+	msg.FromUserName = fromUserName
+	msg.CreateTime = createTime
+	msg.MsgType = "event"
+	return msg
+}
+
+func TestDedupKey(t *testing.T) {
+	msgID := textMessage("u1", 0)
+	msgID.MsgID = 1
+
+	tests := []struct {
+		a, b *mp.MixedMessage
+		same bool
+	}{
+		{textMessage("u1", 1), textMessage("u1", 1), true},
+		{textMessage("u1", 1), textMessage("u1", 2), false},
+		{textMessage("u1", 1), textMessage("u2", 1), false},
+		{textMessage("u1", 1), msgID, true}, // MsgId 和 MsgID 等价
+		{eventMessage("u1", 1, "subscribe", ""), eventMessage("u1", 1, "subscribe", ""), true},
+		{eventMessage("u1", 1, "subscribe", ""), eventMessage("u1", 2, "subscribe", ""), false},
+		{eventMessage("u1", 1, "CLICK", "a"), eventMessage("u1", 1, "CLICK", "b"), false},
+		{eventMessage("u1", 1, "CLICK", "a"), eventMessage("u1", 1, "VIEW", "a"), false},
+	}
+	for i, tt := range tests {
+		if same := mp.DedupKey(tt.a) == mp.DedupKey(tt.b); same != tt.same {
+			t.Errorf("%d: DedupKey(%q) == DedupKey(%q): have %v, want %v", i, mp.DedupKey(tt.a), mp.DedupKey(tt.b), same, tt.same)
+		}
+	}
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ttl      time.Duration
+		sleep    time.Duration // 第二次保存前等待的时间
+		filler   int           // 两次保存之间保存的其他 key 的个数
+		want     bool          // 第二次保存的结果
+	}{
+		{"duplicate", 10, time.Minute, 0, 0, false},
+		{"expired", 10, 10 * time.Millisecond, 20 * time.Millisecond, 0, true},
+		{"evicted", 2, time.Minute, 0, 2, true},
+		{"not evicted", 3, time.Minute, 0, 2, false},
+	}
+	for _, tt := range tests {
+		store := mp.NewMemoryDedupStore(tt.capacity)
+		if ok, err := store.SetIfAbsent("key", tt.ttl); !ok || err != nil {
+			t.Errorf("%s: first SetIfAbsent: have %v, %v", tt.name, ok, err)
+			continue
+		}
+		for i := 0; i < tt.filler; i++ {
+			store.SetIfAbsent("filler"+string(rune('a'+i)), tt.ttl)
+		}
+		time.Sleep(tt.sleep)
+		if ok, _ := store.SetIfAbsent("key", tt.ttl); ok != tt.want {
+			t.Errorf("%s: second SetIfAbsent: have %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+}
+
+type failingDedupStore struct{}
+
+func (failingDedupStore) SetIfAbsent(key string, ttl time.Duration) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func TestDedupMessageHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		store mp.DedupStore
+		msgs  []*mp.MixedMessage
+		appId []string
+		want  int // handler 被调用的次数
+	}{
+		{
+			name:  "retried message",
+			store: mp.NewMemoryDedupStore(0),
+			msgs:  []*mp.MixedMessage{textMessage("u1", 1), textMessage("u1", 1), textMessage("u1", 1)},
+			appId: []string{"app", "app", "app"},
+			want:  1,
+		},
+		{
+			name:  "different messages",
+			store: mp.NewMemoryDedupStore(0),
+			msgs:  []*mp.MixedMessage{textMessage("u1", 1), textMessage("u1", 2), eventMessage("u1", 1, "subscribe", "")},
+			appId: []string{"app", "app", "app"},
+			want:  3,
+		},
+		{
+			name:  "same message for different accounts",
+			store: mp.NewMemoryDedupStore(0),
+			msgs:  []*mp.MixedMessage{textMessage("u1", 1), textMessage("u1", 1)},
+			appId: []string{"app1", "app2"},
+			want:  2,
+		},
+		{
+			name:  "store error",
+			store: failingDedupStore{},
+			msgs:  []*mp.MixedMessage{textMessage("u1", 1), textMessage("u1", 1)},
+			appId: []string{"app", "app"},
+			want:  2,
+		},
+	}
+	for _, tt := range tests {
+		calls := 0
+		handler := mp.NewDedupMessageHandler(mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+			calls++
+		}), tt.store, 0)
+		for i, msg := range tt.msgs {
+			handler.ServeMessage(httptest.NewRecorder(), &mp.Request{MixedMsg: msg, AppId: tt.appId[i]})
+		}
+		if calls != tt.want {
+			t.Errorf("%s: have %d calls, want %d", tt.name, calls, tt.want)
+		}
+	}
+}
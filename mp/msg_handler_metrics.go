@@ -0,0 +1,78 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// 收到的消息(事件)个数, labels: msg_type, event, outcome
+	MetricServerMessagesTotal = "wechat_mp_server_messages_total"
+	// 处理消息(事件)的耗时(秒), labels: msg_type, event, outcome
+	MetricServerHandlerDuration = "wechat_mp_server_handler_duration_seconds"
+)
+
+const (
+	MetricOutcomeSuccess = "success"
+	MetricOutcomeFailure = "failure" // handler 回复了 4xx, 5xx 状态码或者 panic
+)
+
+// 创建一个统计消息(事件)类型和处理结果的 MessageHandler, 实际的处理交给 handler.
+// 一般用来包装 MessageServeMux:
+//  srv := mp.NewDefaultServer(oriId, token, appId, aesKey, mp.NewMetricsMessageHandler(mux, metrics))
+func NewMetricsMessageHandler(handler MessageHandler, metrics Metrics) MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if metrics == nil {
+		panic("nil Metrics")
+	}
+	return &metricsMessageHandler{
+		handler: handler,
+		metrics: metrics,
+	}
+}
+
+type metricsMessageHandler struct {
+	handler MessageHandler
+	metrics Metrics
+}
+
+func (h *metricsMessageHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	begin := time.Now()
+
+	panicked := true
+	defer func() {
+		outcome := MetricOutcomeSuccess
+		if panicked || sw.status >= 400 {
+			outcome = MetricOutcomeFailure
+		}
+		labels := map[string]string{
+			"msg_type": r.MixedMsg.MsgType,
+			"event":    r.MixedMsg.Event,
+			"outcome":  outcome,
+		}
+		h.metrics.IncCounter(MetricServerMessagesTotal, labels)
+		h.metrics.ObserveHistogram(MetricServerHandlerDuration, labels, time.Since(begin).Seconds())
+	}()
+
+	h.handler.ServeMessage(sw, r)
+	panicked = false
+}
+
+// 记录 handler 回复的 http 状态码
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,31 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package poi
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 获取门店类目表.
+//  每一项为用“,”隔开的各级类目, 比如 "美食,江浙菜,上海菜", 用于 PoiAddParameters 的 Categories.
+func (clt Client) GetWxCategory() (categoryList []string, err error) {
+	var result struct {
+		mp.Error
+		CategoryList []string `json:"category_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/poi/getwxcategory?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	categoryList = result.CategoryList
+	return
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package poi
+
+import (
+	"io"
+
+	"github.com/chanxuehong/wechat/mp/media"
+)
+
+// 门店图片
+type Photo struct {
+	PhotoURL string `json:"photo_url"`
+}
+
+// 把 UploadPhoto 返回的 url 转换为 PhotoList.
+func NewPhotoList(urls ...string) []Photo {
+	list := make([]Photo, len(urls))
+	for i, url := range urls {
+		list[i].PhotoURL = url
+	}
+	return list
+}
+
+// 上传门店图片, 返回的 url 用于 PoiAddParameters, PoiUpdateParameters 的 PhotoList.
+//  图片建议尺寸为 640*340px, 仅支持 jpg/png 格式, 大小不超过 1MB.
+func (clt Client) UploadPhoto(imgPath string) (url string, err error) {
+	info, err := (media.Client{Client: clt.Client}).UploadImagePermanent(imgPath)
+	if err != nil {
+		return
+	}
+	url = info.URL
+	return
+}
+
+// 同 UploadPhoto, 从 reader 读取图片, filename 用于判断图片格式, 比如 "shop.jpg".
+func (clt Client) UploadPhotoFromReader(filename string, reader io.Reader) (url string, err error) {
+	info, err := (media.Client{Client: clt.Client}).UploadImagePermanentFromReader(filename, reader)
+	if err != nil {
+		return
+	}
+	url = info.URL
+	return
+}
@@ -0,0 +1,225 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// QuotaBudget.Thresholds 为空时的告警阈值
+var DefaultQuotaThresholds = []float64{0.8, 0.9, 0.95}
+
+// 一个接口的每日调用次数预算.
+type QuotaBudget struct {
+	DailyLimit int       // 每日调用次数上限, 0 表示使用 QuotaMonitor.Sync 查询到的 daily_limit
+	Thresholds []float64 // 告警阈值, 为 DailyLimit 的比例, 比如 0.8 表示用掉 80% 时告警; 为空时使用 DefaultQuotaThresholds
+}
+
+// 接口调用次数达到阈值的告警
+type QuotaAlert struct {
+	Endpoint   string  // 比如 "/cgi-bin/message/custom/send"
+	Used       int     // 当天已经调用的次数
+	DailyLimit int     // 每日调用次数上限
+	Threshold  float64 // 达到的阈值
+}
+
+var _ Metrics = (*QuotaMonitor)(nil)
+
+// 在本地统计每个接口当天的调用次数, 达到预算的阈值时调用 OnAlert, 避免活动期间突然用完接口的调用次数.
+//  QuotaMonitor 实现了 Metrics, 从 MetricClientAttemptsTotal 统计调用次数(微信对每次 http 请求都计数, 包括重试), 一般这样用:
+//   monitor := mp.NewQuotaMonitor(func(alert *mp.QuotaAlert) { ... })
+//   monitor.SetBudget("/cgi-bin/message/custom/send", mp.QuotaBudget{DailyLimit: 500000})
+//   clt.Metrics = monitor // 同时使用其他 Metrics 时见 MultiMetrics
+//
+//  NOTE:
+//  1. 只能统计经过本进程的调用, 多个进程或者其他系统共用同一个账号时, 用 Sync 定期从 GetQuota 同步微信服务器统计的次数;
+//  2. 每个阈值每天只告警一次, 微信按北京时间零点清零调用次数, QuotaMonitor 同样在北京时间零点清零.
+type QuotaMonitor struct {
+	OnAlert func(alert *QuotaAlert) // 必须; 在 IncCounter 或 Sync 的 goroutine 里调用, 不要阻塞
+
+	mutex   sync.Mutex
+	day     string                  // 当天的日期, 北京时间
+	budgets map[string]*QuotaBudget // endpoint => *QuotaBudget
+	limits  map[string]int          // endpoint => Sync 查询到的 daily_limit
+	used    map[string]int          // endpoint => 当天已经调用的次数
+	fired   map[string]float64      // endpoint => 当天已经告警的最大阈值
+}
+
+func NewQuotaMonitor(onAlert func(alert *QuotaAlert)) *QuotaMonitor {
+	if onAlert == nil {
+		panic("nil onAlert")
+	}
+	return &QuotaMonitor{
+		OnAlert: onAlert,
+		budgets: make(map[string]*QuotaBudget),
+		limits:  make(map[string]int),
+		used:    make(map[string]int),
+		fired:   make(map[string]float64),
+	}
+}
+
+// 设置 endpoint 的预算, endpoint 为接口路径, 比如 "/cgi-bin/message/custom/send".
+//  没有设置预算的接口只统计次数, 不告警.
+func (m *QuotaMonitor) SetBudget(endpoint string, budget QuotaBudget) {
+	thresholds := budget.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = DefaultQuotaThresholds
+	}
+	budget.Thresholds = append([]float64(nil), thresholds...)
+	sort.Float64s(budget.Thresholds)
+
+	m.mutex.Lock()
+	m.budgets[endpoint] = &budget
+	m.mutex.Unlock()
+}
+
+// 记录 endpoint 的一次调用.
+func (m *QuotaMonitor) Record(endpoint string) {
+	m.mutex.Lock()
+	m.resetIfNewDay()
+	m.used[endpoint]++
+	alert := m.check(endpoint)
+	m.mutex.Unlock()
+
+	if alert != nil {
+		m.OnAlert(alert)
+	}
+}
+
+// 返回 endpoint 当天的调用次数.
+func (m *QuotaMonitor) Used(endpoint string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.resetIfNewDay()
+	return m.used[endpoint]
+}
+
+// 返回所有接口当天的调用次数, endpoint => 次数.
+func (m *QuotaMonitor) Usage() map[string]int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.resetIfNewDay()
+	usage := make(map[string]int, len(m.used))
+	for endpoint, n := range m.used {
+		usage[endpoint] = n
+	}
+	return usage
+}
+
+// 用 Client.GetQuota 查询 endpoint 当天的调用次数和上限, 更新本地的统计后检查预算.
+//  NOTE: GetQuota 本身也有调用次数限制, 不要频繁调用, 一般几分钟一次即可.
+func (m *QuotaMonitor) Sync(clt *Client, endpoint string) (quota *Quota, err error) {
+	if quota, err = clt.GetQuota(endpoint); err != nil {
+		return
+	}
+
+	m.mutex.Lock()
+	m.resetIfNewDay()
+	m.used[endpoint] = quota.Used
+	m.limits[endpoint] = quota.DailyLimit
+	alert := m.check(endpoint)
+	m.mutex.Unlock()
+
+	if alert != nil {
+		m.OnAlert(alert)
+	}
+	return
+}
+
+// 实现 Metrics, 统计 MetricClientAttemptsTotal.
+func (m *QuotaMonitor) IncCounter(name string, labels map[string]string) {
+	if name == MetricClientAttemptsTotal {
+		m.Record(labels["endpoint"])
+	}
+}
+
+func (m *QuotaMonitor) ObserveHistogram(name string, labels map[string]string, value float64) {}
+
+// 北京时间过了零点后清零.
+//  NOTE: 调用者负责加锁.
+func (m *QuotaMonitor) resetIfNewDay() {
+	day := time.Now().In(beijingLocation).Format("2006-01-02")
+	if day == m.day {
+		return
+	}
+	m.day = day
+	m.used = make(map[string]int)
+	m.fired = make(map[string]float64)
+}
+
+// 返回新达到的最大阈值的告警, 没有时返回 nil.
+//  NOTE: 调用者负责加锁.
+func (m *QuotaMonitor) check(endpoint string) *QuotaAlert {
+	budget := m.budgets[endpoint]
+	if budget == nil {
+		return nil
+	}
+	limit := budget.DailyLimit
+	if limit <= 0 {
+		limit = m.limits[endpoint]
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	used := m.used[endpoint]
+	var reached float64
+	for _, threshold := range budget.Thresholds {
+		if float64(used) >= threshold*float64(limit) {
+			reached = threshold
+		}
+	}
+	if reached <= m.fired[endpoint] {
+		return nil
+	}
+	m.fired[endpoint] = reached
+	return &QuotaAlert{
+		Endpoint:   endpoint,
+		Used:       used,
+		DailyLimit: limit,
+		Threshold:  reached,
+	}
+}
+
+// 把多个 Metrics 组合为一个, 比如同时使用 PrometheusMetrics 和 QuotaMonitor:
+//  clt.Metrics = mp.MultiMetrics{prometheusMetrics, quotaMonitor}
+type MultiMetrics []Metrics
+
+func (ms MultiMetrics) IncCounter(name string, labels map[string]string) {
+	for _, m := range ms {
+		m.IncCounter(name, labels)
+	}
+}
+
+func (ms MultiMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	for _, m := range ms {
+		m.ObserveHistogram(name, labels, value)
+	}
+}
+
+func (ms MultiMetrics) IncCounterWithExemplar(name string, labels, exemplar map[string]string) {
+	for _, m := range ms {
+		if em, ok := m.(ExemplarMetrics); ok {
+			em.IncCounterWithExemplar(name, labels, exemplar)
+		} else {
+			m.IncCounter(name, labels)
+		}
+	}
+}
+
+func (ms MultiMetrics) ObserveHistogramWithExemplar(name string, labels map[string]string, value float64, exemplar map[string]string) {
+	for _, m := range ms {
+		if em, ok := m.(ExemplarMetrics); ok {
+			em.ObserveHistogramWithExemplar(name, labels, value, exemplar)
+		} else {
+			m.ObserveHistogram(name, labels, value)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestQuotaMonitorCountsAttempts(t *testing.T) {
+	tests := []struct {
+		name    string
+		errCode int64
+		policy  *mp.RetryPolicy
+		want    int
+	}{
+		{"ok", mp.ErrCodeOK, nil, 1},
+		{"retried", mp.ErrCodeSystemBusy, &mp.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}, 3},
+		{"token refreshed", mp.ErrCodeInvalidCredential, nil, 2},
+	}
+	for _, tt := range tests {
+		srv := wechattest.NewServer()
+		srv.HandleFunc("/cgi-bin/test", func(w http.ResponseWriter, r *http.Request) {
+			w.Write(wechattest.ErrorJSON(tt.errCode, "error"))
+		})
+
+		var alerts []*mp.QuotaAlert
+		monitor := mp.NewQuotaMonitor(func(alert *mp.QuotaAlert) { alerts = append(alerts, alert) })
+		monitor.SetBudget("/cgi-bin/test", mp.QuotaBudget{DailyLimit: tt.want, Thresholds: []float64{1}})
+
+		clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+		clt.RetryPolicy = tt.policy
+		clt.Metrics = monitor
+		var result mp.Error
+		clt.GetJSON(testIncompleteURL, &result)
+
+		if have := monitor.Used("/cgi-bin/test"); have != tt.want {
+			t.Errorf("%s: have %d calls, want %d", tt.name, have, tt.want)
+		}
+		if len(alerts) != 1 || alerts[0].Used != tt.want {
+			t.Errorf("%s: have alerts %+v, want one alert at %d", tt.name, alerts, tt.want)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,92 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    mp.RateLimit
+		calls    int
+		wantOK   int   // 不需要等待的调用次数
+		wantLast error // 最后一次调用的错误
+	}{
+		{"unlimited", mp.RateLimit{}, 100, 100, nil},
+		{"burst", mp.RateLimit{Rate: 0.01, Burst: 3}, 4, 3, context.DeadlineExceeded},
+		{"burst defaults to 1", mp.RateLimit{Rate: 0.01}, 2, 1, context.DeadlineExceeded},
+		{"daily", mp.RateLimit{Daily: 2}, 3, 2, mp.ErrDailyQuotaExceeded},
+		{"daily and rate", mp.RateLimit{Rate: 1000, Burst: 10, Daily: 5}, 6, 5, mp.ErrDailyQuotaExceeded},
+	}
+	for _, tt := range tests {
+		limiter := mp.NewTokenBucketLimiter(tt.limit)
+		ok := 0
+		var err error
+		for i := 0; i < tt.calls; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			err = limiter.WaitContext(ctx, "/cgi-bin/test")
+			cancel()
+			if err == nil {
+				ok++
+			}
+		}
+		if ok != tt.wantOK || err != tt.wantLast {
+			t.Errorf("%s: have %d ok and last error %v, want %d ok and %v", tt.name, ok, err, tt.wantOK, tt.wantLast)
+		}
+	}
+}
+
+func TestTokenBucketLimiterSetLimit(t *testing.T) {
+	limiter := mp.NewTokenBucketLimiter(mp.RateLimit{Daily: 1})
+	limiter.SetLimit("/cgi-bin/message/custom/send", mp.RateLimit{Daily: 2})
+
+	tests := []struct {
+		endpoint string
+		want     error
+	}{
+		{"/cgi-bin/user/info", nil},
+		{"/cgi-bin/user/info", mp.ErrDailyQuotaExceeded},
+		{"/cgi-bin/user/get", nil}, // 每个 endpoint 单独计数
+		{"/cgi-bin/message/custom/send", nil},
+		{"/cgi-bin/message/custom/send", nil},
+		{"/cgi-bin/message/custom/send", mp.ErrDailyQuotaExceeded},
+	}
+	for i, tt := range tests {
+		if err := limiter.Wait(tt.endpoint); err != tt.want {
+			t.Errorf("%d: Wait(%q): have %v, want %v", i, tt.endpoint, err, tt.want)
+		}
+	}
+}
+
+func TestClientRateLimiterEndpoint(t *testing.T) {
+	srv := wechattest.NewServer()
+	defer srv.Close()
+	srv.HandleError("/cgi-bin/test", mp.ErrCodeOK, "ok")
+
+	limiter := mp.NewTokenBucketLimiter(mp.RateLimit{})
+	limiter.SetLimit("/cgi-bin/test", mp.RateLimit{Daily: 1})
+	clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+	clt.RateLimiter = limiter
+
+	var result mp.Error
+	if err := clt.GetJSON(testIncompleteURL, &result); err != nil {
+		t.Fatal(err)
+	}
+	if err := clt.GetJSON(testIncompleteURL, &result); !errors.Is(err, mp.ErrDailyQuotaExceeded) {
+		t.Errorf("have error %v, want %v", err, mp.ErrDailyQuotaExceeded)
+	}
+	if n := len(srv.RequestsTo("/cgi-bin/test")); n != 1 {
+		t.Errorf("have %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,35 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 同 util.RedactError, DisableRedaction 为 true 时原样返回.
+func (clt *Client) redactError(err error) error {
+	if clt.DisableRedaction {
+		return err
+	}
+	return util.RedactError(err)
+}
+
+// 同 util.MaskToken, DisableRedaction 为 true 时原样返回.
+func (clt *Client) maskToken(token string) string {
+	if clt.DisableRedaction {
+		return token
+	}
+	return util.MaskToken(token)
+}
+
+// 同 LogInfoln, DisableRedaction 为 true 时不隐藏敏感参数.
+func (clt *Client) logInfoln(v ...interface{}) {
+	if clt.DisableRedaction {
+		logln(v)
+		return
+	}
+	logln(util.RedactArgs(v))
+}
@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestClientDisableRedaction(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+	mp.SetLogInfoln(func(v ...interface{}) {
+		mu.Lock()
+		lines = append(lines, fmt.Sprintln(v...))
+		mu.Unlock()
+	})
+	defer mp.SetLogInfoln(nil)
+
+	tests := []struct {
+		name             string
+		disableRedaction bool
+		want             string
+	}{
+		{"redacted", false, "toke***"},
+		{"disabled", true, "token-expired"},
+	}
+	for _, tt := range tests {
+		lines = nil
+		srv := wechattest.NewServer()
+		srv.HandleError("/cgi-bin/test", mp.ErrCodeAccessTokenExpired, "access_token expired")
+
+		clt := mp.NewClient(wechattest.NewAccessTokenServer("token-expired"), srv.Client())
+		clt.DisableRedaction = tt.disableRedaction
+		var result mp.Error
+		clt.GetJSON(testIncompleteURL, &result)
+		srv.Close()
+
+		log := strings.Join(lines, "")
+		if !strings.Contains(log, "current token: "+tt.want) {
+			t.Errorf("%s: want token %q in log, have:\n%s", tt.name, tt.want, log)
+		}
+	}
+
+	// LogInfoln 总是隐藏敏感参数
+	lines = nil
+	mp.LogInfoln("url:", "https://api.weixin.qq.com/cgi-bin/test?access_token=ACCESS_TOKEN")
+	if len(lines) != 1 || strings.Contains(lines[0], "ACCESS_TOKEN") {
+		t.Errorf("LogInfoln: have %q", lines)
+	}
+}
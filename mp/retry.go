@@ -0,0 +1,132 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	ErrCodeSystemBusy        = -1    // 系统繁忙，此时请开发者稍候再试
+	ErrCodeAPIFreqOutOfLimit = 45009 // 接口调用超过限制
+)
+
+// 默认可以重试的 errcode
+var DefaultRetryableErrCodes = []int{ErrCodeSystemBusy, ErrCodeAPIFreqOutOfLimit}
+
+// Client.PostJSON, Client.GetJSON 的重试策略, 按指数退避加随机抖动的间隔重试.
+//  NOTE:
+//  1. access_token 失效的重试不受 RetryPolicy 影响, 也不计入 MaxAttempts;
+//  2. 网络错误不会重试, 因为无法知道微信服务器是否已经处理了请求(比如发送消息);
+//  3. 上传文件的接口不使用 RetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts    int           // 最多请求的次数(包括第一次), <= 1 表示不重试
+	InitialBackoff time.Duration // 第一次重试前等待的时间, <= 0 时为 200ms
+	MaxBackoff     time.Duration // 最长的等待时间, <= 0 时为 5s
+	Multiplier     float64       // 每次重试等待时间的倍数, < 1 时为 2
+	Jitter         float64       // 随机抖动的比例, 取值 [0, 1], 实际等待时间为 backoff * (1 ± Jitter)
+
+	RetryableErrCodes []int // 可以重试的 errcode, 为 nil 时为 DefaultRetryableErrCodes
+	RetryOn5xx        bool  // http 状态码为 5xx 时是否重试
+
+	// 不为 nil 时替代 RetryableErrCodes 和 RetryOn5xx 的判断.
+	//  attempt:    已经请求的次数, 从 1 开始
+	//  statusCode: http 状态码
+	//  errCode:    statusCode 为 200 时微信服务器返回的 errcode
+	ShouldRetry func(attempt, statusCode, errCode int) bool
+}
+
+// 创建一个常用的 RetryPolicy, 最多请求 maxAttempts 次, 5xx 和 DefaultRetryableErrCodes 都重试.
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryOn5xx:     true,
+	}
+}
+
+// 第 attempt 次请求失败后需要等待的时间.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = 200 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(backoff)
+	for i := 1; i < attempt && d < float64(maxBackoff); i++ {
+		d *= multiplier
+	}
+	if d > float64(maxBackoff) {
+		d = float64(maxBackoff)
+	}
+	if jitter := p.Jitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		d *= 1 + jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
+
+// p 可以为 nil, 表示不重试.
+func (p *RetryPolicy) shouldRetry(attempt, statusCode, errCode int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(attempt, statusCode, errCode)
+	}
+	if statusCode >= 500 {
+		return p.RetryOn5xx
+	}
+	if statusCode != 200 || errCode == ErrCodeOK {
+		return false
+	}
+	codes := p.RetryableErrCodes
+	if codes == nil {
+		codes = DefaultRetryableErrCodes
+	}
+	for _, code := range codes {
+		if code == errCode {
+			return true
+		}
+	}
+	return false
+}
+
+// 第 attempt 次请求失败后等待, 等待期间 ctx 取消时立即返回 ctx.Err(); ctx 可以为 nil.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	return sleepContext(ctx, p.Backoff(attempt))
+}
+
+// 等待 d, ctx 取消时立即返回 ctx.Err(); ctx 可以为 nil.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,89 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/wechattest"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		policy  mp.RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{mp.RetryPolicy{}, 1, 200 * time.Millisecond},
+		{mp.RetryPolicy{}, 2, 400 * time.Millisecond},
+		{mp.RetryPolicy{}, 10, 5 * time.Second},
+		{mp.RetryPolicy{InitialBackoff: time.Second, Multiplier: 3}, 3, 5 * time.Second},
+		{mp.RetryPolicy{InitialBackoff: time.Second, Multiplier: 3, MaxBackoff: time.Minute}, 3, 9 * time.Second},
+		{mp.RetryPolicy{InitialBackoff: time.Second, Multiplier: 0.5}, 2, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if have := tt.policy.Backoff(tt.attempt); have != tt.want {
+			t.Errorf("%+v.Backoff(%d): have %s, want %s", tt.policy, tt.attempt, have, tt.want)
+		}
+	}
+
+	policy := mp.RetryPolicy{InitialBackoff: time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if d := policy.Backoff(1); d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("Backoff with jitter 0.2: have %s, want in [800ms, 1200ms]", d)
+		}
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *mp.RetryPolicy
+		statusCode int
+		errCode    int64
+		want       int // 请求的次数
+	}{
+		{"nil policy", nil, http.StatusOK, mp.ErrCodeSystemBusy, 1},
+		{"system busy", &mp.RetryPolicy{MaxAttempts: 3}, http.StatusOK, mp.ErrCodeSystemBusy, 3},
+		{"freq out of limit", &mp.RetryPolicy{MaxAttempts: 3}, http.StatusOK, mp.ErrCodeAPIFreqOutOfLimit, 3},
+		{"not retryable errcode", &mp.RetryPolicy{MaxAttempts: 3}, http.StatusOK, 40013, 1},
+		{"custom errcodes", &mp.RetryPolicy{MaxAttempts: 2, RetryableErrCodes: []int{40013}}, http.StatusOK, 40013, 2},
+		{"5xx", &mp.RetryPolicy{MaxAttempts: 3, RetryOn5xx: true}, http.StatusBadGateway, 0, 3},
+		{"5xx disabled", &mp.RetryPolicy{MaxAttempts: 3}, http.StatusBadGateway, 0, 1},
+		{"4xx", &mp.RetryPolicy{MaxAttempts: 3, RetryOn5xx: true}, http.StatusNotFound, 0, 1},
+		{"max attempts 1", &mp.RetryPolicy{MaxAttempts: 1}, http.StatusOK, mp.ErrCodeSystemBusy, 1},
+		{
+			"ShouldRetry overrides",
+			&mp.RetryPolicy{MaxAttempts: 5, ShouldRetry: func(attempt, statusCode, errCode int) bool { return attempt < 2 }},
+			http.StatusOK, 40013, 2,
+		},
+	}
+	for _, tt := range tests {
+		srv := wechattest.NewServer()
+		srv.HandleFunc("/cgi-bin/test", func(w http.ResponseWriter, r *http.Request) {
+			if tt.statusCode != http.StatusOK {
+				http.Error(w, http.StatusText(tt.statusCode), tt.statusCode)
+				return
+			}
+			w.Write(wechattest.ErrorJSON(tt.errCode, "error"))
+		})
+
+		clt := mp.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+		clt.RetryPolicy = tt.policy
+		if clt.RetryPolicy != nil {
+			clt.RetryPolicy.InitialBackoff = time.Millisecond
+		}
+		var result mp.Error
+		clt.GetJSON(testIncompleteURL, &result)
+		if n := len(srv.RequestsTo("/cgi-bin/test")); n != tt.want {
+			t.Errorf("%s: have %d requests, want %d", tt.name, n, tt.want)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package semantic
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DateTimeTypeSingle   = "DT_SINGLE"   // 单个时间
+	DateTimeTypeInterval = "DT_INTERVAL" // 时间段
+	DateTimeTypeRepeat   = "DT_REPEAT"   // 重复时间
+	DateTimeTypeInfinity = "DT_INFINITY" // 无限时间
+)
+
+// 语义解析出来的日期时间, 比如 "明天下午三点"
+type DateTime struct {
+	Type      string `json:"type"`                 // DateTimeTypeSingle, DateTimeTypeInterval...
+	Date      string `json:"date,omitempty"`       // 公历日期, 格式 2006-01-02
+	DateOri   string `json:"date_ori,omitempty"`   // 原始日期字符串, 比如 "明天"
+	DateLunar string `json:"date_lunar,omitempty"` // 农历日期, 格式 2006-01-02
+	Time      string `json:"time,omitempty"`       // 时间, 格式 15:04:05
+	TimeOri   string `json:"time_ori,omitempty"`   // 原始时间字符串, 比如 "下午三点"
+	Week      string `json:"week,omitempty"`       // 星期, 1~7, 多个用逗号分隔
+	Repeat    string `json:"repeat,omitempty"`     // DT_REPEAT 时的重复类型
+
+	// DT_INTERVAL 时的结束日期时间
+	EndDate    string `json:"end_date,omitempty"`
+	EndDateOri string `json:"end_date_ori,omitempty"`
+	EndTime    string `json:"end_time,omitempty"`
+	EndTimeOri string `json:"end_time_ori,omitempty"`
+}
+
+// 微信返回的日期时间都是北京时间
+var beijingLocation = time.FixedZone("CST", 8*60*60)
+
+// 把 Date, Time 解析为 time.Time(北京时间), Time 为空时为当天零点.
+func (dt *DateTime) Parse() (t time.Time, err error) {
+	return parseDateTime(dt.Date, dt.Time)
+}
+
+// 把 EndDate, EndTime 解析为 time.Time(北京时间), EndDate 为空时使用 Date.
+func (dt *DateTime) ParseEnd() (t time.Time, err error) {
+	date := dt.EndDate
+	if date == "" {
+		date = dt.Date
+	}
+	return parseDateTime(date, dt.EndTime)
+}
+
+func parseDateTime(date, clock string) (t time.Time, err error) {
+	if date == "" {
+		err = errors.New("empty date")
+		return
+	}
+	if clock == "" {
+		return time.ParseInLocation("2006-01-02", date, beijingLocation)
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05", date+" "+clock, beijingLocation)
+}
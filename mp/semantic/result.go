@@ -0,0 +1,165 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package semantic
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// 服务类别
+const (
+	CategoryRestaurant = "restaurant" // 餐馆
+	CategoryMap        = "map"        // 地图
+	CategoryNearby     = "nearby"     // 周边
+	CategoryCoupon     = "coupon"     // 优惠
+	CategoryHotel      = "hotel"      // 酒店
+	CategoryFlight     = "flight"     // 航班
+	CategoryTrain      = "train"      // 火车
+	CategoryMovie      = "movie"      // 电影
+	CategoryWeather    = "weather"    // 天气
+)
+
+// 语义解析出来的地点
+type Location struct {
+	Type       string `json:"type"`                  // LOC_COUNTRY, LOC_PROVINCE, LOC_CITY, LOC_TOWN, LOC_POI, NORMAL_POI
+	Country    string `json:"country,omitempty"`     // 国家
+	Province   string `json:"province,omitempty"`    // 省全称
+	City       string `json:"city,omitempty"`        // 市全称
+	CitySimple string `json:"city_simple,omitempty"` // 市简称, 多个用逗号分隔
+	Town       string `json:"town,omitempty"`        // 县区全称
+	TownSimple string `json:"town_simple,omitempty"` // 县区简称
+	Poi        string `json:"poi,omitempty"`         // poi 详细地址
+	LocOri     string `json:"loc_ori,omitempty"`     // 用户语义中的原始地点字符串
+}
+
+type RestaurantDetails struct {
+	Location Location `json:"location"`
+	Name     string   `json:"name,omitempty"`     // 餐馆名称
+	Category string   `json:"category,omitempty"` // 菜系
+	Special  string   `json:"special,omitempty"`  // 特色菜
+	Price    string   `json:"price,omitempty"`    // 价格
+	Sort     int      `json:"sort,omitempty"`     // 排序类型
+}
+
+type MapDetails struct {
+	StartLoc Location `json:"start_loc"`
+	EndLoc   Location `json:"end_loc"`
+	Type     string   `json:"type,omitempty"` // 路线类型: 公交, 驾车, 步行
+}
+
+type NearbyDetails struct {
+	Location Location `json:"location"`
+	Keyword  string   `json:"keyword,omitempty"`
+	Sort     int      `json:"sort,omitempty"`
+}
+
+type CouponDetails struct {
+	Location Location `json:"location"`
+	Keyword  string   `json:"keyword,omitempty"`
+	Name     string   `json:"name,omitempty"`
+}
+
+type HotelDetails struct {
+	Location  Location `json:"location"`
+	StartDate DateTime `json:"start_date"` // 入住时间
+	EndDate   DateTime `json:"end_date"`   // 离店时间
+	Name      string   `json:"name,omitempty"`
+	Brand     string   `json:"brand,omitempty"`
+	Star      string   `json:"star,omitempty"`
+	Price     string   `json:"price,omitempty"`
+}
+
+type FlightDetails struct {
+	StartLoc  Location `json:"start_loc"`
+	EndLoc    Location `json:"end_loc"`
+	StartDate DateTime `json:"start_date"`
+	EndDate   DateTime `json:"end_date"`
+	Airline   string   `json:"airline,omitempty"`   // 航空公司
+	FlightNo  string   `json:"flight_no,omitempty"` // 航班号
+	Seat      string   `json:"seat,omitempty"`      // 舱位
+	Sort      int      `json:"sort,omitempty"`
+}
+
+type TrainDetails struct {
+	StartLoc  Location `json:"start_loc"`
+	EndLoc    Location `json:"end_loc"`
+	StartDate DateTime `json:"start_date"`
+	EndDate   DateTime `json:"end_date"`
+	Type      string   `json:"type,omitempty"`     // 火车类型
+	TrainNo   string   `json:"train_no,omitempty"` // 车次
+	Seat      string   `json:"seat,omitempty"`     // 座位类型
+	Sort      int      `json:"sort,omitempty"`
+}
+
+type MovieDetails struct {
+	Location  Location `json:"location"`
+	Name      string   `json:"name,omitempty"`     // 电影名称
+	Actor     string   `json:"actor,omitempty"`    // 演员
+	Director  string   `json:"director,omitempty"` // 导演
+	Tag       string   `json:"tag,omitempty"`      // 类型
+	Country   string   `json:"country,omitempty"`  // 国家
+	Cinema    string   `json:"cinema,omitempty"`   // 影院
+	StartDate DateTime `json:"start_date"`         // 放映时间
+	Sort      int      `json:"sort,omitempty"`
+}
+
+type WeatherDetails struct {
+	Location    Location `json:"location"`
+	StartDate   DateTime `json:"datetime"`
+	Type        int      `json:"type,omitempty"`         // 查询类型: 1 天气, 2 温度, 3 风力...
+	ContentType string   `json:"content_type,omitempty"` // 查询内容
+}
+
+// 语义理解的结果
+type Result struct {
+	Query    string `json:"query"` // 用户的输入字符串
+	Type     string `json:"type"`  // 服务的全局类型id, CategoryXxx
+	Semantic struct {
+		Details json.RawMessage `json:"details"` // 详细信息, 结构由 Type 决定, 用 Result.Details 解析
+		Intent  string          `json:"intent"`  // 查询类别, 比如 SEARCH
+	} `json:"semantic"`
+	Result json.RawMessage `json:"result,omitempty"` // 部分服务返回的结果
+	Answer string          `json:"answer,omitempty"` // 部分服务返回的答案
+	Text   string          `json:"text,omitempty"`   // 部分服务返回的提示语
+}
+
+var ErrUnknownCategory = errors.New("unknown semantic category")
+
+// 根据 Result.Type 把 Semantic.Details 解析为对应的结构体指针, 比如 *FlightDetails.
+//  未知的类别返回 ErrUnknownCategory, 这时候可以自行解析 Semantic.Details.
+func (result *Result) Details() (details interface{}, err error) {
+	switch result.Type {
+	case CategoryRestaurant:
+		details = new(RestaurantDetails)
+	case CategoryMap:
+		details = new(MapDetails)
+	case CategoryNearby:
+		details = new(NearbyDetails)
+	case CategoryCoupon:
+		details = new(CouponDetails)
+	case CategoryHotel:
+		details = new(HotelDetails)
+	case CategoryFlight:
+		details = new(FlightDetails)
+	case CategoryTrain:
+		details = new(TrainDetails)
+	case CategoryMovie:
+		details = new(MovieDetails)
+	case CategoryWeather:
+		details = new(WeatherDetails)
+	default:
+		err = ErrUnknownCategory
+		return
+	}
+	if len(result.Semantic.Details) == 0 {
+		return
+	}
+	if err = json.Unmarshal(result.Semantic.Details, details); err != nil {
+		details = nil
+	}
+	return
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package semantic
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 语义理解的请求参数
+type SearchRequest struct {
+	Query    string   // 必须; 输入文本串
+	Category []string // 必须; 需要使用的服务类别, CategoryXxx
+	AppId    string   // 必须; 公众号的 AppId
+	UID      string   // 可选; 用户唯一id(非开发者id), 用户区分公众号下的不同用户(建议填入用户openid), 需要多轮语义理解时必须填写
+
+	// 下面的参数 City 和 (Latitude, Longitude) 二选一
+	City      string  // 城市名称, 与经纬度二选一传入
+	Region    string  // 区域名称, 在城市存在的情况下可省, 与经纬度二选一传入
+	Latitude  float64 // 纬度坐标, 与经度同时传入; 与城市二选一传入
+	Longitude float64 // 经度坐标, 与纬度同时传入; 与城市二选一传入
+}
+
+func (req *SearchRequest) CheckValid() (err error) {
+	if req.Query == "" {
+		return errors.New("empty Query")
+	}
+	if len(req.Category) == 0 {
+		return errors.New("empty Category")
+	}
+	if req.AppId == "" {
+		return errors.New("empty AppId")
+	}
+	return
+}
+
+type searchRequest struct {
+	Query     string  `json:"query"`
+	Category  string  `json:"category"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Region    string  `json:"region,omitempty"`
+	AppId     string  `json:"appid"`
+	UID       string  `json:"uid,omitempty"`
+}
+
+// 语义理解, 返回的 result.Type 为命中的服务类别, 用 result.Details 获取对应类别的详细信息.
+func (clt Client) Search(req *SearchRequest) (result *Result, err error) {
+	if req == nil {
+		err = errors.New("nil SearchRequest")
+		return
+	}
+	if err = req.CheckValid(); err != nil {
+		return
+	}
+
+	var request = searchRequest{
+		Query:     req.Query,
+		Category:  strings.Join(req.Category, ","),
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		City:      req.City,
+		Region:    req.Region,
+		AppId:     req.AppId,
+		UID:       req.UID,
+	}
+
+	var response struct {
+		mp.Error
+		Result
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/semantic/semproxy/search?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &response); err != nil {
+		return
+	}
+
+	if response.ErrCode != mp.ErrCodeOK {
+		err = &response.Error
+		return
+	}
+	result = &response.Result
+	return
+}
@@ -19,7 +19,7 @@ import (
 	"net/url"
 	"strconv"
 
-	"github.com/chanxuehong/wechat/util"
+	"github.com/chanxuehong/wechat/cipher"
 )
 
 // 安全模式, 微信服务器推送过来的 http body
@@ -104,7 +104,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			token := srv.Token()
 
 			// 验证签名
-			msgSignature2 := util.MsgSign(token, timestampStr, nonce, requestHttpBody.EncryptedMsg)
+			msgSignature2 := cipher.MsgSign(token, timestampStr, nonce, requestHttpBody.EncryptedMsg)
 			if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
 				err = fmt.Errorf("check msg_signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
 				irh.ServeInvalidRequest(w, r, err)
@@ -121,7 +121,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			appId := srv.AppId()
 			aesKey := srv.CurrentAESKey()
 
-			random, rawMsgXML, err := util.AESDecryptMsg(encryptedMsgBytes, appId, aesKey)
+			random, rawMsgXML, err := cipher.Decrypt(encryptedMsgBytes, appId, aesKey)
 			if err != nil {
 				// 尝试用上一次的 AESKey 来解密
 				lastAESKey, isLastAESKeyValid := srv.LastAESKey()
@@ -132,7 +132,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 				aesKey = lastAESKey // NOTE
 
-				random, rawMsgXML, err = util.AESDecryptMsg(encryptedMsgBytes, appId, aesKey)
+				random, rawMsgXML, err = cipher.Decrypt(encryptedMsgBytes, appId, aesKey)
 				if err != nil {
 					irh.ServeInvalidRequest(w, r, err)
 					return
@@ -210,7 +210,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 			token := srv.Token()
 
-			signature2 := util.Sign(token, timestampStr, nonce)
+			signature2 := cipher.Sign(token, timestampStr, nonce)
 			if subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) != 1 {
 				err = fmt.Errorf("check signature failed, input: %s, local: %s", signature1, signature2)
 				irh.ServeInvalidRequest(w, r, err)
@@ -302,7 +302,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			return
 		}
 
-		signature2 := util.Sign(srv.Token(), timestamp, nonce)
+		signature2 := cipher.Sign(srv.Token(), timestamp, nonce)
 		if subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) != 1 {
 			err := fmt.Errorf("check signature failed, input: %s, local: %s", signature1, signature2)
 			irh.ServeInvalidRequest(w, r, err)
@@ -8,6 +8,9 @@ package mp
 import (
 	"errors"
 	"sync"
+
+	"github.com/chanxuehong/wechat/cipher"
+	"github.com/chanxuehong/wechat/util"
 )
 
 // 公众号服务端接口, 处理单个公众号的消息(事件)请求.
@@ -94,3 +97,24 @@ func (srv *DefaultServer) UpdateAESKey(aesKey []byte) (err error) {
 	srv.rwmutex.Unlock()
 	return
 }
+
+// 从 provider 获取 EncodingAESKey(43 个字符), 和当前的不同时调用 UpdateAESKey, 返回是否更新了.
+//  一般定时调用, 在公众平台修改 EncodingAESKey 后的过渡期内, 之前的 AESKey 仍然有效.
+func (srv *DefaultServer) SyncAESKey(provider util.SecretProvider) (updated bool, err error) {
+	encodedAESKey, err := provider.Secret()
+	if err != nil {
+		return
+	}
+	aesKey, err := cipher.DecodeAESKey(encodedAESKey)
+	if err != nil {
+		return
+	}
+	if aesKey == srv.CurrentAESKey() {
+		return
+	}
+	if err = srv.UpdateAESKey(aesKey[:]); err != nil {
+		return
+	}
+	updated = true
+	return
+}
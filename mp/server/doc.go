@@ -0,0 +1,24 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 公众号消息(事件)推送的回调服务器.
+//
+//  在 mp.ServerFrontend, mp.MessageServeMux 的基础上提供了按消息类型注册强类型处理函数的路由器,
+//  签名验证, AES 解密, 消息解析都由 mp 包完成.
+//
+//  mux := server.NewMux()
+//  mux.OnText(func(w http.ResponseWriter, r *mp.Request, msg *request.Text) {
+//      // TODO: 增加你的代码
+//  })
+//  mux.OnEventClick(func(w http.ResponseWriter, r *mp.Request, event *menu.ClickEvent) {
+//      // TODO: 增加你的代码
+//  })
+//
+//  handler, err := server.NewHandler(oriId, token, appId, encodedAESKey, mux, nil)
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  http.Handle("/wechat", handler)
+package server
@@ -0,0 +1,53 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package server
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 创建处理一个公众号消息(事件)请求的 http.Handler.
+//  oriId:         公众号的原始ID, 如果为空则不检查消息的 ToUserName
+//  encodedAESKey: 公众号后台的 EncodingAESKey, 明文模式可以为空
+//  irh:           可以为 nil
+func NewHandler(oriId, token, appId, encodedAESKey string, handler mp.MessageHandler,
+	irh mp.InvalidRequestHandler) (frontend *mp.ServerFrontend, err error) {
+
+	srv, err := NewServer(oriId, token, appId, encodedAESKey, handler)
+	if err != nil {
+		return
+	}
+	frontend = mp.NewServerFrontend(srv, irh, nil)
+	return
+}
+
+// 创建一个 mp.DefaultServer, 和 mp.NewDefaultServer 不同的是 AESKey 传入的是
+// 公众号后台的 EncodingAESKey, 并且明文模式下可以为空.
+func NewServer(oriId, token, appId, encodedAESKey string, handler mp.MessageHandler) (srv *mp.DefaultServer, err error) {
+	if token == "" {
+		err = errors.New("empty token")
+		return
+	}
+	if handler == nil {
+		err = errors.New("nil MessageHandler")
+		return
+	}
+
+	var aesKey []byte
+	if encodedAESKey == "" {
+		aesKey = make([]byte, 32) // 明文模式不会用到
+	} else {
+		if aesKey, err = util.AESKeyDecode(encodedAESKey); err != nil {
+			return
+		}
+	}
+
+	srv = mp.NewDefaultServer(oriId, token, appId, aesKey, handler)
+	return
+}
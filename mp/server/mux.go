@@ -0,0 +1,211 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package server
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/menu"
+	"github.com/chanxuehong/wechat/mp/message/mass"
+	"github.com/chanxuehong/wechat/mp/message/request"
+	"github.com/chanxuehong/wechat/mp/message/template"
+)
+
+var _ mp.MessageHandler = (*Mux)(nil)
+
+// Mux 在 mp.MessageServeMux 的基础上按照 MsgType/Event 注册强类型的处理函数,
+// 同时也是一个 mp.MessageHandler.
+//
+//  NOTE: 同一个 MsgType/Event 重复注册, 后注册的覆盖先注册的.
+type Mux struct {
+	*mp.MessageServeMux
+
+	rwmutex                sync.RWMutex
+	subscribeHandler       func(http.ResponseWriter, *mp.Request, *request.SubscribeEvent)
+	subscribeByScanHandler func(http.ResponseWriter, *mp.Request, *request.SubscribeByScanEvent)
+}
+
+func NewMux() *Mux {
+	return &Mux{
+		MessageServeMux: mp.NewMessageServeMux(),
+	}
+}
+
+// 文本消息
+func (mux *Mux) OnText(handler func(http.ResponseWriter, *mp.Request, *request.Text)) {
+	mux.MessageHandleFunc(request.MsgTypeText, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetText(r.MixedMsg))
+	})
+}
+
+// 图片消息
+func (mux *Mux) OnImage(handler func(http.ResponseWriter, *mp.Request, *request.Image)) {
+	mux.MessageHandleFunc(request.MsgTypeImage, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetImage(r.MixedMsg))
+	})
+}
+
+// 语音消息
+func (mux *Mux) OnVoice(handler func(http.ResponseWriter, *mp.Request, *request.Voice)) {
+	mux.MessageHandleFunc(request.MsgTypeVoice, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetVoice(r.MixedMsg))
+	})
+}
+
+// 视频消息
+func (mux *Mux) OnVideo(handler func(http.ResponseWriter, *mp.Request, *request.Video)) {
+	mux.MessageHandleFunc(request.MsgTypeVideo, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetVideo(r.MixedMsg))
+	})
+}
+
+// 小视频消息
+func (mux *Mux) OnShortVideo(handler func(http.ResponseWriter, *mp.Request, *request.ShortVideo)) {
+	mux.MessageHandleFunc(request.MsgTypeShortVideo, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetShortVideo(r.MixedMsg))
+	})
+}
+
+// 地理位置消息
+func (mux *Mux) OnLocation(handler func(http.ResponseWriter, *mp.Request, *request.Location)) {
+	mux.MessageHandleFunc(request.MsgTypeLocation, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetLocation(r.MixedMsg))
+	})
+}
+
+// 链接消息
+func (mux *Mux) OnLink(handler func(http.ResponseWriter, *mp.Request, *request.Link)) {
+	mux.MessageHandleFunc(request.MsgTypeLink, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetLink(r.MixedMsg))
+	})
+}
+
+// 关注事件(普通关注).
+//  NOTE: 如果没有通过 OnEventSubscribeByScan 注册扫码关注的处理函数, 扫码关注也会交给这个函数处理.
+func (mux *Mux) OnEventSubscribe(handler func(http.ResponseWriter, *mp.Request, *request.SubscribeEvent)) {
+	mux.rwmutex.Lock()
+	mux.subscribeHandler = handler
+	mux.rwmutex.Unlock()
+	mux.EventHandleFunc(request.EventTypeSubscribe, mux.serveSubscribe)
+}
+
+// 用户未关注时, 扫描带参数二维码进行关注的事件.
+func (mux *Mux) OnEventSubscribeByScan(handler func(http.ResponseWriter, *mp.Request, *request.SubscribeByScanEvent)) {
+	mux.rwmutex.Lock()
+	mux.subscribeByScanHandler = handler
+	mux.rwmutex.Unlock()
+	mux.EventHandleFunc(request.EventTypeSubscribe, mux.serveSubscribe)
+}
+
+// 关注和扫码关注的 Event 都是 subscribe, 根据 EventKey 是否以 qrscene_ 为前缀来区分.
+func (mux *Mux) serveSubscribe(w http.ResponseWriter, r *mp.Request) {
+	mux.rwmutex.RLock()
+	subscribeHandler := mux.subscribeHandler
+	subscribeByScanHandler := mux.subscribeByScanHandler
+	mux.rwmutex.RUnlock()
+
+	if subscribeByScanHandler != nil && strings.HasPrefix(r.MixedMsg.EventKey, "qrscene_") {
+		subscribeByScanHandler(w, r, request.GetSubscribeByScanEvent(r.MixedMsg))
+		return
+	}
+	if subscribeHandler != nil {
+		subscribeHandler(w, r, request.GetSubscribeEvent(r.MixedMsg))
+	}
+}
+
+// 取消关注事件
+func (mux *Mux) OnEventUnsubscribe(handler func(http.ResponseWriter, *mp.Request, *request.UnsubscribeEvent)) {
+	mux.EventHandleFunc(request.EventTypeUnsubscribe, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetUnsubscribeEvent(r.MixedMsg))
+	})
+}
+
+// 用户已关注时, 扫描带参数二维码的事件
+func (mux *Mux) OnEventScan(handler func(http.ResponseWriter, *mp.Request, *request.ScanEvent)) {
+	mux.EventHandleFunc(request.EventTypeScan, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetScanEvent(r.MixedMsg))
+	})
+}
+
+// 上报地理位置事件
+func (mux *Mux) OnEventLocation(handler func(http.ResponseWriter, *mp.Request, *request.LocationEvent)) {
+	mux.EventHandleFunc(request.EventTypeLocation, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, request.GetLocationEvent(r.MixedMsg))
+	})
+}
+
+// 点击菜单拉取消息时的事件
+func (mux *Mux) OnEventClick(handler func(http.ResponseWriter, *mp.Request, *menu.ClickEvent)) {
+	mux.EventHandleFunc(menu.EventTypeClick, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetClickEvent(r.MixedMsg))
+	})
+}
+
+// 点击菜单跳转链接时的事件
+func (mux *Mux) OnEventView(handler func(http.ResponseWriter, *mp.Request, *menu.ViewEvent)) {
+	mux.EventHandleFunc(menu.EventTypeView, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetViewEvent(r.MixedMsg))
+	})
+}
+
+// 扫码推事件
+func (mux *Mux) OnEventScanCodePush(handler func(http.ResponseWriter, *mp.Request, *menu.ScanCodePushEvent)) {
+	mux.EventHandleFunc(menu.EventTypeScanCodePush, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetScanCodePushEvent(r.MixedMsg))
+	})
+}
+
+// 扫码推事件且弹出“消息接收中”提示框的事件
+func (mux *Mux) OnEventScanCodeWaitMsg(handler func(http.ResponseWriter, *mp.Request, *menu.ScanCodeWaitMsgEvent)) {
+	mux.EventHandleFunc(menu.EventTypeScanCodeWaitMsg, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetScanCodeWaitMsgEvent(r.MixedMsg))
+	})
+}
+
+// 弹出系统拍照发图的事件
+func (mux *Mux) OnEventPicSysPhoto(handler func(http.ResponseWriter, *mp.Request, *menu.PicSysPhotoEvent)) {
+	mux.EventHandleFunc(menu.EventTypePicSysPhoto, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetPicSysPhotoEvent(r.MixedMsg))
+	})
+}
+
+// 弹出拍照或者相册发图的事件
+func (mux *Mux) OnEventPicPhotoOrAlbum(handler func(http.ResponseWriter, *mp.Request, *menu.PicPhotoOrAlbumEvent)) {
+	mux.EventHandleFunc(menu.EventTypePicPhotoOrAlbum, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetPicPhotoOrAlbumEvent(r.MixedMsg))
+	})
+}
+
+// 弹出微信相册发图器的事件
+func (mux *Mux) OnEventPicWeixin(handler func(http.ResponseWriter, *mp.Request, *menu.PicWeixinEvent)) {
+	mux.EventHandleFunc(menu.EventTypePicWeixin, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetPicWeixinEvent(r.MixedMsg))
+	})
+}
+
+// 弹出地理位置选择器的事件
+func (mux *Mux) OnEventLocationSelect(handler func(http.ResponseWriter, *mp.Request, *menu.LocationSelectEvent)) {
+	mux.EventHandleFunc(menu.EventTypeLocationSelect, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, menu.GetLocationSelectEvent(r.MixedMsg))
+	})
+}
+
+// 群发结果的事件
+func (mux *Mux) OnEventMassSendJobFinish(handler func(http.ResponseWriter, *mp.Request, *mass.MassSendJobFinishEvent)) {
+	mux.EventHandleFunc(mass.EventTypeMassSendJobFinish, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, mass.GetMassSendJobFinishEvent(r.MixedMsg))
+	})
+}
+
+// 模板消息发送结果的事件
+func (mux *Mux) OnEventTemplateSendJobFinish(handler func(http.ResponseWriter, *mp.Request, *template.TemplateSendJobFinishEvent)) {
+	mux.EventHandleFunc(template.EventTypeTemplateSendJobFinish, func(w http.ResponseWriter, r *mp.Request) {
+		handler(w, r, template.GetTemplateSendJobFinishEvent(r.MixedMsg))
+	})
+}
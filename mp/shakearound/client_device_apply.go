@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 申请设备ID的审核状态
+const (
+	AuditStatusRejected = 0 // 审核未通过
+	AuditStatusAuditing = 1 // 审核中
+	AuditStatusApproved = 2 // 审核已通过
+)
+
+// 申请设备ID的审核结果
+type ApplyStatus struct {
+	ApplyTime    int64  `json:"apply_time"`    // 提交申请的时间戳
+	AuditComment string `json:"audit_comment"` // 审核备注，对审核状态的文字说明
+	AuditStatus  int    `json:"audit_status"`  // 审核状态, AuditStatusRejected...
+	AuditTime    int64  `json:"audit_time"`    // 确定审核结果的时间戳，若状态为审核中，则该时间值为0
+}
+
+// 查询设备ID申请审核状态.
+//  applyId: 批次ID，申请设备ID时所返回的批次ID
+func (clt Client) ApplyDeviceStatus(applyId int) (status *ApplyStatus, err error) {
+	var request = struct {
+		ApplyId int `json:"apply_id"`
+	}{
+		ApplyId: applyId,
+	}
+
+	var result struct {
+		mp.Error
+		Data ApplyStatus `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/applystatus?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	status = &result.Data
+	return
+}
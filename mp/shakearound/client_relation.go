@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 设备与页面的关联关系
+type Relation struct {
+	DeviceId int    `json:"device_id"`
+	UUID     string `json:"uuid"`
+	Major    int    `json:"major"`
+	Minor    int    `json:"minor"`
+	PageId   int    `json:"page_id"`
+}
+
+// 查询设备关联的页面.
+//  deviceBase: 设备信息，包括device_id或UUID、major、minor
+func (clt Client) SearchRelationByDevice(deviceBase *DeviceBase) (relations []Relation, totalCount int, err error) {
+	if deviceBase == nil {
+		err = errors.New("nil DeviceBase")
+		return
+	}
+
+	var request = struct {
+		Type             int         `json:"type"`
+		DeviceIdentifier *DeviceBase `json:"device_identifier"`
+	}{
+		Type:             1,
+		DeviceIdentifier: deviceBase,
+	}
+	return clt.searchRelation(&request)
+}
+
+// 查询页面关联的设备.
+//  begin: 关联关系列表的起始索引值
+//  count: 待查询的关联关系数量，不能超过50个
+func (clt Client) SearchRelationByPage(pageId, begin, count int) (relations []Relation, totalCount int, err error) {
+	var request = struct {
+		Type   int `json:"type"`
+		PageId int `json:"page_id"`
+		Begin  int `json:"begin"`
+		Count  int `json:"count"`
+	}{
+		Type:   2,
+		PageId: pageId,
+		Begin:  begin,
+		Count:  count,
+	}
+	return clt.searchRelation(&request)
+}
+
+func (clt Client) searchRelation(request interface{}) (relations []Relation, totalCount int, err error) {
+	var result struct {
+		mp.Error
+		Data struct {
+			Relations  []Relation `json:"relations"`
+			TotalCount int        `json:"total_count"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/relation/search?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	relations = result.Data.Relations
+	totalCount = result.Data.TotalCount
+	return
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/chanxuehong/wechat/mp"
 )
 
-type statistic struct {
+// 摇周边的数据统计
+type Statistic struct {
 	Ftime int64 `json:"ftime"`		//当天0点对应的时间戳
 	ClickPv int `json:"click_pv"`	//打开摇周边页面的次数
 	ClickUv int `json:"click_uv"`	//打开摇周边页面的人数
@@ -20,7 +21,7 @@ type statistic struct {
 //	deviceBase:		设备信息，包括device_id或UUID、major、minor
 //	beginDate:		起始日期时间戳，最长时间跨度为30天
 //	endDate:		结束日期时间戳，最长时间跨度为30天
-func (clt Client) GetDeviceStatistics(deviceBase *DeviceBase, beginDate, endDate int64) (statistics *[]statistic, err error) {
+func (clt Client) GetDeviceStatistics(deviceBase *DeviceBase, beginDate, endDate int64) (statistics *[]Statistic, err error) {
 	var request = struct {
 		DeviceIdentifier *DeviceBase `json:"device_identifier"`
 		BeginDate int64 `json:"begin_date"`
@@ -33,7 +34,7 @@ func (clt Client) GetDeviceStatistics(deviceBase *DeviceBase, beginDate, endDate
 
 	var result struct {
 		mp.Error
-		Data []statistic `json:"data"`
+		Data []Statistic `json:"data"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/device?access_token="
@@ -53,7 +54,7 @@ func (clt Client) GetDeviceStatistics(deviceBase *DeviceBase, beginDate, endDate
 //	pageId:		指定页面的ID
 //	beginDate:		起始日期时间戳，最长时间跨度为30天
 //	endDate:		结束日期时间戳，最长时间跨度为30天
-func (clt Client) GetPageStatistics(pageId int, beginDate, endDate int64) (statistics *[]statistic, err error) {
+func (clt Client) GetPageStatistics(pageId int, beginDate, endDate int64) (statistics *[]Statistic, err error) {
 	var request = struct {
 		PageId int `json:"page_id"`
 		BeginDate int64 `json:"begin_date"`
@@ -66,7 +67,7 @@ func (clt Client) GetPageStatistics(pageId int, beginDate, endDate int64) (stati
 
 	var result struct {
 		mp.Error
-		Data []statistic `json:"data"`
+		Data []Statistic `json:"data"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/page?access_token="
@@ -80,4 +81,59 @@ func (clt Client) GetPageStatistics(pageId int, beginDate, endDate int64) (stati
 	}
 	statistics = &result.Data
 	return
-}
\ No newline at end of file
+}
+
+// 批量查询设备或者页面统计数据的一行
+type ListStatistic struct {
+	Statistic
+	DeviceId int    `json:"device_id,omitempty"` // 设备统计时有效
+	UUID     string `json:"uuid,omitempty"`
+	Major    int    `json:"major,omitempty"`
+	Minor    int    `json:"minor,omitempty"`
+	PageId   int    `json:"page_id,omitempty"` // 页面统计时有效
+}
+
+// 批量查询设备统计数据.
+//  date:      指定查询日期时间戳，单位为秒
+//  pageIndex: 指定查询的结果页序号；返回结果按摇周边人数降序排序，每50条记录为一页, 从1开始
+func (clt Client) GetDeviceListStatistics(date int64, pageIndex int) (list []ListStatistic, totalCount int, err error) {
+	return clt.getListStatistics("https://api.weixin.qq.com/shakearound/statistics/devicelist?access_token=", date, pageIndex)
+}
+
+// 批量查询页面统计数据.
+//  date:      指定查询日期时间戳，单位为秒
+//  pageIndex: 指定查询的结果页序号；返回结果按摇周边人数降序排序，每50条记录为一页, 从1开始
+func (clt Client) GetPageListStatistics(date int64, pageIndex int) (list []ListStatistic, totalCount int, err error) {
+	return clt.getListStatistics("https://api.weixin.qq.com/shakearound/statistics/pagelist?access_token=", date, pageIndex)
+}
+
+func (clt Client) getListStatistics(incompleteURL string, date int64, pageIndex int) (list []ListStatistic, totalCount int, err error) {
+	var request = struct {
+		Date      int64 `json:"date"`
+		PageIndex int   `json:"page_index"`
+	}{
+		Date:      date,
+		PageIndex: pageIndex,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			Devices []ListStatistic `json:"devices"`
+			Pages   []ListStatistic `json:"pages"`
+		} `json:"data"`
+		TotalCount int `json:"total_count"`
+	}
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = append(result.Data.Devices, result.Data.Pages...) // 只会有一个不为空
+	totalCount = result.TotalCount
+	return
+}
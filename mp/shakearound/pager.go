@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"context"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+const DevicePageSizeLimit = 50 // 查询设备列表, 每次最多返回 50 个
+
+// 逐个返回设备的遍历器, 需要时自动拉取下一页, 用法见 util.Iterator.
+type DevicePager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个设备, 遍历结束时返回 util.Done.
+func (pager *DevicePager) Next(ctx context.Context) (device *Device, err error) {
+	v, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return v.(*Device), nil
+}
+
+// 获取设备的 DevicePager, begin 为开始遍历的索引, applyId 为申请设备ID时返回的批次ID, 为 0 表示遍历所有设备.
+//  NOTE: 第一次调用 Next 时才拉取数据.
+func (clt Client) DevicePager(begin, applyId int) *DevicePager {
+	return &DevicePager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			devices, totalCount, err := Client{Client: clt.WithContext(ctx)}.SearchDeviceByCount(begin, DevicePageSizeLimit, applyId)
+			if err != nil {
+				return
+			}
+			var list []Device
+			if devices != nil {
+				list = *devices
+			}
+			items = make([]interface{}, len(list))
+			for i := range list {
+				items[i] = &list[i]
+			}
+			begin += len(list)
+			hasNext = len(list) > 0 && begin < totalCount
+			return
+		}),
+	}
+}
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/cipher"
+)
+
+// 验证回调 URL 上的 signature, 用常量时间比较.
+//  明文模式, 兼容模式和安全模式的回调 URL 上都有 signature, 所以可以用于所有的回调请求;
+//  安全模式下消息体的 msg_signature 见 cipher.VerifyMsgSignature.
+func VerifySignature(token, timestamp, nonce, signature string) bool {
+	if len(signature) != 40 { // sha1
+		return false
+	}
+	return cipher.VerifySignature(signature, token, timestamp, nonce)
+}
+
+// 验证回调请求签名的 http 中间件, 用于把回调接入已有的路由(chi, gin, echo 等)而不使用 Server.
+//  GET 请求为配置回调 URL 时的首次验证, 签名正确时直接回复 echostr, 不会调用 next;
+//  其他请求签名正确时交给 next 处理, 消息的解析和解密由 next 负责.
+//  签名错误时调用 irh, irh 为 nil 时回复 403 Forbidden.
+//
+//  chi:  r.With(mp.SignatureMiddleware(token, nil)).Handle("/wechat", handler)
+//  gin:  r.Any("/wechat", gin.WrapH(mp.SignatureMiddleware(token, nil)(handler)))
+//  echo: e.Any("/wechat", echo.WrapHandler(mp.SignatureMiddleware(token, nil)(handler)))
+func SignatureMiddleware(token string, irh InvalidRequestHandler) func(next http.Handler) http.Handler {
+	if irh == nil {
+		irh = forbiddenInvalidRequestHandler
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queryValues := r.URL.Query()
+			signature := queryValues.Get("signature")
+			if signature == "" {
+				irh.ServeInvalidRequest(w, r, errors.New("signature is empty"))
+				return
+			}
+			timestamp := queryValues.Get("timestamp")
+			if timestamp == "" {
+				irh.ServeInvalidRequest(w, r, errors.New("timestamp is empty"))
+				return
+			}
+			nonce := queryValues.Get("nonce")
+			if nonce == "" {
+				irh.ServeInvalidRequest(w, r, errors.New("nonce is empty"))
+				return
+			}
+			if !VerifySignature(token, timestamp, nonce, signature) {
+				irh.ServeInvalidRequest(w, r, errors.New("check signature failed"))
+				return
+			}
+
+			if r.Method == "GET" { // 首次验证
+				echostr := queryValues.Get("echostr")
+				if echostr == "" {
+					irh.ServeInvalidRequest(w, r, errors.New("echostr is empty"))
+					return
+				}
+				io.WriteString(w, echostr)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+var forbiddenInvalidRequestHandler = InvalidRequestHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+})
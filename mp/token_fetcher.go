@@ -0,0 +1,150 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 获取 access_token 的方法, 用于替换 DefaultAccessTokenServer 默认的 /cgi-bin/token 接口.
+//  比如企业内部有统一的 access_token 中控服务(多个出口 IP 各自获取 access_token 会导致之前的失效),
+//  可以实现 TokenFetcher 从中控服务获取, 其他的逻辑(缓存, 主动刷新, Client 的重试)保持不变.
+type TokenFetcher interface {
+	// 返回 access_token 和剩余的有效时间(秒).
+	FetchToken() (token string, expiresIn int64, err error)
+}
+
+type TokenFetcherFunc func() (token string, expiresIn int64, err error)
+
+func (fn TokenFetcherFunc) FetchToken() (token string, expiresIn int64, err error) {
+	return fn()
+}
+
+// 创建一个通过 fetcher 获取 access_token 的 DefaultAccessTokenServer.
+func NewDefaultAccessTokenServerWithFetcher(fetcher TokenFetcher) (srv *DefaultAccessTokenServer) {
+	if fetcher == nil {
+		panic("nil TokenFetcher")
+	}
+
+	srv = &DefaultAccessTokenServer{
+		fetcher:         fetcher,
+		resetTickerChan: make(chan time.Duration),
+		quitChan:        make(chan struct{}),
+	}
+
+	go srv.tokenDaemon(time.Hour * 24) // 启动 tokenDaemon
+	return
+}
+
+// 通过 srv.fetcher 获取 access_token, 调用者需要持有 srv.tokenGet 的锁.
+func (srv *DefaultAccessTokenServer) getTokenFromFetcher(timeNowUnix int64) (token accessTokenInfo, cached bool, err error) {
+	token.Token, token.ExpiresIn, err = srv.fetcher.FetchToken()
+	switch {
+	case err != nil:
+	case token.Token == "":
+		err = errors.New("empty access_token")
+	case token.ExpiresIn > 31556952: // 60*60*24*365.2425
+		err = errors.New("expires_in too large: " + strconv.FormatInt(token.ExpiresIn, 10))
+	case token.ExpiresIn <= 0: // 中控服务返回的是剩余的有效时间, 可能很小
+		err = errors.New("expires_in too small: " + strconv.FormatInt(token.ExpiresIn, 10))
+	}
+	if err != nil {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.Unlock()
+		return
+	}
+
+	// 更新 tokenGet 信息
+	srv.tokenGet.LastTokenInfo = token
+	srv.tokenGet.LastTimestamp = timeNowUnix
+
+	// 更新缓存
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = token.Token
+	srv.tokenCache.Unlock()
+	return
+}
+
+var _ TokenFetcher = (*StableTokenFetcher)(nil)
+
+// 通过 /cgi-bin/stable_token 接口获取 access_token.
+//  和 /cgi-bin/token 不同, 普通模式(ForceRefresh 为 false)下有效期内重复获取返回的是同一个 access_token,
+//  不会使之前的 access_token 失效, 所以多个进程各自获取也没有问题.
+//
+//  NOTE: 普通模式下, access_token 因为其他原因失效后, 在原来的有效期内获取到的仍然是失效的 access_token;
+//  强制刷新模式每天最多调用 20 次, 并且会使之前的 access_token 失效, 一般不要开启.
+type StableTokenFetcher struct {
+	AppId             string
+	AppSecret         string
+	AppSecretProvider util.SecretProvider // 不为 nil 时替代 AppSecret
+	ForceRefresh      bool
+	HttpClient        *http.Client // 为 nil 时使用 TextHttpClient
+	BaseURL           string       // 见 Client.BaseURL
+}
+
+func (f *StableTokenFetcher) FetchToken() (token string, expiresIn int64, err error) {
+	appSecret := f.AppSecret
+	if f.AppSecretProvider != nil {
+		if appSecret, err = f.AppSecretProvider.Secret(); err != nil {
+			return
+		}
+	}
+
+	var request = struct {
+		GrantType    string `json:"grant_type"`
+		AppId        string `json:"appid"`
+		Secret       string `json:"secret"`
+		ForceRefresh bool   `json:"force_refresh,omitempty"`
+	}{
+		GrantType:    "client_credential",
+		AppId:        f.AppId,
+		Secret:       appSecret,
+		ForceRefresh: f.ForceRefresh,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	httpClient := f.HttpClient
+	if httpClient == nil {
+		httpClient = TextHttpClient
+	}
+	httpResp, err := httpClient.Post(ReplaceBaseURL("https://api.weixin.qq.com/cgi-bin/stable_token", f.BaseURL), "application/json; charset=utf-8", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		Error
+		accessTokenInfo
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	token = result.Token
+	expiresIn = result.ExpiresIn
+	return
+}
@@ -0,0 +1,106 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	BatchGetUserInfoCountLimit = 100 // 批量获取用户基本信息, 一次最多拉取 100 个
+)
+
+// 批量获取用户基本信息, 没有订阅公众号的用户只有 OpenId 有效, 并且不在返回的 userinfos 里.
+//  lang 可以是 zh_CN, zh_TW, en, 如果留空 "" 则默认为 zh_CN;
+//  openIds 的个数不能超过 BatchGetUserInfoCountLimit, 更多的用户请使用 UserInfoList.
+func (clt Client) BatchGetUserInfo(openIds []string, lang string) (userinfos []UserInfo, err error) {
+	if len(openIds) <= 0 {
+		return
+	}
+	if len(openIds) > BatchGetUserInfoCountLimit {
+		err = fmt.Errorf("the length of openIds must be less than or equal to %d", BatchGetUserInfoCountLimit)
+		return
+	}
+
+	switch lang {
+	case "":
+		lang = Language_zh_CN
+	case Language_zh_CN, Language_zh_TW, Language_en:
+	default:
+		err = errors.New("invalid lang: " + lang)
+		return
+	}
+
+	type user struct {
+		OpenId string `json:"openid"`
+		Lang   string `json:"lang"`
+	}
+	var request struct {
+		UserList []user `json:"user_list"`
+	}
+	request.UserList = make([]user, len(openIds))
+	for i, openId := range openIds {
+		request.UserList[i] = user{OpenId: openId, Lang: lang}
+	}
+
+	var result struct {
+		mp.Error
+		UserInfoList []struct {
+			Subscribed int `json:"subscribe"`
+			UserInfo
+		} `json:"user_info_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/info/batchget?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	userinfos = make([]UserInfo, 0, len(result.UserInfoList))
+	for i := range result.UserInfoList {
+		if result.UserInfoList[i].Subscribed == 0 {
+			continue
+		}
+		userinfos = append(userinfos, result.UserInfoList[i].UserInfo)
+	}
+	return
+}
+
+// 获取 openIds 对应的用户基本信息, 会按 BatchGetUserInfoCountLimit 分批调用 BatchGetUserInfo.
+// 一般和 UserIterator 配合使用:
+//
+//  for iter.HasNext() {
+//      openids, err := iter.NextPage()
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      userinfos, err := clt.UserInfoList(openids, "")
+//      // TODO: 增加你的代码
+//  }
+func (clt Client) UserInfoList(openIds []string, lang string) (userinfos []UserInfo, err error) {
+	for len(openIds) > 0 {
+		n := len(openIds)
+		if n > BatchGetUserInfoCountLimit {
+			n = BatchGetUserInfoCountLimit
+		}
+
+		var infos []UserInfo
+		if infos, err = clt.BatchGetUserInfo(openIds[:n], lang); err != nil {
+			return
+		}
+		userinfos = append(userinfos, infos...)
+		openIds = openIds[n:]
+	}
+	return
+}
@@ -0,0 +1,79 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	BatchBlackListCountLimit = 20 // 拉黑/取消拉黑用户, 一次最多 20 个
+)
+
+// 获取公众号的黑名单列表, 每次最多能获取 10000 个用户, 如果 beginOpenId == "" 则表示从头获取.
+func (clt Client) BlackList(beginOpenId string) (data *UserListResult, err error) {
+	var request = struct {
+		BeginOpenId string `json:"begin_openid"`
+	}{
+		BeginOpenId: beginOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		UserListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/members/getblacklist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.UserListResult
+	return
+}
+
+// 拉黑用户, openIdList 的个数不能超过 BatchBlackListCountLimit.
+func (clt Client) BatchBlackList(openIdList []string) (err error) {
+	return clt.batchBlackList("https://api.weixin.qq.com/cgi-bin/tags/members/batchblacklist?access_token=", openIdList)
+}
+
+// 取消拉黑用户, openIdList 的个数不能超过 BatchBlackListCountLimit.
+func (clt Client) BatchUnblackList(openIdList []string) (err error) {
+	return clt.batchBlackList("https://api.weixin.qq.com/cgi-bin/tags/members/batchunblacklist?access_token=", openIdList)
+}
+
+func (clt Client) batchBlackList(incompleteURL string, openIdList []string) (err error) {
+	if len(openIdList) <= 0 {
+		return
+	}
+	if len(openIdList) > BatchBlackListCountLimit {
+		return errors.New("the length of openIdList must be less than or equal to 20")
+	}
+
+	var request = struct {
+		OpenIdList []string `json:"openid_list,omitempty"`
+	}{
+		OpenIdList: openIdList,
+	}
+
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
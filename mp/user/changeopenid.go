@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ChangeOpenIdCountLimit = 100 // 公众号迁移转换 openid, 一次最多转换 100 个
+)
+
+// 转换 openid 的一行结果
+type ChangeOpenIdResult struct {
+	OriOpenId string `json:"ori_openid"`           // 原账号的 openid
+	NewOpenId string `json:"new_openid,omitempty"` // 新账号的 openid, 转换失败时为空
+	ErrMsg    string `json:"err_msg,omitempty"`    // 转换成功为 "ok", 否则为失败的原因, 比如 "ori_openid error"
+}
+
+// 是否转换成功
+func (r *ChangeOpenIdResult) OK() bool {
+	return r.NewOpenId != "" && (r.ErrMsg == "" || r.ErrMsg == "ok")
+}
+
+// 公众号迁移后, 把原账号(fromAppId)的 openid 转换成新账号(调用者)的 openid.
+//  NOTE: 只能在迁移完成后的 15 天内调用, 需要用新账号的 access_token 调用;
+//  openIds 的个数不能超过 ChangeOpenIdCountLimit, 更多的用户请使用 ChangeOpenIdList.
+func (clt Client) ChangeOpenId(fromAppId string, openIds []string) (results []ChangeOpenIdResult, err error) {
+	if fromAppId == "" {
+		err = errors.New("empty fromAppId")
+		return
+	}
+	if len(openIds) <= 0 {
+		return
+	}
+	if len(openIds) > ChangeOpenIdCountLimit {
+		err = fmt.Errorf("the length of openIds must be less than or equal to %d", ChangeOpenIdCountLimit)
+		return
+	}
+
+	var request = struct {
+		FromAppId  string   `json:"from_appid"`
+		OpenIdList []string `json:"openid_list"`
+	}{
+		FromAppId:  fromAppId,
+		OpenIdList: openIds,
+	}
+
+	var result struct {
+		mp.Error
+		ResultList []ChangeOpenIdResult `json:"result_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/changeopenid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.ResultList
+	return
+}
+
+// 转换 openIds, 会按 ChangeOpenIdCountLimit 分批调用 ChangeOpenId.
+//  mapping 为转换成功的 原openid => 新openid, failures 为转换失败的行(包括失败原因);
+//  某一批调用出错时立即返回, 之前批次的结果仍然有效, 调用者可以跳过已经转换的 openid 后重试.
+func (clt Client) ChangeOpenIdList(fromAppId string, openIds []string) (mapping map[string]string, failures []ChangeOpenIdResult, err error) {
+	mapping = make(map[string]string, len(openIds))
+	for len(openIds) > 0 {
+		n := len(openIds)
+		if n > ChangeOpenIdCountLimit {
+			n = ChangeOpenIdCountLimit
+		}
+
+		var results []ChangeOpenIdResult
+		if results, err = clt.ChangeOpenId(fromAppId, openIds[:n]); err != nil {
+			return
+		}
+		for i := range results {
+			if results[i].OK() {
+				mapping[results[i].OriOpenId] = results[i].NewOpenId
+			} else {
+				failures = append(failures, results[i])
+			}
+		}
+		openIds = openIds[n:]
+	}
+	return
+}
@@ -26,8 +26,8 @@ const (
 type UserIterator struct {
 	lastUserListData *UserListResult // 最近一次获取的用户数据
 
-	clt            Client // 关联的微信 Client
-	nextPageCalled bool   // NextPage() 是否调用过
+	list           func(beginOpenId string) (*UserListResult, error) // 拉取下一页的函数
+	nextPageCalled bool                                               // NextPage() 是否调用过
 }
 
 func (iter *UserIterator) Total() int {
@@ -72,7 +72,7 @@ func (iter *UserIterator) NextPage() (openids []string, err error) {
 	}
 
 	// 不是第一次调用的都要从服务器拉取数据
-	data, err := iter.clt.UserList(iter.lastUserListData.NextOpenId)
+	data, err := iter.list(iter.lastUserListData.NextOpenId)
 	if err != nil {
 		return
 	}
@@ -84,14 +84,31 @@ func (iter *UserIterator) NextPage() (openids []string, err error) {
 
 // 获取用户遍历器, beginOpenId 表示开始遍历用户, 如果 beginOpenId == "" 则表示从头遍历.
 func (clt Client) UserIterator(beginOpenId string) (iter *UserIterator, err error) {
-	data, err := clt.UserList(beginOpenId)
+	return newUserIterator(clt.UserList, beginOpenId)
+}
+
+// 获取标签下粉丝的遍历器, beginOpenId 表示开始遍历用户, 如果 beginOpenId == "" 则表示从头遍历.
+//  NOTE: 返回的 UserIterator.Total() 无意义.
+func (clt Client) TagUserIterator(tagId int64, beginOpenId string) (iter *UserIterator, err error) {
+	return newUserIterator(func(beginOpenId string) (*UserListResult, error) {
+		return clt.TagUserList(tagId, beginOpenId)
+	}, beginOpenId)
+}
+
+// 获取黑名单的遍历器, beginOpenId 表示开始遍历用户, 如果 beginOpenId == "" 则表示从头遍历.
+func (clt Client) BlackListIterator(beginOpenId string) (iter *UserIterator, err error) {
+	return newUserIterator(clt.BlackList, beginOpenId)
+}
+
+func newUserIterator(list func(beginOpenId string) (*UserListResult, error), beginOpenId string) (iter *UserIterator, err error) {
+	data, err := list(beginOpenId)
 	if err != nil {
 		return
 	}
 
 	iter = &UserIterator{
 		lastUserListData: data,
-		clt:              clt,
+		list:             list,
 		nextPageCalled:   false,
 	}
 	return
@@ -4,4 +4,5 @@
 // @authors     chanxuehong(chanxuehong@gmail.com)
 
 // 网页授权获取用户基本信息.
+//  企业号的网页授权见 github.com/chanxuehong/wechat/corp/oauth2.
 package oauth2
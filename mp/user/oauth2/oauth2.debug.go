@@ -61,6 +61,8 @@ type Client struct {
 	*OAuth2Token // 程序会自动更新最新的 OAuth2Token 到这个字段, 如有必要该字段可以保存起来
 
 	HttpClient *http.Client // 如果 httpClient == nil 则默认用 http.DefaultClient
+
+	TokenStorage TokenStorage // 可以为 nil; 不为 nil 时获取或刷新 OAuth2Token 后会自动保存
 }
 
 func (clt *Client) httpClient() *http.Client {
@@ -97,6 +99,7 @@ func (clt *Client) Exchange(code string) (token *OAuth2Token, err error) {
 
 	clt.OAuth2Token = tk
 	token = tk
+	err = clt.putToken(tk)
 	return
 }
 
@@ -124,6 +127,7 @@ func (clt *Client) TokenRefresh() (token *OAuth2Token, err error) {
 	}
 
 	token = clt.OAuth2Token
+	err = clt.putToken(token)
 	return
 }
 
@@ -0,0 +1,255 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSession = errors.New("invalid session token")
+	ErrSessionExpired = errors.New("session token expired")
+)
+
+// 网页授权成功后的登录会话
+type Session struct {
+	OpenId    string `json:"openid"`
+	UnionId   string `json:"unionid,omitempty"`
+	ExpiresAt int64  `json:"exp"` // 过期时间, unixtime
+}
+
+// SessionSigner 用 HMAC-SHA256 对 Session 签名, 生成可以放到 cookie 里的 token.
+type SessionSigner struct {
+	key    []byte
+	maxAge time.Duration
+}
+
+// 创建 SessionSigner.
+//  key:    签名的密钥, 建议不少于 32 字节, 修改后之前签发的 token 都会失效
+//  maxAge: token 的有效期
+func NewSessionSigner(key []byte, maxAge time.Duration) *SessionSigner {
+	if len(key) == 0 {
+		panic("empty key")
+	}
+	if maxAge <= 0 {
+		panic("maxAge must be greater than 0")
+	}
+	return &SessionSigner{
+		key:    append([]byte(nil), key...),
+		maxAge: maxAge,
+	}
+}
+
+// 为 openId, unionId 签发 token, token 的格式为 base64url(payload) + "." + base64url(signature).
+func (signer *SessionSigner) Sign(openId, unionId string) (token string, session *Session, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+	session = &Session{
+		OpenId:    openId,
+		UnionId:   unionId,
+		ExpiresAt: time.Now().Add(signer.maxAge).Unix(),
+	}
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	token = encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signer.signature(encodedPayload))
+	return
+}
+
+// 校验 token 的签名和有效期, 成功则返回对应的 Session.
+func (signer *SessionSigner) Verify(token string) (session *Session, err error) {
+	i := strings.IndexByte(token, '.')
+	if i <= 0 {
+		err = ErrInvalidSession
+		return
+	}
+	encodedPayload, encodedSignature := token[:i], token[i+1:]
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		err = ErrInvalidSession
+		return
+	}
+	if !hmac.Equal(signature, signer.signature(encodedPayload)) {
+		err = ErrInvalidSession
+		return
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		err = ErrInvalidSession
+		return
+	}
+	var sess Session
+	if err = json.Unmarshal(payload, &sess); err != nil {
+		err = ErrInvalidSession
+		return
+	}
+	if sess.OpenId == "" {
+		err = ErrInvalidSession
+		return
+	}
+	if time.Now().Unix() >= sess.ExpiresAt {
+		err = ErrSessionExpired
+		return
+	}
+	session = &sess
+	return
+}
+
+func (signer *SessionSigner) signature(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, signer.key)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
+
+// SessionHandler 完成网页授权的跳转流程, 并把签名后的 Session 写入 cookie.
+//  1. 需要登录的页面调用 Login, 跳转到微信授权页面;
+//  2. OAuth2Config.RedirectURL 对应的页面调用 Callback, 成功后跳转到业务页面;
+//  3. 业务页面调用 Session 获取当前登录的用户.
+type SessionHandler struct {
+	Config *OAuth2Config
+	Signer *SessionSigner
+
+	CookieName string       // session cookie 的名字, 为空则为 "wechat_session"
+	CookiePath string       // 为空则为 "/"
+	Secure     *bool        // cookie 的 Secure 属性, 为 nil 时为 true; 本地用 http 调试时可以设置为 false
+	HttpClient *http.Client // 如果 HttpClient == nil 则默认用 http.DefaultClient
+}
+
+func (h *SessionHandler) cookieName() string {
+	if h.CookieName != "" {
+		return h.CookieName
+	}
+	return "wechat_session"
+}
+
+func (h *SessionHandler) stateCookieName() string {
+	return h.cookieName() + "_state"
+}
+
+func (h *SessionHandler) cookiePath() string {
+	if h.CookiePath != "" {
+		return h.CookiePath
+	}
+	return "/"
+}
+
+// 生成 SessionHandler 使用的 cookie, 统一设置 Path, Secure, HttpOnly 和 SameSite.
+//  SameSite 为 Lax, 从微信授权页面跳转回来的 GET 请求仍然会带上 cookie.
+func (h *SessionHandler) newCookie(name, value string) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     h.cookiePath(),
+		Secure:   h.Secure == nil || *h.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// 生成随机的 state 写入 cookie, 并跳转到微信的授权页面.
+func (h *SessionHandler) Login(w http.ResponseWriter, r *http.Request) (err error) {
+	if h.Config == nil {
+		return errors.New("没有提供 OAuth2Config")
+	}
+
+	var b [16]byte
+	if _, err = rand.Read(b[:]); err != nil {
+		return
+	}
+	state := hex.EncodeToString(b[:])
+
+	cookie := h.newCookie(h.stateCookieName(), state)
+	cookie.MaxAge = 600
+	http.SetCookie(w, cookie)
+	http.Redirect(w, r, h.Config.AuthCodeURL(state), http.StatusFound)
+	return
+}
+
+// 处理授权后的回调: 校验 state, 用 code 换取 openid/unionid, 签发 Session 并写入 cookie.
+//  NOTE: 成功后调用者负责跳转到业务页面; 用户禁止授权时返回错误.
+func (h *SessionHandler) Callback(w http.ResponseWriter, r *http.Request) (session *Session, err error) {
+	if h.Config == nil {
+		err = errors.New("没有提供 OAuth2Config")
+		return
+	}
+	if h.Signer == nil {
+		err = errors.New("没有提供 SessionSigner")
+		return
+	}
+
+	queryValues := r.URL.Query()
+	stateCookie, err := r.Cookie(h.stateCookieName())
+	if err != nil {
+		err = errors.New("state cookie not found")
+		return
+	}
+	if state := queryValues.Get("state"); state == "" || state != stateCookie.Value {
+		err = errors.New("state mismatch")
+		return
+	}
+	cookie := h.newCookie(h.stateCookieName(), "")
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+
+	code := queryValues.Get("code")
+	if code == "" {
+		err = errors.New("用户禁止授权")
+		return
+	}
+
+	clt := Client{
+		OAuth2Config: h.Config,
+		HttpClient:   h.HttpClient,
+	}
+	token, err := clt.Exchange(code)
+	if err != nil {
+		return
+	}
+
+	tokenStr, session, err := h.Signer.Sign(token.OpenId, token.UnionId)
+	if err != nil {
+		return
+	}
+	cookie = h.newCookie(h.cookieName(), tokenStr)
+	cookie.Expires = time.Unix(session.ExpiresAt, 0)
+	http.SetCookie(w, cookie)
+	return
+}
+
+// 从请求的 cookie 里获取并校验 Session.
+func (h *SessionHandler) Session(r *http.Request) (session *Session, err error) {
+	if h.Signer == nil {
+		err = errors.New("没有提供 SessionSigner")
+		return
+	}
+	cookie, err := r.Cookie(h.cookieName())
+	if err != nil {
+		return
+	}
+	return h.Signer.Verify(cookie.Value)
+}
+
+// 删除 session cookie.
+func (h *SessionHandler) Logout(w http.ResponseWriter) {
+	cookie := h.newCookie(h.cookieName(), "")
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+}
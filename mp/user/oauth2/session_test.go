@@ -0,0 +1,43 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSessionHandlerCookie(t *testing.T) {
+	insecure := false
+	tests := []struct {
+		name   string
+		secure *bool
+		want   bool
+	}{
+		{"default", nil, true},
+		{"insecure", &insecure, false},
+	}
+	for _, tt := range tests {
+		h := &SessionHandler{
+			Config: NewOAuth2Config("appid", "secret", "https://example.com/callback", "snsapi_base"),
+			Secure: tt.secure,
+		}
+		w := httptest.NewRecorder()
+		// 请求本身是 http, Secure 只由 SessionHandler.Secure 决定
+		if err := h.Login(w, httptest.NewRequest("GET", "http://example.com/login", nil)); err != nil {
+			t.Fatal(err)
+		}
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 {
+			t.Fatalf("%s: have %d cookies, want 1", tt.name, len(cookies))
+		}
+		if c := cookies[0]; c.Secure != tt.want || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+			t.Errorf("%s: have Secure=%v HttpOnly=%v SameSite=%v, want Secure=%v HttpOnly=true SameSite=Lax",
+				tt.name, c.Secure, c.HttpOnly, c.SameSite, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+var ErrTokenNotFound = errors.New("oauth2 token not found")
+
+// 用户 OAuth2Token 的存储接口, 以 OpenId 为 key.
+type TokenStorage interface {
+	// 获取 openId 对应的 OAuth2Token, 没有找到返回 ErrTokenNotFound.
+	Token(openId string) (token *OAuth2Token, err error)
+	// 保存 OAuth2Token, 已经存在则覆盖.
+	PutToken(token *OAuth2Token) (err error)
+}
+
+func (clt *Client) putToken(token *OAuth2Token) (err error) {
+	if clt.TokenStorage == nil {
+		return
+	}
+	return clt.TokenStorage.PutToken(token)
+}
+
+// 从 storage 中加载 openId 的 OAuth2Token, 创建一个新的 Client, 后续刷新的 OAuth2Token 也会保存到 storage.
+//  如果 httpClient == nil 则默认用 http.DefaultClient
+func NewClientFromStorage(cfg *OAuth2Config, storage TokenStorage, openId string, httpClient *http.Client) (clt *Client, err error) {
+	if cfg == nil {
+		err = errors.New("nil OAuth2Config")
+		return
+	}
+	if storage == nil {
+		err = errors.New("nil TokenStorage")
+		return
+	}
+
+	token, err := storage.Token(openId)
+	if err != nil {
+		return
+	}
+	clt = &Client{
+		OAuth2Config: cfg,
+		OAuth2Token:  token,
+		HttpClient:   httpClient,
+		TokenStorage: storage,
+	}
+	return
+}
+
+var _ TokenStorage = (*MemoryTokenStorage)(nil)
+
+// TokenStorage 的简单实现, 保存在内存里, 用于单进程环境.
+type MemoryTokenStorage struct {
+	rwmutex sync.RWMutex
+	tokens  map[string]OAuth2Token
+}
+
+func NewMemoryTokenStorage() *MemoryTokenStorage {
+	return &MemoryTokenStorage{
+		tokens: make(map[string]OAuth2Token),
+	}
+}
+
+func (storage *MemoryTokenStorage) Token(openId string) (token *OAuth2Token, err error) {
+	storage.rwmutex.RLock()
+	tk, ok := storage.tokens[openId]
+	storage.rwmutex.RUnlock()
+
+	if !ok {
+		err = ErrTokenNotFound
+		return
+	}
+	token = &tk
+	return
+}
+
+func (storage *MemoryTokenStorage) PutToken(token *OAuth2Token) (err error) {
+	if token == nil {
+		return errors.New("nil OAuth2Token")
+	}
+	if token.OpenId == "" {
+		return errors.New("empty OpenId")
+	}
+
+	tk := *token
+	tk.Scopes = append([]string(nil), token.Scopes...)
+
+	storage.rwmutex.Lock()
+	storage.tokens[tk.OpenId] = tk
+	storage.rwmutex.Unlock()
+	return
+}
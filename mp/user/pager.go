@@ -0,0 +1,68 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"context"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 逐个返回 openid 的遍历器, 需要时自动拉取下一页, 用法见 util.Iterator.
+type OpenIdPager struct {
+	iter *util.Iterator
+}
+
+// 返回下一个 openid, 遍历结束时返回 util.Done.
+func (pager *OpenIdPager) Next(ctx context.Context) (openId string, err error) {
+	item, err := pager.iter.Next(ctx)
+	if err != nil {
+		return
+	}
+	return item.(string), nil
+}
+
+// 关注者的 OpenIdPager, beginOpenId 表示开始遍历用户, 如果 beginOpenId == "" 则表示从头遍历.
+//  NOTE: 第一次调用 Next 时才拉取数据.
+func (clt Client) OpenIdPager(beginOpenId string) *OpenIdPager {
+	return newOpenIdPager(clt, func(clt Client, beginOpenId string) (*UserListResult, error) {
+		return clt.UserList(beginOpenId)
+	}, beginOpenId)
+}
+
+// 标签下粉丝的 OpenIdPager.
+func (clt Client) TagOpenIdPager(tagId int64, beginOpenId string) *OpenIdPager {
+	return newOpenIdPager(clt, func(clt Client, beginOpenId string) (*UserListResult, error) {
+		return clt.TagUserList(tagId, beginOpenId)
+	}, beginOpenId)
+}
+
+// 黑名单的 OpenIdPager.
+func (clt Client) BlackListOpenIdPager(beginOpenId string) *OpenIdPager {
+	return newOpenIdPager(clt, func(clt Client, beginOpenId string) (*UserListResult, error) {
+		return clt.BlackList(beginOpenId)
+	}, beginOpenId)
+}
+
+func newOpenIdPager(clt Client, list func(clt Client, beginOpenId string) (*UserListResult, error), beginOpenId string) *OpenIdPager {
+	nextOpenId := beginOpenId
+	return &OpenIdPager{
+		iter: util.NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+			data, err := list(Client{Client: clt.WithContext(ctx)}, nextOpenId)
+			if err != nil {
+				return
+			}
+			items = make([]interface{}, len(data.Data.OpenId))
+			for i, openId := range data.Data.OpenId {
+				items[i] = openId
+			}
+			// 见 UserIterator.HasNext 的说明, 没有更多用户时 next_openid 也可能不为空
+			hasNext = data.NextOpenId != "" && data.GotCount == UserPageSizeLimit
+			nextOpenId = data.NextOpenId
+			return
+		}),
+	}
+}
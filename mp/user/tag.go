@@ -0,0 +1,230 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	TagCountLimit          = 100 // 一个公众号，最多可以创建100个标签
+	BatchTaggingCountLimit = 50  // 批量为用户打标签, 每次传入的openid列表个数不能超过50个
+)
+
+// 用户标签
+type Tag struct {
+	Id        int64  `json:"id"`    // 标签id, 由微信分配
+	Name      string `json:"name"`  // 标签名, UTF8编码
+	UserCount int    `json:"count"` // 此标签下粉丝数
+}
+
+// 创建标签.
+//  name: 标签名（30个字符以内）
+func (clt Client) TagCreate(name string) (tag *Tag, err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+
+	var request struct {
+		Tag struct {
+			Name string `json:"name"`
+		} `json:"tag"`
+	}
+	request.Tag.Name = name
+
+	var result struct {
+		mp.Error
+		Tag `json:"tag"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/create?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.Tag.UserCount = 0 //
+	tag = &result.Tag
+	return
+}
+
+// 获取公众号已创建的标签.
+func (clt Client) TagList() (tags []Tag, err error) {
+	var result struct {
+		mp.Error
+		Tags []Tag `json:"tags"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/get?access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tags = result.Tags
+	return
+}
+
+// 编辑标签.
+func (clt Client) TagUpdate(tagId int64, name string) (err error) {
+	if name == "" {
+		return errors.New("empty name")
+	}
+
+	var request struct {
+		Tag struct {
+			Id   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"tag"`
+	}
+	request.Tag.Id = tagId
+	request.Tag.Name = name
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除标签.
+//  NOTE: 当某个标签下的粉丝超过10w时，后台不可直接删除标签, 需要先取消标签.
+func (clt Client) TagDelete(tagId int64) (err error) {
+	var request struct {
+		Tag struct {
+			Id int64 `json:"id"`
+		} `json:"tag"`
+	}
+	request.Tag.Id = tagId
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取标签下粉丝列表, 每次最多能获取 10000 个用户, 如果 beginOpenId == "" 则表示从头获取.
+//  返回的 data.TotalCount 无意义.
+func (clt Client) TagUserList(tagId int64, beginOpenId string) (data *UserListResult, err error) {
+	var request = struct {
+		TagId      int64  `json:"tagid"`
+		NextOpenId string `json:"next_openid"`
+	}{
+		TagId:      tagId,
+		NextOpenId: beginOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		UserListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/tag/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.UserListResult
+	return
+}
+
+// 批量为用户打标签, openIdList 的个数不能超过 BatchTaggingCountLimit.
+func (clt Client) BatchTagging(openIdList []string, tagId int64) (err error) {
+	return clt.batchTagging("https://api.weixin.qq.com/cgi-bin/tags/members/batchtagging?access_token=", openIdList, tagId)
+}
+
+// 批量为用户取消标签, openIdList 的个数不能超过 BatchTaggingCountLimit.
+func (clt Client) BatchUntagging(openIdList []string, tagId int64) (err error) {
+	return clt.batchTagging("https://api.weixin.qq.com/cgi-bin/tags/members/batchuntagging?access_token=", openIdList, tagId)
+}
+
+func (clt Client) batchTagging(incompleteURL string, openIdList []string, tagId int64) (err error) {
+	if len(openIdList) <= 0 {
+		return
+	}
+	if len(openIdList) > BatchTaggingCountLimit {
+		return errors.New("the length of openIdList must be less than or equal to 50")
+	}
+
+	var request = struct {
+		OpenIdList []string `json:"openid_list,omitempty"`
+		TagId      int64    `json:"tagid"`
+	}{
+		OpenIdList: openIdList,
+		TagId:      tagId,
+	}
+
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取用户身上的标签列表.
+func (clt Client) UserTagIdList(openId string) (tagIdList []int64, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: openId,
+	}
+
+	var result struct {
+		mp.Error
+		TagIdList []int64 `json:"tagid_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/getidlist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tagIdList = result.TagIdList
+	return
+}
@@ -48,6 +48,13 @@ type UserInfo struct {
 
 	// 备注名
 	Remark string `json:"remark,omitempty"`
+
+	GroupId   int64   `json:"groupid"`              // 用户所在的分组ID
+	TagIdList []int64 `json:"tagid_list,omitempty"` // 用户被打上的标签ID列表
+
+	SubscribeScene string `json:"subscribe_scene,omitempty"` // 用户关注的渠道来源
+	QrScene        int64  `json:"qr_scene,omitempty"`        // 二维码扫码场景
+	QrSceneStr     string `json:"qr_scene_str,omitempty"`    // 二维码扫码场景描述
 }
 
 var ErrNoHeadImage = errors.New("没有头像")
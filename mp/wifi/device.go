@@ -0,0 +1,159 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wifi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 添加密码型设备.
+//  ssid 必须以大写字母 "WX" 开头, password 长度不小于8位.
+func (clt Client) AddDevice(shopId int64, ssid, password string) (err error) {
+	if !strings.HasPrefix(ssid, "WX") {
+		err = errors.New(`ssid must start with "WX"`)
+		return
+	}
+	if len(password) < 8 {
+		err = errors.New("the length of password must be greater than or equal to 8")
+		return
+	}
+
+	var request = struct {
+		ShopId   int64  `json:"shop_id"`
+		SSID     string `json:"ssid"`
+		Password string `json:"password"`
+	}{
+		ShopId:   shopId,
+		SSID:     ssid,
+		Password: password,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/device/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 添加portal型设备, 返回的 secretKey 用于设备的 portal 认证.
+//  reset 为 true 时重置 secretKey, 之前的 secretKey 失效.
+func (clt Client) AddPortalDevice(shopId int64, ssid string, reset bool) (secretKey string, err error) {
+	if !strings.HasPrefix(ssid, "WX") {
+		err = errors.New(`ssid must start with "WX"`)
+		return
+	}
+
+	var request = struct {
+		ShopId int64  `json:"shop_id"`
+		SSID   string `json:"ssid"`
+		Reset  bool   `json:"reset"`
+	}{
+		ShopId: shopId,
+		SSID:   ssid,
+		Reset:  reset,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			SecretKey string `json:"secretkey"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/apportal/register?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	secretKey = result.Data.SecretKey
+	return
+}
+
+// 设备列表的一行
+type DeviceRecord struct {
+	ShopId       int64  `json:"shop_id"`
+	SSID         string `json:"ssid"`
+	BSSID        string `json:"bssid"`         // 无线MAC地址
+	ProtocolType int    `json:"protocol_type"` // ProtocolTypePortal, ProtocolTypePassword
+}
+
+// 查询设备, shopId 为 0 时查询所有门店的设备; pageIndex 从1开始, pageSize 不超过 PageSizeLimit.
+func (clt Client) DeviceList(shopId int64, pageIndex, pageSize int) (records []DeviceRecord, totalCount, pageCount int, err error) {
+	var request = struct {
+		PageIndex int   `json:"pageindex"`
+		PageSize  int   `json:"pagesize"`
+		ShopId    int64 `json:"shop_id,omitempty"`
+	}{
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		ShopId:    shopId,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			TotalCount int            `json:"totalcount"`
+			PageIndex  int            `json:"pageindex"`
+			PageCount  int            `json:"pagecount"`
+			Records    []DeviceRecord `json:"records"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/device/list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	records = result.Data.Records
+	totalCount = result.Data.TotalCount
+	pageCount = result.Data.PageCount
+	return
+}
+
+// 删除设备, bssid 为无线网络设备无线mac地址, 格式冒号分隔, 字符长度17个, 并且字母小写, 例如: 00:1f:7a:ad:5c:a8
+func (clt Client) DeleteDevice(bssid string) (err error) {
+	if bssid == "" {
+		err = errors.New("empty bssid")
+		return
+	}
+
+	var request = struct {
+		BSSID string `json:"bssid"`
+	}{
+		BSSID: strings.ToLower(bssid),
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/device/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
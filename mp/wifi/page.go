@@ -0,0 +1,153 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wifi
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 二维码的类型
+const (
+	QRCodeImgIdPlain    = 0 // 纯二维码，可用于自由设计宣传材料
+	QRCodeImgIdMaterial = 1 // 二维码物料，155mm×215mm(宽×高)，可直接张贴
+)
+
+// 获取门店的连网二维码, 返回二维码图片的 url.
+//  imgId 为 QRCodeImgIdPlain 或者 QRCodeImgIdMaterial.
+func (clt Client) GetQRCode(shopId int64, ssid string, imgId int) (qrcodeURL string, err error) {
+	var request = struct {
+		ShopId int64  `json:"shop_id"`
+		SSID   string `json:"ssid"`
+		ImgId  int    `json:"img_id"`
+	}{
+		ShopId: shopId,
+		SSID:   ssid,
+		ImgId:  imgId,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			QRCodeURL string `json:"qrcode_url"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/qrcode/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	qrcodeURL = result.Data.QRCodeURL
+	return
+}
+
+// 商家主页模板类型
+const (
+	TemplateIdDefault   = 0 // 默认模板
+	TemplateIdCustomURL = 1 // 自定义url
+)
+
+// 设置商家主页, templateId 为 TemplateIdCustomURL 时 url 不能为空.
+func (clt Client) SetHomePage(shopId int64, templateId int, url string) (err error) {
+	if templateId == TemplateIdCustomURL && url == "" {
+		err = errors.New("empty url")
+		return
+	}
+
+	type homePageStruct struct {
+		URL string `json:"url"`
+	}
+	var request = struct {
+		ShopId     int64           `json:"shop_id"`
+		TemplateId int             `json:"template_id"`
+		Struct     *homePageStruct `json:"struct,omitempty"`
+	}{
+		ShopId:     shopId,
+		TemplateId: templateId,
+	}
+	if templateId == TemplateIdCustomURL {
+		request.Struct = &homePageStruct{URL: url}
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/homepage/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询商家主页.
+func (clt Client) GetHomePage(shopId int64) (templateId int, url string, err error) {
+	var request = struct {
+		ShopId int64 `json:"shop_id"`
+	}{
+		ShopId: shopId,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			ShopId     int64  `json:"shop_id"`
+			TemplateId int    `json:"template_id"`
+			URL        string `json:"url"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/homepage/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templateId = result.Data.TemplateId
+	url = result.Data.URL
+	return
+}
+
+// 设置连网完成页, 用户连网成功后会跳转到 finishPageURL.
+func (clt Client) SetFinishPage(shopId int64, finishPageURL string) (err error) {
+	if finishPageURL == "" {
+		err = errors.New("empty finishPageURL")
+		return
+	}
+
+	var request = struct {
+		ShopId        int64  `json:"shop_id"`
+		FinishpageURL string `json:"finishpage_url"`
+	}{
+		ShopId:        shopId,
+		FinishpageURL: finishPageURL,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/finishpage/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,110 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wifi
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 无线网络设备类型
+const (
+	ProtocolTypePortal   = 1 // portal 型设备
+	ProtocolTypePassword = 4 // 密码型设备
+)
+
+const PageSizeLimit = 20 // 分页查询, 每页最多 20 条
+
+// 门店列表的一行
+type ShopRecord struct {
+	ShopId       int64    `json:"shop_id"`       // 门店ID（适用于微信连Wi-Fi业务）
+	ShopName     string   `json:"shop_name"`     // 门店名称
+	SSID         string   `json:"ssid"`          // 无线网络设备的ssid，未添加设备为空，多个ssid时显示第一个
+	SSIDList     []string `json:"ssid_list"`     // 无线网络设备的ssid列表
+	ProtocolType int      `json:"protocol_type"` // 门店内设备的设备类型, ProtocolTypePortal, ProtocolTypePassword
+	Sid          string   `json:"sid"`           // 商户自己的id，与门店poi_id对应关系
+	PoiId        string   `json:"poi_id"`        // 门店ID（适用于微信卡券、微信门店业务）
+}
+
+// 获取Wi-Fi门店列表, pageIndex 从1开始, pageSize 不超过 PageSizeLimit.
+func (clt Client) ShopList(pageIndex, pageSize int) (records []ShopRecord, totalCount, pageCount int, err error) {
+	var request = struct {
+		PageIndex int `json:"pageindex"`
+		PageSize  int `json:"pagesize"`
+	}{
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			TotalCount int          `json:"totalcount"`
+			PageIndex  int          `json:"pageindex"`
+			PageCount  int          `json:"pagecount"`
+			Records    []ShopRecord `json:"records"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/shop/list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	records = result.Data.Records
+	totalCount = result.Data.TotalCount
+	pageCount = result.Data.PageCount
+	return
+}
+
+// 门店的 Wi-Fi 信息
+type Shop struct {
+	ShopName         string   `json:"shop_name"`
+	SSID             string   `json:"ssid"`
+	SSIDList         []string `json:"ssid_list"`
+	SSIDPasswordList []struct {
+		SSID     string `json:"ssid"`
+		Password string `json:"password"`
+	} `json:"ssid_password_list"` // 密码型设备的ssid和密码
+	Password      string `json:"password"`       // 无线网络设备的密码，多个ssid时显示第一个
+	ProtocolType  int    `json:"protocol_type"`  // ProtocolTypePortal, ProtocolTypePassword
+	APCount       int    `json:"ap_count"`       // 门店内无线网络设备数量
+	TemplateId    int    `json:"template_id"`    // 商家主页模板类型, TemplateIdDefault, TemplateIdCustomURL
+	HomepageURL   string `json:"homepage_url"`   // 商家主页链接
+	BarType       int    `json:"bar_type"`       // 顶部常驻入口上显示的文本内容类型, 0: 欢迎光临+公众号名称, 1: 欢迎光临+门店名称...
+	FinishpageURL string `json:"finishpage_url"` // 连网完成页链接
+	Sid           string `json:"sid"`
+	PoiId         string `json:"poi_id"`
+}
+
+// 查询门店Wi-Fi信息.
+func (clt Client) GetShop(shopId int64) (shop *Shop, err error) {
+	var request = struct {
+		ShopId int64 `json:"shop_id"`
+	}{
+		ShopId: shopId,
+	}
+
+	var result struct {
+		mp.Error
+		Data Shop `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/shop/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	shop = &result.Data
+	return
+}
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wifi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	StatisticsMaxDays = 30 // 数据统计, 时间跨度不超过30天
+	StatisticsAllShop = -1 // 数据统计, 查询全部门店的汇总数据
+)
+
+// 一个门店一天的统计数据
+type Statistics struct {
+	ShopId         int64 `json:"shop_id"`          // 门店ID，-1为总统计
+	StatisTime     int64 `json:"statis_time"`      // 统计时间，单位为毫秒
+	TotalUser      int   `json:"total_user"`       // 微信连wifi成功人数
+	HomepageUV     int   `json:"homepage_uv"`      // 商家主页访问人数
+	NewFans        int   `json:"new_fans"`         // 新增公众号关注人数
+	TotalFans      int   `json:"total_fans"`       // 累计公众号关注人数
+	WxConnectUser  int   `json:"wxconnect_user"`   // 微信方式连Wi-Fi的人数
+	ConnectMsgUser int   `json:"connect_msg_user"` // 连网后消息发送人数
+}
+
+// 统计的日期.
+func (s *Statistics) Date() time.Time {
+	return time.Unix(s.StatisTime/1000, 0).In(util.BeijingLocation)
+}
+
+// 数据统计, 按天返回 beginDate 到 endDate(包括)每个门店的数据.
+//  shopId 为 StatisticsAllShop 时返回全部门店的汇总数据; 时间跨度不超过 StatisticsMaxDays.
+func (clt Client) StatisticsList(shopId int64, beginDate, endDate time.Time) (list []Statistics, err error) {
+	begin, end := util.NewDate(beginDate), util.NewDate(endDate)
+	if end.Before(begin.Time) {
+		err = fmt.Errorf("endDate %s is before beginDate %s", end, begin)
+		return
+	}
+	if days := int(end.Sub(begin.Time)/(24*time.Hour)) + 1; days > StatisticsMaxDays {
+		err = fmt.Errorf("the date range must be less than or equal to %d days, now is %d days", StatisticsMaxDays, days)
+		return
+	}
+
+	var request = struct {
+		BeginDate string `json:"begin_date"`
+		EndDate   string `json:"end_date"`
+		ShopId    int64  `json:"shop_id"`
+	}{
+		BeginDate: begin.DashString(),
+		EndDate:   end.DashString(),
+		ShopId:    shopId,
+	}
+
+	var result struct {
+		mp.Error
+		Data []Statistics `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/bizwifi/statistics/list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.Data
+	return
+}
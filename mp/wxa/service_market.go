@@ -0,0 +1,73 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wxa
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	// 服务市场: 文字识别(OCR)
+	ServiceOCR            = "wx79ac3de8be320b71"
+	ServiceOCRApiAllInOne = "OcrAllInOne"
+)
+
+// 调用服务市场服务的参数
+type ServiceRequest struct {
+	Service     string      `json:"service"`                 // 必须; 服务的 appid
+	Api         string      `json:"api"`                     // 必须; 接口名
+	Data        interface{} `json:"data"`                    // 必须; 服务提供方接口定义的请求参数, 会被 marshal 为 JSON object
+	ClientMsgId string      `json:"client_msg_id,omitempty"` // 非必须; 随机字符串, 用于去重
+}
+
+// 调用服务市场购买的服务, 返回服务提供方返回的原始 JSON 字符串.
+func (clt Client) InvokeService(req *ServiceRequest) (data string, err error) {
+	if req == nil {
+		err = errors.New("nil ServiceRequest")
+		return
+	}
+	if req.Service == "" {
+		err = errors.New("empty service")
+		return
+	}
+	if req.Api == "" {
+		err = errors.New("empty api")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Data string `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/servicemarket?access_token="
+	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = result.Data
+	return
+}
+
+// 调用服务市场购买的服务, 并将服务提供方返回的 JSON 用 encoding/json 解析到 response.
+func (clt Client) InvokeServiceJSON(req *ServiceRequest, response interface{}) (err error) {
+	if response == nil {
+		return errors.New("nil response")
+	}
+
+	data, err := clt.InvokeService(req)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal([]byte(data), response)
+}
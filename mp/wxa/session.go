@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wxa
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// session_key 签名无效, checkSessionKey 返回这个错误码表示 session_key 已经失效或者不正确.
+const ErrCodeInvalidSessionKeySignature = 87009
+
+// 用户登录态签名, 即用 session_key 对空字符串做 hmac_sha256, 小写的十六进制.
+//  session_key 不在网络上传输, 服务器只传这个签名给微信校验.
+func SessionKeySignature(sessionKey string) string {
+	mac := hmac.New(sha256.New, []byte(sessionKey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 检验服务器保存的 openid 的 session_key 是否还有效.
+//  有效时返回 valid == true; session_key 失效或者不正确时返回 valid == false, err == nil;
+//  其他错误返回 err.
+func (clt Client) CheckSessionKey(openId, sessionKey string) (valid bool, err error) {
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/checksession?sig_method=hmac_sha256&openid=" + url.QueryEscape(openId) +
+		"&signature=" + SessionKeySignature(sessionKey) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		valid = true
+	case ErrCodeInvalidSessionKeySignature:
+	default:
+		err = &result
+	}
+	return
+}
+
+// 重置 openid 的 session_key, 用于怀疑 session_key 泄露的场景.
+//  sessionKey 为服务器当前保存的 session_key(必须有效), 返回新的 session_key, 旧的 session_key 随即失效.
+//  NOTE: 调用成功后需要用新的 session_key 替换服务器上保存的.
+func (clt Client) ResetUserSessionKey(openId, sessionKey string) (newSessionKey string, err error) {
+	var result struct {
+		mp.Error
+		OpenId     string `json:"openid"`
+		SessionKey string `json:"session_key"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/resetusersessionkey?sig_method=hmac_sha256&openid=" + url.QueryEscape(openId) +
+		"&signature=" + SessionKeySignature(sessionKey) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	newSessionKey = result.SessionKey
+	return
+}
@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wxa
+
+import (
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 下面的接口需要第三方平台代小程序调用, mp.Client 的 AccessTokenServer 需要返回 authorizer_access_token.
+
+// 小程序的版本信息
+type VersionInfo struct {
+	ExpInfo struct {
+		ExpTime    int64  `json:"exp_time"`    // 提交体验版的时间
+		ExpVersion string `json:"exp_version"` // 体验版版本信息
+		ExpDesc    string `json:"exp_desc"`    // 体验版版本描述
+	} `json:"exp_info"` // 体验版信息
+	ReleaseInfo struct {
+		ReleaseTime    int64  `json:"release_time"`    // 发布线上版的时间
+		ReleaseVersion string `json:"release_version"` // 线上版版本信息
+		ReleaseDesc    string `json:"release_desc"`    // 线上版本描述
+	} `json:"release_info"` // 线上版信息
+}
+
+// 查询小程序的体验版和线上版的版本信息.
+func (clt Client) GetVersionInfo() (info *VersionInfo, err error) {
+	var result struct {
+		mp.Error
+		VersionInfo
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/getversioninfo?access_token="
+	if err = clt.PostJSON(incompleteURL, struct{}{}, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.VersionInfo
+	return
+}
+
+// 可以回退的历史版本
+type HistoryVersion struct {
+	AppVersion  int64  `json:"app_version"` // 小程序版本号, 回退时使用
+	UserVersion string `json:"user_version"`
+	UserDesc    string `json:"user_desc"`
+	CommitTime  int64  `json:"commit_time"`
+}
+
+// 获取可回退的小程序版本, 最多保存最近发布或回退的5个版本.
+func (clt Client) GetHistoryVersion() (list []HistoryVersion, err error) {
+	var result struct {
+		mp.Error
+		VersionList []HistoryVersion `json:"version_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/revertcoderelease?action=get_history_version&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.VersionList
+	return
+}
+
+// 版本回退.
+//  appVersion 为 GetHistoryVersion 返回的 AppVersion, 为 0 时回退到上一个线上版本.
+//  NOTE: 只能回退到最近发布或回退的5个版本, 回退成功后对应版本会从 GetHistoryVersion 的结果中删除.
+func (clt Client) RevertCodeRelease(appVersion int64) (err error) {
+	incompleteURL := "https://api.weixin.qq.com/wxa/revertcoderelease?access_token="
+	if appVersion != 0 {
+		incompleteURL = "https://api.weixin.qq.com/wxa/revertcoderelease?app_version=" +
+			strconv.FormatInt(appVersion, 10) + "&access_token="
+	}
+
+	var result mp.Error
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
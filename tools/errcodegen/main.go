@@ -0,0 +1,105 @@
+// 根据 tab 分隔的返回码表生成 errcode 包的常量和说明表, 见 mp/errcode/errcode.tsv.
+//  用法: errcodegen -i errcode.tsv -o errcode_table.go -p errcode
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type entry struct {
+	Code int
+	Name string
+	Zh   string
+	En   string
+}
+
+func main() {
+	input := flag.String("i", "errcode.tsv", "input file")
+	output := flag.String("o", "errcode_table.go", "output file")
+	pkg := flag.String("p", "errcode", "package name")
+	flag.Parse()
+
+	entries, err := parse(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	src, err := generate(*pkg, entries)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func parse(filename string) (entries []entry, err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	codes := make(map[int]bool)
+	names := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%s:%d: want 4 fields, got %d", filename, lineNum, len(fields))
+		}
+		code, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", filename, lineNum, err)
+		}
+		if codes[code] {
+			return nil, fmt.Errorf("%s:%d: duplicate code %d", filename, lineNum, code)
+		}
+		if names[fields[1]] {
+			return nil, fmt.Errorf("%s:%d: duplicate name %s", filename, lineNum, fields[1])
+		}
+		codes[code] = true
+		names[fields[1]] = true
+		entries = append(entries, entry{Code: code, Name: fields[1], Zh: fields[2], En: fields[3]})
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Code < entries[j].Code })
+	return
+}
+
+func generate(pkg string, entries []entry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by errcodegen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	buf.WriteString("const (\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s = %d // %s\n", e.Name, e.Code, e.Zh)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("var table = map[int]Info{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s: {Code: %s, Name: %q, Zh: %q, En: %q},\n", e.Name, e.Name, e.Name, e.Zh, e.En)
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,69 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// 下载的文件流, 数据直接从 http 应答读取, 不会整个读入内存; 调用者负责 Close.
+type DownloadStream struct {
+	ContentType   string      // 去掉参数的 Content-Type, 比如 image/jpeg
+	ContentLength int64       // -1 表示未知
+	Filename      string      // Content-Disposition 里的 filename, 可能为空
+	Header        http.Header // 完整的应答 header
+
+	r    *bufio.Reader
+	body io.Closer
+}
+
+// 用 httpResp 创建 DownloadStream, 不检查状态码.
+func NewDownloadStream(httpResp *http.Response) *DownloadStream {
+	s := &DownloadStream{
+		ContentLength: httpResp.ContentLength,
+		Header:        httpResp.Header,
+		r:             bufio.NewReaderSize(httpResp.Body, 512),
+		body:          httpResp.Body,
+	}
+	s.ContentType, _, _ = mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
+	if _, params, err := mime.ParseMediaType(httpResp.Header.Get("Content-Disposition")); err == nil {
+		s.Filename = params["filename"]
+	}
+	return s
+}
+
+func (s *DownloadStream) Read(p []byte) (int, error) {
+	return s.r.Read(p)
+}
+
+func (s *DownloadStream) Close() error {
+	return s.body.Close()
+}
+
+// 把剩下的数据写入 w, 实现 io.WriterTo.
+func (s *DownloadStream) WriteTo(w io.Writer) (int64, error) {
+	return s.r.WriteTo(w)
+}
+
+// 判断数据是否以 prefixes 中的某一个开头(前导的空白字符被忽略), 不消耗数据; prefix 最长 512 字节.
+//  用来识别 Content-Type 不可靠的接口返回的是错误信息还是文件, 比如 {"errcode": 或者 <xml>.
+func (s *DownloadStream) HasPrefix(prefixes ...string) (bool, error) {
+	b, err := s.r.Peek(s.r.Size())
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return false, err
+	}
+	b = bytes.TrimLeft(b, " \t\r\n")
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(b, []byte(prefix)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,116 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DumpTransport 打印的 body 的默认最大字节数
+const DefaultDumpBodySize = 4096
+
+// 打印每个请求和应答的 http.RoundTripper, 用于运行时调试, 不需要重新编译(wechatdebug 编译标签).
+//  url 和 body 里的 access_token, secret 等敏感参数会被隐藏, 见 Redact;
+//  只打印文本(json, xml, text, 表单)的 body, 文件等其他内容只打印 Content-Type 和长度.
+type DumpTransport struct {
+	Transport   http.RoundTripper // 为 nil 时使用 http.DefaultTransport
+	MaxBodySize int               // 打印的 body 的最大字节数, 超过的部分被截断, 0 表示 DefaultDumpBodySize
+	Log         func(line string) // 必须, 输出一行调试日志
+}
+
+func (t *DumpTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	target := req.Method + " " + Redact(req.URL.String())
+
+	reqBody := "<empty>"
+	if req.Body != nil && req.Body != http.NoBody {
+		contentType := req.Header.Get("Content-Type")
+		if isTextContentType(contentType) {
+			body, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			reqBody = t.formatBody(body)
+		} else {
+			reqBody = "<" + contentType + ", " + strconv.FormatInt(req.ContentLength, 10) + " bytes>"
+		}
+	}
+	t.Log("request: " + target + " body: " + reqBody)
+
+	start := time.Now()
+	resp, err = transport.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Log("response: " + target + " elapsed: " + elapsed.String() + " error: " + Redact(err.Error()))
+		return
+	}
+
+	respBody := "<empty>"
+	contentType := resp.Header.Get("Content-Type")
+	if isTextContentType(contentType) {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if len(body) > 0 {
+			respBody = t.formatBody(body)
+		}
+	} else if resp.ContentLength != 0 {
+		respBody = "<" + contentType + ", " + strconv.FormatInt(resp.ContentLength, 10) + " bytes>"
+	}
+	t.Log("response: " + target + " status: " + resp.Status + " elapsed: " + elapsed.String() + " body: " + respBody)
+	return
+}
+
+func (t *DumpTransport) formatBody(body []byte) string {
+	body = bytes.TrimRight(body, "\r\n")
+	maxSize := t.MaxBodySize
+	if maxSize <= 0 {
+		maxSize = DefaultDumpBodySize
+	}
+	if len(body) > maxSize {
+		return Redact(string(body[:maxSize])) + "...(" + strconv.Itoa(len(body)) + " bytes)"
+	}
+	return Redact(string(body))
+}
+
+func isTextContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == "application/json",
+		mediaType == "application/xml",
+		mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+// 返回一个 Transport 被 DumpTransport 包装的 clt 的浅拷贝, clt 为 nil 时使用 http.DefaultClient.
+func NewDumpHttpClient(clt *http.Client, log func(line string)) *http.Client {
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+	c := *clt
+	c.Transport = &DumpTransport{
+		Transport: clt.Transport,
+		Log:       log,
+	}
+	return &c
+}
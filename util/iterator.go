@@ -0,0 +1,74 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"context"
+	"errors"
+)
+
+// 遍历结束后 Iterator.Next 返回的错误
+var Done = errors.New("no more items in iterator")
+
+// 拉取下一页, 返回这一页的元素和后面是否还有数据.
+//  offset, next_openid, next_cursor 等状态由 PageFunc 自己保存, 只在成功时更新,
+//  这样出错后再次调用 Iterator.Next 会重新拉取同一页.
+type PageFunc func(ctx context.Context) (items []interface{}, hasNext bool, err error)
+
+// 分页接口的通用遍历器, 按需拉取下一页, 逐个返回元素.
+// 各个包在它的基础上提供返回具体类型的 Pager, 比如 user.OpenIdPager:
+//
+//  pager := userClient.OpenIdPager("")
+//  for {
+//      openId, err := pager.Next(ctx)
+//      if err == util.Done {
+//          break
+//      }
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+type Iterator struct {
+	fetch   PageFunc
+	items   []interface{}
+	hasNext bool
+}
+
+func NewIterator(fetch PageFunc) *Iterator {
+	if fetch == nil {
+		panic("nil PageFunc")
+	}
+	return &Iterator{
+		fetch:   fetch,
+		hasNext: true,
+	}
+}
+
+// 返回下一个元素, 没有更多元素时返回 Done; ctx 取消后返回 ctx.Err().
+//  NOTE: 不是并发安全的.
+func (iter *Iterator) Next(ctx context.Context) (item interface{}, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for len(iter.items) == 0 {
+		if !iter.hasNext {
+			return nil, Done
+		}
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		items, hasNext, err := iter.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		iter.items, iter.hasNext = items, hasNext
+	}
+	item = iter.items[0]
+	iter.items[0] = nil
+	iter.items = iter.items[1:]
+	return
+}
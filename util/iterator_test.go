@@ -0,0 +1,54 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := [][]interface{}{{1, 2}, {}, {3}}
+	fetched, failOnce := 0, true
+	iter := NewIterator(func(ctx context.Context) (items []interface{}, hasNext bool, err error) {
+		if fetched == 2 && failOnce {
+			failOnce = false
+			return nil, false, errors.New("temporary error")
+		}
+		items = pages[fetched]
+		fetched++
+		return items, fetched < len(pages), nil
+	})
+
+	var have []interface{}
+	var errs int
+	for {
+		item, err := iter.Next(context.Background())
+		if err == Done {
+			break
+		}
+		if err != nil {
+			errs++
+			continue
+		}
+		have = append(have, item)
+	}
+	if want := []interface{}{1, 2, 3}; !reflect.DeepEqual(have, want) || errs != 1 {
+		t.Errorf("have %v with %d errors, want %v with 1 error", have, errs, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	iter = NewIterator(func(ctx context.Context) ([]interface{}, bool, error) {
+		t.Fatal("fetch called with a cancelled context")
+		return nil, false, nil
+	})
+	if _, err := iter.Next(ctx); err != context.Canceled {
+		t.Errorf("have error %v, want %v", err, context.Canceled)
+	}
+}
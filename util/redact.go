@@ -0,0 +1,115 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 被隐藏的参数值的替代
+const Redacted = "***"
+
+// 需要隐藏的参数名, 包括 url 的 query 参数, 表单参数和 json 的字段.
+var redactedParams = []string{
+	"access_token",
+	"suite_access_token",
+	"component_access_token",
+	"authorizer_access_token",
+	"provider_access_token",
+	"refresh_token",
+	"authorizer_refresh_token",
+	"secret",
+	"corpsecret",
+	"appsecret",
+	"component_appsecret",
+	"provider_secret",
+	"suite_secret",
+	"session_key",
+}
+
+var (
+	redactQueryRegexp = regexp.MustCompile(`\b((?:` + strings.Join(redactedParams, "|") + `)=)[^&#\s"']*`)
+	redactJSONRegexp  = regexp.MustCompile(`("(?:` + strings.Join(redactedParams, "|") + `)"\s*:\s*")(?:[^"\\]|\\.)*"`)
+)
+
+// 隐藏 s 里的 access_token, secret 等敏感参数的值, s 可以是 url, 表单, json 或者包含它们的错误信息, 比如:
+//  https://api.weixin.qq.com/cgi-bin/user/info?access_token=ACCESS_TOKEN&openid=OPENID
+//
+// 变为
+//  https://api.weixin.qq.com/cgi-bin/user/info?access_token=***&openid=OPENID
+func Redact(s string) string {
+	if !strings.Contains(s, "token") && !strings.Contains(s, "secret") && !strings.Contains(s, "session_key") {
+		return s
+	}
+	s = redactQueryRegexp.ReplaceAllString(s, "${1}"+Redacted)
+	return redactJSONRegexp.ReplaceAllString(s, `${1}`+Redacted+`"`)
+}
+
+// 用于日志里的 access_token 等凭证, 只保留前 4 个字符, 比如 "ACCE***", 足够区分刷新前后的凭证.
+func MaskToken(token string) string {
+	if len(token) <= 8 {
+		return Redacted
+	}
+	return token[:4] + Redacted
+}
+
+// 隐藏错误信息里的敏感参数, 不包含敏感参数时原样返回 err.
+//  http.Client 返回的 *url.Error 会复制一份并隐藏 URL, 其他错误包装为 Error() 被隐藏, 可以 errors.Unwrap 的错误.
+func RedactError(err error) error {
+	if err == nil {
+		return err
+	}
+	if e, ok := err.(*url.Error); ok {
+		if redacted := Redact(e.URL); redacted != e.URL {
+			e2 := *e
+			e2.URL = redacted
+			return &e2
+		}
+		return err
+	}
+	if msg := err.Error(); Redact(msg) != msg {
+		return &redactedError{err: err}
+	}
+	return err
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string { return Redact(e.err.Error()) }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// 隐藏日志参数里的敏感参数, 用于 LogInfoln 等 func(v ...interface{}) 形式的日志函数:
+//  string 和 error 类型的参数会经过 Redact, 其他类型原样保留.
+func RedactArgs(v []interface{}) []interface{} {
+	var redacted []interface{}
+	for i, arg := range v {
+		var s, r string
+		switch a := arg.(type) {
+		case string:
+			s = a
+		case error:
+			s = a.Error()
+		default:
+			continue
+		}
+		if r = Redact(s); r == s {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]interface{}, len(v))
+			copy(redacted, v)
+		}
+		redacted[i] = r
+	}
+	if redacted == nil {
+		return v
+	}
+	return redacted
+}
@@ -0,0 +1,78 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			"https://api.weixin.qq.com/cgi-bin/user/info?access_token=AbC-123_x&openid=o1",
+			"https://api.weixin.qq.com/cgi-bin/user/info?access_token=***&openid=o1",
+		},
+		{
+			`Post "https://qyapi.weixin.qq.com/cgi-bin/service/get_corp_token?suite_access_token=xyz": EOF`,
+			`Post "https://qyapi.weixin.qq.com/cgi-bin/service/get_corp_token?suite_access_token=***": EOF`,
+		},
+		{
+			"grant_type=client_credential&appid=wx1&secret=s3cr3t",
+			"grant_type=client_credential&appid=wx1&secret=***",
+		},
+		{
+			`{"access_token":"ACCESS\"TOKEN","expires_in":7200,"session_key": "k=="}`,
+			`{"access_token":"***","expires_in":7200,"session_key": "***"}`,
+		},
+		{
+			`{"errcode":0,"errmsg":"ok"}`,
+			`{"errcode":0,"errmsg":"ok"}`,
+		},
+	}
+	for _, tt := range tests {
+		if have := Redact(tt.in); have != tt.want {
+			t.Errorf("Redact(%q):\nhave: %s\nwant: %s", tt.in, have, tt.want)
+		}
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	if have := MaskToken("ACCESS_TOKEN_VALUE"); have != "ACCE***" {
+		t.Errorf("MaskToken: have %s, want ACCE***", have)
+	}
+	if have := MaskToken("short"); have != Redacted {
+		t.Errorf("MaskToken: have %s, want %s", have, Redacted)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://api.weixin.qq.com/cgi-bin/token?appid=wx1&secret=s3cr3t", Err: io.EOF}
+	want := `Get "https://api.weixin.qq.com/cgi-bin/token?appid=wx1&secret=***": EOF`
+	if have := RedactError(err).Error(); have != want {
+		t.Errorf("RedactError:\nhave: %s\nwant: %s", have, want)
+	}
+	if err.URL != "https://api.weixin.qq.com/cgi-bin/token?appid=wx1&secret=s3cr3t" {
+		t.Error("RedactError modified the original error")
+	}
+
+	err2 := errors.New("post https://qyapi.weixin.qq.com/cgi-bin/message/send?access_token=xyz failed")
+	redacted := RedactError(err2)
+	if have := redacted.Error(); have != "post https://qyapi.weixin.qq.com/cgi-bin/message/send?access_token=*** failed" {
+		t.Errorf("RedactError: have %s", have)
+	}
+	if !errors.Is(redacted, err2) {
+		t.Error("RedactError: errors.Is(redacted, err) == false")
+	}
+	if RedactError(io.EOF) != io.EOF {
+		t.Error("RedactError changed an error without sensitive parameters")
+	}
+}
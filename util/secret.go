@@ -0,0 +1,83 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// 密钥等敏感信息(AppSecret, EncodingAESKey, 支付 API 密钥等)的来源.
+//  每次使用时调用, 实现可以从 KMS, Vault 等外部服务获取, 从而支持密钥轮换, 而不是在创建时就固定下来.
+type SecretProvider interface {
+	Secret() (string, error)
+}
+
+var _ SecretProvider = StaticSecret("")
+
+// 固定的密钥
+type StaticSecret string
+
+func (s StaticSecret) Secret() (string, error) {
+	return string(s), nil
+}
+
+type SecretProviderFunc func() (string, error)
+
+func (fn SecretProviderFunc) Secret() (string, error) {
+	return fn()
+}
+
+var _ SecretProvider = (*CachedSecretProvider)(nil)
+
+// 缓存 Provider 的结果 TTL 时间, 避免每次使用都访问外部服务.
+//  缓存过期后 Provider 返回错误时继续使用之前的结果, 直到 Provider 恢复.
+type CachedSecretProvider struct {
+	Provider SecretProvider
+	TTL      time.Duration
+
+	mutex     sync.Mutex
+	secret    string
+	expiresAt time.Time
+	valid     bool
+}
+
+func NewCachedSecretProvider(provider SecretProvider, ttl time.Duration) *CachedSecretProvider {
+	if provider == nil {
+		panic("nil SecretProvider")
+	}
+	return &CachedSecretProvider{
+		Provider: provider,
+		TTL:      ttl,
+	}
+}
+
+func (p *CachedSecretProvider) Secret() (secret string, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	if p.valid && now.Before(p.expiresAt) {
+		return p.secret, nil
+	}
+	if secret, err = p.Provider.Secret(); err != nil {
+		if p.valid {
+			return p.secret, nil
+		}
+		return
+	}
+	p.secret = secret
+	p.expiresAt = now.Add(p.TTL)
+	p.valid = true
+	return
+}
+
+// 清除缓存, 下一次 Secret 重新从 Provider 获取, 一般在确认密钥已经轮换后调用.
+func (p *CachedSecretProvider) Invalidate() {
+	p.mutex.Lock()
+	p.expiresAt = time.Time{}
+	p.mutex.Unlock()
+}
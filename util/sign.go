@@ -6,40 +6,17 @@
 package util
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
-	"sort"
+	"github.com/chanxuehong/wechat/cipher"
 )
 
 // 微信公众号 明文模式/URL认证 签名
+//  兼容保留, 见 cipher.Sign
 func Sign(token, timestamp, nonce string) (signature string) {
-	strs := sort.StringSlice{token, timestamp, nonce}
-	strs.Sort()
-
-	n := len(token) + len(timestamp) + len(nonce)
-	buf := make([]byte, 0, n)
-
-	buf = append(buf, strs[0]...)
-	buf = append(buf, strs[1]...)
-	buf = append(buf, strs[2]...)
-
-	hashsum := sha1.Sum(buf)
-	return hex.EncodeToString(hashsum[:])
+	return cipher.Sign(token, timestamp, nonce)
 }
 
 // 微信公众号/企业号 密文模式消息签名
+//  兼容保留, 见 cipher.MsgSign
 func MsgSign(token, timestamp, nonce, encryptedMsg string) (signature string) {
-	strs := sort.StringSlice{token, timestamp, nonce, encryptedMsg}
-	strs.Sort()
-
-	n := len(token) + len(timestamp) + len(nonce) + len(encryptedMsg)
-	buf := make([]byte, 0, n)
-
-	buf = append(buf, strs[0]...)
-	buf = append(buf, strs[1]...)
-	buf = append(buf, strs[2]...)
-	buf = append(buf, strs[3]...)
-
-	hashsum := sha1.Sum(buf)
-	return hex.EncodeToString(hashsum[:])
+	return cipher.MsgSign(token, timestamp, nonce, encryptedMsg)
 }
@@ -0,0 +1,239 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// 微信的日期和时间都是北京时间
+var BeijingLocation = time.FixedZone("Asia/Shanghai", 8*60*60)
+
+const (
+	DateLayout     = "20060102"
+	DashDateLayout = "2006-01-02"
+	DateTimeLayout = "2006-01-02 15:04:05"
+)
+
+// ==============================================================================
+
+// Unix 时间戳, 单位为秒.
+//  json 解码时兼容数字和字符串两种格式, 比如 1400000000 和 "1400000000".
+type UnixTime int64
+
+func NewUnixTime(t time.Time) UnixTime {
+	if t.IsZero() {
+		return 0
+	}
+	return UnixTime(t.Unix())
+}
+
+// 返回北京时间的 time.Time, 0 返回 time.Time{}.
+func (t UnixTime) Time() time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t), 0).In(BeijingLocation)
+}
+
+func (t UnixTime) IsZero() bool {
+	return t == 0
+}
+
+func (t UnixTime) String() string {
+	if t == 0 {
+		return ""
+	}
+	return t.Time().Format(DateTimeLayout)
+}
+
+func (t *UnixTime) UnmarshalJSON(data []byte) (err error) {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*t = 0
+		return
+	}
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid unix time %q", data)
+	}
+	*t = UnixTime(n)
+	return
+}
+
+// ==============================================================================
+
+// YYYYMMDD 格式的日期, 比如 20150102.
+//  解码时兼容 YYYY-MM-DD 格式, 空字符串解码为零值, 零值编码为空字符串.
+type Date struct {
+	time.Time
+}
+
+// 创建一个 Date, 日期为 t 在北京时间的日期.
+func NewDate(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	t = t.In(BeijingLocation)
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, BeijingLocation)}
+}
+
+// 解析 YYYYMMDD 或者 YYYY-MM-DD 格式的日期.
+func ParseDate(s string) (d Date, err error) {
+	layout := DateLayout
+	if len(s) == len(DashDateLayout) {
+		layout = DashDateLayout
+	}
+	t, err := time.ParseInLocation(layout, s, BeijingLocation)
+	if err != nil {
+		err = fmt.Errorf("invalid date %q", s)
+		return
+	}
+	d = Date{t}
+	return
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.In(BeijingLocation).Format(DateLayout)
+}
+
+// 返回 YYYY-MM-DD 格式的日期.
+func (d Date) DashString() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.In(BeijingLocation).Format(DashDateLayout)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// time.Time 实现了 json.Marshaler, 这里必须覆盖.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid date %s", data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d *Date) UnmarshalText(text []byte) (err error) {
+	if len(text) == 0 {
+		*d = Date{}
+		return
+	}
+	*d, err = ParseDate(string(text))
+	return
+}
+
+// ==============================================================================
+
+// YYYY-MM-DD 格式的日期, 比如 2015-01-02, 用于数据统计(datacube)等使用这种格式的接口.
+//  底层类型为 string, 可以直接和字符串常量比较, 空字符串为零值.
+type DashDate string
+
+// 创建一个 DashDate, 日期为 t 在北京时间的日期.
+func NewDashDate(t time.Time) DashDate {
+	if t.IsZero() {
+		return ""
+	}
+	return DashDate(t.In(BeijingLocation).Format(DashDateLayout))
+}
+
+// 解析为 Date, 空字符串返回零值.
+func (d DashDate) Date() (date Date, err error) {
+	if d == "" {
+		return
+	}
+	t, err := time.ParseInLocation(DashDateLayout, string(d), BeijingLocation)
+	if err != nil {
+		err = fmt.Errorf("invalid date %q", string(d))
+		return
+	}
+	date = Date{t}
+	return
+}
+
+// 返回北京时间当天 0 点, 空字符串或者格式错误时返回 time.Time{}.
+func (d DashDate) Time() time.Time {
+	date, _ := d.Date()
+	return date.Time
+}
+
+// ==============================================================================
+
+// YYYY-MM-DD hh:mm:ss 格式的时间, 比如 2015-01-02 15:04:05.
+//  空字符串解码为零值, 零值编码为空字符串.
+type DateTime struct {
+	time.Time
+}
+
+func NewDateTime(t time.Time) DateTime {
+	if t.IsZero() {
+		return DateTime{}
+	}
+	return DateTime{t.In(BeijingLocation).Truncate(time.Second)}
+}
+
+// 解析 YYYY-MM-DD hh:mm:ss 格式的时间.
+func ParseDateTime(s string) (dt DateTime, err error) {
+	t, err := time.ParseInLocation(DateTimeLayout, s, BeijingLocation)
+	if err != nil {
+		err = fmt.Errorf("invalid datetime %q", s)
+		return
+	}
+	dt = DateTime{t}
+	return
+}
+
+func (dt DateTime) String() string {
+	if dt.IsZero() {
+		return ""
+	}
+	return dt.In(BeijingLocation).Format(DateTimeLayout)
+}
+
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+func (dt DateTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(dt.String())), nil
+}
+
+func (dt *DateTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid datetime %s", data)
+	}
+	return dt.UnmarshalText([]byte(s))
+}
+
+func (dt *DateTime) UnmarshalText(text []byte) (err error) {
+	if len(text) == 0 {
+		*dt = DateTime{}
+		return
+	}
+	*dt, err = ParseDateTime(string(text))
+	return
+}
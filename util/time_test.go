@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`"20150102"`, `"20150102"`},
+		{`"2015-01-02"`, `"20150102"`},
+		{`""`, `""`},
+	}
+	for _, tt := range tests {
+		var d Date
+		if err := json.Unmarshal([]byte(tt.input), &d); err != nil {
+			t.Errorf("%s: %v", tt.input, err)
+			continue
+		}
+		have, err := json.Marshal(d)
+		if err != nil {
+			t.Errorf("%s: %v", tt.input, err)
+			continue
+		}
+		if string(have) != tt.want {
+			t.Errorf("%s: have %s, want %s", tt.input, have, tt.want)
+		}
+	}
+}
+
+func TestDashDate(t *testing.T) {
+	var v struct {
+		RefDate DashDate `json:"ref_date"`
+	}
+	if err := json.Unmarshal([]byte(`{"ref_date":"2015-01-02"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.RefDate != "2015-01-02" {
+		t.Errorf("have %q", v.RefDate)
+	}
+	want := time.Date(2015, 1, 2, 0, 0, 0, 0, BeijingLocation)
+	if have := v.RefDate.Time(); !have.Equal(want) {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	if have := NewDashDate(want.Add(23 * time.Hour)); have != "2015-01-02" {
+		t.Errorf("have %q", have)
+	}
+	if b, _ := json.Marshal(v); string(b) != `{"ref_date":"2015-01-02"}` {
+		t.Errorf("have %s", b)
+	}
+
+	if _, err := DashDate("20150102").Date(); err == nil {
+		t.Error("want error for YYYYMMDD")
+	}
+	if date, err := DashDate("").Date(); err != nil || !date.IsZero() {
+		t.Errorf("have %v, %v", date, err)
+	}
+}
+
+func TestUnixTimeJSON(t *testing.T) {
+	for _, input := range []string{`1420128000`, `"1420128000"`} {
+		var ut UnixTime
+		if err := json.Unmarshal([]byte(input), &ut); err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		if have := ut.String(); have != "2015-01-02 00:00:00" {
+			t.Errorf("%s: have %s", input, have)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DecodeXMLToMap 读取的 xml 的最大字节数, 超过时返回 ErrXMLTooLarge.
+var MaxXMLSize int64 = 1 << 20
+
+var ErrXMLTooLarge = errors.New("xml too large")
+
+// 把 m 编码为一层的 xml 写入 w, 比如:
+//  <xml><appid><![CDATA[wx2421b1c4370ec43b]]></appid><mch_id><![CDATA[10000100]]></mch_id></xml>
+//  根元素为 <xml>, 子元素按 key 的字典序排列, 同样的 m 总是得到同样的输出, 可以用于签名;
+//  值总是放在 CDATA 里, 值里的 "]]>" 会被拆分到两个 CDATA 里.
+//  key 必须是合法的 xml 元素名, 否则返回错误.
+func EncodeXMLFromMap(w io.Writer, m map[string]string) (err error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !isXMLName(k) {
+			return fmt.Errorf("invalid xml element name: %q", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("<xml>")
+	for _, k := range keys {
+		bw.WriteString("<")
+		bw.WriteString(k)
+		bw.WriteString("><![CDATA[")
+		bw.WriteString(strings.Replace(m[k], "]]>", "]]]]><![CDATA[>", -1))
+		bw.WriteString("]]></")
+		bw.WriteString(k)
+		bw.WriteString(">")
+	}
+	bw.WriteString("</xml>")
+	return bw.Flush()
+}
+
+// 解析一层的 xml 为 map, 根元素的名字不限, 子元素的名字为 key, 子元素的文本(包括 CDATA)为 value.
+//  为了安全, 下面的情况返回错误:
+//  1. 超过 MaxXMLSize 字节;
+//  2. 包含 <!DOCTYPE ...> 等指令(防止实体扩展攻击);
+//  3. 子元素里还有元素;
+//  4. 重复的子元素.
+func DecodeXMLToMap(r io.Reader) (m map[string]string, err error) {
+	lr := &io.LimitedReader{R: r, N: MaxXMLSize + 1}
+	d := xml.NewDecoder(lr)
+	d.Strict = true
+
+	var (
+		depth int
+		key   string
+		value strings.Builder
+		done  bool
+	)
+	m = make(map[string]string)
+	for {
+		tok, err2 := d.Token()
+		if lr.N <= 0 {
+			return nil, ErrXMLTooLarge
+		}
+		if err2 != nil {
+			if err2 == io.EOF {
+				if !done {
+					return nil, io.ErrUnexpectedEOF
+				}
+				return m, nil
+			}
+			return nil, err2
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if done {
+				return nil, errors.New("xml has multiple root elements")
+			}
+			depth++
+			switch depth {
+			case 1:
+			case 2:
+				key = t.Name.Local
+				if _, ok := m[key]; ok {
+					return nil, fmt.Errorf("duplicate xml element: %s", key)
+				}
+				value.Reset()
+			default:
+				return nil, fmt.Errorf("nested xml element in %s: %s", key, t.Name.Local)
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				m[key] = value.String()
+			}
+			depth--
+			if depth == 0 {
+				done = true
+			}
+		case xml.CharData:
+			if depth == 2 {
+				value.Write(t)
+			} else if len(strings.TrimSpace(string(t))) > 0 {
+				return nil, errors.New("unexpected xml text outside of elements")
+			}
+		case xml.Directive:
+			return nil, errors.New("xml directive is not allowed")
+		}
+	}
+}
+
+func isXMLName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c == '-' || c == '.' || c >= '0' && c <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
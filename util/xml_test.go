@@ -0,0 +1,78 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeXMLFromMap(t *testing.T) {
+	m := map[string]string{
+		"mch_id": "10000100",
+		"appid":  "wx2421b1c4370ec43b",
+		"body":   "a]]>b<c>&",
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeXMLFromMap(&buf, m); err != nil {
+		t.Error(err)
+		return
+	}
+	want := `<xml><appid><![CDATA[wx2421b1c4370ec43b]]></appid><body><![CDATA[a]]]]><![CDATA[>b<c>&]]></body><mch_id><![CDATA[10000100]]></mch_id></xml>`
+	if have := buf.String(); have != want {
+		t.Errorf("EncodeXMLFromMap:\nhave: %s\nwant: %s", have, want)
+		return
+	}
+
+	m2, err := DecodeXMLToMap(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(m, m2) {
+		t.Errorf("DecodeXMLToMap:\nhave: %v\nwant: %v", m2, m)
+		return
+	}
+
+	if err = EncodeXMLFromMap(&buf, map[string]string{"a b": "c"}); err == nil {
+		t.Error("EncodeXMLFromMap: want error for invalid element name")
+		return
+	}
+}
+
+func TestDecodeXMLToMap(t *testing.T) {
+	m, err := DecodeXMLToMap(strings.NewReader(`<xml>
+<return_code><![CDATA[SUCCESS]]></return_code>
+<total_fee>1</total_fee>
+<attach></attach>
+</xml>`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := map[string]string{"return_code": "SUCCESS", "total_fee": "1", "attach": ""}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("have: %v\nwant: %v", m, want)
+		return
+	}
+
+	bad := []string{
+		`<!DOCTYPE xml [<!ENTITY a "aaaa">]><xml><a>&a;</a></xml>`,
+		`<xml><a><b>1</b></a></xml>`,
+		`<xml><a>1</a><a>2</a></xml>`,
+		`<xml><a>1</a></xml><xml></xml>`,
+		`<xml><a>1</a>`,
+		`<xml><a>` + strings.Repeat("a", int(MaxXMLSize)) + `</a></xml>`,
+	}
+	for _, s := range bad {
+		if _, err = DecodeXMLToMap(strings.NewReader(s)); err == nil {
+			t.Errorf("want error for %.64q", s)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// OpenTelemetry 链路追踪的适配, 把 mp.Client, corp.Client 的每次接口调用记录为一个 span:
+//
+//  tracer := otel.Tracer("github.com/chanxuehong/wechat")
+//  mpClient.Tracer = wechatotel.NewMPTracer(tracer)
+//  corpClient.Tracer = wechatotel.NewCorpTracer(tracer)
+//
+//  span 的名字为接口的 endpoint(比如 "/cgi-bin/message/custom/send"), 上级 span 为请求绑定的 context
+//  (mp.Client.WithContext, corp.Client.WithContext 等)里的 span, errcode 不为 0 或者出错时 span 的状态为 Error.
+//  这次调用的 http 请求(包括重试)都绑定到新 span 的 context, HttpClient 使用 otelhttp.NewTransport 时
+//  每次 http 请求会记录为它的子 span, 并通过 http 头传播 trace.
+//
+// NOTE: 为了不给其他包引入依赖, 需要加上 wechatotel 编译标签才会编译本包的实现:
+//  go build -tags wechatotel
+package wechatotel
@@ -0,0 +1,95 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+//go:build wechatotel
+// +build wechatotel
+
+package wechatotel
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var (
+	_ mp.ContextTracer   = (*MPTracer)(nil)
+	_ corp.ContextTracer = (*CorpTracer)(nil)
+)
+
+// 用于 mp.Client.Tracer
+type MPTracer struct {
+	tracer trace.Tracer
+}
+
+func NewMPTracer(tracer trace.Tracer) *MPTracer {
+	if tracer == nil {
+		panic("nil trace.Tracer")
+	}
+	return &MPTracer{tracer: tracer}
+}
+
+func (t *MPTracer) StartSpan(name string, attrs map[string]string) mp.Span {
+	_, s := t.StartSpanContext(context.Background(), name, attrs)
+	return s
+}
+
+func (t *MPTracer) StartSpanContext(ctx context.Context, name string, attrs map[string]string) (context.Context, mp.Span) {
+	return startSpan(ctx, t.tracer, "mp", name, attrs)
+}
+
+// 用于 corp.Client.Tracer
+type CorpTracer struct {
+	tracer trace.Tracer
+}
+
+func NewCorpTracer(tracer trace.Tracer) *CorpTracer {
+	if tracer == nil {
+		panic("nil trace.Tracer")
+	}
+	return &CorpTracer{tracer: tracer}
+}
+
+func (t *CorpTracer) StartSpan(name string, attrs map[string]string) corp.Span {
+	_, s := t.StartSpanContext(context.Background(), name, attrs)
+	return s
+}
+
+func (t *CorpTracer) StartSpanContext(ctx context.Context, name string, attrs map[string]string) (context.Context, corp.Span) {
+	return startSpan(ctx, t.tracer, "corp", name, attrs)
+}
+
+// 同时实现了 mp.Span 和 corp.Span
+type span struct {
+	span trace.Span
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, platform, name string, attrs map[string]string) (context.Context, *span) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs)+1)
+	kvs = append(kvs, attribute.String("wechat.platform", platform))
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	ctx, s := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(kvs...))
+	return ctx, &span{span: s}
+}
+
+func (s *span) End(errCode int, err error) {
+	s.span.SetAttributes(attribute.Int("wechat.errcode", errCode))
+	switch {
+	case err != nil:
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	case errCode != 0:
+		s.span.SetStatus(codes.Error, "errcode "+strconv.Itoa(errCode))
+	}
+	s.span.End()
+}
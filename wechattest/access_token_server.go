@@ -0,0 +1,82 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wechattest
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var (
+	_ mp.AccessTokenServer   = (*AccessTokenServer)(nil)
+	_ corp.AccessTokenServer = (*AccessTokenServer)(nil)
+)
+
+// 模拟的 access_token 中控服务器.
+//  Token 返回当前的 access_token, TokenRefresh 生成一个新的 access_token: token + "-" + 刷新次数.
+type AccessTokenServer struct {
+	mutex        sync.Mutex
+	token        string
+	baseToken    string
+	refreshCount int
+	err          error
+}
+
+func NewAccessTokenServer(token string) *AccessTokenServer {
+	return &AccessTokenServer{
+		token:     token,
+		baseToken: token,
+	}
+}
+
+func (srv *AccessTokenServer) Token() (token string, err error) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+
+	if srv.err != nil {
+		return "", srv.err
+	}
+	return srv.token, nil
+}
+
+func (srv *AccessTokenServer) TokenRefresh() (token string, err error) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+
+	if srv.err != nil {
+		return "", srv.err
+	}
+	srv.refreshCount++
+	srv.token = srv.baseToken + "-" + strconv.Itoa(srv.refreshCount)
+	return srv.token, nil
+}
+
+// 设置当前的 access_token.
+func (srv *AccessTokenServer) SetToken(token string) {
+	srv.mutex.Lock()
+	srv.token = token
+	srv.mutex.Unlock()
+}
+
+// 设置 Token 和 TokenRefresh 返回的错误, err == nil 时恢复正常.
+func (srv *AccessTokenServer) SetError(err error) {
+	srv.mutex.Lock()
+	srv.err = err
+	srv.mutex.Unlock()
+}
+
+// TokenRefresh 成功的次数.
+func (srv *AccessTokenServer) RefreshCount() int {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	return srv.refreshCount
+}
+
+func (srv *AccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+func (srv *AccessTokenServer) Tag6D89F2E2FE9811E49EAAA4DB30FED8E1() {}
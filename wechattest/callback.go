@@ -0,0 +1,189 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wechattest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/chanxuehong/wechat/cipher"
+)
+
+// 构造微信服务器推送给公众号的消息请求(明文模式).
+//  target 为回调 url, 比如 "/wechat/callback"; msgXML 为消息的 xml, 可以用 TextMessageXML 等生成.
+func NewMPRequest(target, token string, msgXML []byte) *http.Request {
+	timestamp, nonce := newTimestampNonce()
+
+	query := make(url.Values)
+	query.Set("signature", cipher.Sign(token, timestamp, nonce))
+	query.Set("timestamp", timestamp)
+	query.Set("nonce", nonce)
+
+	return newPostRequest(target, query, msgXML)
+}
+
+// 构造微信服务器推送给公众号的消息请求(安全模式).
+//  encodedAESKey 为公众号后台的 EncodingAESKey(43 个字符), toUserName 为公众号的原始ID.
+func NewMPAESRequest(target, token, appId, encodedAESKey, toUserName string, msgXML []byte) (*http.Request, error) {
+	timestamp, nonce := newTimestampNonce()
+	encryptedMsg, err := encryptMsg(msgXML, appId, encodedAESKey)
+	if err != nil {
+		return nil, err
+	}
+
+	query := make(url.Values)
+	query.Set("signature", cipher.Sign(token, timestamp, nonce))
+	query.Set("timestamp", timestamp)
+	query.Set("nonce", nonce)
+	query.Set("encrypt_type", "aes")
+	query.Set("msg_signature", cipher.MsgSign(token, timestamp, nonce, encryptedMsg))
+
+	body, err := xml.Marshal(struct {
+		XMLName      struct{} `xml:"xml"`
+		ToUserName   string   `xml:"ToUserName"`
+		EncryptedMsg string   `xml:"Encrypt"`
+	}{
+		ToUserName:   toUserName,
+		EncryptedMsg: encryptedMsg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newPostRequest(target, query, body), nil
+}
+
+// 构造微信服务器推送给企业号应用的消息请求.
+func NewCorpRequest(target, token, corpId string, agentId int64, encodedAESKey string, msgXML []byte) (*http.Request, error) {
+	timestamp, nonce := newTimestampNonce()
+	encryptedMsg, err := encryptMsg(msgXML, corpId, encodedAESKey)
+	if err != nil {
+		return nil, err
+	}
+
+	query := make(url.Values)
+	query.Set("msg_signature", cipher.MsgSign(token, timestamp, nonce, encryptedMsg))
+	query.Set("timestamp", timestamp)
+	query.Set("nonce", nonce)
+
+	body, err := xml.Marshal(struct {
+		XMLName      struct{} `xml:"xml"`
+		CorpId       string   `xml:"ToUserName"`
+		AgentId      int64    `xml:"AgentID"`
+		EncryptedMsg string   `xml:"Encrypt"`
+	}{
+		CorpId:       corpId,
+		AgentId:      agentId,
+		EncryptedMsg: encryptedMsg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newPostRequest(target, query, body), nil
+}
+
+// 校验并解密安全模式下回复给微信服务器的消息, 返回明文的 xml.
+//  appId 在企业号里为 corpId.
+func DecryptResponse(body []byte, token, appId, encodedAESKey string) (msgXML []byte, err error) {
+	var resp struct {
+		EncryptedMsg string `xml:"Encrypt"`
+		MsgSignature string `xml:"MsgSignature"`
+		Timestamp    int64  `xml:"TimeStamp"`
+		Nonce        string `xml:"Nonce"`
+	}
+	if err = xml.Unmarshal(body, &resp); err != nil {
+		return
+	}
+	c, err := cipher.New(token, appId, encodedAESKey)
+	if err != nil {
+		return
+	}
+	return c.DecryptMsg(resp.MsgSignature, strconv.FormatInt(resp.Timestamp, 10), resp.Nonce, resp.EncryptedMsg)
+}
+
+// 公众号文本消息的 xml
+func TextMessageXML(toUserName, fromUserName, content string) []byte {
+	return messageXML(toUserName, fromUserName, "text", map[string]string{
+		"Content": content,
+		"MsgId":   strconv.FormatInt(time.Now().UnixNano(), 10),
+	})
+}
+
+// 事件推送的 xml, fields 为事件特有的字段, 比如 EventKey.
+func EventXML(toUserName, fromUserName, event string, fields map[string]string) []byte {
+	all := map[string]string{"Event": event}
+	for k, v := range fields {
+		all[k] = v
+	}
+	return messageXML(toUserName, fromUserName, "event", all)
+}
+
+func messageXML(toUserName, fromUserName, msgType string, fields map[string]string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<xml>")
+	writeXMLElement(&buf, "ToUserName", toUserName)
+	writeXMLElement(&buf, "FromUserName", fromUserName)
+	buf.WriteString("<CreateTime>" + strconv.FormatInt(time.Now().Unix(), 10) + "</CreateTime>")
+	writeXMLElement(&buf, "MsgType", msgType)
+	for _, k := range sortedKeys(fields) {
+		writeXMLElement(&buf, k, fields[k])
+	}
+	buf.WriteString("</xml>")
+	return buf.Bytes()
+}
+
+func writeXMLElement(buf *bytes.Buffer, name, value string) {
+	buf.WriteString("<" + name + ">")
+	xml.EscapeText(buf, []byte(value))
+	buf.WriteString("</" + name + ">")
+}
+
+func encryptMsg(msgXML []byte, appId, encodedAESKey string) (string, error) {
+	aesKey, err := cipher.DecodeAESKey(encodedAESKey)
+	if err != nil {
+		return "", err
+	}
+	random := cipher.NewRandom()
+	return base64.StdEncoding.EncodeToString(cipher.Encrypt(random[:], msgXML, appId, aesKey)), nil
+}
+
+func newTimestampNonce() (timestamp, nonce string) {
+	var b [8]byte
+	rand.Read(b[:])
+	return strconv.FormatInt(time.Now().Unix(), 10), hex.EncodeToString(b[:])
+}
+
+func newPostRequest(target string, query url.Values, body []byte) *http.Request {
+	if u, err := url.Parse(target); err == nil && u.RawQuery != "" {
+		for k, vs := range u.Query() {
+			for _, v := range vs {
+				query.Add(k, v)
+			}
+		}
+		u.RawQuery = ""
+		target = u.String()
+	}
+	req := httptest.NewRequest("POST", target+"?"+query.Encode(), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	return req
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
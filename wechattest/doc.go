@@ -0,0 +1,20 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 用于单元测试的辅助工具, 不需要访问腾讯的服务器就可以测试和微信的集成:
+//  1. AccessTokenServer 同时实现了 mp.AccessTokenServer 和 corp.AccessTokenServer;
+//  2. Server 是一个模拟的微信服务器(httptest.Server), Server.Client() 返回的 *http.Client
+//     会把 api.weixin.qq.com, qyapi.weixin.qq.com 等域名的请求转发到 Server;
+//  3. NewMPRequest, NewMPAESRequest, NewCorpRequest 构造模拟的微信服务器回调请求(带签名, 加密).
+//
+//  srv := wechattest.NewServer()
+//  defer srv.Close()
+//  srv.HandleJSON("/cgi-bin/user/info", `{"subscribe":1,"openid":"o1"}`)
+//
+//  clt := user.NewClient(wechattest.NewAccessTokenServer("token"), srv.Client())
+//  info, err := clt.UserInfo("o1", "")
+//
+// NOTE: 只能在测试中使用.
+package wechattest
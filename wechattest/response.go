@@ -0,0 +1,60 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wechattest
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// 常用的错误码
+const (
+	ErrCodeOK                 = 0
+	ErrCodeSystemBusy         = -1    // 系统繁忙
+	ErrCodeInvalidCredential  = 40001 // access_token 无效
+	ErrCodeInvalidOpenId      = 40003 // 不合法的 openid
+	ErrCodeAccessTokenExpired = 42001 // access_token 超时
+	ErrCodeAPIFreqOutOfLimit  = 45009 // 接口调用超过限制
+	ErrCodeOutOfResponseTime  = 45015 // 回复时间超过限制(客服消息 48 小时)
+)
+
+// 返回 {"errcode":errcode,"errmsg":errmsg}
+func ErrorJSON(errcode int64, errmsg string) []byte {
+	b, _ := json.Marshal(struct {
+		ErrCode int64  `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}{
+		ErrCode: errcode,
+		ErrMsg:  errmsg,
+	})
+	return b
+}
+
+// 常用接口的返回数据
+var (
+	OKJSON = []byte(`{"errcode":0,"errmsg":"ok"}`)
+)
+
+// 获取 access_token 的返回数据
+func AccessTokenJSON(token string, expiresIn int64) []byte {
+	return []byte(`{"access_token":` + strconv.Quote(token) + `,"expires_in":` + strconv.FormatInt(expiresIn, 10) + `}`)
+}
+
+// 获取 jsapi_ticket 的返回数据
+func TicketJSON(ticket string, expiresIn int64) []byte {
+	return []byte(`{"errcode":0,"errmsg":"ok","ticket":` + strconv.Quote(ticket) + `,"expires_in":` + strconv.FormatInt(expiresIn, 10) + `}`)
+}
+
+// 获取用户基本信息的返回数据
+func UserInfoJSON(openId, nickname string) []byte {
+	return []byte(`{"subscribe":1,"openid":` + strconv.Quote(openId) + `,"nickname":` + strconv.Quote(nickname) +
+		`,"sex":1,"language":"zh_CN","city":"","province":"","country":"","headimgurl":"","subscribe_time":1400000000}`)
+}
+
+// 发送模板消息成功的返回数据
+func MsgIdJSON(msgId int64) []byte {
+	return []byte(`{"errcode":0,"errmsg":"ok","msgid":` + strconv.FormatInt(msgId, 10) + `}`)
+}
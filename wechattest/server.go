@@ -0,0 +1,210 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wechattest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+// Server.Client() 会转发到 Server 的域名
+var Hosts = []string{
+	"api.weixin.qq.com",
+	"file.api.weixin.qq.com",
+	"qyapi.weixin.qq.com",
+	"api.mch.weixin.qq.com",
+	"mp.weixin.qq.com",
+}
+
+// Server 收到的一个请求
+type RecordedRequest struct {
+	Method string
+	Host   string // 原始请求的域名, 比如 api.weixin.qq.com
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// 模拟的微信服务器.
+//  按 url path 注册处理函数, 没有注册的 path 返回 404;
+//  InvalidateToken 之后带有该 access_token 的请求返回 errcode 40001, 用于测试 access_token 的刷新.
+type Server struct {
+	*httptest.Server
+
+	mutex         sync.Mutex
+	handlers      map[string]http.Handler
+	requests      []RecordedRequest
+	invalidTokens map[string]bool
+}
+
+// 创建并启动一个模拟的微信服务器, 使用完需要调用 Close.
+func NewServer() *Server {
+	srv := &Server{
+		handlers:      make(map[string]http.Handler),
+		invalidTokens: make(map[string]bool),
+	}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+	return srv
+}
+
+// 注册 path 的处理函数, 比如 "/cgi-bin/message/custom/send".
+func (srv *Server) Handle(path string, handler http.Handler) {
+	srv.mutex.Lock()
+	srv.handlers[path] = handler
+	srv.mutex.Unlock()
+}
+
+func (srv *Server) HandleFunc(path string, handler func(http.ResponseWriter, *http.Request)) {
+	srv.Handle(path, http.HandlerFunc(handler))
+}
+
+// path 返回固定的 json, response 可以是 string, []byte 或者可以 json.Marshal 的数据结构.
+func (srv *Server) HandleJSON(path string, response interface{}) {
+	body := jsonBytes(response)
+	srv.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(body)
+	})
+}
+
+// path 返回错误码 errcode, errmsg.
+func (srv *Server) HandleError(path string, errcode int64, errmsg string) {
+	srv.HandleJSON(path, ErrorJSON(errcode, errmsg))
+}
+
+// path 返回固定的 xml, 用于模拟微信支付等 xml 接口.
+func (srv *Server) HandleXML(path string, response string) {
+	srv.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Write([]byte(response))
+	})
+}
+
+// 使 access_token 失效, 之后带有该 access_token 的请求都返回 errcode 40001.
+func (srv *Server) InvalidateToken(token string) {
+	srv.mutex.Lock()
+	srv.invalidTokens[token] = true
+	srv.mutex.Unlock()
+}
+
+// 返回收到的所有请求(按收到的顺序).
+func (srv *Server) Requests() []RecordedRequest {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	return append([]RecordedRequest(nil), srv.requests...)
+}
+
+// 返回 path 收到的请求(按收到的顺序).
+func (srv *Server) RequestsTo(path string) (requests []RecordedRequest) {
+	for _, req := range srv.Requests() {
+		if req.Path == path {
+			requests = append(requests, req)
+		}
+	}
+	return
+}
+
+// 清空收到的请求.
+func (srv *Server) Reset() {
+	srv.mutex.Lock()
+	srv.requests = nil
+	srv.mutex.Unlock()
+}
+
+// 返回一个 *http.Client, 它会把 Hosts 里的域名的请求转发到 srv, 其他请求正常发送.
+func (srv *Server) Client() *http.Client {
+	target, _ := url.Parse(srv.URL)
+	return &http.Client{
+		Transport: &rewriteTransport{
+			target: target,
+			base:   srv.Server.Client().Transport,
+		},
+	}
+}
+
+func (srv *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	host := r.Header.Get(originalHostHeader)
+	if host == "" {
+		host = r.Host
+	}
+	query := r.URL.Query()
+
+	srv.mutex.Lock()
+	srv.requests = append(srv.requests, RecordedRequest{
+		Method: r.Method,
+		Host:   host,
+		Path:   r.URL.Path,
+		Query:  query,
+		Header: r.Header,
+		Body:   body,
+	})
+	handler := srv.handlers[r.URL.Path]
+	invalidToken := srv.invalidTokens[query.Get("access_token")]
+	srv.mutex.Unlock()
+
+	if invalidToken {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(ErrorJSON(ErrCodeInvalidCredential, "invalid credential, access_token is invalid or not latest"))
+		return
+	}
+	if handler == nil {
+		http.Error(w, "wechattest: no handler for "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+const originalHostHeader = "X-Wechattest-Host"
+
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isWechatHost(req.URL.Hostname()) {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	req2 := req.Clone(req.Context())
+	req2.URL.Scheme = t.target.Scheme
+	req2.URL.Host = t.target.Host
+	req2.Host = t.target.Host
+	req2.Header.Set(originalHostHeader, req.URL.Hostname())
+	return t.base.RoundTrip(req2)
+}
+
+func isWechatHost(host string) bool {
+	for _, h := range Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonBytes(v interface{}) []byte {
+	switch v := v.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}